@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/activity/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/httputil"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=activity.go -destination activity_mock.go -package handler
+
+type IActivityService interface {
+	GetListActivity(listID uint, page int, pageSize int) (*models.ActivityPage, error)
+	GetUserFeed(userID uint, page int, pageSize int) (*models.ActivityPage, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+type ActivityHandler struct {
+	activityService IActivityService
+	userListService IUserListService
+}
+
+func NewActivityHandler(activityService IActivityService, userListService IUserListService) ActivityHandler {
+	return ActivityHandler{activityService: activityService, userListService: userListService}
+}
+
+// GetListActivity answers a page of the history of the list on the request path, asked with ?page= and ?page_size=.
+func (ah *ActivityHandler) GetListActivity(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || listID < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list id",
+		})
+		c.Abort()
+		return
+	}
+
+	page, pageSize, ok := pageQuery(c)
+	if !ok {
+		return
+	}
+
+	if !httputil.CheckListMember(c, ah.userListService, fmt.Sprint(listID), uint(userID)) {
+		return
+	}
+
+	result, err := ah.activityService.GetListActivity(uint(listID), page, pageSize)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// GetFeed answers a page of the history of every list the user is a member of.
+func (ah *ActivityHandler) GetFeed(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, pageSize, ok := pageQuery(c)
+	if !ok {
+		return
+	}
+
+	result, err := ah.activityService.GetUserFeed(uint(userID), page, pageSize)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func pageQuery(c *gin.Context) (int, int, bool) {
+	page, ok := httputil.QueryNumber(c, "page", 1, 1, 0)
+	if !ok {
+		return 0, 0, false
+	}
+
+	pageSize, ok := httputil.QueryNumber(c, "page_size", models.DefaultPageSize, 1, models.MaxPageSize)
+	if !ok {
+		return 0, 0, false
+	}
+
+	return page, pageSize, true
+}
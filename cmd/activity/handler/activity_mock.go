@@ -0,0 +1,104 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: activity.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/activity/models"
+	models0 "SuperListsAPI/cmd/userLists/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIActivityService is a mock of IActivityService interface.
+type MockIActivityService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIActivityServiceMockRecorder
+}
+
+// MockIActivityServiceMockRecorder is the mock recorder for MockIActivityService.
+type MockIActivityServiceMockRecorder struct {
+	mock *MockIActivityService
+}
+
+// NewMockIActivityService creates a new mock instance.
+func NewMockIActivityService(ctrl *gomock.Controller) *MockIActivityService {
+	mock := &MockIActivityService{ctrl: ctrl}
+	mock.recorder = &MockIActivityServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIActivityService) EXPECT() *MockIActivityServiceMockRecorder {
+	return m.recorder
+}
+
+// GetListActivity mocks base method.
+func (m *MockIActivityService) GetListActivity(listID uint, page, pageSize int) (*models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListActivity", listID, page, pageSize)
+	ret0, _ := ret[0].(*models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListActivity indicates an expected call of GetListActivity.
+func (mr *MockIActivityServiceMockRecorder) GetListActivity(listID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListActivity", reflect.TypeOf((*MockIActivityService)(nil).GetListActivity), listID, page, pageSize)
+}
+
+// GetUserFeed mocks base method.
+func (m *MockIActivityService) GetUserFeed(userID uint, page, pageSize int) (*models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFeed", userID, page, pageSize)
+	ret0, _ := ret[0].(*models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFeed indicates an expected call of GetUserFeed.
+func (mr *MockIActivityServiceMockRecorder) GetUserFeed(userID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFeed", reflect.TypeOf((*MockIActivityService)(nil).GetUserFeed), userID, page, pageSize)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models0.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models0.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
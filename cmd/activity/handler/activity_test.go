@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/activity/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestActivityHandler_GetListActivity(t *testing.T) {
+	tests := []struct {
+		name       string
+		listID     string
+		query      string
+		setup      func(activities *MockIActivityService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name:   "Default page",
+			listID: "3",
+			setup: func(activities *MockIActivityService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				activities.EXPECT().GetListActivity(uint(3), 1, models.DefaultPageSize).Return(&models.ActivityPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Second page",
+			listID: "3",
+			query:  "?page=2&page_size=5",
+			setup: func(activities *MockIActivityService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				activities.EXPECT().GetListActivity(uint(3), 2, 5).Return(&models.ActivityPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Invalid list id",
+			listID:     "abc",
+			setup:      func(activities *MockIActivityService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Page size too large",
+			listID:     "3",
+			query:      "?page_size=500",
+			setup:      func(activities *MockIActivityService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Caller is not a list member",
+			listID: "3",
+			setup: func(activities *MockIActivityService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 8}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Service error",
+			listID: "3",
+			setup: func(activities *MockIActivityService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				activities.EXPECT().GetListActivity(uint(3), 1, models.DefaultPageSize).Return(nil, errors.New("error from db"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			activities := NewMockIActivityService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(activities, userLists)
+
+			activityHandler := NewActivityHandler(activities, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.listID}}
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/lists/"+tt.listID+"/activity"+tt.query, nil)
+			c.Request.Header.Set("user_id", "7")
+
+			activityHandler.GetListActivity(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestActivityHandler_GetFeed(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		query      string
+		setup      func(activities *MockIActivityService)
+		wantStatus int
+	}{
+		{
+			name:   "Default page",
+			userID: "7",
+			setup: func(activities *MockIActivityService) {
+				activities.EXPECT().GetUserFeed(uint(7), 1, models.DefaultPageSize).Return(&models.ActivityPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Missing user id",
+			setup:      func(activities *MockIActivityService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid page",
+			userID:     "7",
+			query:      "?page=0",
+			setup:      func(activities *MockIActivityService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activities := NewMockIActivityService(gomock.NewController(t))
+			tt.setup(activities)
+
+			activityHandler := NewActivityHandler(activities, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/activity/"+tt.query, nil)
+			if tt.userID != "" {
+				c.Request.Header.Set("user_id", tt.userID)
+			}
+
+			activityHandler.GetFeed(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "SuperListsAPI/internal/activity"
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// ActivityPage is a page of activity entries, newest entries first.
+type ActivityPage struct {
+	Entries  []activity.Entry `json:"entries"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+	Total    int64            `json:"total"`
+}
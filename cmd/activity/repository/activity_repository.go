@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/activity/models"
+	"SuperListsAPI/internal/activity"
+	"gorm.io/gorm"
+)
+
+type ActivityRepository struct {
+	db *gorm.DB
+}
+
+func NewActivityRepository(db *gorm.DB) ActivityRepository {
+	return ActivityRepository{db: db}
+}
+
+// GetListActivity returns a page of the history of a list, newest entries first, along with the history size.
+func (ar *ActivityRepository) GetListActivity(listID uint, page int, pageSize int) (*models.ActivityPage, error) {
+	return ar.getPage(func(db *gorm.DB) *gorm.DB {
+		return db.Where("list_id = ?", listID)
+	}, page, pageSize)
+}
+
+// GetUserFeed returns a page of the history of every list userID is currently a member of, newest entries first.
+func (ar *ActivityRepository) GetUserFeed(userID uint, page int, pageSize int) (*models.ActivityPage, error) {
+	return ar.getPage(func(db *gorm.DB) *gorm.DB {
+		memberLists := db.Session(&gorm.Session{NewDB: true}).Table("user_lists").Select("list_id").
+			Where("user_id = ? AND deleted_at IS NULL", userID)
+		return db.Where("list_id IN (?)", memberLists)
+	}, page, pageSize)
+}
+
+func (ar *ActivityRepository) getPage(scope func(db *gorm.DB) *gorm.DB, page int, pageSize int) (*models.ActivityPage, error) {
+
+	activityPage := models.ActivityPage{Entries: []activity.Entry{}, Page: page, PageSize: pageSize}
+
+	if result := ar.db.Model(&activity.Entry{}).Scopes(scope).Count(&activityPage.Total); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if activityPage.Total == 0 {
+		return &activityPage, nil
+	}
+
+	result := ar.db.Scopes(scope).Order("created_at DESC, id DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&activityPage.Entries)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &activityPage, nil
+}
@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"SuperListsAPI/internal/activity"
+	"encoding/json"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewActivityRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want ActivityRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewActivityRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewActivityRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewActivityRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewActivityRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActivityRepository_GetListActivity(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	activityRepository := NewActivityRepository(gormDb)
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `activities` WHERE list_id = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE list_id = ? ORDER BY created_at DESC, id DESC LIMIT 10 OFFSET 10")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "actor_id", "action", "target_type", "target_id", "changes", "created_at"}).
+			AddRow(2, 3, 7, activity.ActionUpdated, activity.TargetList, 3, `{"name":{"before":"Old","after":"New"}}`, createdAt).
+			AddRow(1, 3, nil, activity.ActionReset, activity.TargetList, 3, "{}", createdAt))
+
+	result, err := activityRepository.GetListActivity(3, 2, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), result.Total)
+	assert.Len(t, result.Entries, 2)
+	assert.Equal(t, uint(7), *result.Entries[0].ActorID)
+	assert.Equal(t, activity.Change{Before: json.RawMessage(`"Old"`), After: json.RawMessage(`"New"`)}, result.Entries[0].Changes["name"])
+	assert.Nil(t, result.Entries[1].ActorID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepository_GetListActivity_Empty(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	activityRepository := NewActivityRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `activities` WHERE list_id = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	result, err := activityRepository.GetListActivity(3, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Entries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepository_GetUserFeed(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	activityRepository := NewActivityRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `activities` WHERE list_id IN (SELECT list_id FROM `user_lists` WHERE user_id = ? AND deleted_at IS NULL)")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `activities` WHERE list_id IN (SELECT list_id FROM `user_lists` WHERE user_id = ? AND deleted_at IS NULL) ORDER BY created_at DESC, id DESC LIMIT 20")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "action"}).AddRow(1, 3, activity.ActionCreated))
+
+	result, err := activityRepository.GetUserFeed(7, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Entries, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepository_GetUserFeed_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	activityRepository := NewActivityRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `activities`")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := activityRepository.GetUserFeed(7, 1, 20)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
@@ -0,0 +1,26 @@
+package service
+
+import "SuperListsAPI/cmd/activity/models"
+
+//go:generate mockgen -source=activity_service.go -destination activity_service_mock.go -package service
+
+type IActivityRepository interface {
+	GetListActivity(listID uint, page int, pageSize int) (*models.ActivityPage, error)
+	GetUserFeed(userID uint, page int, pageSize int) (*models.ActivityPage, error)
+}
+
+type ActivityService struct {
+	repository IActivityRepository
+}
+
+func NewActivityService(repository IActivityRepository) ActivityService {
+	return ActivityService{repository: repository}
+}
+
+func (as *ActivityService) GetListActivity(listID uint, page int, pageSize int) (*models.ActivityPage, error) {
+	return as.repository.GetListActivity(listID, page, pageSize)
+}
+
+func (as *ActivityService) GetUserFeed(userID uint, page int, pageSize int) (*models.ActivityPage, error) {
+	return as.repository.GetUserFeed(userID, page, pageSize)
+}
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: activity_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/activity/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIActivityRepository is a mock of IActivityRepository interface.
+type MockIActivityRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIActivityRepositoryMockRecorder
+}
+
+// MockIActivityRepositoryMockRecorder is the mock recorder for MockIActivityRepository.
+type MockIActivityRepositoryMockRecorder struct {
+	mock *MockIActivityRepository
+}
+
+// NewMockIActivityRepository creates a new mock instance.
+func NewMockIActivityRepository(ctrl *gomock.Controller) *MockIActivityRepository {
+	mock := &MockIActivityRepository{ctrl: ctrl}
+	mock.recorder = &MockIActivityRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIActivityRepository) EXPECT() *MockIActivityRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetListActivity mocks base method.
+func (m *MockIActivityRepository) GetListActivity(listID uint, page, pageSize int) (*models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListActivity", listID, page, pageSize)
+	ret0, _ := ret[0].(*models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListActivity indicates an expected call of GetListActivity.
+func (mr *MockIActivityRepositoryMockRecorder) GetListActivity(listID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListActivity", reflect.TypeOf((*MockIActivityRepository)(nil).GetListActivity), listID, page, pageSize)
+}
+
+// GetUserFeed mocks base method.
+func (m *MockIActivityRepository) GetUserFeed(userID uint, page, pageSize int) (*models.ActivityPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserFeed", userID, page, pageSize)
+	ret0, _ := ret[0].(*models.ActivityPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserFeed indicates an expected call of GetUserFeed.
+func (mr *MockIActivityRepositoryMockRecorder) GetUserFeed(userID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserFeed", reflect.TypeOf((*MockIActivityRepository)(nil).GetUserFeed), userID, page, pageSize)
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/activity/models"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestActivityService_GetListActivity(t *testing.T) {
+	mockedRepo := NewMockIActivityRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetListActivity(uint(3), 2, 10).Return(&models.ActivityPage{Page: 2, PageSize: 10, Total: 12}, nil)
+
+	activityService := NewActivityService(mockedRepo)
+
+	result, err := activityService.GetListActivity(3, 2, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), result.Total)
+}
+
+func TestActivityService_GetUserFeed_Error(t *testing.T) {
+	mockedRepo := NewMockIActivityRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetUserFeed(uint(7), 1, 20).Return(nil, errors.New("error from db"))
+
+	activityService := NewActivityService(mockedRepo)
+
+	result, err := activityService.GetUserFeed(7, 1, 20)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
@@ -1,34 +1,93 @@
 package main
 
 import (
+	activityHandler "SuperListsAPI/cmd/activity/handler"
+	activityRepository "SuperListsAPI/cmd/activity/repository"
+	activityService "SuperListsAPI/cmd/activity/service"
 	"SuperListsAPI/cmd/api/middleware"
+	attachmentHandler "SuperListsAPI/cmd/attachments/handler"
+	attachmentRepository "SuperListsAPI/cmd/attachments/repository"
+	attachmentService "SuperListsAPI/cmd/attachments/service"
 	"SuperListsAPI/cmd/auth/handler"
 	"SuperListsAPI/cmd/auth/repository"
 	"SuperListsAPI/cmd/auth/service"
+	commentHandler "SuperListsAPI/cmd/comments/handler"
+	commentRepository "SuperListsAPI/cmd/comments/repository"
+	commentService "SuperListsAPI/cmd/comments/service"
+	exportHandler "SuperListsAPI/cmd/export/handler"
+	importHandler "SuperListsAPI/cmd/imports/handler"
+	importJobRepository "SuperListsAPI/cmd/imports/repository"
+	importJobService "SuperListsAPI/cmd/imports/service"
 	listItemHandler "SuperListsAPI/cmd/listItems/handler"
 	listItemRepository "SuperListsAPI/cmd/listItems/repository"
 	listItemService "SuperListsAPI/cmd/listItems/service"
 	listHandler "SuperListsAPI/cmd/lists/handler"
 	listRepository "SuperListsAPI/cmd/lists/repository"
 	listService "SuperListsAPI/cmd/lists/service"
+	notificationHandler "SuperListsAPI/cmd/notifications/handler"
+	notificationRepository "SuperListsAPI/cmd/notifications/repository"
+	notificationService "SuperListsAPI/cmd/notifications/service"
+	productHandler "SuperListsAPI/cmd/products/handler"
+	productRepository "SuperListsAPI/cmd/products/repository"
+	productService "SuperListsAPI/cmd/products/service"
+	reminderHandler "SuperListsAPI/cmd/reminders/handler"
+	reminderRepository "SuperListsAPI/cmd/reminders/repository"
+	reminderService "SuperListsAPI/cmd/reminders/service"
+	searchHandler "SuperListsAPI/cmd/search/handler"
+	searchRepository "SuperListsAPI/cmd/search/repository"
+	searchService "SuperListsAPI/cmd/search/service"
+	storeProfileHandler "SuperListsAPI/cmd/storeProfiles/handler"
+	storeProfileRepository "SuperListsAPI/cmd/storeProfiles/repository"
+	storeProfileService "SuperListsAPI/cmd/storeProfiles/service"
 	userListHandler "SuperListsAPI/cmd/userLists/handler"
 	userListRepository "SuperListsAPI/cmd/userLists/repository"
 	userListService "SuperListsAPI/cmd/userLists/service"
 	userHandler "SuperListsAPI/cmd/users/handler"
 	userRepository "SuperListsAPI/cmd/users/repository"
 	userService "SuperListsAPI/cmd/users/service"
+	versionHandler "SuperListsAPI/cmd/versions/handler"
+	versionRepository "SuperListsAPI/cmd/versions/repository"
+	versionService "SuperListsAPI/cmd/versions/service"
+	"SuperListsAPI/internal/cache"
 	"SuperListsAPI/internal/database"
+	"SuperListsAPI/internal/notify"
+	"SuperListsAPI/internal/ratelimit"
+	"SuperListsAPI/internal/scheduler"
+	"SuperListsAPI/internal/storage"
+	"context"
+	"fmt"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"log"
+	"os"
+	"strings"
 	"time"
 )
 
+const (
+	defaultReminderInterval = 30 * time.Second
+	listResetInterval       = time.Minute
+	attachmentPurgeInterval = time.Hour
+	archiveImportInterval   = 10 * time.Second
+	defaultReadCacheTTL     = 5 * time.Minute
+)
+
+var (
+	defaultAPIRateLimit  = ratelimit.Limit{Requests: 300, Per: time.Minute}
+	defaultAuthRateLimit = ratelimit.Limit{Requests: 10, Per: time.Minute}
+	defaultBulkRateLimit = ratelimit.Limit{Requests: 20, Per: time.Minute}
+)
+
 func main() {
 
 	database.InitDatabase()
 
 	router := gin.Default()
-	//router.SetTrustedProxies([]string{"127.0.0.1"})
+
+	// Client IPs key the rate limits, forwarding headers are only read from the proxies on TRUSTED_PROXIES
+	if err := router.SetTrustedProxies(trustedProxies()); err != nil {
+		panic(fmt.Sprintf("Error setting up trusted proxies: %s", err.Error()))
+	}
 
 	router.Use(cors.New(cors.Config{
 		AllowOrigins: []string{"*"},
@@ -43,6 +102,12 @@ func main() {
 	}))
 	gin.ForceConsoleColor()
 
+	backingCache, err := cache.NewFromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("Error setting up the read cache: %s", err.Error()))
+	}
+	readCache := cache.WithMetrics(backingCache)
+
 	authRepository := repository.NewAuthRepository(database.AppDatabase)
 	authService := service.NewAuthService(&authRepository)
 	authHandler := handler.NewAuthHandler(&authService)
@@ -55,13 +120,96 @@ func main() {
 	userListService := userListService.NewUserListService(&userListRepository)
 	userListHandler := userListHandler.NewUserListHandler(&userListService)
 
+	cacheInvalidator := cache.NewInvalidator(readCache, func(listID uint) ([]uint, error) {
+		members, err := userListService.GetUserListsByListID(fmt.Sprint(listID))
+		if err != nil {
+			return nil, err
+		}
+		userIDs := make([]uint, 0, len(*members))
+		for _, member := range *members {
+			userIDs = append(userIDs, member.UserID)
+		}
+		return userIDs, nil
+	})
+	router.Use(middleware.InvalidateCache(&cacheInvalidator))
+
+	productRepository := productRepository.NewProductRepository(database.AppDatabase)
+	productService := productService.NewProductService(&productRepository)
+	productHandler := productHandler.NewProductHandler(&productService)
+
+	storeProfileRepository := storeProfileRepository.NewStoreProfileRepository(database.AppDatabase)
+	storeProfileService := storeProfileService.NewStoreProfileService(&storeProfileRepository)
+	storeProfileHandler := storeProfileHandler.NewStoreProfileHandler(&storeProfileService)
+
 	listItemRepository := listItemRepository.NewListItemRepository(database.AppDatabase)
-	listItemService := listItemService.NewListItemService(&listItemRepository)
-	listItemHandler := listItemHandler.NewListItemHandler(&listItemService)
+	cachedListItemService := listItemService.NewCachedListItemService(listItemService.NewListItemService(&listItemRepository),
+		readCache, readCacheTTL())
 
 	listRepository := listRepository.NewListRepository(database.AppDatabase)
-	listService := listService.NewListService(&listRepository)
-	listsHandler := listHandler.NewListHandler(&listService, &userListService, &listItemService)
+	listResetJob := listService.NewListResetJob(&listRepository)
+	cachedListService := listService.NewCachedListService(listService.NewListService(&listRepository), readCache, readCacheTTL())
+	listItemHandler := listItemHandler.NewListItemHandler(&cachedListItemService, &productService, &userListService, &cachedListService)
+	listsHandler := listHandler.NewListHandler(&cachedListService, &userListService, &cachedListItemService, &storeProfileService)
+
+	notificationRepository := notificationRepository.NewNotificationRepository(database.AppDatabase)
+	notificationService := notificationService.NewNotificationService(&notificationRepository)
+	notificationHandler := notificationHandler.NewNotificationHandler(&notificationService)
+
+	dispatcher := notify.NewDispatcher()
+	dispatcher.Register(notify.ChannelInApp, &notificationService)
+	dispatcher.Register(notify.ChannelWebhook, notify.NewWebhookNotifier(os.Getenv("WEBHOOK_SECRET")))
+	if emailNotifier, ok := notify.NewEmailNotifierFromEnv(); ok {
+		dispatcher.Register(notify.ChannelEmail, emailNotifier)
+	}
+
+	commentRepository := commentRepository.NewCommentRepository(database.AppDatabase)
+	commentService := commentService.NewCommentService(&commentRepository, dispatcher)
+	commentHandler := commentHandler.NewCommentHandler(&commentService, &cachedListItemService, &userListService)
+
+	reminderRepository := reminderRepository.NewReminderRepository(database.AppDatabase)
+	reminderJob := reminderService.NewReminderJob(&reminderRepository, dispatcher, replicaID())
+	reminderService := reminderService.NewReminderService(&reminderRepository)
+	reminderHandler := reminderHandler.NewReminderHandler(&reminderService, &cachedListItemService, &userListService)
+
+	fileStorage, err := storage.NewFromEnv()
+	if err != nil {
+		panic(fmt.Sprintf("Error setting up attachment storage: %s", err.Error()))
+	}
+
+	attachmentRepository := attachmentRepository.NewAttachmentRepository(database.AppDatabase)
+	attachmentPurgeJob := attachmentService.NewAttachmentPurgeJob(&attachmentRepository, fileStorage)
+	attachmentService := attachmentService.NewAttachmentService(&attachmentRepository, fileStorage)
+	attachmentHandler := attachmentHandler.NewAttachmentHandler(&attachmentService, &cachedListItemService, &userListService)
+
+	activityRepository := activityRepository.NewActivityRepository(database.AppDatabase)
+	activityService := activityService.NewActivityService(&activityRepository)
+	activityHandler := activityHandler.NewActivityHandler(&activityService, &userListService)
+
+	versionRepository := versionRepository.NewVersionRepository(database.AppDatabase)
+	versionService := versionService.NewVersionService(&versionRepository)
+	versionHandler := versionHandler.NewVersionHandler(&versionService, &userListService)
+
+	searchRepository := searchRepository.NewSearchRepository(database.AppDatabase)
+	searchService := searchService.NewSearchService(&searchRepository)
+	searchHandler := searchHandler.NewSearchHandler(&searchService)
+
+	exportHandler := exportHandler.NewExportHandler(&cachedListService, &cachedListItemService, &userListService)
+
+	importJobRepository := importJobRepository.NewImportJobRepository(database.AppDatabase)
+	archiveImportJob := importJobService.NewArchiveImportJob(&importJobRepository, fileStorage, &cachedListItemService, replicaID())
+	importJobService := importJobService.NewImportJobService(&importJobRepository, fileStorage)
+	importJobHandler := importHandler.NewImportJobHandler(&importJobService)
+	importHandler := importHandler.NewImportHandler(&cachedListItemService, &userListService)
+
+	jobs := scheduler.New()
+	jobs.Every(reminderInterval(), &reminderJob)
+	jobs.Every(listResetInterval, cacheInvalidator.Job(&listResetJob))
+	jobs.Every(attachmentPurgeInterval, &attachmentPurgeJob)
+	jobs.Every(archiveImportInterval, cacheInvalidator.Job(&archiveImportJob))
+
+	ctx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobs.Start(ctx)
 
 	router.GET("/ping", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -69,9 +217,13 @@ func main() {
 		})
 	})
 
-	v1 := router.Group("/v1")
+	// Buckets are kept per replica, a shared store would make the limits hold across replicas
+	rateLimits := ratelimit.NewMemoryStore()
+	bulkRateLimit := middleware.RateLimit(rateLimits, "bulk", rateLimit("RATE_LIMIT_BULK", defaultBulkRateLimit))
+
+	v1 := router.Group("/v1", middleware.RateLimit(rateLimits, "api", rateLimit("RATE_LIMIT_API", defaultAPIRateLimit)))
 	{
-		auth := v1.Group("/auth")
+		auth := v1.Group("/auth", middleware.RateLimit(rateLimits, "auth", rateLimit("RATE_LIMIT_AUTH", defaultAuthRateLimit)))
 		{ //TODO cambiar los tests de login por POST
 			auth.POST("/login", authHandler.Login)
 			auth.POST("/signup", authHandler.SignUp)
@@ -90,7 +242,17 @@ func main() {
 			lists.PUT("/:id", middleware.ValidateJWTOnRequest, listsHandler.Update)
 			lists.DELETE("/:id", middleware.ValidateJWTOnRequest, listsHandler.Delete)
 			lists.POST("/joinList/:inviteCode", middleware.ValidateJWTOnRequest, listsHandler.JoinList)
-			lists.POST("/bulkDelete", middleware.ValidateJWTOnRequest, listsHandler.BulkDelete)
+			lists.POST("/bulkDelete", bulkRateLimit, middleware.ValidateJWTOnRequest, listsHandler.BulkDelete)
+			lists.POST("/import", middleware.ValidateJWTOnRequest, importHandler.Import)
+			lists.POST("/:id/items/reorder", middleware.ValidateJWTOnRequest, listItemHandler.Reorder)
+			lists.POST("/:id/items/merge", middleware.ValidateJWTOnRequest, listItemHandler.MergeDuplicates)
+			lists.POST("/:id/items/quick", middleware.ValidateJWTOnRequest, listItemHandler.QuickAdd)
+			lists.GET("/:id/comments", middleware.ValidateJWTOnRequest, commentHandler.GetListComments)
+			lists.POST("/:id/comments", middleware.ValidateJWTOnRequest, commentHandler.CreateListComment)
+			lists.GET("/:id/activity", middleware.ValidateJWTOnRequest, activityHandler.GetListActivity)
+			lists.GET("/:id/versions", middleware.ValidateJWTOnRequest, versionHandler.GetListVersions)
+			lists.POST("/:id/versions/:version/restore", middleware.ValidateJWTOnRequest, versionHandler.RestoreList)
+			lists.GET("/:id/export", middleware.ValidateJWTOnRequest, exportHandler.ExportList)
 		}
 
 		userLists := v1.Group("/userLists")
@@ -107,15 +269,153 @@ func main() {
 			listItems.GET("/:id", middleware.ValidateJWTOnRequest, listItemHandler.Get)
 			listItems.PUT("/:id", middleware.ValidateJWTOnRequest, listItemHandler.Update)
 			listItems.DELETE("/:id", middleware.ValidateJWTOnRequest, listItemHandler.Delete)
-			listItems.POST("/bulkDelete", middleware.ValidateJWTOnRequest, listItemHandler.BulkDelete)
+			listItems.POST("/bulk", bulkRateLimit, middleware.ValidateJWTOnRequest, listItemHandler.BulkCreate)
+			listItems.POST("/bulkDelete", bulkRateLimit, middleware.ValidateJWTOnRequest, listItemHandler.BulkDelete)
 			listItems.POST("/markAsCompleted", middleware.ValidateJWTOnRequest, listItemHandler.MarkAsCompleted)
 			listItems.POST("/markAsPending", middleware.ValidateJWTOnRequest, listItemHandler.MarkAsPending)
+			listItems.POST("/move", middleware.ValidateJWTOnRequest, listItemHandler.Move)
+			listItems.POST("/copy", middleware.ValidateJWTOnRequest, listItemHandler.Copy)
+			listItems.POST("/:id/assignees", middleware.ValidateJWTOnRequest, listItemHandler.Assign)
+			listItems.DELETE("/:id/assignees/:userId", middleware.ValidateJWTOnRequest, listItemHandler.Unassign)
+			listItems.GET("/:id/comments", middleware.ValidateJWTOnRequest, commentHandler.GetItemComments)
+			listItems.POST("/:id/comments", middleware.ValidateJWTOnRequest, commentHandler.CreateItemComment)
+			listItems.GET("/:id/attachments", middleware.ValidateJWTOnRequest, attachmentHandler.GetAttachments)
+			listItems.POST("/:id/attachments", middleware.ValidateJWTOnRequest, attachmentHandler.Upload)
+			listItems.GET("/:id/versions", middleware.ValidateJWTOnRequest, versionHandler.GetItemVersions)
+			listItems.POST("/:id/versions/:version/restore", middleware.ValidateJWTOnRequest, versionHandler.RestoreListItem)
+		}
+
+		me := v1.Group("/me")
+		{
+			me.GET("/assigned", middleware.ValidateJWTOnRequest, listItemHandler.GetAssigned)
+			me.GET("/today", middleware.ValidateJWTOnRequest, listItemHandler.Today)
+			me.GET("/upcoming", middleware.ValidateJWTOnRequest, listItemHandler.Upcoming)
+			me.GET("/overdue", middleware.ValidateJWTOnRequest, listItemHandler.Overdue)
+			me.GET("/activity", middleware.ValidateJWTOnRequest, activityHandler.GetFeed)
+			me.GET("/export", middleware.ValidateJWTOnRequest, exportHandler.ExportAccount)
+			me.GET("/stats", middleware.ValidateJWTOnRequest, listsHandler.GetStats)
+		}
+
+		products := v1.Group("/products")
+		{
+			products.GET("/", middleware.ValidateJWTOnRequest, productHandler.Search)
+			products.GET("/:id", middleware.ValidateJWTOnRequest, productHandler.Get)
+			products.POST("/", middleware.ValidateJWTOnRequest, middleware.ValidateAdminRole, productHandler.Create)
+			products.PUT("/:id", middleware.ValidateJWTOnRequest, middleware.ValidateAdminRole, productHandler.Update)
+			products.DELETE("/:id", middleware.ValidateJWTOnRequest, middleware.ValidateAdminRole, productHandler.Delete)
+			products.POST("/import", middleware.ValidateJWTOnRequest, middleware.ValidateAdminRole, productHandler.Import)
+		}
+
+		storeProfiles := v1.Group("/storeProfiles")
+		{
+			storeProfiles.POST("/", middleware.ValidateJWTOnRequest, storeProfileHandler.Create)
+			storeProfiles.GET("/", middleware.ValidateJWTOnRequest, storeProfileHandler.GetProfiles)
+			storeProfiles.GET("/:id", middleware.ValidateJWTOnRequest, storeProfileHandler.Get)
+			storeProfiles.PUT("/:id", middleware.ValidateJWTOnRequest, storeProfileHandler.Update)
+			storeProfiles.DELETE("/:id", middleware.ValidateJWTOnRequest, storeProfileHandler.Delete)
+		}
+
+		reminders := v1.Group("/reminders")
+		{
+			reminders.POST("/", middleware.ValidateJWTOnRequest, reminderHandler.Create)
+			reminders.GET("/", middleware.ValidateJWTOnRequest, reminderHandler.GetReminders)
+			reminders.DELETE("/:id", middleware.ValidateJWTOnRequest, reminderHandler.Delete)
+		}
+
+		comments := v1.Group("/comments")
+		{
+			comments.PUT("/:id", middleware.ValidateJWTOnRequest, commentHandler.Update)
+			comments.DELETE("/:id", middleware.ValidateJWTOnRequest, commentHandler.Delete)
+		}
+
+		attachments := v1.Group("/attachments")
+		{
+			attachments.GET("/:id", middleware.ValidateJWTOnRequest, attachmentHandler.Download)
+			attachments.DELETE("/:id", middleware.ValidateJWTOnRequest, attachmentHandler.Delete)
+		}
+
+		imports := v1.Group("/imports")
+		{
+			imports.POST("/", middleware.ValidateJWTOnRequest, importJobHandler.CreateJob)
+			imports.GET("/", middleware.ValidateJWTOnRequest, importJobHandler.GetJobs)
+			imports.GET("/:id", middleware.ValidateJWTOnRequest, importJobHandler.GetJob)
+		}
+
+		undo := v1.Group("/undo")
+		{
+			undo.POST("/:token", middleware.ValidateJWTOnRequest, versionHandler.Undo)
+		}
+
+		v1.GET("/search", middleware.ValidateJWTOnRequest, searchHandler.Search)
+
+		v1.GET("/cache/stats", middleware.ValidateJWTOnRequest, middleware.ValidateAdminRole, func(c *gin.Context) {
+			c.JSON(200, readCache.Stats())
+		})
+
+		notifications := v1.Group("/notifications")
+		{
+			notifications.GET("/", middleware.ValidateJWTOnRequest, notificationHandler.GetNotifications)
+			notifications.POST("/:id/read", middleware.ValidateJWTOnRequest, notificationHandler.MarkAsRead)
 		}
 
 	}
 
-	err := router.Run()
+	err = router.Run()
 	if err != nil {
 		panic("Error running sv!")
 	}
 }
+
+// reminderInterval reads how often reminders are checked from REMINDER_INTERVAL, as a duration like "30s".
+func reminderInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("REMINDER_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return defaultReminderInterval
+	}
+	return interval
+}
+
+// readCacheTTL reads how long list reads stay cached from CACHE_TTL, as a duration like "5m".
+func readCacheTTL() time.Duration {
+	ttl, err := time.ParseDuration(os.Getenv("CACHE_TTL"))
+	if err != nil || ttl <= 0 {
+		return defaultReadCacheTTL
+	}
+	return ttl
+}
+
+// trustedProxies reads the comma separated IPs or CIDRs of TRUSTED_PROXIES, nil when unset so the client IP is
+// the address of the connection.
+func trustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// rateLimit reads a limit like "10/1m" from the env var name, an unset or invalid one keeps fallback.
+func rateLimit(name string, fallback ratelimit.Limit) ratelimit.Limit {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	limit, err := ratelimit.ParseLimit(value)
+	if err != nil {
+		log.Print(fmt.Sprintf("Ignoring %s: %s", name, err.Error()))
+		return fallback
+	}
+	return limit
+}
+
+// replicaID names this process on the reminder deliveries it claims.
+func replicaID() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s-%d", hostname, os.Getpid())
+}
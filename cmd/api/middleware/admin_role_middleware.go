@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"SuperListsAPI/cmd/auth/repository"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// ValidateAdminRole must run after ValidateJWTOnRequest, which sets the role header from the token claims.
+func ValidateAdminRole(c *gin.Context) {
+
+	if c.Request.Header.Get("role") != repository.ADMIN {
+		c.JSON(http.StatusForbidden, "admin role required")
+		c.Abort()
+		return
+	}
+
+	return
+}
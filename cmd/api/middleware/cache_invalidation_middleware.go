@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/cache"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"sync"
+)
+
+// InvalidateCache tracks the changes recorded while handling a request and drops the cached reads they made stale.
+// Handlers answer once their transactions committed, so the invalidation runs right before the response is
+// written and a client reading its own change never gets the cached read from before it.
+func InvalidateCache(invalidator *cache.Invalidator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, changes := activity.TrackChanges(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		var once sync.Once
+		invalidate := func() {
+			once.Do(func() {
+				if err := invalidator.Invalidate(ctx, changes); err != nil {
+					log.Print(fmt.Sprintf("Error invalidating the cache after %s %s: %s", c.Request.Method, c.FullPath(), err.Error()))
+				}
+			})
+		}
+
+		c.Writer = &invalidatingWriter{ResponseWriter: c.Writer, invalidate: invalidate}
+		c.Next()
+
+		// Requests answered without a body never wrote through the writer
+		invalidate()
+	}
+}
+
+type invalidatingWriter struct {
+	gin.ResponseWriter
+	invalidate func()
+}
+
+func (w *invalidatingWriter) WriteHeaderNow() {
+	w.invalidate()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *invalidatingWriter) Write(data []byte) (int, error) {
+	w.invalidate()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *invalidatingWriter) WriteString(s string) (int, error) {
+	w.invalidate()
+	return w.ResponseWriter.WriteString(s)
+}
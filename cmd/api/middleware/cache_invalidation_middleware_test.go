@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/cache"
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInvalidateCache(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `activities`").WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	ctx := context.Background()
+	readCache := cache.NewLRU(10)
+	assert.NoError(t, readCache.Set(ctx, cache.UserListsKey(2), []byte("[]"), 0))
+	assert.NoError(t, readCache.Set(ctx, cache.UserListsKey(9), []byte("[]"), 0))
+	invalidator := cache.NewInvalidator(readCache, func(listID uint) ([]uint, error) {
+		return []uint{2}, nil
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(InvalidateCache(&invalidator))
+	router.PUT("/lists/:id", func(c *gin.Context) {
+		err := gormDb.WithContext(c.Request.Context()).Transaction(func(tx *gorm.DB) error {
+			return activity.Record(tx, activity.Entry{ListID: 4, Action: activity.ActionUpdated, TargetType: activity.TargetList, TargetID: 4})
+		})
+		assert.NoError(t, err)
+
+		_, found, _ := readCache.Get(ctx, cache.UserListsKey(2))
+		assert.True(t, found)
+
+		c.JSON(http.StatusOK, gin.H{"id": 4})
+
+		// The cache was cleared before the response went out
+		_, found, _ = readCache.Get(ctx, cache.UserListsKey(2))
+		assert.False(t, found)
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/lists/4", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, 1, readCache.Len())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"SuperListsAPI/cmd/auth/models"
 	"SuperListsAPI/cmd/auth/repository"
+	"SuperListsAPI/internal/activity"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -21,13 +22,7 @@ func ValidateJWTOnRequest(c *gin.Context) {
 
 	//token := strings.Join(parsedToken, "")
 
-	jwtWrapper := models.JwtWrapper{
-		SecretKey:       repository.SECRET_KEY,
-		Issuer:          repository.ISSUER,
-		ExpirationHours: repository.EXPIRATION_HOURS,
-	}
-
-	claims, err := jwtWrapper.ValidateToken(parsedToken)
+	claims, err := validateToken(parsedToken)
 
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, "invalid token present on request's header")
@@ -37,8 +32,22 @@ func ValidateJWTOnRequest(c *gin.Context) {
 
 	userID := strconv.Itoa(int(claims.UserID))
 
-	c.Request.Header.Add("role", claims.Role)
-	c.Request.Header.Add("email", claims.Email)
-	c.Request.Header.Add("user_id", userID)
+	// Set instead of Add, otherwise values sent by the client on these headers would take precedence over the claims
+	c.Request.Header.Set("role", claims.Role)
+	c.Request.Header.Set("email", claims.Email)
+	c.Request.Header.Set("user_id", userID)
+
+	// Changes made on this request are recorded on the activity log as made by the token owner
+	c.Request = c.Request.WithContext(activity.WithActor(c.Request.Context(), claims.UserID))
 	return
 }
+
+func validateToken(token string) (*models.JwtClaim, error) {
+	jwtWrapper := models.JwtWrapper{
+		SecretKey:       repository.SECRET_KEY,
+		Issuer:          repository.ISSUER,
+		ExpirationHours: repository.EXPIRATION_HOURS,
+	}
+
+	return jwtWrapper.ValidateToken(token)
+}
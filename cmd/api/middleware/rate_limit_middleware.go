@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/ratelimit"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit lets each client make up to limit requests on the routes it guards, counted on the buckets named
+// name. Clients with a valid token are counted by user and the others by IP, so it can run before
+// ValidateJWTOnRequest. When several limits guard a route the headers tell about the one with the fewest
+// requests left.
+func RateLimit(store ratelimit.Store, name string, limit ratelimit.Limit) gin.HandlerFunc {
+	policy := fmt.Sprintf("%d;w=%d", limit.Requests, wholeSeconds(limit.Per))
+
+	return func(c *gin.Context) {
+		key := name + ":" + rateLimitKey(c)
+
+		result, err := store.Take(c.Request.Context(), key, limit, time.Now())
+		if err != nil {
+			// Requests go through while the store is down instead of the whole API failing with it
+			log.Print(fmt.Sprintf("Error taking a token for %s: %s", key, err.Error()))
+			return
+		}
+
+		remaining, err := strconv.Atoi(c.Writer.Header().Get("RateLimit-Remaining"))
+		if err != nil || result.Remaining < remaining {
+			c.Header("RateLimit-Limit", strconv.Itoa(limit.Requests))
+			c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+			c.Header("RateLimit-Reset", strconv.Itoa(wholeSeconds(result.Reset)))
+			c.Header("RateLimit-Policy", policy)
+		}
+
+		if !result.Allowed {
+			c.Header("Retry-After", strconv.Itoa(wholeSeconds(result.RetryAfter)))
+			c.JSON(http.StatusTooManyRequests, gin.H{"msg": "too many requests, retry later"})
+			c.Abort()
+			return
+		}
+	}
+}
+
+// rateLimitKey names the client of the request, by the user of its token when valid or by its IP otherwise.
+func rateLimitKey(c *gin.Context) string {
+	if actorID := activity.ActorFrom(c.Request.Context()); actorID != nil {
+		return fmt.Sprintf("user:%d", *actorID)
+	}
+
+	if token := c.Request.Header.Get("token"); token != "" {
+		if claims, err := validateToken(token); err == nil {
+			return fmt.Sprintf("user:%d", claims.UserID)
+		}
+	}
+
+	return "ip:" + c.ClientIP()
+}
+
+// wholeSeconds rounds d up to seconds, as the headers carry.
+func wholeSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
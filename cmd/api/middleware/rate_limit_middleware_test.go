@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"SuperListsAPI/cmd/auth/models"
+	"SuperListsAPI/cmd/auth/repository"
+	"SuperListsAPI/internal/ratelimit"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// failingStore fails every take, like a shared store that is down.
+type failingStore struct{}
+
+func (failingStore) Take(ctx context.Context, key string, limit ratelimit.Limit, now time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("connection refused")
+}
+
+func getRateLimitedRouter(handlers ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.SetTrustedProxies(nil)
+	router.POST("/v1/listItems/bulkDelete", append(handlers, func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})...)
+	return router
+}
+
+func rateLimitedRequest(router *gin.Engine, remoteAddr string, token string, headers ...string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/v1/listItems/bulkDelete", nil)
+	request.RemoteAddr = remoteAddr
+	for i := 0; i+1 < len(headers); i += 2 {
+		request.Header.Set(headers[i], headers[i+1])
+	}
+	if token != "" {
+		request.Header.Set("token", token)
+	}
+	request.Header.Set("user_id", "99")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, request)
+	return w
+}
+
+func TestRateLimit_By_IP(t *testing.T) {
+	router := getRateLimitedRouter(RateLimit(ratelimit.NewMemoryStore(), "bulk", ratelimit.Limit{Requests: 2, Per: time.Minute}))
+
+	w := rateLimitedRequest(router, "10.0.0.1:1234", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("RateLimit-Reset"))
+	assert.Equal(t, "2;w=60", w.Header().Get("RateLimit-Policy"))
+	assert.Empty(t, w.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.1:1235", "").Code)
+
+	// The user_id header is sent by the client, it does not change the bucket
+	w = rateLimitedRequest(router, "10.0.0.1:1236", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"msg":"too many requests, retry later"}`, w.Body.String())
+
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.2:1234", "").Code)
+}
+
+func TestRateLimit_Spoofed_Forwarded_For(t *testing.T) {
+	router := getRateLimitedRouter(RateLimit(ratelimit.NewMemoryStore(), "auth", ratelimit.Limit{Requests: 1, Per: time.Minute}))
+
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.1:1234", "", "X-Forwarded-For", "1.1.1.1").Code)
+
+	// Without trusted proxies a new forwarding header does not get a new bucket
+	w := rateLimitedRequest(router, "10.0.0.1:1234", "", "X-Forwarded-For", "2.2.2.2", "X-Real-IP", "3.3.3.3")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+}
+
+func TestRateLimit_Trusted_Proxy(t *testing.T) {
+	router := getRateLimitedRouter(RateLimit(ratelimit.NewMemoryStore(), "auth", ratelimit.Limit{Requests: 1, Per: time.Minute}))
+	assert.NoError(t, router.SetTrustedProxies([]string{"10.0.0.1"}))
+
+	// Clients behind a trusted proxy are told apart by the address it forwards
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.1:1234", "", "X-Forwarded-For", "1.1.1.1").Code)
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.1:1234", "", "X-Forwarded-For", "2.2.2.2").Code)
+	assert.Equal(t, http.StatusTooManyRequests, rateLimitedRequest(router, "10.0.0.1:1234", "", "X-Forwarded-For", "1.1.1.1").Code)
+}
+
+func TestRateLimit_By_User(t *testing.T) {
+	jwtWrapper := models.JwtWrapper{SecretKey: repository.SECRET_KEY, Issuer: repository.ISSUER, ExpirationHours: 1}
+	firstUser, err := jwtWrapper.GenerateToken("first@mail.com", "USER", 1)
+	assert.NoError(t, err)
+	secondUser, err := jwtWrapper.GenerateToken("second@mail.com", "USER", 2)
+	assert.NoError(t, err)
+
+	router := getRateLimitedRouter(RateLimit(ratelimit.NewMemoryStore(), "bulk", ratelimit.Limit{Requests: 1, Per: time.Minute}),
+		ValidateJWTOnRequest)
+
+	// Requests of a user share the bucket from any IP, users behind the same IP do not
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.1:1234", firstUser).Code)
+	assert.Equal(t, http.StatusTooManyRequests, rateLimitedRequest(router, "10.0.0.2:1234", firstUser).Code)
+	assert.Equal(t, http.StatusOK, rateLimitedRequest(router, "10.0.0.1:1234", secondUser).Code)
+
+	// Invalid tokens are counted by IP
+	assert.Equal(t, http.StatusUnauthorized, rateLimitedRequest(router, "10.0.0.1:1234", "invalid").Code)
+	assert.Equal(t, http.StatusTooManyRequests, rateLimitedRequest(router, "10.0.0.1:1234", "invalid").Code)
+}
+
+func TestRateLimit_Stacked_Limits(t *testing.T) {
+	store := ratelimit.NewMemoryStore()
+	router := getRateLimitedRouter(
+		RateLimit(store, "api", ratelimit.Limit{Requests: 100, Per: time.Minute}),
+		RateLimit(store, "bulk", ratelimit.Limit{Requests: 5, Per: time.Minute}),
+		RateLimit(store, "burst", ratelimit.Limit{Requests: 50, Per: time.Second}),
+	)
+
+	w := rateLimitedRequest(router, "10.0.0.1:1234", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "5", w.Header().Get("RateLimit-Limit"))
+	assert.Equal(t, "4", w.Header().Get("RateLimit-Remaining"))
+	assert.Equal(t, "5;w=60", w.Header().Get("RateLimit-Policy"))
+}
+
+func TestRateLimit_Store_Error(t *testing.T) {
+	router := getRateLimitedRouter(RateLimit(failingStore{}, "bulk", ratelimit.Limit{Requests: 1, Per: time.Minute}))
+
+	w := rateLimitedRequest(router, "10.0.0.1:1234", "")
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+}
@@ -0,0 +1,259 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/httputil"
+	"SuperListsAPI/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+)
+
+//go:generate mockgen -source=attachments.go -destination attachments_mock.go -package handler
+
+type IAttachmentService interface {
+	Upload(ctx context.Context, attachment models.Attachment, content io.Reader) (*models.Attachment, error)
+	Get(attachmentID string) (*models.Attachment, error)
+	GetAttachmentsByListItemID(listItemID string) (*[]models.Attachment, error)
+	Open(ctx context.Context, attachment models.Attachment) (io.ReadCloser, error)
+	Delete(attachmentID string) (*int, error)
+}
+
+type IListItemService interface {
+	Get(listItemID string) (*listItemModels.ListItem, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+// multipartOverhead leaves room for the multipart boundaries and headers around the file.
+const multipartOverhead = 64 << 10
+
+type AttachmentHandler struct {
+	attachmentService IAttachmentService
+	listItemService   IListItemService
+	userListService   IUserListService
+}
+
+func NewAttachmentHandler(attachmentService IAttachmentService, listItemService IListItemService, userListService IUserListService) AttachmentHandler {
+	return AttachmentHandler{attachmentService: attachmentService, listItemService: listItemService, userListService: userListService}
+}
+
+// Upload attaches the multipart form file named "file" to the item on the request path.
+func (ah *AttachmentHandler) Upload(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxAttachmentSize+multipartOverhead)
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listItem, ok := ah.memberItem(c, uint(userID))
+	if !ok {
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing file on multipart form",
+		})
+		c.Abort()
+		return
+	}
+
+	if fileHeader.Size > models.MaxAttachmentSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"msg": models.ErrAttachmentTooLarge.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := ah.attachmentService.Upload(c.Request.Context(), models.Attachment{
+		ListItemID: listItem.ID,
+		ListID:     uint(listItem.ListID),
+		UserID:     uint(userID),
+		FileName:   filepath.Base(fileHeader.Filename),
+		Size:       fileHeader.Size,
+	}, file)
+
+	switch {
+	case errors.Is(err, models.ErrAttachmentTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	case errors.Is(err, models.ErrContentTypeRejected):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+func (ah *AttachmentHandler) GetAttachments(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listItem, ok := ah.memberItem(c, uint(userID))
+	if !ok {
+		return
+	}
+
+	attachments, err := ah.attachmentService.GetAttachmentsByListItemID(fmt.Sprint(listItem.ID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attachments)
+	return
+}
+
+// Download streams the file, with its checksum on the X-Checksum-Sha256 header.
+func (ah *AttachmentHandler) Download(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	attachment, ok := ah.memberAttachment(c, uint(userID))
+	if !ok {
+		return
+	}
+
+	file, err := ah.attachmentService.Open(c.Request.Context(), *attachment)
+
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("File of attachment %d not found", attachment.ID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+	defer file.Close()
+
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, file, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Checksum-Sha256":      attachment.Checksum,
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+func (ah *AttachmentHandler) Delete(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	attachment, ok := ah.memberAttachment(c, uint(userID))
+	if !ok {
+		return
+	}
+
+	result, err := ah.attachmentService.Delete(fmt.Sprint(attachment.ID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// memberItem loads the item on the request path, answering the request when it doesn't exist or userID is not a
+// member of its list.
+func (ah *AttachmentHandler) memberItem(c *gin.Context, userID uint) (*listItemModels.ListItem, bool) {
+	listItemID := c.Param("id")
+
+	if _, err := strconv.Atoi(listItemID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list item id",
+		})
+		c.Abort()
+		return nil, false
+	}
+
+	listItem, err := ah.listItemService.Get(listItemID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("ListItem with id %s not found", listItemID))
+		return nil, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if !httputil.CheckListMember(c, ah.userListService, fmt.Sprint(listItem.ListID), userID) {
+		return nil, false
+	}
+
+	return listItem, true
+}
+
+// memberAttachment loads the attachment on the request path, answering the request when it doesn't exist or
+// userID is not a member of its list.
+func (ah *AttachmentHandler) memberAttachment(c *gin.Context, userID uint) (*models.Attachment, bool) {
+	attachmentID := c.Param("id")
+
+	if _, err := strconv.Atoi(attachmentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid attachment id",
+		})
+		c.Abort()
+		return nil, false
+	}
+
+	attachment, err := ah.attachmentService.Get(attachmentID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Attachment with id %s not found", attachmentID))
+		return nil, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return nil, false
+	}
+
+	if !httputil.CheckListMember(c, ah.userListService, fmt.Sprint(attachment.ListID), userID) {
+		return nil, false
+	}
+
+	return attachment, true
+}
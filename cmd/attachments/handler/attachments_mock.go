@@ -0,0 +1,190 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachments.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/attachments/models"
+	models0 "SuperListsAPI/cmd/listItems/models"
+	models1 "SuperListsAPI/cmd/userLists/models"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAttachmentService is a mock of IAttachmentService interface.
+type MockIAttachmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAttachmentServiceMockRecorder
+}
+
+// MockIAttachmentServiceMockRecorder is the mock recorder for MockIAttachmentService.
+type MockIAttachmentServiceMockRecorder struct {
+	mock *MockIAttachmentService
+}
+
+// NewMockIAttachmentService creates a new mock instance.
+func NewMockIAttachmentService(ctrl *gomock.Controller) *MockIAttachmentService {
+	mock := &MockIAttachmentService{ctrl: ctrl}
+	mock.recorder = &MockIAttachmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAttachmentService) EXPECT() *MockIAttachmentServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIAttachmentService) Delete(attachmentID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", attachmentID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIAttachmentServiceMockRecorder) Delete(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIAttachmentService)(nil).Delete), attachmentID)
+}
+
+// Get mocks base method.
+func (m *MockIAttachmentService) Get(attachmentID string) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", attachmentID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIAttachmentServiceMockRecorder) Get(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIAttachmentService)(nil).Get), attachmentID)
+}
+
+// GetAttachmentsByListItemID mocks base method.
+func (m *MockIAttachmentService) GetAttachmentsByListItemID(listItemID string) (*[]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByListItemID", listItemID)
+	ret0, _ := ret[0].(*[]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByListItemID indicates an expected call of GetAttachmentsByListItemID.
+func (mr *MockIAttachmentServiceMockRecorder) GetAttachmentsByListItemID(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByListItemID", reflect.TypeOf((*MockIAttachmentService)(nil).GetAttachmentsByListItemID), listItemID)
+}
+
+// Open mocks base method.
+func (m *MockIAttachmentService) Open(ctx context.Context, attachment models.Attachment) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, attachment)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockIAttachmentServiceMockRecorder) Open(ctx, attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockIAttachmentService)(nil).Open), ctx, attachment)
+}
+
+// Upload mocks base method.
+func (m *MockIAttachmentService) Upload(ctx context.Context, attachment models.Attachment, content io.Reader) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upload", ctx, attachment, content)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Upload indicates an expected call of Upload.
+func (mr *MockIAttachmentServiceMockRecorder) Upload(ctx, attachment, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockIAttachmentService)(nil).Upload), ctx, attachment, content)
+}
+
+// MockIListItemService is a mock of IListItemService interface.
+type MockIListItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListItemServiceMockRecorder
+}
+
+// MockIListItemServiceMockRecorder is the mock recorder for MockIListItemService.
+type MockIListItemServiceMockRecorder struct {
+	mock *MockIListItemService
+}
+
+// NewMockIListItemService creates a new mock instance.
+func NewMockIListItemService(ctrl *gomock.Controller) *MockIListItemService {
+	mock := &MockIListItemService{ctrl: ctrl}
+	mock.recorder = &MockIListItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIListItemService) Get(listItemID string) (*models0.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", listItemID)
+	ret0, _ := ret[0].(*models0.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIListItemServiceMockRecorder) Get(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListItemService)(nil).Get), listItemID)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models1.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
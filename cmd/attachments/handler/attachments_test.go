@@ -0,0 +1,263 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/storage"
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewAttachmentHandler(t *testing.T) {
+	type args struct {
+		service IAttachmentService
+	}
+	tests := []struct {
+		name string
+		args args
+		want AttachmentHandler
+	}{
+		{
+			name: "Test with nil service should pass",
+			args: args{nil},
+			want: NewAttachmentHandler(nil, nil, nil),
+		},
+		{
+			name: "Test with no nil service should pass",
+			args: args{NewMockIAttachmentService(gomock.NewController(t))},
+			want: NewAttachmentHandler(NewMockIAttachmentService(gomock.NewController(t)), nil, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAttachmentHandler(tt.args.service, nil, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAttachmentHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentHandler_Upload(t *testing.T) {
+	members := &[]userListModels.UserList{{ListID: 3, UserID: 7}}
+	listItem := listItemModels.ListItem{ListID: 3}
+	listItem.ID = 4
+
+	tests := []struct {
+		name       string
+		field      string
+		setup      func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name:  "Upload by a list member",
+			field: "file",
+			setup: func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				attachments.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, attachment models.Attachment, content io.Reader) (*models.Attachment, error) {
+					if attachment.ListItemID != 4 || attachment.ListID != 3 || attachment.UserID != 7 || attachment.FileName != "photo.png" || attachment.Size != 4 {
+						return nil, errors.New("unexpected attachment")
+					}
+					return &attachment, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:  "Missing file",
+			field: "other",
+			setup: func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Rejected content type",
+			field: "file",
+			setup: func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				attachments.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, models.ErrContentTypeRejected)
+			},
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:  "File too large",
+			field: "file",
+			setup: func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				attachments.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, models.ErrAttachmentTooLarge)
+			},
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "Item not found",
+			field: "file",
+			setup: func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Caller is not a list member",
+			field: "file",
+			setup: func(attachments *MockIAttachmentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 8}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			attachments := NewMockIAttachmentService(ctrl)
+			listItems := NewMockIListItemService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(attachments, listItems, userLists)
+
+			attachmentHandler := NewAttachmentHandler(attachments, listItems, userLists)
+
+			body := &bytes.Buffer{}
+			form := multipart.NewWriter(body)
+			part, _ := form.CreateFormFile(tt.field, "../photo.png")
+			part.Write([]byte("\x89PNG"))
+			form.Close()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/listItems/4/attachments", body)
+			c.Request.Header.Set("Content-Type", form.FormDataContentType())
+			c.Request.Header.Set("user_id", "7")
+
+			attachmentHandler.Upload(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestAttachmentHandler_Download(t *testing.T) {
+	attachment := models.Attachment{ListID: 3, FileName: "ticket 1.pdf", ContentType: "application/pdf", Size: 4, Checksum: "abc", StorageKey: "items/4/key"}
+	attachment.ID = 1
+
+	tests := []struct {
+		name       string
+		setup      func(attachments *MockIAttachmentService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name: "Download by a list member",
+			setup: func(attachments *MockIAttachmentService, userLists *MockIUserListService) {
+				attachments.EXPECT().Get("1").Return(&attachment, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				attachments.EXPECT().Open(gomock.Any(), attachment).Return(ioutil.NopCloser(bytes.NewReader([]byte("%PDF"))), nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "File missing from the storage",
+			setup: func(attachments *MockIAttachmentService, userLists *MockIUserListService) {
+				attachments.EXPECT().Get("1").Return(&attachment, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				attachments.EXPECT().Open(gomock.Any(), attachment).Return(nil, storage.ErrNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Attachment not found",
+			setup: func(attachments *MockIAttachmentService, userLists *MockIUserListService) {
+				attachments.EXPECT().Get("1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Caller is not a list member",
+			setup: func(attachments *MockIAttachmentService, userLists *MockIUserListService) {
+				attachments.EXPECT().Get("1").Return(&attachment, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 8}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			attachments := NewMockIAttachmentService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(attachments, userLists)
+
+			attachmentHandler := NewAttachmentHandler(attachments, nil, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "1"}}
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/attachments/1", nil)
+			c.Request.Header.Set("user_id", "7")
+
+			attachmentHandler.Download(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "%PDF", w.Body.String())
+				assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+				assert.Equal(t, `attachment; filename="ticket 1.pdf"`, w.Header().Get("Content-Disposition"))
+				assert.Equal(t, "abc", w.Header().Get("X-Checksum-Sha256"))
+			}
+		})
+	}
+}
+
+func TestAttachmentHandler_Delete(t *testing.T) {
+	attachment := models.Attachment{ListID: 3}
+	attachment.ID = 1
+	rowsAffected := 1
+
+	ctrl := gomock.NewController(t)
+	attachments := NewMockIAttachmentService(ctrl)
+	userLists := NewMockIUserListService(ctrl)
+	attachments.EXPECT().Get("1").Return(&attachment, nil)
+	userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+	attachments.EXPECT().Delete("1").Return(&rowsAffected, nil)
+
+	attachmentHandler := NewAttachmentHandler(attachments, nil, userLists)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/v1/attachments/1", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	attachmentHandler.Delete(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAttachmentHandler_Delete_Invalid_ID(t *testing.T) {
+	attachmentHandler := NewAttachmentHandler(nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "abc"}}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/v1/attachments/abc", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	attachmentHandler.Delete(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+)
+
+// MaxAttachmentSize is the largest file accepted, in bytes.
+const MaxAttachmentSize = 10 << 20
+
+var (
+	ErrAttachmentTooLarge  = errors.New("attachments can't be larger than 10 MB")
+	ErrContentTypeRejected = errors.New("only jpeg, png, gif, webp and pdf files can be attached")
+)
+
+// AllowedContentTypes are the types that can be attached. The type is sniffed from the file content, the one sent
+// by the client is ignored.
+var AllowedContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+// Attachment is a file attached to a list item. The file itself lives on the storage under StorageKey and
+// Checksum is its hex encoded SHA-256.
+type Attachment struct {
+	gorm.Model
+	ListItemID  uint   `json:"list_item_id"`
+	ListID      uint   `json:"list_id"`
+	UserID      uint   `json:"user_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Checksum    string `json:"checksum"`
+	StorageKey  string `json:"-"`
+}
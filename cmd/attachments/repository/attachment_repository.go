@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return AttachmentRepository{db: db}
+}
+
+func (ar *AttachmentRepository) Create(attachment models.Attachment) (*models.Attachment, error) {
+
+	if result := ar.db.Create(&attachment); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &attachment, nil
+}
+
+func (ar *AttachmentRepository) Get(attachmentID string) (*models.Attachment, error) {
+
+	var attachment models.Attachment
+
+	if result := ar.db.First(&attachment, attachmentID); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &attachment, nil
+}
+
+func (ar *AttachmentRepository) GetAttachmentsByListItemID(listItemID string) (*[]models.Attachment, error) {
+
+	var attachments []models.Attachment
+
+	if result := ar.db.Where("list_item_id = ?", listItemID).Order("id").Find(&attachments); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &attachments, nil
+}
+
+// Delete only hides the attachment, its file is removed later by the purge job.
+func (ar *AttachmentRepository) Delete(attachmentID string) (*int, error) {
+
+	result := ar.db.Delete(&models.Attachment{}, attachmentID)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rowsDeleted := int(result.RowsAffected)
+
+	return &rowsDeleted, nil
+}
+
+// GetPurgeable returns attachments that were deleted, or whose item was deleted before deletedBefore or no
+// longer exists.
+func (ar *AttachmentRepository) GetPurgeable(deletedBefore time.Time, limit int) (*[]models.Attachment, error) {
+
+	var attachments []models.Attachment
+
+	liveItems := ar.db.Table("list_items").Select("id").Where("deleted_at IS NULL OR deleted_at > ?", deletedBefore)
+
+	result := ar.db.Unscoped().
+		Where("deleted_at IS NOT NULL OR list_item_id NOT IN (?)", liveItems).
+		Order("id").
+		Limit(limit).
+		Find(&attachments)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &attachments, nil
+}
+
+// Purge removes the attachment row for good, once its file is gone.
+func (ar *AttachmentRepository) Purge(attachmentID uint) error {
+	return ar.db.Unscoped().Delete(&models.Attachment{}, attachmentID).Error
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewAttachmentRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want AttachmentRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewAttachmentRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewAttachmentRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAttachmentRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAttachmentRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentRepository_Create(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments` (`created_at`,`updated_at`,`deleted_at`,`list_item_id`,`list_id`,`user_id`,`file_name`,`content_type`,`size`,`checksum`,`storage_key`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := attachmentRepository.Create(GetValidAttachment())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestAttachmentRepository_Create_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments`")).WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	result, err := attachmentRepository.Create(GetValidAttachment())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestAttachmentRepository_Get(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE `attachments`.`id` = ? AND `attachments`.`deleted_at` IS NULL ORDER BY `attachments`.`id` LIMIT 1")).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_item_id", "storage_key"}).AddRow(1, 4, "items/4/key"))
+
+	result, err := attachmentRepository.Get("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(4), result.ListItemID)
+	assert.Equal(t, "items/4/key", result.StorageKey)
+}
+
+func TestAttachmentRepository_Get_NotFound(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments`")).WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := attachmentRepository.Get("1")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func TestAttachmentRepository_GetAttachmentsByListItemID(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE list_item_id = ? AND `attachments`.`deleted_at` IS NULL ORDER BY id")).
+		WithArgs("4").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_item_id"}).AddRow(1, 4).AddRow(2, 4))
+
+	result, err := attachmentRepository.GetAttachmentsByListItemID("4")
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 2)
+}
+
+func TestAttachmentRepository_Delete(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `attachments` SET `deleted_at`=? WHERE `attachments`.`id` = ? AND `attachments`.`deleted_at` IS NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := attachmentRepository.Delete("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestAttachmentRepository_GetPurgeable(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+	deletedBefore := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE deleted_at IS NOT NULL OR list_item_id NOT IN (SELECT id FROM `list_items` WHERE deleted_at IS NULL OR deleted_at > ?) ORDER BY id LIMIT 100")).
+		WithArgs(deletedBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "storage_key"}).AddRow(1, "items/4/key"))
+
+	result, err := attachmentRepository.GetPurgeable(deletedBefore, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+	assert.Equal(t, "items/4/key", (*result)[0].StorageKey)
+}
+
+func TestAttachmentRepository_Purge(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	attachmentRepository := NewAttachmentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `attachments` WHERE `attachments`.`id` = ?")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := attachmentRepository.Purge(1)
+
+	assert.NoError(t, err)
+}
+
+func GetValidAttachment() models.Attachment {
+	return models.Attachment{
+		ListItemID:  4,
+		ListID:      3,
+		UserID:      1,
+		FileName:    "receipt.png",
+		ContentType: "image/png",
+		Size:        128,
+		Checksum:    "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+		StorageKey:  "items/4/key",
+	}
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
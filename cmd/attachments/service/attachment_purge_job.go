@@ -0,0 +1,57 @@
+package service
+
+import (
+	"SuperListsAPI/internal/storage"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	purgeBatchSize = 100
+	// attachmentRetention is how long the files of deleted items are kept, so restoring an item brings them back.
+	attachmentRetention = 30 * 24 * time.Hour
+)
+
+// AttachmentPurgeJob removes the files of deleted attachments and of items deleted more than attachmentRetention
+// ago, it implements scheduler.Job. Deleting a file twice is harmless, so every replica can run it.
+type AttachmentPurgeJob struct {
+	repository IAttachmentRepository
+	storage    storage.Storage
+	now        func() time.Time
+}
+
+func NewAttachmentPurgeJob(repository IAttachmentRepository, storage storage.Storage) AttachmentPurgeJob {
+	return AttachmentPurgeJob{repository: repository, storage: storage, now: time.Now}
+}
+
+func (apj *AttachmentPurgeJob) Name() string {
+	return "attachment-purge"
+}
+
+func (apj *AttachmentPurgeJob) Run(ctx context.Context) error {
+
+	attachments, err := apj.repository.GetPurgeable(apj.now().Add(-attachmentRetention), purgeBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range *attachments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		// The row stays while its file can't be removed, so the next run tries again.
+		if err := apj.storage.Delete(ctx, attachment.StorageKey); err != nil {
+			log.Print(fmt.Sprintf("Error removing file of attachment %d: %s", attachment.ID, err.Error()))
+			continue
+		}
+
+		if err := apj.repository.Purge(attachment.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	"SuperListsAPI/internal/storage"
+	"bytes"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestAttachmentPurgeJob_Run(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	fileStorage := getLocalStorage(t)
+	assert.NoError(t, fileStorage.Put(context.Background(), "items/4/key", bytes.NewReader(pngHeader), int64(len(pngHeader)), "image/png"))
+
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetPurgeable(now.Add(-attachmentRetention), purgeBatchSize).Return(&[]models.Attachment{
+		{StorageKey: "items/4/key"},
+		{StorageKey: "items/5/already-gone"},
+	}, nil)
+	mockedRepo.EXPECT().Purge(gomock.Any()).Return(nil).Times(2)
+
+	job := NewAttachmentPurgeJob(mockedRepo, fileStorage)
+	job.now = func() time.Time { return now }
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	_, err = fileStorage.Get(context.Background(), "items/4/key")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestAttachmentPurgeJob_Run_Keeps_Row_When_File_Remains(t *testing.T) {
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetPurgeable(gomock.Any(), purgeBatchSize).Return(&[]models.Attachment{
+		{StorageKey: "items/4/key"},
+	}, nil)
+
+	job := NewAttachmentPurgeJob(mockedRepo, failingStorage{Storage: getLocalStorage(t)})
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestAttachmentPurgeJob_Run_Repository_Error(t *testing.T) {
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetPurgeable(gomock.Any(), purgeBatchSize).Return(nil, errors.New("query failed"))
+
+	job := NewAttachmentPurgeJob(mockedRepo, getLocalStorage(t))
+
+	err := job.Run(context.Background())
+
+	assert.Error(t, err)
+}
+
+// failingStorage can't delete anything.
+type failingStorage struct {
+	storage.Storage
+}
+
+func (fs failingStorage) Delete(ctx context.Context, key string) error {
+	return errors.New("storage unavailable")
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	"SuperListsAPI/internal/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen -source=attachment_service.go -destination attachment_service_mock.go -package service
+
+type IAttachmentRepository interface {
+	Create(attachment models.Attachment) (*models.Attachment, error)
+	Get(attachmentID string) (*models.Attachment, error)
+	GetAttachmentsByListItemID(listItemID string) (*[]models.Attachment, error)
+	Delete(attachmentID string) (*int, error)
+	GetPurgeable(deletedBefore time.Time, limit int) (*[]models.Attachment, error)
+	Purge(attachmentID uint) error
+}
+
+// sniffLength is how much of the file http.DetectContentType looks at.
+const sniffLength = 512
+
+type AttachmentService struct {
+	repository IAttachmentRepository
+	storage    storage.Storage
+}
+
+func NewAttachmentService(repository IAttachmentRepository, storage storage.Storage) AttachmentService {
+	return AttachmentService{repository: repository, storage: storage}
+}
+
+// Upload stores attachment.Size bytes of content and saves the attachment. The content type is sniffed from the
+// content and the checksum is computed while the file is written to the storage.
+func (as *AttachmentService) Upload(ctx context.Context, attachment models.Attachment, content io.Reader) (*models.Attachment, error) {
+
+	if attachment.Size > models.MaxAttachmentSize {
+		return nil, models.ErrAttachmentTooLarge
+	}
+
+	head := make([]byte, sniffLength)
+	read, err := io.ReadFull(content, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	head = head[:read]
+
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil || !models.AllowedContentTypes[contentType] {
+		return nil, models.ErrContentTypeRejected
+	}
+
+	key, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.ContentType = contentType
+	attachment.StorageKey = fmt.Sprintf("items/%d/%s", attachment.ListItemID, key)
+
+	checksum := sha256.New()
+	body := io.TeeReader(io.MultiReader(bytes.NewReader(head), content), checksum)
+
+	if err := as.storage.Put(ctx, attachment.StorageKey, body, attachment.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	attachment.Checksum = hex.EncodeToString(checksum.Sum(nil))
+
+	result, err := as.repository.Create(attachment)
+
+	if err != nil {
+		if deleteErr := as.storage.Delete(ctx, attachment.StorageKey); deleteErr != nil {
+			log.Print(fmt.Sprintf("Error removing file %s of unsaved attachment: %s", attachment.StorageKey, deleteErr.Error()))
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (as *AttachmentService) Get(attachmentID string) (*models.Attachment, error) {
+	return as.repository.Get(attachmentID)
+}
+
+func (as *AttachmentService) GetAttachmentsByListItemID(listItemID string) (*[]models.Attachment, error) {
+	return as.repository.GetAttachmentsByListItemID(listItemID)
+}
+
+// Open reads the file of the attachment, the caller closes it.
+func (as *AttachmentService) Open(ctx context.Context, attachment models.Attachment) (io.ReadCloser, error) {
+	return as.storage.Get(ctx, attachment.StorageKey)
+}
+
+func (as *AttachmentService) Delete(attachmentID string) (*int, error) {
+	return as.repository.Delete(attachmentID)
+}
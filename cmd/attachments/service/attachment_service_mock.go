@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachment_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/attachments/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAttachmentRepository is a mock of IAttachmentRepository interface.
+type MockIAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAttachmentRepositoryMockRecorder
+}
+
+// MockIAttachmentRepositoryMockRecorder is the mock recorder for MockIAttachmentRepository.
+type MockIAttachmentRepositoryMockRecorder struct {
+	mock *MockIAttachmentRepository
+}
+
+// NewMockIAttachmentRepository creates a new mock instance.
+func NewMockIAttachmentRepository(ctrl *gomock.Controller) *MockIAttachmentRepository {
+	mock := &MockIAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockIAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAttachmentRepository) EXPECT() *MockIAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIAttachmentRepository) Create(attachment models.Attachment) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", attachment)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIAttachmentRepositoryMockRecorder) Create(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIAttachmentRepository)(nil).Create), attachment)
+}
+
+// Delete mocks base method.
+func (m *MockIAttachmentRepository) Delete(attachmentID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", attachmentID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIAttachmentRepositoryMockRecorder) Delete(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIAttachmentRepository)(nil).Delete), attachmentID)
+}
+
+// Get mocks base method.
+func (m *MockIAttachmentRepository) Get(attachmentID string) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", attachmentID)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIAttachmentRepositoryMockRecorder) Get(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIAttachmentRepository)(nil).Get), attachmentID)
+}
+
+// GetAttachmentsByListItemID mocks base method.
+func (m *MockIAttachmentRepository) GetAttachmentsByListItemID(listItemID string) (*[]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByListItemID", listItemID)
+	ret0, _ := ret[0].(*[]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachmentsByListItemID indicates an expected call of GetAttachmentsByListItemID.
+func (mr *MockIAttachmentRepositoryMockRecorder) GetAttachmentsByListItemID(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByListItemID", reflect.TypeOf((*MockIAttachmentRepository)(nil).GetAttachmentsByListItemID), listItemID)
+}
+
+// GetPurgeable mocks base method.
+func (m *MockIAttachmentRepository) GetPurgeable(deletedBefore time.Time, limit int) (*[]models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurgeable", deletedBefore, limit)
+	ret0, _ := ret[0].(*[]models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurgeable indicates an expected call of GetPurgeable.
+func (mr *MockIAttachmentRepositoryMockRecorder) GetPurgeable(deletedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurgeable", reflect.TypeOf((*MockIAttachmentRepository)(nil).GetPurgeable), deletedBefore, limit)
+}
+
+// Purge mocks base method.
+func (m *MockIAttachmentRepository) Purge(attachmentID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", attachmentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockIAttachmentRepositoryMockRecorder) Purge(attachmentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockIAttachmentRepository)(nil).Purge), attachmentID)
+}
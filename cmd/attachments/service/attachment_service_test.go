@@ -0,0 +1,176 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/attachments/models"
+	"SuperListsAPI/internal/storage"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+func TestNewAttachmentService(t *testing.T) {
+	type args struct {
+		repository IAttachmentRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want AttachmentService
+	}{
+		{
+			name: "Service with nil repo should pass",
+			args: args{nil},
+			want: NewAttachmentService(nil, nil),
+		},
+		{
+			name: "Service with no nil repo should pass",
+			args: args{NewMockIAttachmentRepository(gomock.NewController(t))},
+			want: NewAttachmentService(NewMockIAttachmentRepository(gomock.NewController(t)), nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAttachmentService(tt.args.repository, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAttachmentService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{1}, 1000)...)
+	sum := sha256.Sum256(content)
+
+	fileStorage := getLocalStorage(t)
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(attachment models.Attachment) (*models.Attachment, error) {
+		attachment.ID = 1
+		return &attachment, nil
+	})
+
+	attachmentService := NewAttachmentService(mockedRepo, fileStorage)
+
+	result, err := attachmentService.Upload(context.Background(), models.Attachment{
+		ListItemID: 4, ListID: 3, UserID: 1, FileName: "photo.png", Size: int64(len(content)),
+	}, bytes.NewReader(content))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", result.ContentType)
+	assert.Equal(t, hex.EncodeToString(sum[:]), result.Checksum)
+	assert.True(t, strings.HasPrefix(result.StorageKey, "items/4/"))
+
+	stored, err := attachmentService.Open(context.Background(), *result)
+	assert.NoError(t, err)
+	defer stored.Close()
+	storedContent, _ := ioutil.ReadAll(stored)
+	assert.Equal(t, content, storedContent)
+}
+
+func TestAttachmentService_Upload_Pdf(t *testing.T) {
+	content := []byte("%PDF-1.4\n%fake document")
+
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(attachment models.Attachment) (*models.Attachment, error) {
+		return &attachment, nil
+	})
+
+	attachmentService := NewAttachmentService(mockedRepo, getLocalStorage(t))
+
+	result, err := attachmentService.Upload(context.Background(), models.Attachment{
+		ListItemID: 4, FileName: "ticket.pdf", Size: int64(len(content)),
+	}, bytes.NewReader(content))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "application/pdf", result.ContentType)
+}
+
+func TestAttachmentService_Upload_Rejected_Type(t *testing.T) {
+	content := []byte("<html><script>alert(1)</script></html>")
+
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+
+	attachmentService := NewAttachmentService(mockedRepo, getLocalStorage(t))
+
+	result, err := attachmentService.Upload(context.Background(), models.Attachment{
+		ListItemID: 4, FileName: "photo.png", Size: int64(len(content)),
+	}, bytes.NewReader(content))
+
+	assert.ErrorIs(t, err, models.ErrContentTypeRejected)
+	assert.Nil(t, result)
+}
+
+func TestAttachmentService_Upload_Too_Large(t *testing.T) {
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+
+	attachmentService := NewAttachmentService(mockedRepo, getLocalStorage(t))
+
+	result, err := attachmentService.Upload(context.Background(), models.Attachment{
+		ListItemID: 4, FileName: "photo.png", Size: models.MaxAttachmentSize + 1,
+	}, bytes.NewReader(pngHeader))
+
+	assert.ErrorIs(t, err, models.ErrAttachmentTooLarge)
+	assert.Nil(t, result)
+}
+
+func TestAttachmentService_Upload_Removes_File_On_Repository_Error(t *testing.T) {
+	fileStorage := &recordingStorage{Storage: getLocalStorage(t)}
+
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("insert failed"))
+
+	attachmentService := NewAttachmentService(mockedRepo, fileStorage)
+
+	result, err := attachmentService.Upload(context.Background(), models.Attachment{
+		ListItemID: 4, FileName: "photo.png", Size: int64(len(pngHeader)),
+	}, bytes.NewReader(pngHeader))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Len(t, fileStorage.deleted, 1)
+
+	_, err = fileStorage.Get(context.Background(), fileStorage.deleted[0])
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	rowsAffected := 1
+
+	mockedRepo := NewMockIAttachmentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Delete("1").Return(&rowsAffected, nil)
+
+	attachmentService := NewAttachmentService(mockedRepo, nil)
+
+	result, err := attachmentService.Delete("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+// recordingStorage keeps the keys deleted from the wrapped storage.
+type recordingStorage struct {
+	storage.Storage
+	deleted []string
+}
+
+func (rs *recordingStorage) Delete(ctx context.Context, key string) error {
+	rs.deleted = append(rs.deleted, key)
+	return rs.Storage.Delete(ctx, key)
+}
+
+func getLocalStorage(t *testing.T) storage.Storage {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return fileStorage
+}
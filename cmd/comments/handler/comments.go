@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/comments/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/httputil"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=comments.go -destination comments_mock.go -package handler
+
+type ICommentService interface {
+	Create(ctx context.Context, comment models.Comment) (*models.Comment, error)
+	Update(userID uint, commentID string, body string) (*models.Comment, error)
+	Delete(ctx context.Context, userID uint, commentID string) (*int, error)
+	GetComments(thread models.Thread, page int, pageSize int) (*models.CommentPage, error)
+}
+
+type IListItemService interface {
+	Get(listItemID string) (*listItemModels.ListItem, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+type CommentHandler struct {
+	commentService  ICommentService
+	listItemService IListItemService
+	userListService IUserListService
+}
+
+func NewCommentHandler(commentService ICommentService, listItemService IListItemService, userListService IUserListService) CommentHandler {
+	return CommentHandler{commentService: commentService, listItemService: listItemService, userListService: userListService}
+}
+
+// CreateListComment adds a comment to the thread of the list itself.
+func (ch *CommentHandler) CreateListComment(c *gin.Context) {
+	thread, ok := listThread(c)
+	if !ok {
+		return
+	}
+
+	ch.create(c, thread)
+}
+
+// CreateItemComment adds a comment to the thread of a list item.
+func (ch *CommentHandler) CreateItemComment(c *gin.Context) {
+	thread, ok := ch.itemThread(c)
+	if !ok {
+		return
+	}
+
+	ch.create(c, thread)
+}
+
+func (ch *CommentHandler) GetListComments(c *gin.Context) {
+	thread, ok := listThread(c)
+	if !ok {
+		return
+	}
+
+	ch.getComments(c, thread)
+}
+
+func (ch *CommentHandler) GetItemComments(c *gin.Context) {
+	thread, ok := ch.itemThread(c)
+	if !ok {
+		return
+	}
+
+	ch.getComments(c, thread)
+}
+
+// Update changes the body of a comment, only its author can do it.
+func (ch *CommentHandler) Update(c *gin.Context) {
+	var comment models.Comment
+
+	commentID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := strconv.Atoi(commentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid comment id",
+		})
+		c.Abort()
+		return
+	}
+
+	if !bindComment(c, &comment) {
+		return
+	}
+
+	result, err := ch.commentService.Update(uint(userID), commentID, comment.Body)
+
+	if !commentFound(c, commentID, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// Delete removes a comment, only its author can do it.
+func (ch *CommentHandler) Delete(c *gin.Context) {
+	commentID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := strconv.Atoi(commentID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid comment id",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := ch.commentService.Delete(c.Request.Context(), uint(userID), commentID)
+
+	if !commentFound(c, commentID, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (ch *CommentHandler) create(c *gin.Context, thread models.Thread) {
+	var comment models.Comment
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if !bindComment(c, &comment) {
+		return
+	}
+
+	if !httputil.CheckListMember(c, ch.userListService, fmt.Sprint(thread.ListID), uint(userID)) {
+		return
+	}
+
+	comment = models.Comment{
+		ListID:     thread.ListID,
+		ListItemID: thread.ListItemID,
+		UserID:     uint(userID),
+		Body:       comment.Body,
+	}
+
+	result, err := ch.commentService.Create(c.Request.Context(), comment)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+// getComments answers a page of the thread, asked with ?page= and ?page_size=.
+func (ch *CommentHandler) getComments(c *gin.Context, thread models.Thread) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	page, ok := httputil.QueryNumber(c, "page", 1, 1, 0)
+	if !ok {
+		return
+	}
+
+	pageSize, ok := httputil.QueryNumber(c, "page_size", models.DefaultPageSize, 1, models.MaxPageSize)
+	if !ok {
+		return
+	}
+
+	if !httputil.CheckListMember(c, ch.userListService, fmt.Sprint(thread.ListID), uint(userID)) {
+		return
+	}
+
+	result, err := ch.commentService.GetComments(thread, page, pageSize)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func listThread(c *gin.Context) (models.Thread, bool) {
+	listID, err := strconv.Atoi(c.Param("id"))
+
+	if err != nil || listID < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list id",
+		})
+		c.Abort()
+		return models.Thread{}, false
+	}
+
+	return models.Thread{ListID: uint(listID)}, true
+}
+
+// itemThread loads the item on the request path to know which list its thread belongs to.
+func (ch *CommentHandler) itemThread(c *gin.Context) (models.Thread, bool) {
+	listItemID := c.Param("id")
+
+	if _, err := strconv.Atoi(listItemID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list item id",
+		})
+		c.Abort()
+		return models.Thread{}, false
+	}
+
+	listItem, err := ch.listItemService.Get(listItemID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("ListItem with id %s not found", listItemID))
+		return models.Thread{}, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return models.Thread{}, false
+	}
+
+	return models.Thread{ListID: uint(listItem.ListID), ListItemID: &listItem.ID}, true
+}
+
+func bindComment(c *gin.Context, comment *models.Comment) bool {
+	if err := c.ShouldBindJSON(comment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return false
+	}
+
+	if err := validator.New().Struct(comment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// commentFound answers the request when changing a comment failed, telling missing comments and comments of
+// other users apart.
+func commentFound(c *gin.Context, commentID string, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Comment with id %s not found", commentID))
+	case errors.Is(err, models.ErrNotCommentAuthor):
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, err)
+	}
+
+	c.Abort()
+	return false
+}
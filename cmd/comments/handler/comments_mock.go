@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comments.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/comments/models"
+	models0 "SuperListsAPI/cmd/listItems/models"
+	models1 "SuperListsAPI/cmd/userLists/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICommentService is a mock of ICommentService interface.
+type MockICommentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockICommentServiceMockRecorder
+}
+
+// MockICommentServiceMockRecorder is the mock recorder for MockICommentService.
+type MockICommentServiceMockRecorder struct {
+	mock *MockICommentService
+}
+
+// NewMockICommentService creates a new mock instance.
+func NewMockICommentService(ctrl *gomock.Controller) *MockICommentService {
+	mock := &MockICommentService{ctrl: ctrl}
+	mock.recorder = &MockICommentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICommentService) EXPECT() *MockICommentServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockICommentService) Create(ctx context.Context, comment models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICommentServiceMockRecorder) Create(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICommentService)(nil).Create), ctx, comment)
+}
+
+// Delete mocks base method.
+func (m *MockICommentService) Delete(ctx context.Context, userID uint, commentID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, userID, commentID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockICommentServiceMockRecorder) Delete(ctx, userID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICommentService)(nil).Delete), ctx, userID, commentID)
+}
+
+// GetComments mocks base method.
+func (m *MockICommentService) GetComments(thread models.Thread, page, pageSize int) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", thread, page, pageSize)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockICommentServiceMockRecorder) GetComments(thread, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockICommentService)(nil).GetComments), thread, page, pageSize)
+}
+
+// Update mocks base method.
+func (m *MockICommentService) Update(userID uint, commentID, body string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userID, commentID, body)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockICommentServiceMockRecorder) Update(userID, commentID, body interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockICommentService)(nil).Update), userID, commentID, body)
+}
+
+// MockIListItemService is a mock of IListItemService interface.
+type MockIListItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListItemServiceMockRecorder
+}
+
+// MockIListItemServiceMockRecorder is the mock recorder for MockIListItemService.
+type MockIListItemServiceMockRecorder struct {
+	mock *MockIListItemService
+}
+
+// NewMockIListItemService creates a new mock instance.
+func NewMockIListItemService(ctrl *gomock.Controller) *MockIListItemService {
+	mock := &MockIListItemService{ctrl: ctrl}
+	mock.recorder = &MockIListItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIListItemService) Get(listItemID string) (*models0.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", listItemID)
+	ret0, _ := ret[0].(*models0.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIListItemServiceMockRecorder) Get(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListItemService)(nil).Get), listItemID)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models1.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
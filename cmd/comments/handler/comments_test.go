@@ -0,0 +1,286 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/comments/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewCommentHandler(t *testing.T) {
+	type args struct {
+		service ICommentService
+	}
+	tests := []struct {
+		name string
+		args args
+		want CommentHandler
+	}{
+		{
+			name: "Test with nil service should pass",
+			args: args{nil},
+			want: NewCommentHandler(nil, nil, nil),
+		},
+		{
+			name: "Test with no nil service should pass",
+			args: args{NewMockICommentService(gomock.NewController(t))},
+			want: NewCommentHandler(NewMockICommentService(gomock.NewController(t)), nil, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCommentHandler(tt.args.service, nil, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCommentHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentHandler_CreateItemComment(t *testing.T) {
+	members := &[]userListModels.UserList{{ListID: 3, UserID: 7}}
+	listItem := listItemModels.ListItem{ListID: 3}
+	listItem.ID = 4
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		setup      func(comments *MockICommentService, listItems *MockIListItemService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name: "Comment by a list member",
+			body: map[string]interface{}{"body": "@jose can you buy it?", "user_id": 1, "list_id": 9},
+			setup: func(comments *MockICommentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				comments.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, comment models.Comment) (*models.Comment, error) {
+					if comment.UserID != 7 || comment.ListID != 3 || *comment.ListItemID != 4 {
+						return nil, errors.New("unexpected comment")
+					}
+					return &comment, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Empty comment",
+			body: map[string]interface{}{"body": ""},
+			setup: func(comments *MockICommentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Item not found",
+			body: map[string]interface{}{"body": "hola"},
+			setup: func(comments *MockICommentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Caller is not a list member",
+			body: map[string]interface{}{"body": "hola"},
+			setup: func(comments *MockICommentService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItem, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 8}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			comments := NewMockICommentService(ctrl)
+			listItems := NewMockIListItemService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(comments, listItems, userLists)
+
+			commentHandler := NewCommentHandler(comments, listItems, userLists)
+
+			body, _ := json.Marshal(tt.body)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "4"}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/listItems/4/comments", strings.NewReader(string(body)))
+			c.Request.Header.Set("user_id", "7")
+
+			commentHandler.CreateItemComment(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCommentHandler_CreateListComment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	comments := NewMockICommentService(ctrl)
+	userLists := NewMockIUserListService(ctrl)
+	userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+	comments.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, comment models.Comment) (*models.Comment, error) {
+		assert.Nil(t, comment.ListItemID)
+		return &comment, nil
+	})
+
+	commentHandler := NewCommentHandler(comments, nil, userLists)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "3"}}
+	c.Request, _ = http.NewRequest(http.MethodPost, "/v1/lists/3/comments", strings.NewReader(`{"body": "hola"}`))
+	c.Request.Header.Set("user_id", "7")
+
+	commentHandler.CreateListComment(c)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestCommentHandler_GetListComments(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		setup      func(comments *MockICommentService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name:  "Default page",
+			query: "",
+			setup: func(comments *MockICommentService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				comments.EXPECT().GetComments(models.Thread{ListID: 3}, 1, models.DefaultPageSize).Return(&models.CommentPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "Second page",
+			query: "?page=2&page_size=5",
+			setup: func(comments *MockICommentService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{ListID: 3, UserID: 7}}, nil)
+				comments.EXPECT().GetComments(models.Thread{ListID: 3}, 2, 5).Return(&models.CommentPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "Page size too large",
+			query: "?page_size=500",
+			setup: func(comments *MockICommentService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Invalid page",
+			query: "?page=0",
+			setup: func(comments *MockICommentService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Caller is not a list member",
+			query: "",
+			setup: func(comments *MockICommentService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			comments := NewMockICommentService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(comments, userLists)
+
+			commentHandler := NewCommentHandler(comments, nil, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "3"}}
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/lists/3/comments"+tt.query, nil)
+			c.Request.Header.Set("user_id", "7")
+
+			commentHandler.GetListComments(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCommentHandler_Update(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "Author edits the comment", wantStatus: http.StatusOK},
+		{name: "Comment of another user", err: models.ErrNotCommentAuthor, wantStatus: http.StatusForbidden},
+		{name: "Comment not found", err: gorm.ErrRecordNotFound, wantStatus: http.StatusNotFound},
+		{name: "Error from service", err: errors.New("error from comment service"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			comments := NewMockICommentService(gomock.NewController(t))
+			var result *models.Comment
+			if tt.err == nil {
+				result = &models.Comment{Body: "chau"}
+			}
+			comments.EXPECT().Update(uint(7), "9", "chau").Return(result, tt.err)
+
+			commentHandler := NewCommentHandler(comments, nil, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: "9"}}
+			c.Request, _ = http.NewRequest(http.MethodPut, "/v1/comments/9", strings.NewReader(`{"body": "chau"}`))
+			c.Request.Header.Set("user_id", "7")
+
+			commentHandler.Update(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestCommentHandler_Delete(t *testing.T) {
+	deleted := 1
+
+	comments := NewMockICommentService(gomock.NewController(t))
+	comments.EXPECT().Delete(gomock.Any(), uint(7), "9").Return(&deleted, nil)
+
+	commentHandler := NewCommentHandler(comments, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "9"}}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/v1/comments/9", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	commentHandler.Delete(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCommentHandler_Delete_Invalid_ID(t *testing.T) {
+	commentHandler := NewCommentHandler(NewMockICommentService(gomock.NewController(t)), nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "abc"}}
+	c.Request, _ = http.NewRequest(http.MethodDelete, "/v1/comments/abc", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	commentHandler.Delete(c)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
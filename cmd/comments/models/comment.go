@@ -0,0 +1,46 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var ErrNotCommentAuthor = errors.New("only the author can change a comment")
+
+// Comment is a message on a list, or on one of its items when ListItemID is set. ListID is always set so
+// membership can be checked without loading the item.
+type Comment struct {
+	gorm.Model
+	ListID     uint       `json:"list_id"`
+	ListItemID *uint      `json:"list_item_id,omitempty"`
+	UserID     uint       `json:"user_id"`
+	Body       string     `json:"body" validate:"required,max=2000"`
+	EditedAt   *time.Time `json:"edited_at,omitempty"`
+}
+
+// Thread identifies the comments of a list, or of one of its items when ListItemID is set.
+type Thread struct {
+	ListID     uint
+	ListItemID *uint
+}
+
+// CommentPage is a page of a comment thread, oldest comments first.
+type CommentPage struct {
+	Comments []Comment `json:"comments"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+	Total    int64     `json:"total"`
+}
+
+// Member is a user of the list a comment belongs to, the only users that can be mentioned on it.
+type Member struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+var mentionPattern = regexp.MustCompile(`(^|[^\w@.])@([\w.+-]+@[\w-]+(?:\.[\w-]+)+|[\p{L}\p{N}_-]+)`)
+
+// MentionedMembers returns the members mentioned on body, in order and without repeats. A mention is either
+// "@email" or "@name", where name is the member name without spaces or only its first word. Names shared by
+// more than one member are ambiguous and mention nobody.
+func MentionedMembers(body string, members []Member) []Member {
+
+	byKey := map[string][]Member{}
+	for _, member := range members {
+		keys := map[string]bool{strings.ToLower(member.Email): true}
+		if words := strings.Fields(strings.ToLower(member.Name)); len(words) > 0 {
+			keys[strings.Join(words, "")] = true
+			keys[words[0]] = true
+		}
+		for key := range keys {
+			if key != "" {
+				byKey[key] = append(byKey[key], member)
+			}
+		}
+	}
+
+	var mentioned []Member
+	seen := map[uint]bool{}
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		token := strings.ToLower(strings.TrimRight(match[2], ".-"))
+
+		candidates := byKey[token]
+		if len(candidates) != 1 || seen[candidates[0].ID] {
+			continue
+		}
+
+		seen[candidates[0].ID] = true
+		mentioned = append(mentioned, candidates[0])
+	}
+
+	return mentioned
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMentionedMembers(t *testing.T) {
+	members := []Member{
+		{ID: 1, Name: "Ana Gómez", Email: "ana@mail.com"},
+		{ID: 2, Name: "José Pérez", Email: "jose@mail.com"},
+		{ID: 3, Name: "Ana Ruiz", Email: "aruiz@mail.com"},
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want []uint
+	}{
+		{name: "By email", body: "@jose@mail.com can you buy it?", want: []uint{2}},
+		{name: "By full name", body: "thanks @AnaGómez!", want: []uint{1}},
+		{name: "By first name", body: "@josé, @anaruiz.", want: []uint{2, 3}},
+		{name: "Ambiguous first name", body: "@ana can you?", want: nil},
+		{name: "Repeated mention", body: "@jose @jose@mail.com", want: []uint{2}},
+		{name: "Not a member", body: "@pedro", want: nil},
+		{name: "Email address is not a mention", body: "write to ana@mail.com", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []uint
+			for _, member := range MentionedMembers(tt.body, members) {
+				got = append(got, member.ID)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/comments/models"
+	"SuperListsAPI/internal/activity"
+	"context"
+	"gorm.io/gorm"
+)
+
+type CommentRepository struct {
+	db *gorm.DB
+}
+
+func NewCommentRepository(db *gorm.DB) CommentRepository {
+	return CommentRepository{db: db}
+}
+
+// Create saves comment and records it on the activity of its list, the comment counts of the list items change with it.
+func (cr *CommentRepository) Create(ctx context.Context, comment models.Comment) (*models.Comment, error) {
+
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Create(&comment); result.Error != nil {
+			return result.Error
+		}
+
+		return activity.Record(tx, commentEntry(comment, activity.ActionCreated, activity.Diff(nil, comment)))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (cr *CommentRepository) Get(commentID string) (*models.Comment, error) {
+
+	var comment models.Comment
+
+	if result := cr.db.First(&comment, commentID); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &comment, nil
+}
+
+func (cr *CommentRepository) Update(comment models.Comment) (*models.Comment, error) {
+
+	result := cr.db.Model(&comment).Updates(map[string]interface{}{
+		"body":      comment.Body,
+		"edited_at": comment.EditedAt,
+	})
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &comment, nil
+}
+
+// Delete removes the comment and records it on the activity of its list, like Create.
+func (cr *CommentRepository) Delete(ctx context.Context, commentID string) (*int, error) {
+
+	var rowsDeleted int
+
+	err := cr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var comment models.Comment
+		if result := tx.First(&comment, commentID); result.Error != nil {
+			return result.Error
+		}
+
+		result := tx.Delete(&models.Comment{}, comment.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		rowsDeleted = int(result.RowsAffected)
+
+		return activity.Record(tx, commentEntry(comment, activity.ActionDeleted, activity.Diff(comment, nil)))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rowsDeleted, nil
+}
+
+// GetComments returns a page of the thread, oldest comments first, along with the thread size.
+func (cr *CommentRepository) GetComments(thread models.Thread, page int, pageSize int) (*models.CommentPage, error) {
+
+	commentPage := models.CommentPage{Comments: []models.Comment{}, Page: page, PageSize: pageSize}
+
+	if result := cr.db.Model(&models.Comment{}).Scopes(inThread(thread)).Count(&commentPage.Total); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if commentPage.Total == 0 {
+		return &commentPage, nil
+	}
+
+	result := cr.db.Scopes(inThread(thread)).Order("created_at, id").Offset((page - 1) * pageSize).Limit(pageSize).Find(&commentPage.Comments)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &commentPage, nil
+}
+
+// inThread keeps the comments of the list itself apart from the ones on its items.
+func inThread(thread models.Thread) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if thread.ListItemID != nil {
+			return db.Where("list_id = ? AND list_item_id = ?", thread.ListID, *thread.ListItemID)
+		}
+		return db.Where("list_id = ? AND list_item_id IS NULL", thread.ListID)
+	}
+}
+
+// GetListMembers returns the name and email of every member of the list.
+func (cr *CommentRepository) GetListMembers(listID uint) (*[]models.Member, error) {
+
+	var members []models.Member
+
+	result := cr.db.Table("users").
+		Select("users.id, users.name, users.email").
+		Joins("JOIN user_lists ON user_lists.user_id = users.id").
+		Where("user_lists.list_id = ? AND user_lists.deleted_at IS NULL AND users.deleted_at IS NULL", listID).
+		Order("users.id").
+		Scan(&members)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &members, nil
+}
+
+func commentEntry(comment models.Comment, action string, changes activity.Changes) activity.Entry {
+	return activity.Entry{
+		ListID:     comment.ListID,
+		Action:     action,
+		TargetType: activity.TargetComment,
+		TargetID:   comment.ID,
+		Changes:    changes,
+	}
+}
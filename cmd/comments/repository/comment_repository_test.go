@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/comments/models"
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewCommentRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want CommentRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewCommentRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewCommentRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCommentRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCommentRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentRepository_Create(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `comments` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`list_item_id`,`user_id`,`body`,`edited_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := commentRepository.Create(context.Background(), GetValidComment())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestCommentRepository_Create_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `comments`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := commentRepository.Create(context.Background(), GetValidComment())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCommentRepository_Get(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `comments` WHERE `comments`.`id` = ? AND `comments`.`deleted_at` IS NULL ORDER BY `comments`.`id` LIMIT 1")).
+		WithArgs("9").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "body"}).AddRow(9, 1, "hola"))
+
+	result, err := commentRepository.Get("9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hola", result.Body)
+}
+
+func TestCommentRepository_Update(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	comment := GetValidComment()
+	comment.ID = 9
+	editedAt := time.Date(2022, 3, 1, 18, 0, 0, 0, time.UTC)
+	comment.EditedAt = &editedAt
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `comments` SET `body`=?,`edited_at`=?,`updated_at`=? WHERE `id` = ? AND `comments`.`deleted_at` IS NULL")).
+		WithArgs("@jose can you buy it?", editedAt, sqlmock.AnyArg(), 9).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := commentRepository.Update(comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), result.ID)
+}
+
+func TestCommentRepository_Delete(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `comments` WHERE `comments`.`id` = ? AND `comments`.`deleted_at` IS NULL ORDER BY `comments`.`id` LIMIT 1")).
+		WithArgs("9").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "body"}).AddRow(9, 3, 1, "hola"))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `comments` SET `deleted_at`=? WHERE `comments`.`id` = ? AND `comments`.`deleted_at` IS NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WithArgs(3, sqlmock.AnyArg(), "deleted", "comment", 9, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := commentRepository.Delete(context.Background(), "9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentRepository_Delete_Not_Found(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `comments`")).
+		WillReturnError(gorm.ErrRecordNotFound)
+	mock.ExpectRollback()
+
+	result, err := commentRepository.Delete(context.Background(), "9")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func TestCommentRepository_GetComments_Item_Thread(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	listItemID := uint(4)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `comments` WHERE (list_id = ? AND list_item_id = ?) AND `comments`.`deleted_at` IS NULL")).
+		WithArgs(3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(12))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `comments` WHERE (list_id = ? AND list_item_id = ?) AND `comments`.`deleted_at` IS NULL ORDER BY created_at, id LIMIT 10 OFFSET 10")).
+		WithArgs(3, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "body"}).AddRow(11, "hola").AddRow(12, "chau"))
+
+	result, err := commentRepository.GetComments(models.Thread{ListID: 3, ListItemID: &listItemID}, 2, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), result.Total)
+	assert.Len(t, result.Comments, 2)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentRepository_GetComments_Empty_List_Thread(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `comments` WHERE (list_id = ? AND list_item_id IS NULL) AND `comments`.`deleted_at` IS NULL")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	result, err := commentRepository.GetComments(models.Thread{ListID: 3}, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Comments)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCommentRepository_GetListMembers(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	commentRepository := NewCommentRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT users.id, users.name, users.email FROM `users` JOIN user_lists ON user_lists.user_id = users.id WHERE user_lists.list_id = ? AND user_lists.deleted_at IS NULL AND users.deleted_at IS NULL ORDER BY users.id")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "email"}).AddRow(1, "Ana Gómez", "ana@mail.com"))
+
+	result, err := commentRepository.GetListMembers(3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Member{{ID: 1, Name: "Ana Gómez", Email: "ana@mail.com"}}, *result)
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func GetValidComment() models.Comment {
+	listItemID := uint(4)
+
+	return models.Comment{
+		ListID:     3,
+		ListItemID: &listItemID,
+		UserID:     1,
+		Body:       "@jose can you buy it?",
+	}
+}
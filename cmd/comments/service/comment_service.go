@@ -0,0 +1,157 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/comments/models"
+	"SuperListsAPI/internal/notify"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+//go:generate mockgen -source=comment_service.go -destination comment_service_mock.go -package service
+
+type ICommentRepository interface {
+	Create(ctx context.Context, comment models.Comment) (*models.Comment, error)
+	Get(commentID string) (*models.Comment, error)
+	Update(comment models.Comment) (*models.Comment, error)
+	Delete(ctx context.Context, commentID string) (*int, error)
+	GetComments(thread models.Thread, page int, pageSize int) (*models.CommentPage, error)
+	GetListMembers(listID uint) (*[]models.Member, error)
+}
+
+// maxMentionTitle is the size of the notifications title column.
+const maxMentionTitle = 150
+
+type CommentService struct {
+	repository ICommentRepository
+	notifier   notify.Notifier
+}
+
+func NewCommentService(repository ICommentRepository, notifier notify.Notifier) CommentService {
+	return CommentService{repository: repository, notifier: notifier}
+}
+
+func (cs *CommentService) Create(ctx context.Context, comment models.Comment) (*models.Comment, error) {
+
+	result, err := cs.repository.Create(ctx, comment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cs.notifyMentions(*result)
+
+	return result, nil
+}
+
+func (cs *CommentService) Get(commentID string) (*models.Comment, error) {
+	return cs.repository.Get(commentID)
+}
+
+// Update changes the body of a comment written by userID. Members mentioned on the new body get notified,
+// the ones already mentioned before are not notified again.
+func (cs *CommentService) Update(userID uint, commentID string, body string) (*models.Comment, error) {
+
+	comment, err := cs.authoredComment(userID, commentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	editedAt := time.Now()
+	comment.Body = body
+	comment.EditedAt = &editedAt
+
+	result, err := cs.repository.Update(*comment)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cs.notifyMentions(*result)
+
+	return result, nil
+}
+
+func (cs *CommentService) Delete(ctx context.Context, userID uint, commentID string) (*int, error) {
+
+	if _, err := cs.authoredComment(userID, commentID); err != nil {
+		return nil, err
+	}
+
+	return cs.repository.Delete(ctx, commentID)
+}
+
+func (cs *CommentService) GetComments(thread models.Thread, page int, pageSize int) (*models.CommentPage, error) {
+	return cs.repository.GetComments(thread, page, pageSize)
+}
+
+func (cs *CommentService) authoredComment(userID uint, commentID string) (*models.Comment, error) {
+
+	comment, err := cs.repository.Get(commentID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, models.ErrNotCommentAuthor
+	}
+
+	return comment, nil
+}
+
+// notifyMentions sends an in-app notification to every member mentioned on the comment. The delivery id is the
+// same for a comment and a member, so editing a comment doesn't notify twice. Failures are only logged, the
+// comment is already saved by then.
+func (cs *CommentService) notifyMentions(comment models.Comment) {
+
+	members, err := cs.repository.GetListMembers(comment.ListID)
+
+	if err != nil {
+		log.Print(fmt.Sprintf("Error loading members of list %d for comment %d: %s", comment.ListID, comment.ID, err.Error()))
+		return
+	}
+
+	author := fmt.Sprintf("User %d", comment.UserID)
+	for _, member := range *members {
+		if member.ID == comment.UserID && member.Name != "" {
+			author = member.Name
+		}
+	}
+
+	var listItemID uint
+	if comment.ListItemID != nil {
+		listItemID = *comment.ListItemID
+	}
+
+	for _, member := range models.MentionedMembers(comment.Body, *members) {
+		if member.ID == comment.UserID {
+			continue
+		}
+
+		err := cs.notifier.Notify(context.Background(), notify.Event{
+			DeliveryID: fmt.Sprintf("mention-%d-%d", comment.ID, member.ID),
+			Kind:       notify.KindMention,
+			CommentID:  comment.ID,
+			UserID:     member.ID,
+			ListID:     comment.ListID,
+			ListItemID: listItemID,
+			Title:      truncate(fmt.Sprintf("%s mentioned you: %s", author, comment.Body), maxMentionTitle),
+			Channel:    notify.ChannelInApp,
+		})
+
+		if err != nil {
+			log.Print(fmt.Sprintf("Error notifying user %d of comment %d: %s", member.ID, comment.ID, err.Error()))
+		}
+	}
+}
+
+func truncate(text string, size int) string {
+	runes := []rune(text)
+	if len(runes) <= size {
+		return text
+	}
+	return string(runes[:size-1]) + "…"
+}
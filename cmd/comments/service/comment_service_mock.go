@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/comments/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICommentRepository is a mock of ICommentRepository interface.
+type MockICommentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockICommentRepositoryMockRecorder
+}
+
+// MockICommentRepositoryMockRecorder is the mock recorder for MockICommentRepository.
+type MockICommentRepositoryMockRecorder struct {
+	mock *MockICommentRepository
+}
+
+// NewMockICommentRepository creates a new mock instance.
+func NewMockICommentRepository(ctrl *gomock.Controller) *MockICommentRepository {
+	mock := &MockICommentRepository{ctrl: ctrl}
+	mock.recorder = &MockICommentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICommentRepository) EXPECT() *MockICommentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockICommentRepository) Create(ctx context.Context, comment models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockICommentRepositoryMockRecorder) Create(ctx, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockICommentRepository)(nil).Create), ctx, comment)
+}
+
+// Delete mocks base method.
+func (m *MockICommentRepository) Delete(ctx context.Context, commentID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, commentID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockICommentRepositoryMockRecorder) Delete(ctx, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockICommentRepository)(nil).Delete), ctx, commentID)
+}
+
+// Get mocks base method.
+func (m *MockICommentRepository) Get(commentID string) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", commentID)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockICommentRepositoryMockRecorder) Get(commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockICommentRepository)(nil).Get), commentID)
+}
+
+// GetComments mocks base method.
+func (m *MockICommentRepository) GetComments(thread models.Thread, page, pageSize int) (*models.CommentPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", thread, page, pageSize)
+	ret0, _ := ret[0].(*models.CommentPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockICommentRepositoryMockRecorder) GetComments(thread, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockICommentRepository)(nil).GetComments), thread, page, pageSize)
+}
+
+// GetListMembers mocks base method.
+func (m *MockICommentRepository) GetListMembers(listID uint) (*[]models.Member, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMembers", listID)
+	ret0, _ := ret[0].(*[]models.Member)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMembers indicates an expected call of GetListMembers.
+func (mr *MockICommentRepositoryMockRecorder) GetListMembers(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMembers", reflect.TypeOf((*MockICommentRepository)(nil).GetListMembers), listID)
+}
+
+// Update mocks base method.
+func (m *MockICommentRepository) Update(comment models.Comment) (*models.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", comment)
+	ret0, _ := ret[0].(*models.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockICommentRepositoryMockRecorder) Update(comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockICommentRepository)(nil).Update), comment)
+}
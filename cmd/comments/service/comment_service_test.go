@@ -0,0 +1,193 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/comments/models"
+	"SuperListsAPI/internal/notify"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"reflect"
+	"testing"
+)
+
+type notifierFunc func(ctx context.Context, event notify.Event) error
+
+func (f notifierFunc) Notify(ctx context.Context, event notify.Event) error {
+	return f(ctx, event)
+}
+
+func TestNewCommentService(t *testing.T) {
+	type args struct {
+		repository ICommentRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want CommentService
+	}{
+		{
+			name: "Service with nil repo should pass",
+			args: args{nil},
+			want: NewCommentService(nil, nil),
+		},
+		{
+			name: "Service with no nil repo should pass",
+			args: args{NewMockICommentRepository(gomock.NewController(t))},
+			want: NewCommentService(NewMockICommentRepository(gomock.NewController(t)), nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewCommentService(tt.args.repository, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewCommentService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommentService_Create_Notifies_Mentions(t *testing.T) {
+	listItemID := uint(4)
+	comment := models.Comment{ListID: 3, ListItemID: &listItemID, UserID: 1, Body: "@josé can you buy it? cc @ana"}
+	created := comment
+	created.ID = 9
+
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), comment).Return(&created, nil)
+	mockedRepo.EXPECT().GetListMembers(uint(3)).Return(&[]models.Member{
+		{ID: 1, Name: "Ana Gómez", Email: "ana@mail.com"},
+		{ID: 2, Name: "José Pérez", Email: "jose@mail.com"},
+	}, nil)
+
+	var events []notify.Event
+	notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+		events = append(events, event)
+		return nil
+	})
+
+	commentService := NewCommentService(mockedRepo, notifier)
+
+	result, err := commentService.Create(context.Background(), comment)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), result.ID)
+	assert.Equal(t, []notify.Event{{
+		DeliveryID: "mention-9-2",
+		Kind:       notify.KindMention,
+		CommentID:  9,
+		UserID:     2,
+		ListID:     3,
+		ListItemID: 4,
+		Title:      "Ana Gómez mentioned you: @josé can you buy it? cc @ana",
+		Channel:    notify.ChannelInApp,
+	}}, events)
+}
+
+func TestCommentService_Create_Notify_Error_Keeps_Comment(t *testing.T) {
+	comment := models.Comment{ListID: 3, UserID: 1, Body: "@josé"}
+
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), comment).Return(&comment, nil)
+	mockedRepo.EXPECT().GetListMembers(uint(3)).Return(&[]models.Member{{ID: 2, Name: "José"}}, nil)
+
+	notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+		return errors.New("error from notifier")
+	})
+
+	commentService := NewCommentService(mockedRepo, notifier)
+
+	result, err := commentService.Create(context.Background(), comment)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestCommentService_Create_Error(t *testing.T) {
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from db"))
+
+	commentService := NewCommentService(mockedRepo, nil)
+
+	result, err := commentService.Create(context.Background(), models.Comment{Body: "hola"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCommentService_Update(t *testing.T) {
+	comment := models.Comment{ListID: 3, UserID: 1, Body: "hola"}
+	comment.ID = 9
+
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("9").Return(&comment, nil)
+	mockedRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(comment models.Comment) (*models.Comment, error) {
+		assert.Equal(t, "chau", comment.Body)
+		assert.NotNil(t, comment.EditedAt)
+		return &comment, nil
+	})
+	mockedRepo.EXPECT().GetListMembers(uint(3)).Return(&[]models.Member{}, nil)
+
+	commentService := NewCommentService(mockedRepo, nil)
+
+	result, err := commentService.Update(1, "9", "chau")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "chau", result.Body)
+}
+
+func TestCommentService_Update_Not_Author(t *testing.T) {
+	comment := models.Comment{ListID: 3, UserID: 1, Body: "hola"}
+
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("9").Return(&comment, nil)
+
+	commentService := NewCommentService(mockedRepo, nil)
+
+	result, err := commentService.Update(2, "9", "chau")
+
+	assert.ErrorIs(t, err, models.ErrNotCommentAuthor)
+	assert.Nil(t, result)
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	deleted := 1
+	comment := models.Comment{ListID: 3, UserID: 1, Body: "hola"}
+
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("9").Return(&comment, nil)
+	mockedRepo.EXPECT().Delete(gomock.Any(), "9").Return(&deleted, nil)
+
+	commentService := NewCommentService(mockedRepo, nil)
+
+	result, err := commentService.Delete(context.Background(), 1, "9")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestCommentService_Delete_Not_Found(t *testing.T) {
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("9").Return(nil, gorm.ErrRecordNotFound)
+
+	commentService := NewCommentService(mockedRepo, nil)
+
+	result, err := commentService.Delete(context.Background(), 1, "9")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func TestCommentService_GetComments(t *testing.T) {
+	thread := models.Thread{ListID: 3}
+
+	mockedRepo := NewMockICommentRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetComments(thread, 2, 10).Return(&models.CommentPage{Page: 2, PageSize: 10, Total: 12}, nil)
+
+	commentService := NewCommentService(mockedRepo, nil)
+
+	result, err := commentService.GetComments(thread, 2, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), result.Total)
+}
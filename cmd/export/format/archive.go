@@ -0,0 +1,31 @@
+package format
+
+import (
+	listModels "SuperListsAPI/cmd/lists/models"
+	"archive/zip"
+	"fmt"
+	"io"
+)
+
+// Archive writes a zip archive with a file per list on the format of formatter. File names start with the list id,
+// so lists sharing a name don't overwrite each other.
+func Archive(w io.Writer, lists []listModels.List, formatter Formatter) error {
+	archive := zip.NewWriter(w)
+
+	for _, list := range lists {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     fmt.Sprintf("%d-%s", list.ID, FileName(list, formatter)),
+			Method:   zip.Deflate,
+			Modified: list.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := formatter.Format(file, list); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
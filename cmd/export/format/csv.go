@@ -0,0 +1,83 @@
+package format
+
+import (
+	listModels "SuperListsAPI/cmd/lists/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// csvHeader names the columns of the csv export. ids are only meaningful within the file, parent_id points to the
+// row of the parent of a subtask.
+var csvHeader = []string{"id", "parent_id", "title", "description", "done", "quantity", "unit", "unit_price", "currency",
+	"category", "priority", "due_at", "all_day", "time_zone", "tags", "recurrence"}
+
+// csvFormatter writes a row per item. Due dates of all day items only carry the date.
+type csvFormatter struct{}
+
+func (csvFormatter) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (csvFormatter) Extension() string {
+	return CSV
+}
+
+func (csvFormatter) Format(w io.Writer, list listModels.List) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, item := range flatten(list.ListItems) {
+		var parentID, itemQuantity, unitPrice, due string
+
+		if item.ParentID != nil {
+			parentID = fmt.Sprint(*item.ParentID)
+		}
+
+		if item.Quantity != nil {
+			itemQuantity = item.Quantity.String()
+		}
+
+		if item.UnitPrice != nil {
+			unitPrice = item.UnitPrice.StringFixed(2)
+		}
+
+		if moment, ok := dueAt(item); ok {
+			due = moment.Format(time.RFC3339)
+			if item.AllDay {
+				due = moment.Format("2006-01-02")
+			}
+		}
+
+		err := writer.Write([]string{
+			fmt.Sprint(item.ID),
+			parentID,
+			item.Title,
+			item.Description,
+			strconv.FormatBool(item.IsDone),
+			itemQuantity,
+			item.Unit,
+			unitPrice,
+			item.Currency,
+			item.Category,
+			item.Priority,
+			due,
+			strconv.FormatBool(item.AllDay),
+			item.TimeZone,
+			strings.Join(item.Tags, ";"),
+			item.Recurrence,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,108 @@
+// Package format renders lists and their items on the formats they can be exported to. Formatters expect the items
+// of the list nested with listItemModels.NestItems, subtasks are written right after their parent.
+package format
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	CSV      = "csv"
+	Markdown = "md"
+	JSON     = "json"
+	ICal     = "ics"
+)
+
+type Formatter interface {
+	ContentType() string
+	Extension() string
+	Format(w io.Writer, list listModels.List) error
+}
+
+var formatters = map[string]Formatter{
+	CSV:      csvFormatter{},
+	Markdown: markdownFormatter{},
+	JSON:     jsonFormatter{},
+	ICal:     icalFormatter{},
+}
+
+// Get returns the formatter of the format name, false when the format is not supported.
+func Get(name string) (Formatter, bool) {
+	formatter, ok := formatters[name]
+	return formatter, ok
+}
+
+// Names lists the supported formats.
+func Names() []string {
+	return []string{CSV, Markdown, JSON, ICal}
+}
+
+// FileName names the file a list is exported to, out of its name.
+func FileName(list listModels.List, formatter Formatter) string {
+	return slug(list.Name) + "." + formatter.Extension()
+}
+
+// maxSlugLength keeps file names short enough for every file system.
+const maxSlugLength = 50
+
+// slug keeps the ascii letters and digits of name, lower cased and joined by dashes.
+func slug(name string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r))
+	}) {
+		words = append(words, word)
+	}
+
+	result := strings.Join(words, "-")
+	if len(result) > maxSlugLength {
+		result = strings.TrimRight(result[:maxSlugLength], "-")
+	}
+
+	if result == "" {
+		return "list"
+	}
+
+	return result
+}
+
+// flatten walks the nested items of a list, every parent followed by its subtasks.
+func flatten(items []listItemModels.ListItem) []listItemModels.ListItem {
+	var flat []listItemModels.ListItem
+	for _, item := range items {
+		flat = append(flat, item)
+		flat = append(flat, item.Children...)
+	}
+	return flat
+}
+
+// dueAt returns the due date of an item on its own time zone, UTC when it has none or it is unknown.
+func dueAt(item listItemModels.ListItem) (time.Time, bool) {
+	if item.DueAt == nil {
+		return time.Time{}, false
+	}
+
+	location := time.UTC
+	if item.TimeZone != "" {
+		if loaded, err := time.LoadLocation(item.TimeZone); err == nil {
+			location = loaded
+		}
+	}
+
+	return item.DueAt.In(location), true
+}
+
+// quantity is the amount of an item along with its unit, empty when the item has none.
+func quantity(item listItemModels.ListItem) string {
+	if item.Quantity == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s %s", item.Quantity.String(), item.Unit))
+}
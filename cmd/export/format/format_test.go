@@ -0,0 +1,188 @@
+package format
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"archive/zip"
+	"bytes"
+	"flag"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// update rewrites the golden files with the current output: go test ./cmd/export/format -update
+var update = flag.Bool("update", false, "update golden files")
+
+func exportedList() listModels.List {
+	updatedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2026, 10, 21, 3, 0, 0, 0, time.UTC)
+	dueMoment := time.Date(2026, 10, 22, 21, 30, 0, 0, time.UTC)
+	two := decimal.NewFromInt(2)
+	price := decimal.RequireFromString("3.5")
+	half := decimal.RequireFromString("0.5")
+	parentID := uint(1)
+
+	items := []listItemModels.ListItem{
+		{
+			Model:      gorm.Model{ID: 1, UpdatedAt: updatedAt},
+			ListID:     7,
+			Title:      "Tomatoes",
+			Quantity:   &two,
+			Unit:       "kg",
+			UnitPrice:  &price,
+			Currency:   "EUR",
+			Category:   "veggies",
+			Tags:       []string{"market", "weekly"},
+			DueAt:      &dueDate,
+			AllDay:     true,
+			TimeZone:   "America/Argentina/Buenos_Aires",
+			Priority:   "high",
+			Recurrence: "RRULE:FREQ=WEEKLY;BYDAY=SA",
+		},
+		{
+			Model:    gorm.Model{ID: 2, UpdatedAt: updatedAt},
+			ListID:   7,
+			Title:    "Cherry tomatoes",
+			Quantity: &half,
+			Unit:     "kg",
+			IsDone:   true,
+			ParentID: &parentID,
+		},
+		{
+			Model:       gorm.Model{ID: 3, UpdatedAt: updatedAt},
+			ListID:      7,
+			Title:       "Call the plumber, again; about the *kitchen* sink",
+			Description: "Ask for Pedro\nBring the receipt",
+			DueAt:       &dueMoment,
+			TimeZone:    "Europe/Madrid",
+		},
+		{
+			Model:  gorm.Model{ID: 4, UpdatedAt: updatedAt},
+			ListID: 7,
+			Title:  "Bread",
+			IsDone: true,
+			DueAt:  &dueMoment,
+		},
+	}
+
+	return listModels.List{
+		Model:       gorm.Model{ID: 7, UpdatedAt: updatedAt},
+		Name:        "Groceries & chores",
+		Description: "Everything for the weekend",
+		ListItems:   listItemModels.NestItems(items),
+	}
+}
+
+func TestFormat(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			formatter, ok := Get(name)
+			assert.True(t, ok)
+
+			var buffer bytes.Buffer
+			err := formatter.Format(&buffer, exportedList())
+			assert.NoError(t, err)
+
+			assertGolden(t, "groceries."+formatter.Extension()+".golden", buffer.Bytes())
+		})
+	}
+}
+
+func TestFormat_Empty_List(t *testing.T) {
+	formatter, _ := Get(Markdown)
+
+	var buffer bytes.Buffer
+	err := formatter.Format(&buffer, listModels.List{Name: "Empty"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "# Empty\n", buffer.String())
+}
+
+func TestGet_Unknown_Format(t *testing.T) {
+	_, ok := Get("xlsx")
+	assert.False(t, ok)
+}
+
+func TestFileName(t *testing.T) {
+	formatter, _ := Get(CSV)
+
+	tests := []struct {
+		name     string
+		listName string
+		want     string
+	}{
+		{name: "Words joined by dashes", listName: "Groceries & chores", want: "groceries-chores.csv"},
+		{name: "Non ascii letters dropped", listName: "Compras del año", want: "compras-del-a-o.csv"},
+		{name: "Nothing left", listName: "¡¿?!", want: "list.csv"},
+		{name: "Too long", listName: strings.Repeat("ab ", 30), want: strings.TrimRight(strings.Repeat("ab-", 17), "-") + ".csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, FileName(listModels.List{Name: tt.listName}, formatter))
+		})
+	}
+}
+
+func TestWriteICalLine_Folds_Long_Lines(t *testing.T) {
+	var builder strings.Builder
+	writeICalLine(&builder, "SUMMARY:"+strings.Repeat("ñ", 80))
+
+	lines := strings.Split(strings.TrimSuffix(builder.String(), "\r\n"), "\r\n")
+	assert.Len(t, lines, 3)
+
+	var unfolded string
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), icalLineLength)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+			line = line[1:]
+		}
+		unfolded += line
+	}
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("ñ", 80), unfolded)
+}
+
+func TestArchive(t *testing.T) {
+	formatter, _ := Get(JSON)
+	second := exportedList()
+	second.ID = 8
+
+	var buffer bytes.Buffer
+	err := Archive(&buffer, []listModels.List{exportedList(), second}, formatter)
+	assert.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, reader.File, 2)
+	assert.Equal(t, "7-groceries-chores.json", reader.File[0].Name)
+	assert.Equal(t, "8-groceries-chores.json", reader.File[1].Name)
+
+	file, err := reader.File[0].Open()
+	assert.NoError(t, err)
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assertGolden(t, "groceries.json.golden", content)
+}
+
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+
+	if *update {
+		assert.NoError(t, os.WriteFile(path, got, 0644))
+	}
+
+	want, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
@@ -0,0 +1,111 @@
+package format
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// icalLineLength is the longest a content line can be before it is folded, in octets.
+const icalLineLength = 75
+
+// icalFormatter writes an event for every pending item with a due date, so due items show up on calendars. All day
+// items are whole day events and the rest are instants in UTC, items with a recurrence repeat with it.
+type icalFormatter struct{}
+
+func (icalFormatter) ContentType() string {
+	return "text/calendar; charset=utf-8"
+}
+
+func (icalFormatter) Extension() string {
+	return ICal
+}
+
+func (icalFormatter) Format(w io.Writer, list listModels.List) error {
+	var builder strings.Builder
+
+	writeICalLine(&builder, "BEGIN:VCALENDAR")
+	writeICalLine(&builder, "VERSION:2.0")
+	writeICalLine(&builder, "PRODID:-//SuperLists//SuperListsAPI//EN")
+	writeICalLine(&builder, "CALSCALE:GREGORIAN")
+	writeICalLine(&builder, "X-WR-CALNAME:"+escapeICal(list.Name))
+
+	for _, item := range flatten(list.ListItems) {
+		if item.IsDone || item.DueAt == nil {
+			continue
+		}
+		writeICalEvent(&builder, item)
+	}
+
+	writeICalLine(&builder, "END:VCALENDAR")
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func writeICalEvent(builder *strings.Builder, item listItemModels.ListItem) {
+	writeICalLine(builder, "BEGIN:VEVENT")
+	writeICalLine(builder, fmt.Sprintf("UID:list-item-%d@superlists", item.ID))
+	writeICalLine(builder, "DTSTAMP:"+item.UpdatedAt.UTC().Format("20060102T150405Z"))
+
+	if item.AllDay {
+		moment, _ := dueAt(item)
+		writeICalLine(builder, "DTSTART;VALUE=DATE:"+moment.Format("20060102"))
+	} else {
+		writeICalLine(builder, "DTSTART:"+item.DueAt.UTC().Format("20060102T150405Z"))
+	}
+
+	summary := item.Title
+	if itemQuantity := quantity(item); itemQuantity != "" {
+		summary += " (" + itemQuantity + ")"
+	}
+	writeICalLine(builder, "SUMMARY:"+escapeICal(summary))
+
+	if item.Description != "" {
+		writeICalLine(builder, "DESCRIPTION:"+escapeICal(item.Description))
+	}
+
+	if len(item.Tags) > 0 {
+		tags := make([]string, 0, len(item.Tags))
+		for _, tag := range item.Tags {
+			tags = append(tags, escapeICal(tag))
+		}
+		writeICalLine(builder, "CATEGORIES:"+strings.Join(tags, ","))
+	}
+
+	if item.Recurrence != "" {
+		writeICalLine(builder, "RRULE:"+strings.TrimPrefix(strings.ToUpper(item.Recurrence), "RRULE:"))
+	}
+
+	writeICalLine(builder, "END:VEVENT")
+}
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeICal(text string) string {
+	return icalEscaper.Replace(text)
+}
+
+// writeICalLine ends the line with CRLF and folds it when it is too long, never splitting a character in two.
+func writeICalLine(builder *strings.Builder, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+
+		// Continuation lines start with a space, which counts towards their length
+		limit = icalLineLength - 1
+	}
+
+	builder.WriteString(line)
+	builder.WriteString("\r\n")
+}
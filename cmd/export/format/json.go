@@ -0,0 +1,26 @@
+package format
+
+import (
+	listModels "SuperListsAPI/cmd/lists/models"
+	"encoding/json"
+	"io"
+)
+
+// jsonFormatter writes the list the same way the api answers it, indented.
+type jsonFormatter struct{}
+
+func (jsonFormatter) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+func (jsonFormatter) Extension() string {
+	return JSON
+}
+
+func (jsonFormatter) Format(w io.Writer, list listModels.List) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(list)
+}
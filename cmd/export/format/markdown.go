@@ -0,0 +1,97 @@
+package format
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// markdownFormatter writes the list as a task list, ready to be pasted on a chat or a note.
+type markdownFormatter struct{}
+
+func (markdownFormatter) ContentType() string {
+	return "text/markdown; charset=utf-8"
+}
+
+func (markdownFormatter) Extension() string {
+	return Markdown
+}
+
+func (markdownFormatter) Format(w io.Writer, list listModels.List) error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# %s\n", escapeMarkdown(list.Name))
+	if list.Description != "" {
+		fmt.Fprintf(&builder, "\n%s\n", escapeMarkdown(list.Description))
+	}
+
+	if len(list.ListItems) > 0 {
+		builder.WriteString("\n")
+	}
+
+	for _, item := range list.ListItems {
+		writeMarkdownItem(&builder, item, "")
+		for _, child := range item.Children {
+			writeMarkdownItem(&builder, child, "  ")
+		}
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// writeMarkdownItem writes a task line like "- [ ] Tomatoes — 2 kg · due 2026-10-21 · #veggies", with the
+// description indented below it.
+func writeMarkdownItem(builder *strings.Builder, item listItemModels.ListItem, indent string) {
+	check := " "
+	if item.IsDone {
+		check = "x"
+	}
+
+	details := []string{}
+	if itemQuantity := quantity(item); itemQuantity != "" {
+		details = append(details, itemQuantity)
+	}
+
+	if moment, ok := dueAt(item); ok {
+		if item.AllDay {
+			details = append(details, "due "+moment.Format("2006-01-02"))
+		} else {
+			details = append(details, "due "+moment.Format("2006-01-02 15:04 MST"))
+		}
+	}
+
+	if item.Priority != "" {
+		details = append(details, item.Priority+" priority")
+	}
+
+	if len(item.Tags) > 0 {
+		tags := make([]string, 0, len(item.Tags))
+		for _, tag := range item.Tags {
+			tags = append(tags, "#"+tag)
+		}
+		details = append(details, strings.Join(tags, " "))
+	}
+
+	line := escapeMarkdown(item.Title)
+	if len(details) > 0 {
+		line += " — " + strings.Join(details, " · ")
+	}
+
+	fmt.Fprintf(builder, "%s- [%s] %s\n", indent, check, line)
+
+	for _, descriptionLine := range strings.Split(strings.TrimSpace(item.Description), "\n") {
+		if descriptionLine = strings.TrimSpace(descriptionLine); descriptionLine != "" {
+			fmt.Fprintf(builder, "%s  %s\n", indent, escapeMarkdown(descriptionLine))
+		}
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`, "<", `\<`)
+
+// escapeMarkdown keeps user text from being read as emphasis, links, code or html.
+func escapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/export/format"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/httputil"
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockgen -source=export.go -destination export_mock.go -package handler
+
+type IListService interface {
+	Get(listId string) (*listModels.List, error)
+	GetLists(userId string, includes listModels.ListIncludes) (*[]listModels.List, error)
+}
+
+type IListItemService interface {
+	GetItemsListByListID(listId string) (*[]listItemModels.ListItem, error)
+	GetItemsByFilter(filter listItemModels.ItemFilter) (*[]listItemModels.ListItem, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+// accountArchiveName names the zip archive of the whole account export.
+const accountArchiveName = "superlists-export.zip"
+
+type ExportHandler struct {
+	listService     IListService
+	listItemService IListItemService
+	userListService IUserListService
+}
+
+func NewExportHandler(listService IListService, listItemService IListItemService, userListService IUserListService) ExportHandler {
+	return ExportHandler{listService: listService, listItemService: listItemService, userListService: userListService}
+}
+
+// ExportList answers the list on the request path along with its items as a file on the format asked with ?format=,
+// json when it is missing.
+func (eh *ExportHandler) ExportList(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listID := c.Param("id")
+	if _, err := strconv.Atoi(listID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list id",
+		})
+		c.Abort()
+		return
+	}
+
+	formatter, ok := requestFormatter(c)
+	if !ok {
+		return
+	}
+
+	if !httputil.CheckListMember(c, eh.userListService, listID, uint(userID)) {
+		return
+	}
+
+	list, err := eh.listService.Get(listID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("List with id %s not found", listID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	items, err := eh.listItemService.GetItemsListByListID(listID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	list.ListItems = listItemModels.NestItems(*items)
+
+	// Rendered before answering so a failure is still answered with a 500
+	var buffer bytes.Buffer
+	if err := formatter.Format(&buffer, *list); err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": format.FileName(*list, formatter)}))
+	c.Data(http.StatusOK, formatter.ContentType(), buffer.Bytes())
+}
+
+// ExportAccount answers a zip archive with every list of the user, each one on a file on the format asked with
+// ?format=, json when it is missing.
+func (eh *ExportHandler) ExportAccount(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	formatter, ok := requestFormatter(c)
+	if !ok {
+		return
+	}
+
+	lists, err := eh.listService.GetLists(fmt.Sprint(userID), listModels.ListIncludes{})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	items, err := eh.listItemService.GetItemsByFilter(listItemModels.ItemFilter{MemberID: fmt.Sprint(userID)})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	itemsByList := map[uint][]listItemModels.ListItem{}
+	for _, item := range *items {
+		itemsByList[uint(item.ListID)] = append(itemsByList[uint(item.ListID)], item)
+	}
+
+	exported := make([]listModels.List, 0, len(*lists))
+	for _, list := range *lists {
+		list.ListItems = listItemModels.NestItems(itemsByList[list.ID])
+		exported = append(exported, list)
+	}
+
+	var buffer bytes.Buffer
+	if err := format.Archive(&buffer, exported, formatter); err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": accountArchiveName}))
+	c.Data(http.StatusOK, "application/zip", buffer.Bytes())
+}
+
+// requestFormatter reads ?format=, answering the request with 400 when the format is not supported.
+func requestFormatter(c *gin.Context) (format.Formatter, bool) {
+	name := c.DefaultQuery("format", format.JSON)
+
+	formatter, ok := format.Get(name)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": fmt.Sprintf("format must be one of %s", strings.Join(format.Names(), ", ")),
+		})
+		c.Abort()
+		return nil, false
+	}
+
+	return formatter, true
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/lists/models"
+	models1 "SuperListsAPI/cmd/userLists/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIListService is a mock of IListService interface.
+type MockIListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListServiceMockRecorder
+}
+
+// MockIListServiceMockRecorder is the mock recorder for MockIListService.
+type MockIListServiceMockRecorder struct {
+	mock *MockIListService
+}
+
+// NewMockIListService creates a new mock instance.
+func NewMockIListService(ctrl *gomock.Controller) *MockIListService {
+	mock := &MockIListService{ctrl: ctrl}
+	mock.recorder = &MockIListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListService) EXPECT() *MockIListServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIListService) Get(listId string) (*models0.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", listId)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIListServiceMockRecorder) Get(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListService)(nil).Get), listId)
+}
+
+// GetLists mocks base method.
+func (m *MockIListService) GetLists(userId string, includes models0.ListIncludes) (*[]models0.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLists", userId, includes)
+	ret0, _ := ret[0].(*[]models0.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLists indicates an expected call of GetLists.
+func (mr *MockIListServiceMockRecorder) GetLists(userId, includes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockIListService)(nil).GetLists), userId, includes)
+}
+
+// MockIListItemService is a mock of IListItemService interface.
+type MockIListItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListItemServiceMockRecorder
+}
+
+// MockIListItemServiceMockRecorder is the mock recorder for MockIListItemService.
+type MockIListItemServiceMockRecorder struct {
+	mock *MockIListItemService
+}
+
+// NewMockIListItemService creates a new mock instance.
+func NewMockIListItemService(ctrl *gomock.Controller) *MockIListItemService {
+	mock := &MockIListItemService{ctrl: ctrl}
+	mock.recorder = &MockIListItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
+	return m.recorder
+}
+
+// GetItemsByFilter mocks base method.
+func (m *MockIListItemService) GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByFilter", filter)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByFilter indicates an expected call of GetItemsByFilter.
+func (mr *MockIListItemServiceMockRecorder) GetItemsByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByFilter", reflect.TypeOf((*MockIListItemService)(nil).GetItemsByFilter), filter)
+}
+
+// GetItemsListByListID mocks base method.
+func (m *MockIListItemService) GetItemsListByListID(listId string) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsListByListID", listId)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsListByListID indicates an expected call of GetItemsListByListID.
+func (mr *MockIListItemServiceMockRecorder) GetItemsListByListID(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsListByListID", reflect.TypeOf((*MockIListItemService)(nil).GetItemsListByListID), listId)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models1.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"archive/zip"
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var members = &[]userListModels.UserList{{ListID: 3, UserID: 7}}
+
+func TestExportHandler_ExportList(t *testing.T) {
+	tests := []struct {
+		name            string
+		listID          string
+		query           string
+		setup           func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService)
+		wantStatus      int
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:   "Markdown",
+			listID: "3",
+			query:  "?format=md",
+			setup: func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				lists.EXPECT().Get("3").Return(&listModels.List{Model: gorm.Model{ID: 3}, Name: "Groceries"}, nil)
+				items.EXPECT().GetItemsListByListID("3").Return(&[]listItemModels.ListItem{{Title: "Milk", IsDone: true}}, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/markdown; charset=utf-8",
+			wantBody:        "# Groceries\n\n- [x] Milk\n",
+		},
+		{
+			name:   "Json by default",
+			listID: "3",
+			setup: func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				lists.EXPECT().Get("3").Return(&listModels.List{Model: gorm.Model{ID: 3}, Name: "Groceries"}, nil)
+				items.EXPECT().GetItemsListByListID("3").Return(&[]listItemModels.ListItem{}, nil)
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json; charset=utf-8",
+		},
+		{
+			name:       "Unknown format",
+			listID:     "3",
+			query:      "?format=xlsx",
+			setup:      func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid list id",
+			listID:     "groceries",
+			setup:      func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Caller is not a list member",
+			listID: "3",
+			setup: func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "List not found",
+			listID: "3",
+			setup: func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				lists.EXPECT().Get("3").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "Items failure",
+			listID: "3",
+			setup: func(lists *MockIListService, items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				lists.EXPECT().Get("3").Return(&listModels.List{Model: gorm.Model{ID: 3}}, nil)
+				items.EXPECT().GetItemsListByListID("3").Return(nil, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lists := NewMockIListService(ctrl)
+			items := NewMockIListItemService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(lists, items, userLists)
+
+			exportHandler := NewExportHandler(lists, items, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.listID}}
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/lists/"+tt.listID+"/export"+tt.query, nil)
+			c.Request.Header.Set("user_id", "7")
+
+			exportHandler.ExportList(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantContentType != "" {
+				assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+			}
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestExportHandler_ExportAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lists := NewMockIListService(ctrl)
+	items := NewMockIListItemService(ctrl)
+	userLists := NewMockIUserListService(ctrl)
+
+	lists.EXPECT().GetLists("7", listModels.ListIncludes{}).Return(&[]listModels.List{
+		{Model: gorm.Model{ID: 3}, Name: "Groceries"},
+		{Model: gorm.Model{ID: 4}, Name: "Chores"},
+	}, nil)
+	items.EXPECT().GetItemsByFilter(listItemModels.ItemFilter{MemberID: "7"}).Return(&[]listItemModels.ListItem{
+		{ListID: 4, Title: "Sweep"},
+		{ListID: 3, Title: "Milk"},
+	}, nil)
+
+	exportHandler := NewExportHandler(lists, items, userLists)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/v1/me/export?format=md", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	exportHandler.ExportAccount(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=superlists-export.zip`, w.Header().Get("Content-Disposition"))
+
+	reader, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	assert.NoError(t, err)
+
+	files := map[string]string{}
+	for _, file := range reader.File {
+		opened, err := file.Open()
+		assert.NoError(t, err)
+		content, err := io.ReadAll(opened)
+		assert.NoError(t, err)
+		opened.Close()
+		files[file.Name] = string(content)
+	}
+
+	assert.Equal(t, map[string]string{
+		"3-groceries.md": "# Groceries\n\n- [ ] Milk\n",
+		"4-chores.md":    "# Chores\n\n- [ ] Sweep\n",
+	}, files)
+}
+
+func TestExportHandler_ExportAccount_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lists := NewMockIListService(ctrl)
+	lists.EXPECT().GetLists("7", listModels.ListIncludes{}).Return(nil, errors.New("connection refused"))
+
+	exportHandler := NewExportHandler(lists, NewMockIListItemService(ctrl), NewMockIUserListService(ctrl))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/v1/me/export", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	exportHandler.ExportAccount(c)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
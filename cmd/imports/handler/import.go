@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	"SuperListsAPI/cmd/imports/parse"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/httputil"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"time"
+)
+
+//go:generate mockgen -source=import.go -destination import_mock.go -package handler
+
+type IListItemService interface {
+	CreateItems(ctx context.Context, items []listItemModels.ListItem) (*[]listItemModels.ListItem, error)
+	CreateItemsOnNewList(ctx context.Context, list listModels.List, items []listItemModels.ListItem) (*listModels.List, *[]listItemModels.ListItem, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+// requestOverhead leaves room for the json around the imported content.
+const requestOverhead = 64 << 10
+
+type ImportHandler struct {
+	listItemService IListItemService
+	userListService IUserListService
+}
+
+func NewImportHandler(listItemService IListItemService, userListService IUserListService) ImportHandler {
+	return ImportHandler{listItemService: listItemService, userListService: userListService}
+}
+
+// Import creates the items read from the request content, on a new list or at the end of an existing one. Nothing is
+// created when a line can't be read, the lines are answered with 422 instead. Dry runs answer what would be created.
+func (ih *ImportHandler) Import(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxContentSize+requestOverhead)
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	var importRequest models.ImportRequest
+
+	err := c.ShouldBindJSON(&importRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(importRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	if importRequest.ListID != 0 && !httputil.CheckListMember(c, ih.userListService, fmt.Sprint(importRequest.ListID), uint(userID)) {
+		return
+	}
+
+	location := time.UTC
+	if importRequest.TimeZone != "" {
+		location, _ = time.LoadLocation(importRequest.TimeZone)
+	}
+
+	parsed, ok := parse.Parse(importRequest.Format, importRequest.Content, time.Now().In(location))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "unsupported format",
+		})
+		c.Abort()
+		return
+	}
+
+	result := models.ImportResult{
+		DryRun:    importRequest.DryRun,
+		ListID:    importRequest.ListID,
+		ItemCount: parsed.Count(),
+		Items:     parsed.Items,
+		Errors:    parsed.Errors,
+	}
+
+	if result.Items == nil {
+		result.Items = []listItemModels.ListItem{}
+	}
+
+	if result.Errors == nil {
+		result.Errors = []models.LineError{}
+	}
+
+	if importRequest.ListID == 0 {
+		result.Name, result.Description = importRequest.Name, importRequest.Description
+		if result.Name == "" {
+			result.Name = parsed.Name
+		}
+		if result.Description == "" {
+			result.Description = parsed.Description
+		}
+
+		if result.Name == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "missing list name",
+			})
+			c.Abort()
+			return
+		}
+	}
+
+	if importRequest.DryRun {
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	if result.ItemCount == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "nothing to import",
+		})
+		c.Abort()
+		return
+	}
+
+	for i := range result.Items {
+		result.Items[i].ListID, result.Items[i].UserID = int(result.ListID), userID
+		for j := range result.Items[i].Children {
+			result.Items[i].Children[j].ListID, result.Items[i].Children[j].UserID = int(result.ListID), userID
+		}
+	}
+
+	// A new list is created along with its items, nothing is left behind when the items can't be created
+	var created *[]listItemModels.ListItem
+	if importRequest.ListID == 0 {
+		var list *listModels.List
+		list, created, err = ih.listItemService.CreateItemsOnNewList(c.Request.Context(), listModels.List{
+			Name:          result.Name,
+			Description:   result.Description,
+			UserCreatorID: uint(userID),
+		}, result.Items)
+		if list != nil {
+			result.ListID = list.ID
+		}
+	} else {
+		created, err = ih.listItemService.CreateItems(c.Request.Context(), result.Items)
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	result.Items = *created
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	"SuperListsAPI/internal/httputil"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+//go:generate mockgen -source=import_job.go -destination import_job_mock.go -package handler
+
+type IImportJobService interface {
+	Create(ctx context.Context, job models.ImportJob, content io.Reader, size int64) (*models.ImportJob, error)
+	Get(jobID string) (*models.ImportJob, error)
+	GetJobsByUserID(userID string) (*[]models.ImportJob, error)
+}
+
+// multipartOverhead leaves room for the multipart boundaries and headers around the file.
+const multipartOverhead = 64 << 10
+
+type ImportJobHandler struct {
+	importJobService IImportJobService
+}
+
+func NewImportJobHandler(importJobService IImportJobService) ImportJobHandler {
+	return ImportJobHandler{importJobService: importJobService}
+}
+
+// CreateJob queues the import of the export uploaded as the multipart form file named "file", made by the app named
+// on "source". The job runs in the background, its progress is read on GetJob.
+func (ijh *ImportJobHandler) CreateJob(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, models.MaxArchiveSize+multipartOverhead)
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	source := c.PostForm("source")
+	if source != models.SourceKeep && source != models.SourceTodoist && source != models.SourceMSToDo {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "source must be one of keep, todoist or mstodo",
+		})
+		c.Abort()
+		return
+	}
+
+	timeZone := c.PostForm("time_zone")
+	if _, err := time.LoadLocation(timeZone); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid time zone",
+		})
+		c.Abort()
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing file on multipart form",
+		})
+		c.Abort()
+		return
+	}
+
+	if fileHeader.Size > models.MaxArchiveSize {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"msg": models.ErrArchiveTooLarge.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+	defer file.Close()
+
+	result, err := ijh.importJobService.Create(c.Request.Context(), models.ImportJob{
+		UserID:   uint(userID),
+		Source:   source,
+		FileName: filepath.Base(fileHeader.Filename),
+		TimeZone: timeZone,
+	}, file, fileHeader.Size)
+
+	switch {
+	case errors.Is(err, models.ErrArchiveTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/v1/imports/%d", result.ID))
+	c.JSON(http.StatusAccepted, result)
+	return
+}
+
+// GetJob answers an import job of the user, jobs of other users are not found.
+func (ijh *ImportJobHandler) GetJob(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	jobID := c.Param("id")
+
+	if _, err := strconv.Atoi(jobID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid import job id",
+		})
+		c.Abort()
+		return
+	}
+
+	job, err := ijh.importJobService.Get(jobID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && job.UserID != uint(userID)) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Import job with id %s not found", jobID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+	return
+}
+
+func (ijh *ImportJobHandler) GetJobs(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	jobs, err := ijh.importJobService.GetJobsByUserID(fmt.Sprint(userID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, jobs)
+	return
+}
@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import_job.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/imports/models"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIImportJobService is a mock of IImportJobService interface.
+type MockIImportJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImportJobServiceMockRecorder
+}
+
+// MockIImportJobServiceMockRecorder is the mock recorder for MockIImportJobService.
+type MockIImportJobServiceMockRecorder struct {
+	mock *MockIImportJobService
+}
+
+// NewMockIImportJobService creates a new mock instance.
+func NewMockIImportJobService(ctrl *gomock.Controller) *MockIImportJobService {
+	mock := &MockIImportJobService{ctrl: ctrl}
+	mock.recorder = &MockIImportJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImportJobService) EXPECT() *MockIImportJobServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIImportJobService) Create(ctx context.Context, job models.ImportJob, content io.Reader, size int64) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, job, content, size)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIImportJobServiceMockRecorder) Create(ctx, job, content, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIImportJobService)(nil).Create), ctx, job, content, size)
+}
+
+// Get mocks base method.
+func (m *MockIImportJobService) Get(jobID string) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", jobID)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIImportJobServiceMockRecorder) Get(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIImportJobService)(nil).Get), jobID)
+}
+
+// GetJobsByUserID mocks base method.
+func (m *MockIImportJobService) GetJobsByUserID(userID string) (*[]models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobsByUserID", userID)
+	ret0, _ := ret[0].(*[]models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobsByUserID indicates an expected call of GetJobsByUserID.
+func (mr *MockIImportJobServiceMockRecorder) GetJobsByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobsByUserID", reflect.TypeOf((*MockIImportJobService)(nil).GetJobsByUserID), userID)
+}
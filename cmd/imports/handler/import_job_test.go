@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	"bytes"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestImportJobHandler_CreateJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		timeZone   string
+		field      string
+		setup      func(jobs *MockIImportJobService)
+		wantStatus int
+	}{
+		{
+			name:     "Queued",
+			source:   models.SourceTodoist,
+			timeZone: "Europe/Madrid",
+			field:    "file",
+			setup: func(jobs *MockIImportJobService) {
+				jobs.EXPECT().Create(gomock.Any(), models.ImportJob{
+					UserID: 7, Source: models.SourceTodoist, FileName: "export.zip", TimeZone: "Europe/Madrid",
+				}, gomock.Any(), int64(2)).Return(&models.ImportJob{ID: 1, Status: models.JobPending}, nil)
+			},
+			wantStatus: http.StatusAccepted,
+		},
+		{
+			name:       "Unknown source",
+			source:     "wunderlist",
+			field:      "file",
+			setup:      func(jobs *MockIImportJobService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid time zone",
+			source:     models.SourceKeep,
+			timeZone:   "Mars/Olympus",
+			field:      "file",
+			setup:      func(jobs *MockIImportJobService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Missing file",
+			source:     models.SourceKeep,
+			field:      "upload",
+			setup:      func(jobs *MockIImportJobService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service error",
+			source: models.SourceMSToDo,
+			field:  "file",
+			setup: func(jobs *MockIImportJobService) {
+				jobs.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("storage unavailable"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := NewMockIImportJobService(gomock.NewController(t))
+			tt.setup(jobs)
+
+			importJobHandler := NewImportJobHandler(jobs)
+
+			body := &bytes.Buffer{}
+			form := multipart.NewWriter(body)
+			form.WriteField("source", tt.source)
+			form.WriteField("time_zone", tt.timeZone)
+			part, _ := form.CreateFormFile(tt.field, "../export.zip")
+			part.Write([]byte("PK"))
+			form.Close()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/imports", body)
+			c.Request.Header.Set("Content-Type", form.FormDataContentType())
+			c.Request.Header.Set("user_id", "7")
+
+			importJobHandler.CreateJob(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusAccepted {
+				assert.Equal(t, "/v1/imports/1", w.Header().Get("Location"))
+			}
+		})
+	}
+}
+
+func TestImportJobHandler_GetJob(t *testing.T) {
+	tests := []struct {
+		name       string
+		jobID      string
+		setup      func(jobs *MockIImportJobService)
+		wantStatus int
+	}{
+		{
+			name:  "Own job",
+			jobID: "1",
+			setup: func(jobs *MockIImportJobService) {
+				jobs.EXPECT().Get("1").Return(&models.ImportJob{ID: 1, UserID: 7}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "Job of another user",
+			jobID: "1",
+			setup: func(jobs *MockIImportJobService) {
+				jobs.EXPECT().Get("1").Return(&models.ImportJob{ID: 1, UserID: 8}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:  "Not found",
+			jobID: "1",
+			setup: func(jobs *MockIImportJobService) {
+				jobs.EXPECT().Get("1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid id",
+			jobID:      "one",
+			setup:      func(jobs *MockIImportJobService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := NewMockIImportJobService(gomock.NewController(t))
+			tt.setup(jobs)
+
+			importJobHandler := NewImportJobHandler(jobs)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.jobID}}
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/imports/"+tt.jobID, nil)
+			c.Request.Header.Set("user_id", "7")
+
+			importJobHandler.GetJob(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestImportJobHandler_GetJobs(t *testing.T) {
+	jobs := NewMockIImportJobService(gomock.NewController(t))
+	jobs.EXPECT().GetJobsByUserID("7").Return(&[]models.ImportJob{{ID: 1, UserID: 7}}, nil)
+
+	importJobHandler := NewImportJobHandler(jobs)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/v1/imports", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	importJobHandler.GetJobs(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
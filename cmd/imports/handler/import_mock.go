@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/lists/models"
+	models1 "SuperListsAPI/cmd/userLists/models"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIListItemService is a mock of IListItemService interface.
+type MockIListItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListItemServiceMockRecorder
+}
+
+// MockIListItemServiceMockRecorder is the mock recorder for MockIListItemService.
+type MockIListItemServiceMockRecorder struct {
+	mock *MockIListItemService
+}
+
+// NewMockIListItemService creates a new mock instance.
+func NewMockIListItemService(ctrl *gomock.Controller) *MockIListItemService {
+	mock := &MockIListItemService{ctrl: ctrl}
+	mock.recorder = &MockIListItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateItems mocks base method.
+func (m *MockIListItemService) CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItems", ctx, items)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItems indicates an expected call of CreateItems.
+func (mr *MockIListItemServiceMockRecorder) CreateItems(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItems", reflect.TypeOf((*MockIListItemService)(nil).CreateItems), ctx, items)
+}
+
+// CreateItemsOnNewList mocks base method.
+func (m *MockIListItemService) CreateItemsOnNewList(ctx context.Context, list models0.List, items []models.ListItem) (*models0.List, *[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemsOnNewList", ctx, list, items)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(*[]models.ListItem)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateItemsOnNewList indicates an expected call of CreateItemsOnNewList.
+func (mr *MockIListItemServiceMockRecorder) CreateItemsOnNewList(ctx, list, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemsOnNewList", reflect.TypeOf((*MockIListItemService)(nil).CreateItemsOnNewList), ctx, list, items)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models1.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var members = &[]userListModels.UserList{{ListID: 3, UserID: 7}}
+
+// createdItems answers the items it gets, numbered from 1.
+func createdItems(ctx context.Context, items []listItemModels.ListItem) (*[]listItemModels.ListItem, error) {
+	for i := range items {
+		items[i].ID = uint(i + 1)
+	}
+	return &items, nil
+}
+
+func TestImportHandler_Import(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(items *MockIListItemService, userLists *MockIUserListService)
+		wantStatus int
+		wantResult models.ImportResult
+	}{
+		{
+			name: "New list named after the markdown title",
+			body: `{"format":"md","content":"# Groceries\n- [ ] Milk\n  - [x] Skimmed\n- [x] Bread"}`,
+			setup: func(items *MockIListItemService, userLists *MockIUserListService) {
+				items.EXPECT().CreateItemsOnNewList(gomock.Any(), listModels.List{Name: "Groceries", UserCreatorID: 7}, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, list listModels.List, created []listItemModels.ListItem) (*listModels.List, *[]listItemModels.ListItem, error) {
+						assert.Len(t, created, 2)
+						assert.Equal(t, 7, created[0].UserID)
+						assert.True(t, created[0].Children[0].IsDone)
+						list.ID = 5
+						result, err := createdItems(ctx, created)
+						return &list, result, err
+					})
+			},
+			wantStatus: http.StatusCreated,
+			wantResult: models.ImportResult{ListID: 5, Name: "Groceries", ItemCount: 3},
+		},
+		{
+			name: "Existing list",
+			body: `{"format":"text","content":"Milk\nBread","list_id":3}`,
+			setup: func(items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				items.EXPECT().CreateItems(gomock.Any(), gomock.Any()).DoAndReturn(createdItems)
+			},
+			wantStatus: http.StatusCreated,
+			wantResult: models.ImportResult{ListID: 3, ItemCount: 2},
+		},
+		{
+			name:       "Dry run creates nothing",
+			body:       `{"format":"csv","content":"title,done\nMilk,true\nBread,maybe","name":"Groceries","dry_run":true}`,
+			setup:      func(items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusOK,
+			wantResult: models.ImportResult{DryRun: true, Name: "Groceries", ItemCount: 1, Errors: []models.LineError{{Line: 3, Msg: "invalid done"}}},
+		},
+		{
+			name:       "Lines that can't be read",
+			body:       `{"format":"csv","content":"title,done\nMilk,true\nBread,maybe","name":"Groceries"}`,
+			setup:      func(items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantResult: models.ImportResult{Name: "Groceries", ItemCount: 1, Errors: []models.LineError{{Line: 3, Msg: "invalid done"}}},
+		},
+		{
+			name:       "Missing list name",
+			body:       `{"format":"text","content":"Milk"}`,
+			setup:      func(items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Nothing to import",
+			body:       `{"format":"text","content":"\n\n","name":"Groceries"}`,
+			setup:      func(items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Unsupported format",
+			body:       `{"format":"xlsx","content":"Milk","name":"Groceries"}`,
+			setup:      func(items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid time zone",
+			body:       `{"format":"text","content":"Milk","name":"Groceries","time_zone":"Mars/Olympus"}`,
+			setup:      func(items *MockIListItemService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Caller is not a list member",
+			body: `{"format":"text","content":"Milk","list_id":3}`,
+			setup: func(items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "New list failure",
+			body: `{"format":"text","content":"Milk","name":"Groceries"}`,
+			setup: func(items *MockIListItemService, userLists *MockIUserListService) {
+				items.EXPECT().CreateItemsOnNewList(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "Items failure",
+			body: `{"format":"text","content":"Milk","list_id":3}`,
+			setup: func(items *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				items.EXPECT().CreateItems(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			items := NewMockIListItemService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(items, userLists)
+
+			importHandler := NewImportHandler(items, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/lists/import", strings.NewReader(tt.body))
+			c.Request.Header.Set("user_id", "7")
+
+			importHandler.Import(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantResult.ItemCount > 0 {
+				var result models.ImportResult
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Equal(t, tt.wantResult.DryRun, result.DryRun)
+				assert.Equal(t, tt.wantResult.ListID, result.ListID)
+				assert.Equal(t, tt.wantResult.Name, result.Name)
+				assert.Equal(t, tt.wantResult.ItemCount, result.ItemCount)
+				if tt.wantResult.Errors == nil {
+					tt.wantResult.Errors = []models.LineError{}
+				}
+				assert.Equal(t, tt.wantResult.Errors, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+)
+
+const (
+	FormatCSV      = "csv"
+	FormatMarkdown = "md"
+	FormatText     = "text"
+)
+
+// MaxContentSize is the largest text accepted by an import, in bytes.
+const MaxContentSize = 1 << 20
+
+// MaxImportItems is the most items, subtasks included, a single import can create.
+const MaxImportItems = 2000
+
+// ImportRequest creates the items read from Content on the list ListID, or on a new list when ListID is 0. New lists
+// are named Name, or after the title found on the content. Dates without a time zone are read on TimeZone. A DryRun
+// only answers what would be imported.
+type ImportRequest struct {
+	Format      string `json:"format" validate:"required,oneof=csv md text"`
+	Content     string `json:"content" validate:"required,max=1048576"`
+	ListID      uint   `json:"list_id"`
+	Name        string `json:"name" validate:"max=200"`
+	Description string `json:"description" validate:"max=2000"`
+	TimeZone    string `json:"time_zone" validate:"omitempty,timezone"`
+	DryRun      bool   `json:"dry_run"`
+}
+
+// LineError is a line of the imported content that could not be read, lines are counted from 1. Errors of import
+// jobs also name the file of the export they were found on. On json files Line is the position of the item on the
+// file, and it is 0 when the whole file could not be read.
+type LineError struct {
+	File string `json:"file,omitempty"`
+	Line int    `json:"line"`
+	Msg  string `json:"msg"`
+}
+
+// ImportResult is what an import created, or would create on a dry run. Items are nested like on the list endpoints.
+type ImportResult struct {
+	DryRun      bool                      `json:"dry_run"`
+	ListID      uint                      `json:"list_id,omitempty"`
+	Name        string                    `json:"name,omitempty"`
+	Description string                    `json:"description,omitempty"`
+	ItemCount   int                       `json:"item_count"`
+	Items       []listItemModels.ListItem `json:"items"`
+	Errors      []LineError               `json:"errors"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+const (
+	SourceKeep    = "keep"
+	SourceTodoist = "todoist"
+	SourceMSToDo  = "mstodo"
+)
+
+const (
+	JobPending = "pending"
+	JobRunning = "running"
+	JobDone    = "done"
+	JobFailed  = "failed"
+)
+
+// MaxArchiveSize is the largest export file accepted by an import job, in bytes.
+const MaxArchiveSize = 50 << 20
+
+// MaxArchiveLists is the most lists a single import job creates.
+const MaxArchiveLists = 500
+
+var (
+	ErrArchiveTooLarge  = errors.New("import files can't be larger than 50 MB")
+	ErrUnreadableExport = errors.New("the file is not an export of the chosen app")
+	// ErrJobLost is returned when a job being updated is no longer claimed by the caller, because its lease expired
+	// and another replica claimed it.
+	ErrJobLost = errors.New("import job is no longer claimed by this replica")
+)
+
+// ImportJob imports the export of another app, uploaded to the storage under StorageKey, in the background. A job
+// creates a list per list on the export and counts them on ProcessedLists as they are created, a job claimed again
+// after its replica stopped goes on from there. Lines or notes that can't be read are left out and reported on
+// Errors, LastError tells why a failed job stopped.
+type ImportJob struct {
+	ID             uint          `json:"id" gorm:"primarykey"`
+	UserID         uint          `json:"user_id"`
+	Source         string        `json:"source"`
+	FileName       string        `json:"file_name"`
+	StorageKey     string        `json:"-"`
+	TimeZone       string        `json:"time_zone,omitempty"`
+	Status         string        `json:"status"`
+	ClaimedBy      string        `json:"-"`
+	ClaimedUntil   *time.Time    `json:"-"`
+	Attempts       int           `json:"-"`
+	TotalLists     int           `json:"total_lists"`
+	ProcessedLists int           `json:"processed_lists"`
+	ItemCount      int           `json:"item_count"`
+	Lists          ImportedLists `json:"lists" gorm:"type:text"`
+	Errors         LineErrors    `json:"errors" gorm:"type:text"`
+	LastError      string        `json:"last_error,omitempty"`
+	StartedAt      *time.Time    `json:"started_at,omitempty"`
+	FinishedAt     *time.Time    `json:"finished_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// ImportedList is a list created by an import job.
+type ImportedList struct {
+	ListID    uint   `json:"list_id"`
+	Name      string `json:"name"`
+	ItemCount int    `json:"item_count"`
+}
+
+// ImportedLists is stored on a single text column, encoded as a json array.
+type ImportedLists []ImportedList
+
+func (il ImportedLists) Value() (driver.Value, error) {
+	return jsonValue([]ImportedList(il))
+}
+
+func (il *ImportedLists) Scan(value interface{}) error {
+	*il = ImportedLists{}
+	return jsonScan(value, (*[]ImportedList)(il))
+}
+
+// LineErrors is stored on a single text column, encoded as a json array.
+type LineErrors []LineError
+
+func (le LineErrors) Value() (driver.Value, error) {
+	return jsonValue([]LineError(le))
+}
+
+func (le *LineErrors) Scan(value interface{}) error {
+	*le = LineErrors{}
+	return jsonScan(value, (*[]LineError)(le))
+}
+
+func jsonValue(value interface{}) (driver.Value, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if string(encoded) == "null" {
+		return "[]", nil
+	}
+
+	return string(encoded), nil
+}
+
+func jsonScan(value interface{}, target interface{}) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return errors.New("unsupported type for json column")
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(raw, target)
+}
@@ -0,0 +1,234 @@
+package parse
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"io"
+	"strings"
+	"time"
+)
+
+// csvColumns maps the accepted column names to the field they fill, the columns written by the csv export are
+// all understood. Only a title column is required.
+var csvColumns = map[string]string{
+	"id": "id", "parent_id": "parent_id",
+	"title": "title", "name": "title", "item": "title",
+	"description": "description", "notes": "description",
+	"done": "done", "is_done": "done", "quantity": "quantity", "unit": "unit", "unit_price": "unit_price",
+	"currency": "currency", "category": "category", "priority": "priority", "due_at": "due_at", "due": "due_at",
+	"all_day": "all_day", "time_zone": "time_zone", "tags": "tags", "recurrence": "recurrence",
+}
+
+// localLayouts are the due dates accepted without a time zone, read on the time zone of the row.
+var localLayouts = []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04"}
+
+// parseCSV reads an item per row of a csv with a header row. Semicolon separated files and decimal commas, as written
+// by spreadsheets on many locales, are also understood. Tags are separated by semicolons or commas. A row is a subtask of the earlier row whose id is on its parent_id.
+func parseCSV(content string, now time.Time) Result {
+	var result Result
+
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	firstLine := lines(content)[0]
+	if !strings.Contains(firstLine, ",") && strings.Contains(firstLine, ";") {
+		reader.Comma = ';'
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		result.addError(1, "missing header row")
+		return result
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		if field, ok := csvColumns[strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_")]; ok {
+			if _, repeated := columns[field]; !repeated {
+				columns[field] = i
+			}
+		}
+	}
+
+	if _, ok := columns["title"]; !ok {
+		result.addError(1, "missing title column")
+		return result
+	}
+
+	// parents holds the index of the top level items by their id on the file
+	parents := map[string]int{}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			var parseError *csv.ParseError
+			if errors.As(err, &parseError) {
+				result.addError(parseError.Line, parseError.Err.Error())
+			} else {
+				result.addError(0, err.Error())
+			}
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		row := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		if strings.Join(record, "") == "" {
+			continue
+		}
+
+		item, msg, ok := csvItem(row, now)
+		if ok {
+			msg, ok = validateItem(item)
+		}
+
+		if !ok {
+			result.addError(line, msg)
+			continue
+		}
+
+		if parentID := row("parent_id"); parentID != "" {
+			parent, found := parents[parentID]
+			if !found {
+				result.addError(line, fmt.Sprintf("parent_id %s is not a top level item above this row", parentID))
+				continue
+			}
+
+			if !result.addSubtask(line, parent, item) {
+				break
+			}
+			continue
+		}
+
+		index := result.addItem(line, item)
+		if index < 0 {
+			break
+		}
+
+		if id := row("id"); id != "" {
+			parents[id] = index
+		}
+	}
+
+	return result
+}
+
+// csvItem reads the fields of a row, answering the message reported for it when a value is not valid.
+func csvItem(row func(field string) string, now time.Time) (listItemModels.ListItem, string, bool) {
+	item := listItemModels.ListItem{
+		Title:       row("title"),
+		Description: row("description"),
+		Unit:        strings.ToLower(row("unit")),
+		Currency:    strings.ToUpper(row("currency")),
+		Category:    row("category"),
+		Priority:    strings.ToLower(row("priority")),
+		TimeZone:    row("time_zone"),
+		Recurrence:  row("recurrence"),
+	}
+
+	var ok bool
+	if item.IsDone, ok = csvBool(row("done")); !ok {
+		return item, "invalid done", false
+	}
+
+	if item.AllDay, ok = csvBool(row("all_day")); !ok {
+		return item, "invalid all_day", false
+	}
+
+	if value := row("quantity"); value != "" {
+		quantity, err := decimal.NewFromString(decimalPoint(value))
+		if err != nil || quantity.IsNegative() {
+			return item, "invalid quantity", false
+		}
+		item.Quantity = &quantity
+	}
+
+	if value := row("unit_price"); value != "" {
+		unitPrice, err := decimal.NewFromString(decimalPoint(value))
+		if err != nil || unitPrice.IsNegative() {
+			return item, "invalid unit_price", false
+		}
+		item.UnitPrice = &unitPrice
+	}
+
+	for _, tag := range strings.FieldsFunc(row("tags"), func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			item.Tags = append(item.Tags, tag)
+		}
+	}
+
+	if value := row("due_at"); value != "" {
+		location := now.Location()
+		if item.TimeZone != "" {
+			loaded, err := time.LoadLocation(item.TimeZone)
+			if err != nil {
+				return item, "invalid time_zone", false
+			}
+			location = loaded
+		}
+
+		dueAt, allDay, ok := csvDueAt(value, location)
+		if !ok {
+			return item, "invalid due_at", false
+		}
+
+		item.DueAt = &dueAt
+		item.AllDay = item.AllDay || allDay
+		if item.TimeZone == "" {
+			item.TimeZone = location.String()
+		}
+	}
+
+	return item, "", true
+}
+
+// csvDueAt reads an RFC 3339 moment, a local date and time or only a date, which makes the item an all day one.
+func csvDueAt(value string, location *time.Location) (time.Time, bool, bool) {
+	if dueAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return dueAt, false, true
+	}
+
+	for _, layout := range localLayouts {
+		if dueAt, err := time.ParseInLocation(layout, value, location); err == nil {
+			return dueAt, false, true
+		}
+	}
+
+	if dueAt, err := time.ParseInLocation("2006-01-02", value, location); err == nil {
+		return dueAt, true, true
+	}
+
+	return time.Time{}, false, false
+}
+
+// decimalPoint turns the decimal comma used by spreadsheets on many locales into a point, "1,5" into "1.5".
+func decimalPoint(value string) string {
+	if strings.Count(value, ",") == 1 && !strings.Contains(value, ".") {
+		return strings.Replace(value, ",", ".", 1)
+	}
+	return value
+}
+
+func csvBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "", "false", "0", "no", "n":
+		return false, true
+	case "true", "1", "yes", "y", "x":
+		return true, true
+	}
+	return false, false
+}
@@ -0,0 +1,183 @@
+package parse
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Export archives are bounded by their amount of files, the size of each file and the size of all of them once
+// uncompressed, so a small archive can't expand without end.
+const (
+	maxExportFiles     = 1000
+	maxExportFileSize  = 20 << 20
+	maxExportTotalSize = 100 << 20
+)
+
+// exportFile is a file of the export of another app, the upload itself or one of the files on its zip archive.
+type exportFile struct {
+	name    string
+	content []byte
+}
+
+// Export holds the lists read from the export of another app. Errors are those of the files that couldn't be read at
+// all, the errors of the lines of a list are on the list itself.
+type Export struct {
+	Lists  []Result
+	Errors []models.LineError
+}
+
+var exportParsers = map[string]func(files []exportFile, now time.Time) (Export, error){
+	models.SourceKeep:    parseKeep,
+	models.SourceTodoist: parseTodoist,
+	models.SourceMSToDo:  parseMSToDo,
+}
+
+// ParseExport reads the lists on the export of another app, uploaded as fileName. Exports are either a single file
+// or a zip archive of them, like Google Takeout or the Todoist backup. It returns models.ErrUnreadableExport when
+// the content is not an export of source.
+func ParseExport(source string, fileName string, content []byte, now time.Time) (Export, error) {
+	parser, ok := exportParsers[source]
+	if !ok {
+		return Export{}, models.ErrUnreadableExport
+	}
+
+	files, err := exportFiles(fileName, content)
+	if err != nil {
+		return Export{}, err
+	}
+
+	export, err := parser(files, now)
+	if err != nil {
+		return Export{}, err
+	}
+
+	if len(export.Lists) > models.MaxArchiveLists {
+		export.Errors = append(export.Errors, models.LineError{
+			Msg: fmt.Sprintf("%d lists left out, at most %d can be imported at once", len(export.Lists)-models.MaxArchiveLists, models.MaxArchiveLists),
+		})
+		export.Lists = export.Lists[:models.MaxArchiveLists]
+	}
+
+	return export, nil
+}
+
+// exportFiles opens the zip archive on content, sorted by name, or answers content itself when it is not one.
+func exportFiles(fileName string, content []byte) ([]exportFile, error) {
+	if !bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		return []exportFile{{name: fileName, content: content}}, nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, models.ErrUnreadableExport
+	}
+
+	if len(archive.File) > maxExportFiles {
+		return nil, models.ErrArchiveTooLarge
+	}
+
+	var files []exportFile
+	var total uint64
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") || strings.HasPrefix(path.Base(file.Name), ".") {
+			continue
+		}
+
+		// Sizes on the archive are checked before inflating anything, the reads below are limited in case they lie
+		if file.UncompressedSize64 > maxExportFileSize || total+file.UncompressedSize64 > maxExportTotalSize {
+			return nil, models.ErrArchiveTooLarge
+		}
+
+		opened, err := file.Open()
+		if err != nil {
+			return nil, models.ErrUnreadableExport
+		}
+
+		read, err := io.ReadAll(io.LimitReader(opened, maxExportFileSize+1))
+		opened.Close()
+		if err != nil {
+			return nil, models.ErrUnreadableExport
+		}
+
+		total += uint64(len(read))
+		if len(read) > maxExportFileSize || total > maxExportTotalSize {
+			return nil, models.ErrArchiveTooLarge
+		}
+
+		files = append(files, exportFile{name: file.Name, content: read})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].name < files[j].name
+	})
+
+	return files, nil
+}
+
+// filesWithExtension keeps the files named with extension, like ".json".
+func filesWithExtension(files []exportFile, extension string) []exportFile {
+	var kept []exportFile
+	for _, file := range files {
+		if strings.EqualFold(path.Ext(file.name), extension) {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
+// baseName is the name of a file without its folders nor its extension.
+func baseName(name string) string {
+	base := path.Base(name)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+func (r *Result) addFileError(file string, line int, msg string) {
+	r.Errors = append(r.Errors, models.LineError{File: file, Line: line, Msg: msg})
+}
+
+// addExportItem validates item before adding it, reporting it as found on line of file otherwise. It returns the
+// index of the item or -1 when it was not added.
+func (r *Result) addExportItem(file string, line int, item listItemModels.ListItem) int {
+	if msg, ok := validateItem(item); !ok {
+		r.addFileError(file, line, msg)
+		return -1
+	}
+
+	if r.full(line) {
+		return -1
+	}
+
+	r.count++
+	r.Items = append(r.Items, item)
+	return len(r.Items) - 1
+}
+
+// addExportSubtask is addExportItem for subtasks of the item at parent.
+func (r *Result) addExportSubtask(file string, line int, parent int, item listItemModels.ListItem) bool {
+	if msg, ok := validateItem(item); !ok {
+		r.addFileError(file, line, msg)
+		return false
+	}
+
+	return r.addSubtask(line, parent, item)
+}
+
+// appendDescription adds text on a new line of the description of item.
+func appendDescription(item *listItemModels.ListItem, text string) {
+	if text = strings.TrimSpace(text); text == "" {
+		return
+	}
+
+	if item.Description != "" {
+		item.Description += "\n"
+	}
+	item.Description += text
+}
@@ -0,0 +1,229 @@
+package parse
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+// zipped builds a zip archive with files, keyed by their name.
+func zipped(t *testing.T, files map[string]string) []byte {
+	var buffer bytes.Buffer
+	archive := zip.NewWriter(&buffer)
+	for name, content := range files {
+		file, err := archive.Create(name)
+		assert.NoError(t, err)
+		_, err = file.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, archive.Close())
+	return buffer.Bytes()
+}
+
+func TestParseExport_Keep(t *testing.T) {
+	content := zipped(t, map[string]string{
+		"Takeout/Keep/Groceries.json": `{"title":"Groceries","textContent":"","labels":[{"name":"home"}],
+			"listContent":[{"text":"Milk","isChecked":true},{"text":" ","isChecked":false},{"text":"Bread","isChecked":false}]}`,
+		"Takeout/Keep/Plumber.json":   `{"title":"","textContent":"Call on monday\nAsk for Pedro"}`,
+		"Takeout/Keep/Trashed.json":   `{"title":"Old","isTrashed":true,"listContent":[{"text":"Eggs"}]}`,
+		"Takeout/Keep/Labels.json":    `[{"name":"home"}]`,
+		"Takeout/Keep/Groceries.html": `<html></html>`,
+	})
+
+	export, err := ParseExport(models.SourceKeep, "takeout.zip", content, now)
+
+	assert.NoError(t, err)
+	assert.Len(t, export.Lists, 2)
+
+	groceries := export.Lists[0]
+	assert.Equal(t, "Groceries", groceries.Name)
+	assert.Equal(t, 2, groceries.Count())
+	assert.Equal(t, "Milk", groceries.Items[0].Title)
+	assert.True(t, groceries.Items[0].IsDone)
+	assert.Equal(t, []string{"home"}, []string(groceries.Items[1].Tags))
+
+	plumber := export.Lists[1]
+	assert.Equal(t, "Plumber", plumber.Name)
+	assert.Equal(t, "Call on monday\nAsk for Pedro", plumber.Description)
+	assert.Empty(t, plumber.Items)
+
+	assert.Equal(t, []models.LineError{{File: "Takeout/Keep/Labels.json", Msg: "not a Keep note"}}, export.Errors)
+}
+
+func TestParseExport_Todoist_CSV(t *testing.T) {
+	content := zipped(t, map[string]string{
+		"Groceries [2203306141].csv": strings.Join([]string{
+			"TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE",
+			"section,Produce,,,,,,,,",
+			"task,Tomatoes @market,ripe ones,1,1,Ana (1),,2026-10-21,en,Europe/Madrid",
+			"task,Cherry tomatoes,,4,2,Ana (1),,,en,",
+			"note,only the red ones,,,,Ana (1),,,,",
+			",,,,,,,,,",
+			"task,Bread,,4,1,Ana (1),,every monday,en,",
+			"task,,,4,1,Ana (1),,,en,",
+		}, "\n"),
+		"Chores [2203306142].csv": "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+			"task,Call the plumber,,2,1,Ana (1),,21 Oct 2026 18:30,en,UTC\n",
+	})
+
+	export, err := ParseExport(models.SourceTodoist, "backup.zip", content, now)
+
+	assert.NoError(t, err)
+	assert.Empty(t, export.Errors)
+	assert.Len(t, export.Lists, 2)
+
+	chores := export.Lists[0]
+	assert.Equal(t, "Chores", chores.Name)
+	assert.Equal(t, "high", chores.Items[0].Priority)
+	assert.Equal(t, time.Date(2026, 10, 21, 18, 30, 0, 0, time.UTC), *chores.Items[0].DueAt)
+	assert.False(t, chores.Items[0].AllDay)
+
+	groceries := export.Lists[1]
+	assert.Equal(t, "Groceries", groceries.Name)
+	assert.Equal(t, 3, groceries.Count())
+
+	tomatoes := groceries.Items[0]
+	assert.Equal(t, "Tomatoes", tomatoes.Title)
+	assert.Equal(t, "ripe ones", tomatoes.Description)
+	assert.Equal(t, []string{"market"}, []string(tomatoes.Tags))
+	assert.Equal(t, "urgent", tomatoes.Priority)
+	assert.Equal(t, "Produce", tomatoes.Category)
+	assert.True(t, tomatoes.AllDay)
+	assert.Equal(t, "Europe/Madrid", tomatoes.TimeZone)
+	assert.Equal(t, "Cherry tomatoes", tomatoes.Children[0].Title)
+	assert.Equal(t, "only the red ones", tomatoes.Children[0].Description)
+
+	bread := groceries.Items[1]
+	assert.Nil(t, bread.DueAt)
+	assert.Equal(t, "Due: every monday", bread.Description)
+
+	assert.Equal(t, []models.LineError{{File: "Groceries [2203306141].csv", Line: 8, Msg: "missing item title"}}, groceries.Errors)
+}
+
+func TestParseExport_Todoist_JSON(t *testing.T) {
+	content := `{
+		"projects": [{"id": "1", "name": "Groceries"}, {"id": 2, "name": "Old", "is_deleted": 1}],
+		"sections": [{"id": "10", "name": "Produce"}],
+		"items": [
+			{"id": "102", "project_id": "1", "parent_id": "101", "content": "Cherry tomatoes", "checked": true, "child_order": 1},
+			{"id": "103", "project_id": "1", "parent_id": "102", "content": "Red ones", "child_order": 2},
+			{"id": "101", "project_id": "1", "section_id": "10", "content": "Tomatoes", "priority": 4, "labels": ["market"],
+				"due": {"date": "2026-10-21T18:00:00", "timezone": "Europe/Madrid"}, "child_order": 0},
+			{"id": "104", "project_id": "1", "content": "Bread", "due": {"date": "2026-10-22"}, "child_order": 3},
+			{"id": "105", "project_id": 2, "content": "Eggs"}
+		],
+		"notes": [{"item_id": "101", "content": "ripe ones"}]
+	}`
+
+	export, err := ParseExport(models.SourceTodoist, "todoist.json", []byte(content), now)
+
+	assert.NoError(t, err)
+	assert.Len(t, export.Lists, 1)
+
+	groceries := export.Lists[0]
+	assert.Equal(t, "Groceries", groceries.Name)
+	assert.Equal(t, 4, groceries.Count())
+
+	tomatoes := groceries.Items[0]
+	assert.Equal(t, "Tomatoes", tomatoes.Title)
+	assert.Equal(t, "urgent", tomatoes.Priority)
+	assert.Equal(t, "Produce", tomatoes.Category)
+	assert.Equal(t, "ripe ones", tomatoes.Description)
+	assert.Equal(t, []string{"market"}, []string(tomatoes.Tags))
+	assert.Equal(t, time.Date(2026, 10, 21, 16, 0, 0, 0, time.UTC), tomatoes.DueAt.UTC())
+	assert.Len(t, tomatoes.Children, 2)
+	assert.True(t, tomatoes.Children[0].IsDone)
+	assert.Equal(t, "Red ones", tomatoes.Children[1].Title)
+
+	assert.Equal(t, "Bread", groceries.Items[1].Title)
+	assert.True(t, groceries.Items[1].AllDay)
+}
+
+func TestParseExport_MSToDo(t *testing.T) {
+	content := `{"value": [
+		{"displayName": "", "wellknownListName": "defaultList", "tasks": [
+			{"title": "Call the plumber", "status": "notStarted", "importance": "high",
+				"body": {"content": "<p>Ask for <b>Pedro</b> &amp; co</p>", "contentType": "html"},
+				"dueDateTime": {"dateTime": "2026-10-21T00:00:00.0000000", "timeZone": "UTC"},
+				"checklistItems": [{"displayName": "Find the receipt", "isChecked": true}]}
+		]},
+		{"displayName": "Groceries", "tasks": [
+			{"title": "Milk", "status": "completed", "importance": "normal", "categories": ["Dairy"], "body": {"content": "skimmed", "contentType": "text"}},
+			{"title": "  ", "status": "notStarted"}
+		]}
+	]}`
+
+	export, err := ParseExport(models.SourceMSToDo, "todo.json", []byte(content), now)
+
+	assert.NoError(t, err)
+	assert.Len(t, export.Lists, 2)
+
+	tasks := export.Lists[0]
+	assert.Equal(t, "Tasks", tasks.Name)
+	assert.Equal(t, "high", tasks.Items[0].Priority)
+	assert.Equal(t, "Ask for Pedro & co", tasks.Items[0].Description)
+	assert.True(t, tasks.Items[0].AllDay)
+	assert.Equal(t, time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC), *tasks.Items[0].DueAt)
+	assert.Equal(t, "Find the receipt", tasks.Items[0].Children[0].Title)
+	assert.True(t, tasks.Items[0].Children[0].IsDone)
+
+	groceries := export.Lists[1]
+	assert.Equal(t, "Groceries", groceries.Name)
+	assert.True(t, groceries.Items[0].IsDone)
+	assert.Equal(t, "", groceries.Items[0].Priority)
+	assert.Equal(t, []string{"Dairy"}, []string(groceries.Items[0].Tags))
+	assert.Equal(t, []models.LineError{{File: "todo.json", Line: 2, Msg: "missing item title"}}, groceries.Errors)
+}
+
+func TestParseExport_Unreadable(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		content string
+	}{
+		{name: "Keep without notes", source: models.SourceKeep, content: `{"foo": "bar"}`},
+		{name: "Todoist csv of another app", source: models.SourceTodoist, content: "title,done\nMilk,true"},
+		{name: "To Do without lists", source: models.SourceMSToDo, content: `{"value": []}`},
+		{name: "Unknown source", source: "wunderlist", content: `{}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExport(tt.source, "export.json", []byte(tt.content), now)
+			assert.ErrorIs(t, err, models.ErrUnreadableExport)
+		})
+	}
+}
+
+func TestParseExport_Too_Large(t *testing.T) {
+	manyFiles := map[string]string{}
+	for i := 0; i <= maxExportFiles; i++ {
+		manyFiles[fmt.Sprintf("Takeout/Keep/Note %d.json", i)] = `{"title":"Note","listContent":[{"text":"Milk"}]}`
+	}
+
+	// Every file fits, all of them together are larger than an archive may expand to
+	bigFile := strings.Repeat("0", maxExportFileSize)
+	bigFiles := map[string]string{}
+	for i := 0; i <= maxExportTotalSize/maxExportFileSize; i++ {
+		bigFiles[fmt.Sprintf("Takeout/Keep/Note %d.json", i)] = bigFile
+	}
+
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{name: "Too many files", files: manyFiles},
+		{name: "File too large", files: map[string]string{"Takeout/Keep/Note.json": bigFile + "0"}},
+		{name: "Files too large together", files: bigFiles},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExport(models.SourceKeep, "takeout.zip", zipped(t, tt.files), now)
+			assert.ErrorIs(t, err, models.ErrArchiveTooLarge)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package parse
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// keepNote is a note of the Google Keep export on Google Takeout, a json file per note.
+type keepNote struct {
+	Title       string `json:"title"`
+	TextContent string `json:"textContent"`
+	ListContent []struct {
+		Text      string `json:"text"`
+		IsChecked bool   `json:"isChecked"`
+	} `json:"listContent"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	IsTrashed bool `json:"isTrashed"`
+}
+
+// parseKeep reads a list per note, the checklist of the note being its items and its labels their tags. Text notes
+// are imported as lists described by their text. Notes on the trash are left out.
+func parseKeep(files []exportFile, now time.Time) (Export, error) {
+	var export Export
+	read := 0
+
+	for _, file := range filesWithExtension(files, ".json") {
+		var note keepNote
+		if err := json.Unmarshal(file.content, &note); err != nil || (note.Title == "" && note.TextContent == "" && note.ListContent == nil) {
+			export.Errors = append(export.Errors, models.LineError{File: file.name, Msg: "not a Keep note"})
+			continue
+		}
+		read++
+
+		if note.IsTrashed {
+			continue
+		}
+
+		result := Result{Name: strings.TrimSpace(note.Title), Description: strings.TrimSpace(note.TextContent)}
+		if result.Name == "" {
+			result.Name = baseName(file.name)
+		}
+
+		var tags []string
+		for _, label := range note.Labels {
+			tags = append(tags, label.Name)
+		}
+
+		for i, entry := range note.ListContent {
+			if strings.TrimSpace(entry.Text) == "" {
+				continue
+			}
+
+			result.addExportItem(file.name, i+1, listItemModels.ListItem{
+				Title:  strings.TrimSpace(entry.Text),
+				IsDone: entry.IsChecked,
+				Tags:   tags,
+			})
+		}
+
+		export.Lists = append(export.Lists, result)
+	}
+
+	if read == 0 {
+		return Export{}, models.ErrUnreadableExport
+	}
+
+	return export, nil
+}
@@ -0,0 +1,196 @@
+package parse
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"github.com/shopspring/decimal"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	taskLine     = regexp.MustCompile(`^([ \t]*)(?:[-*+]|\d+[.)])[ \t]+(?:\[([ xX])\][ \t]+)?(.*\S)[ \t]*$`)
+	headingLine  = regexp.MustCompile(`^(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	escaped      = regexp.MustCompile(`\\([\\` + "`" + `*_\[\]<>#!~|{}()+.-])`)
+	dueDate      = regexp.MustCompile(`^due (\d{4}-\d{2}-\d{2})$`)
+	dueMoment    = regexp.MustCompile(`^due (\d{4}-\d{2}-\d{2} \d{2}:\d{2})(?: (\S+))?$`)
+	quantityUnit = regexp.MustCompile(`^(\d+(?:\.\d+)?)(?: (\S+))?$`)
+	priorityText = regexp.MustCompile(`^(\S+) priority$`)
+)
+
+// subtaskIndent is the indentation that nests a task under the one above it, tabs count as four spaces.
+const subtaskIndent = 2
+
+// parseMarkdown reads task list items, "- [ ] pending" and "- [x] done", plain bullets being pending items. Indented
+// tasks are subtasks of the task above them and indented text is added to its description. The first title names
+// the list and the text before the first task describes it, later titles set the category of the tasks below them.
+// Lists exported as markdown read back with their quantities, due dates, priorities and tags.
+func parseMarkdown(content string, now time.Time) Result {
+	var result Result
+
+	category := ""
+	parent, lastSubtask := -1, -1
+	var description []string
+
+	for i, line := range lines(content) {
+		lineNumber := i + 1
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if match := headingLine.FindStringSubmatch(line); match != nil {
+			title := unescapeMarkdown(match[2])
+			if len(match[1]) == 1 && result.Name == "" && len(result.Items) == 0 {
+				result.Name = title
+			} else {
+				category = title
+			}
+			continue
+		}
+
+		match := taskLine.FindStringSubmatch(line)
+		if match == nil {
+			text := unescapeMarkdown(strings.TrimSpace(line))
+
+			switch {
+			case indentation(line) >= subtaskIndent && parent >= 0:
+				addDescription(&result, parent, lastSubtask, text)
+			case len(result.Items) == 0:
+				description = append(description, text)
+			default:
+				result.addError(lineNumber, "expected a task line")
+			}
+			continue
+		}
+
+		item := markdownItem(unescapeMarkdown(match[3]), now)
+		item.IsDone = strings.EqualFold(match[2], "x")
+		if item.Category == "" {
+			item.Category = category
+		}
+
+		if msg, ok := validateItem(item); !ok {
+			result.addError(lineNumber, msg)
+			continue
+		}
+
+		if indentation(match[1]) >= subtaskIndent && parent >= 0 {
+			if !result.addSubtask(lineNumber, parent, item) {
+				break
+			}
+			lastSubtask = len(result.Items[parent].Children) - 1
+			continue
+		}
+
+		if parent = result.addItem(lineNumber, item); parent < 0 {
+			break
+		}
+		lastSubtask = -1
+	}
+
+	result.Description = strings.Join(description, "\n")
+	return result
+}
+
+// addDescription appends a line to the description of the last task read.
+func addDescription(result *Result, parent int, subtask int, text string) {
+	item := &result.Items[parent]
+	if subtask >= 0 {
+		item = &item.Children[subtask]
+	}
+
+	if item.Description != "" {
+		item.Description += "\n"
+	}
+	item.Description += text
+}
+
+func indentation(text string) int {
+	width := 0
+	for _, r := range text {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+func unescapeMarkdown(text string) string {
+	return escaped.ReplaceAllString(text, "$1")
+}
+
+// markdownItem reads the text of a task. Text written like the markdown export, "Tomatoes — 2 kg · due 2026-10-21",
+// keeps the title as it is, anything else is read as on the quick add box.
+func markdownItem(text string, now time.Time) listItemModels.ListItem {
+	if separator := strings.LastIndex(text, " — "); separator > 0 {
+		if item, ok := exportedItem(text[:separator], text[separator+len(" — "):], now); ok {
+			return item
+		}
+	}
+
+	return quickAddItem(text, now)
+}
+
+// exportedItem reads the details the markdown export writes after a title, false when they are not understood.
+func exportedItem(title string, details string, now time.Time) (listItemModels.ListItem, bool) {
+	item := listItemModels.ListItem{Title: strings.TrimSpace(title)}
+
+	for _, detail := range strings.Split(details, " · ") {
+		if match := dueDate.FindStringSubmatch(detail); match != nil {
+			dueAt, err := time.ParseInLocation("2006-01-02", match[1], now.Location())
+			if err != nil {
+				return item, false
+			}
+			item.DueAt, item.AllDay, item.TimeZone = &dueAt, true, now.Location().String()
+			continue
+		}
+
+		if match := dueMoment.FindStringSubmatch(detail); match != nil {
+			location := now.Location()
+			if match[2] == "UTC" {
+				location = time.UTC
+			}
+
+			dueAt, err := time.ParseInLocation("2006-01-02 15:04", match[1], location)
+			if err != nil {
+				return item, false
+			}
+			item.DueAt, item.TimeZone = &dueAt, location.String()
+			continue
+		}
+
+		if match := priorityText.FindStringSubmatch(detail); match != nil {
+			item.Priority = match[1]
+			continue
+		}
+
+		if strings.HasPrefix(detail, "#") {
+			for _, tag := range strings.Fields(detail) {
+				if !strings.HasPrefix(tag, "#") || len(tag) == 1 {
+					return item, false
+				}
+				item.Tags = append(item.Tags, tag[1:])
+			}
+			continue
+		}
+
+		if match := quantityUnit.FindStringSubmatch(detail); match != nil {
+			quantity, err := decimal.NewFromString(match[1])
+			if err != nil {
+				return item, false
+			}
+			item.Quantity, item.Unit = &quantity, match[2]
+			continue
+		}
+
+		return item, false
+	}
+
+	return item, true
+}
@@ -0,0 +1,155 @@
+package parse
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"bytes"
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// msToDoDefaultList is the well known name of the list Microsoft To Do calls "Tasks".
+const msToDoDefaultList = "defaultList"
+
+var htmlTag = regexp.MustCompile(`(?s)<[^>]*>`)
+
+var msToDoImportance = map[string]string{
+	"high": listItemModels.PriorityHigh,
+	"low":  listItemModels.PriorityLow,
+}
+
+type msToDoList struct {
+	DisplayName       string       `json:"displayName"`
+	WellknownListName string       `json:"wellknownListName"`
+	Tasks             []msToDoTask `json:"tasks"`
+}
+
+type msToDoTask struct {
+	Title      string   `json:"title"`
+	Status     string   `json:"status"`
+	Importance string   `json:"importance"`
+	Categories []string `json:"categories"`
+	Body       struct {
+		Content     string `json:"content"`
+		ContentType string `json:"contentType"`
+	} `json:"body"`
+	DueDateTime *struct {
+		DateTime string `json:"dateTime"`
+		TimeZone string `json:"timeZone"`
+	} `json:"dueDateTime"`
+	ChecklistItems []struct {
+		DisplayName string `json:"displayName"`
+		IsChecked   bool   `json:"isChecked"`
+	} `json:"checklistItems"`
+}
+
+// parseMSToDo reads Microsoft To Do lists as returned by the Microsoft Graph todo api with their tasks expanded:
+// an object with the lists on "value" or "lists", or the array of lists itself. Archives with a json file per list
+// are read as well. Checklist steps are the subtasks of their task, categories its tags and due dates, which To Do
+// only keeps as dates, make all day items.
+func parseMSToDo(files []exportFile, now time.Time) (Export, error) {
+	var export Export
+
+	for _, file := range filesWithExtension(files, ".json") {
+		lists, ok := msToDoLists(file.content)
+		if !ok {
+			export.Errors = append(export.Errors, models.LineError{File: file.name, Msg: "not a Microsoft To Do export"})
+			continue
+		}
+
+		for _, list := range lists {
+			export.Lists = append(export.Lists, msToDoResult(file.name, list, now))
+		}
+	}
+
+	if len(export.Lists) == 0 {
+		return Export{}, models.ErrUnreadableExport
+	}
+
+	return export, nil
+}
+
+// msToDoLists reads the lists of a file, whatever of the accepted shapes it has.
+func msToDoLists(content []byte) ([]msToDoList, bool) {
+	content = bytes.TrimSpace(bytes.TrimPrefix(content, []byte(byteOrderMark)))
+
+	var lists []msToDoList
+	if bytes.HasPrefix(content, []byte("[")) {
+		if err := json.Unmarshal(content, &lists); err != nil {
+			return nil, false
+		}
+	} else {
+		var wrapped struct {
+			Value []msToDoList `json:"value"`
+			Lists []msToDoList `json:"lists"`
+		}
+		if err := json.Unmarshal(content, &wrapped); err != nil {
+			return nil, false
+		}
+
+		var single msToDoList
+		if err := json.Unmarshal(content, &single); err == nil && single.DisplayName != "" {
+			wrapped.Lists = append(wrapped.Lists, single)
+		}
+
+		lists = append(wrapped.Value, wrapped.Lists...)
+	}
+
+	for _, list := range lists {
+		if list.DisplayName == "" && list.WellknownListName == "" {
+			return nil, false
+		}
+	}
+
+	return lists, len(lists) > 0
+}
+
+func msToDoResult(fileName string, list msToDoList, now time.Time) Result {
+	result := Result{Name: strings.TrimSpace(list.DisplayName)}
+	if result.Name == "" && list.WellknownListName == msToDoDefaultList {
+		result.Name = "Tasks"
+	}
+
+	for i, task := range list.Tasks {
+		item := listItemModels.ListItem{
+			Title:    strings.TrimSpace(task.Title),
+			IsDone:   task.Status == "completed",
+			Priority: msToDoImportance[task.Importance],
+			Tags:     task.Categories,
+		}
+
+		item.Description = task.Body.Content
+		if strings.EqualFold(task.Body.ContentType, "html") {
+			item.Description = html.UnescapeString(htmlTag.ReplaceAllString(item.Description, ""))
+		}
+		item.Description = strings.TrimSpace(item.Description)
+
+		if task.DueDateTime != nil && len(task.DueDateTime.DateTime) >= len("2006-01-02") {
+			location := now.Location()
+			if loaded, err := time.LoadLocation(task.DueDateTime.TimeZone); err == nil && task.DueDateTime.TimeZone != "" {
+				location = loaded
+			}
+
+			if dueAt, err := time.ParseInLocation("2006-01-02", task.DueDateTime.DateTime[:len("2006-01-02")], location); err == nil {
+				item.DueAt, item.AllDay, item.TimeZone = &dueAt, true, location.String()
+			}
+		}
+
+		parent := result.addExportItem(fileName, i+1, item)
+		if parent < 0 {
+			continue
+		}
+
+		for _, step := range task.ChecklistItems {
+			result.addExportSubtask(fileName, i+1, parent, listItemModels.ListItem{
+				Title:  strings.TrimSpace(step.DisplayName),
+				IsDone: step.IsChecked,
+			})
+		}
+	}
+
+	return result
+}
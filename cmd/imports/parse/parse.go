@@ -0,0 +1,154 @@
+// Package parse reads list items out of the content users import: csv files, markdown task lists and plain text with
+// an item per line. Lines that can't be read are reported along with the line number instead of failing the whole
+// import, so callers decide whether to go on without them.
+package parse
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"SuperListsAPI/internal/quickadd"
+	"SuperListsAPI/internal/recurrence"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"strings"
+	"time"
+)
+
+// Result holds what was read from the content. Items are top level items carrying their subtasks on Children.
+// Name and Description are only found on markdown, out of its title and the text before the first task.
+type Result struct {
+	Name        string
+	Description string
+	Items       []listItemModels.ListItem
+	Errors      []models.LineError
+	count       int
+}
+
+// Count is the number of items read, subtasks included.
+func (r Result) Count() int {
+	return r.count
+}
+
+// byteOrderMark starts the utf-8 files saved by some spreadsheets and editors.
+const byteOrderMark = "\uFEFF"
+
+var parsers = map[string]func(content string, now time.Time) Result{
+	models.FormatCSV:      parseCSV,
+	models.FormatMarkdown: parseMarkdown,
+	models.FormatText:     parseText,
+}
+
+// Parse reads content on format. Relative and local dates are read against now, on now's location. It returns false
+// when the format is not supported.
+func Parse(format string, content string, now time.Time) (Result, bool) {
+	parser, ok := parsers[format]
+	if !ok {
+		return Result{}, false
+	}
+
+	return parser(strings.TrimPrefix(content, byteOrderMark), now), true
+}
+
+func (r *Result) addError(line int, msg string) {
+	r.Errors = append(r.Errors, models.LineError{Line: line, Msg: msg})
+}
+
+// full reports, once, that the line would go past MaxImportItems.
+func (r *Result) full(line int) bool {
+	if r.count < models.MaxImportItems {
+		return false
+	}
+
+	if r.count == models.MaxImportItems {
+		r.addError(line, fmt.Sprintf("too many items, at most %d can be imported at once", models.MaxImportItems))
+		r.count++
+	}
+
+	return true
+}
+
+// addItem adds a top level item, returning its index or -1 when the import is full.
+func (r *Result) addItem(line int, item listItemModels.ListItem) int {
+	if r.full(line) {
+		return -1
+	}
+
+	r.count++
+	r.Items = append(r.Items, item)
+	return len(r.Items) - 1
+}
+
+// addSubtask adds item under the top level item at parent.
+func (r *Result) addSubtask(line int, parent int, item listItemModels.ListItem) bool {
+	if r.full(line) {
+		return false
+	}
+
+	r.count++
+	r.Items[parent].Children = append(r.Items[parent].Children, item)
+	return true
+}
+
+// lines splits content on its line breaks, whatever the system that wrote it.
+func lines(content string) []string {
+	return strings.Split(strings.ReplaceAll(strings.ReplaceAll(content, "\r\n", "\n"), "\r", "\n"), "\n")
+}
+
+// quickAddItem reads an item the same way the quick add box does.
+func quickAddItem(text string, now time.Time) listItemModels.ListItem {
+	parsed := quickadd.Parse(text, now)
+
+	item := listItemModels.ListItem{
+		Title:    parsed.Title,
+		Quantity: parsed.Quantity,
+		Unit:     parsed.Unit,
+		Tags:     parsed.Tags,
+		DueAt:    parsed.DueAt,
+		AllDay:   parsed.AllDay,
+		Priority: parsed.Priority,
+	}
+
+	if parsed.DueAt != nil {
+		item.TimeZone = now.Location().String()
+	}
+
+	return item
+}
+
+// validatedFields are the fields of an imported item checked against the rules of ListItem, by their column name.
+var validatedFields = map[string]string{
+	"Unit":     "unit",
+	"Currency": "currency",
+	"TimeZone": "time_zone",
+	"Priority": "priority",
+}
+
+var validate = validator.New()
+
+// validateItem checks the fields of an imported item the same way they are checked when created through the api,
+// answering the message reported for its line.
+func validateItem(item listItemModels.ListItem) (string, bool) {
+	if strings.TrimSpace(item.Title) == "" {
+		return "missing item title", false
+	}
+
+	fields := make([]string, 0, len(validatedFields))
+	for field := range validatedFields {
+		fields = append(fields, field)
+	}
+
+	if err := validate.StructPartial(item, fields...); err != nil {
+		if validationErrors, ok := err.(validator.ValidationErrors); ok && len(validationErrors) > 0 {
+			return "invalid " + validatedFields[validationErrors[0].StructField()], false
+		}
+		return err.Error(), false
+	}
+
+	if item.Recurrence != "" {
+		if _, err := recurrence.Parse(item.Recurrence); err != nil {
+			return "invalid recurrence", false
+		}
+	}
+
+	return "", true
+}
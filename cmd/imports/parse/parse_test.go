@@ -0,0 +1,188 @@
+package parse
+
+import (
+	"SuperListsAPI/cmd/export/format"
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"bytes"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+func TestParse_Unknown_Format(t *testing.T) {
+	_, ok := Parse("xlsx", "Milk", now)
+	assert.False(t, ok)
+}
+
+func TestParse_Text(t *testing.T) {
+	result, ok := Parse(models.FormatText, "- 2 kg tomatoes #veggies\r\n\r\n1. Bread tomorrow\n  \n* !!\n", now)
+
+	assert.True(t, ok)
+	assert.Equal(t, 2, result.Count())
+	assert.Equal(t, "tomatoes", result.Items[0].Title)
+	assert.Equal(t, "2", result.Items[0].Quantity.String())
+	assert.Equal(t, "kg", result.Items[0].Unit)
+	assert.Equal(t, []string{"veggies"}, []string(result.Items[0].Tags))
+	assert.Equal(t, "Bread", result.Items[1].Title)
+	assert.Equal(t, now.AddDate(0, 0, 1).Format("2006-01-02"), result.Items[1].DueAt.Format("2006-01-02"))
+	assert.Equal(t, "UTC", result.Items[1].TimeZone)
+	assert.Equal(t, []models.LineError{{Line: 5, Msg: "missing item title"}}, result.Errors)
+}
+
+func TestParse_Text_Too_Many_Items(t *testing.T) {
+	result, _ := Parse(models.FormatText, strings.Repeat("Milk\n", models.MaxImportItems+10), now)
+
+	assert.Equal(t, models.MaxImportItems, len(result.Items))
+	assert.Len(t, result.Errors, 1)
+	assert.Equal(t, models.MaxImportItems+1, result.Errors[0].Line)
+}
+
+func TestParse_Markdown(t *testing.T) {
+	content := strings.Join([]string{
+		"# Weekend",
+		"Things to get done",
+		"",
+		"## Produce",
+		"- [ ] Tomatoes",
+		"  - [x] Cherry tomatoes",
+		"    only the red ones",
+		"- [X] Lettuce",
+		"## Chores",
+		"* Call the plumber !high",
+		"  ask for \\*Pedro\\*",
+		"stray paragraph",
+		"- [ ] 3 kg",
+	}, "\n")
+
+	result, _ := Parse(models.FormatMarkdown, content, now)
+
+	assert.Equal(t, "Weekend", result.Name)
+	assert.Equal(t, "Things to get done", result.Description)
+	assert.Equal(t, 4, result.Count())
+
+	assert.Equal(t, "Tomatoes", result.Items[0].Title)
+	assert.False(t, result.Items[0].IsDone)
+	assert.Equal(t, "Produce", result.Items[0].Category)
+	assert.Equal(t, "Cherry tomatoes", result.Items[0].Children[0].Title)
+	assert.True(t, result.Items[0].Children[0].IsDone)
+	assert.Equal(t, "only the red ones", result.Items[0].Children[0].Description)
+	assert.True(t, result.Items[1].IsDone)
+	assert.Equal(t, "Call the plumber", result.Items[2].Title)
+	assert.Equal(t, "high", result.Items[2].Priority)
+	assert.Equal(t, "Chores", result.Items[2].Category)
+	assert.Equal(t, "ask for *Pedro*", result.Items[2].Description)
+
+	assert.Equal(t, []models.LineError{
+		{Line: 12, Msg: "expected a task line"},
+		{Line: 13, Msg: "missing item title"},
+	}, result.Errors)
+}
+
+func TestParse_CSV(t *testing.T) {
+	content := strings.Join([]string{
+		"Title;Done;Quantity;Unit;Due;Tags;Parent_ID;ID;Color",
+		"Tomatoes;yes;1,5;KG;2026-10-21;market, weekly;;1;red",
+		"Cherry tomatoes;no;;;;;1;;",
+		"Orphan;;;;;;7;;",
+		"Bread;maybe;;;;;;;",
+		"Milk;;-1;;;;;;",
+		"Eggs;;12;boxes;;;;;",
+		"Plumber;;;;2026-10-22 18:30;;;;",
+		";;;;;;;;",
+		"Soap;;;;tomorrow;;;;",
+	}, "\n")
+
+	result, _ := Parse(models.FormatCSV, content, now)
+
+	assert.Equal(t, 3, result.Count())
+	assert.Equal(t, "Tomatoes", result.Items[0].Title)
+	assert.True(t, result.Items[0].IsDone)
+	assert.True(t, result.Items[0].AllDay)
+	assert.Equal(t, "1.5", result.Items[0].Quantity.String())
+	assert.Equal(t, "kg", result.Items[0].Unit)
+	assert.Equal(t, []string{"market", "weekly"}, []string(result.Items[0].Tags))
+	assert.Equal(t, "Cherry tomatoes", result.Items[0].Children[0].Title)
+	assert.Equal(t, "Plumber", result.Items[1].Title)
+	assert.Equal(t, time.Date(2026, 10, 22, 18, 30, 0, 0, time.UTC), *result.Items[1].DueAt)
+	assert.False(t, result.Items[1].AllDay)
+
+	assert.Equal(t, []models.LineError{
+		{Line: 4, Msg: "parent_id 7 is not a top level item above this row"},
+		{Line: 5, Msg: "invalid done"},
+		{Line: 6, Msg: "invalid quantity"},
+		{Line: 7, Msg: "invalid unit"},
+		{Line: 10, Msg: "invalid due_at"},
+	}, result.Errors)
+}
+
+func TestParse_CSV_Missing_Title(t *testing.T) {
+	result, _ := Parse(models.FormatCSV, "description,done\nMilk,true\n", now)
+
+	assert.Empty(t, result.Items)
+	assert.Equal(t, []models.LineError{{Line: 1, Msg: "missing title column"}}, result.Errors)
+}
+
+// exportedList is written with the export formats to check that they read back.
+func exportedList() listModels.List {
+	dueDate := time.Date(2026, 10, 21, 0, 0, 0, 0, time.UTC)
+	dueMoment := time.Date(2026, 10, 22, 21, 30, 0, 0, time.UTC)
+	two := decimal.NewFromInt(2)
+	price := decimal.RequireFromString("3.5")
+	parentID := uint(1)
+
+	return listModels.List{
+		Name:        "Groceries",
+		Description: "Everything for the weekend",
+		ListItems: listItemModels.NestItems([]listItemModels.ListItem{
+			{
+				Model: gorm.Model{ID: 1}, ListID: 7, Title: "Tomatoes", Quantity: &two, Unit: "kg", UnitPrice: &price, Currency: "EUR",
+				Tags: []string{"market", "weekly"}, DueAt: &dueDate, AllDay: true, TimeZone: "UTC", Priority: "high",
+				Recurrence: "FREQ=WEEKLY;BYDAY=SA",
+			},
+			{Model: gorm.Model{ID: 2}, ListID: 7, Title: "Cherry tomatoes", IsDone: true, ParentID: &parentID},
+			{Model: gorm.Model{ID: 3}, ListID: 7, Title: "Call the plumber, *again*", Description: "Ask for Pedro", DueAt: &dueMoment, TimeZone: "UTC"},
+		}),
+	}
+}
+
+func TestParse_Exported_Lists(t *testing.T) {
+	for _, name := range []string{format.CSV, format.Markdown} {
+		t.Run(name, func(t *testing.T) {
+			list := exportedList()
+
+			formatter, _ := format.Get(name)
+			var buffer bytes.Buffer
+			assert.NoError(t, formatter.Format(&buffer, list))
+
+			result, ok := Parse(name, buffer.String(), now)
+
+			assert.True(t, ok)
+			assert.Empty(t, result.Errors)
+			assert.Equal(t, 3, result.Count())
+
+			tomatoes := result.Items[0]
+			assert.Equal(t, "Tomatoes", tomatoes.Title)
+			assert.Equal(t, "2", tomatoes.Quantity.String())
+			assert.Equal(t, "kg", tomatoes.Unit)
+			assert.Equal(t, "high", tomatoes.Priority)
+			assert.Equal(t, []string{"market", "weekly"}, []string(tomatoes.Tags))
+			assert.True(t, tomatoes.AllDay)
+			assert.Equal(t, "2026-10-21", tomatoes.DueAt.Format("2006-01-02"))
+			assert.Equal(t, "Cherry tomatoes", tomatoes.Children[0].Title)
+			assert.True(t, tomatoes.Children[0].IsDone)
+
+			plumber := result.Items[1]
+			assert.Equal(t, "Call the plumber, *again*", plumber.Title)
+			assert.Equal(t, "Ask for Pedro", plumber.Description)
+			assert.Equal(t, fmt.Sprint(time.Date(2026, 10, 22, 21, 30, 0, 0, time.UTC)), fmt.Sprint(plumber.DueAt.UTC()))
+		})
+	}
+}
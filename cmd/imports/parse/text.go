@@ -0,0 +1,36 @@
+package parse
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// bulletPrefix matches the bullets and numbers lists are usually pasted with.
+var bulletPrefix = regexp.MustCompile(`^(?:[-*+•]|\d+[.)])\s+`)
+
+// parseText reads an item per non blank line, written as on the quick add box. Bullets and numbers in front of the
+// lines are dropped.
+func parseText(content string, now time.Time) Result {
+	var result Result
+
+	for i, line := range lines(content) {
+		line = strings.TrimSpace(bulletPrefix.ReplaceAllString(strings.TrimSpace(line), ""))
+		if line == "" {
+			continue
+		}
+
+		item := quickAddItem(line, now)
+
+		if msg, ok := validateItem(item); !ok {
+			result.addError(i+1, msg)
+			continue
+		}
+
+		if result.addItem(i+1, item) < 0 {
+			break
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,380 @@
+package parse
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// todoistProjectID drops the project id Todoist adds to the names of the files of its backup, "Groceries [2203306141]".
+var todoistProjectID = regexp.MustCompile(`\s*\[\d+\]$`)
+
+// todoistLayouts are the due dates written by Todoist on its csv files that can be read back. Other dates, like
+// recurring ones, are kept on the item description.
+var todoistLayouts = []string{
+	"2006-01-02T15:04:05", "2006-01-02 15:04", "2006-01-02",
+	"2 Jan 2006 15:04", "2 Jan 2006", "Jan 2 2006 15:04", "Jan 2 2006",
+}
+
+// todoistCSVPriorities maps the priorities of the csv files, where 1 is the most urgent, to ListItem priorities.
+var todoistCSVPriorities = map[string]string{
+	"1": listItemModels.PriorityUrgent,
+	"2": listItemModels.PriorityHigh,
+	"3": listItemModels.PriorityMedium,
+}
+
+// todoistPriorities maps the priorities of the json backup, where 4 is the most urgent, to ListItem priorities.
+var todoistPriorities = map[int]string{
+	4: listItemModels.PriorityUrgent,
+	3: listItemModels.PriorityHigh,
+	2: listItemModels.PriorityMedium,
+}
+
+// parseTodoist reads the Todoist backup, a zip archive with a csv file per project, a single csv file or the json
+// of the sync api. Every project is a list, sections set the category of their tasks and comments are added to
+// the description of their task. Subtasks deeper than one level are kept under their top level task.
+func parseTodoist(files []exportFile, now time.Time) (Export, error) {
+	var export Export
+
+	for _, file := range files {
+		content := bytes.TrimSpace(bytes.TrimPrefix(file.content, []byte(byteOrderMark)))
+
+		switch {
+		case bytes.HasPrefix(content, []byte("{")):
+			lists, err := parseTodoistJSON(file, content, now)
+			if err != nil {
+				export.Errors = append(export.Errors, models.LineError{File: file.name, Msg: err.Error()})
+				continue
+			}
+			export.Lists = append(export.Lists, lists...)
+		case strings.EqualFold(path.Ext(file.name), ".csv") || len(files) == 1:
+			list, err := parseTodoistCSV(file, string(content), now)
+			if err != nil {
+				export.Errors = append(export.Errors, models.LineError{File: file.name, Msg: err.Error()})
+				continue
+			}
+			export.Lists = append(export.Lists, list)
+		}
+	}
+
+	if len(export.Lists) == 0 {
+		return Export{}, models.ErrUnreadableExport
+	}
+
+	return export, nil
+}
+
+var errNotTodoist = errors.New("not a Todoist backup")
+
+// parseTodoistCSV reads the csv file of a project, named after the file.
+func parseTodoistCSV(file exportFile, content string, now time.Time) (Result, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return Result{}, errNotTodoist
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToUpper(strings.TrimSpace(name))] = i
+	}
+
+	if _, ok := columns["TYPE"]; !ok {
+		return Result{}, errNotTodoist
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return Result{}, errNotTodoist
+	}
+
+	result := Result{Name: todoistProjectID.ReplaceAllString(baseName(file.name), "")}
+	category := ""
+	parent, lastSubtask := -1, -1
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			var parseError *csv.ParseError
+			line := 0
+			if errors.As(err, &parseError) {
+				line = parseError.Line
+			}
+			result.addFileError(file.name, line, "unreadable row")
+			break
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		row := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		switch strings.ToLower(row("TYPE")) {
+		case "section":
+			category = row("CONTENT")
+		case "note":
+			if parent >= 0 {
+				item := &result.Items[parent]
+				if lastSubtask >= 0 {
+					item = &item.Children[lastSubtask]
+				}
+				appendDescription(item, row("CONTENT"))
+			}
+		case "task":
+			item := listItemModels.ListItem{
+				Description: row("DESCRIPTION"),
+				Category:    category,
+				Priority:    todoistCSVPriorities[row("PRIORITY")],
+			}
+			item.Title, item.Tags = todoistLabels(row("CONTENT"))
+
+			if date := row("DATE"); date != "" {
+				location := now.Location()
+				if loaded, err := time.LoadLocation(row("TIMEZONE")); err == nil && row("TIMEZONE") != "" {
+					location = loaded
+				}
+
+				if dueAt, allDay, ok := todoistDueAt(date, location); ok {
+					item.DueAt, item.AllDay, item.TimeZone = &dueAt, allDay, location.String()
+				} else {
+					appendDescription(&item, "Due: "+date)
+				}
+			}
+
+			indent, _ := strconv.Atoi(row("INDENT"))
+			if indent > 1 && parent >= 0 {
+				if result.addExportSubtask(file.name, line, parent, item) {
+					lastSubtask = len(result.Items[parent].Children) - 1
+				}
+				continue
+			}
+
+			if index := result.addExportItem(file.name, line, item); index >= 0 {
+				parent, lastSubtask = index, -1
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// todoistLabels takes the @labels Todoist writes on the content of the tasks of its csv files out as tags.
+func todoistLabels(content string) (string, []string) {
+	var words, tags []string
+	for _, word := range strings.Fields(content) {
+		if len(word) > 1 && strings.HasPrefix(word, "@") {
+			tags = append(tags, word[1:])
+			continue
+		}
+		words = append(words, word)
+	}
+	return strings.Join(words, " "), tags
+}
+
+// todoistDueAt reads a due date on location, only dates make all day items.
+func todoistDueAt(value string, location *time.Location) (time.Time, bool, bool) {
+	if dueAt, err := time.Parse(time.RFC3339, value); err == nil {
+		return dueAt, false, true
+	}
+
+	for _, layout := range todoistLayouts {
+		if dueAt, err := time.ParseInLocation(layout, value, location); err == nil {
+			return dueAt, !strings.Contains(layout, "15"), true
+		}
+	}
+
+	return time.Time{}, false, false
+}
+
+// todoistID reads the ids of the sync api, strings on its current version and numbers on older ones.
+type todoistID string
+
+func (id *todoistID) UnmarshalJSON(raw []byte) error {
+	if bytes.Equal(raw, []byte("null")) {
+		*id = ""
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		*id = todoistID(text)
+		return nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err != nil {
+		return err
+	}
+
+	*id = todoistID(number.String())
+	return nil
+}
+
+// todoistFlag reads the booleans of the sync api, numbers on its older versions.
+type todoistFlag bool
+
+func (f *todoistFlag) UnmarshalJSON(raw []byte) error {
+	switch string(raw) {
+	case "true", "1":
+		*f = true
+	case "false", "0", "null":
+		*f = false
+	default:
+		return errNotTodoist
+	}
+	return nil
+}
+
+type todoistBackup struct {
+	Projects []struct {
+		ID        todoistID   `json:"id"`
+		Name      string      `json:"name"`
+		IsDeleted todoistFlag `json:"is_deleted"`
+	} `json:"projects"`
+	Sections []struct {
+		ID   todoistID `json:"id"`
+		Name string    `json:"name"`
+	} `json:"sections"`
+	Items []struct {
+		ID          todoistID   `json:"id"`
+		ProjectID   todoistID   `json:"project_id"`
+		SectionID   todoistID   `json:"section_id"`
+		ParentID    todoistID   `json:"parent_id"`
+		Content     string      `json:"content"`
+		Description string      `json:"description"`
+		Checked     todoistFlag `json:"checked"`
+		IsDeleted   todoistFlag `json:"is_deleted"`
+		Priority    int         `json:"priority"`
+		Labels      []string    `json:"labels"`
+		ChildOrder  int         `json:"child_order"`
+		Due         *struct {
+			Date     string `json:"date"`
+			Timezone string `json:"timezone"`
+			String   string `json:"string"`
+		} `json:"due"`
+	} `json:"items"`
+	Notes []struct {
+		ItemID  todoistID `json:"item_id"`
+		Content string    `json:"content"`
+	} `json:"notes"`
+}
+
+// parseTodoistJSON reads the backup of the sync api, a list per project.
+func parseTodoistJSON(file exportFile, content []byte, now time.Time) ([]Result, error) {
+	var backup todoistBackup
+	if err := json.Unmarshal(content, &backup); err != nil || backup.Projects == nil {
+		return nil, errNotTodoist
+	}
+
+	sections := map[todoistID]string{}
+	for _, section := range backup.Sections {
+		sections[section.ID] = section.Name
+	}
+
+	notes := map[todoistID][]string{}
+	for _, note := range backup.Notes {
+		notes[note.ItemID] = append(notes[note.ItemID], note.Content)
+	}
+
+	parents := map[todoistID]todoistID{}
+	for _, item := range backup.Items {
+		parents[item.ID] = item.ParentID
+	}
+
+	// topLevel follows the parents of an item up to its top level task
+	topLevel := func(id todoistID) todoistID {
+		for seen := 0; parents[id] != "" && seen < len(parents); seen++ {
+			id = parents[id]
+		}
+		return id
+	}
+
+	// Items are taken in their order on the project, errors point to their position on the file
+	order := make([]int, len(backup.Items))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return backup.Items[order[i]].ChildOrder < backup.Items[order[j]].ChildOrder
+	})
+
+	var results []Result
+	for _, project := range backup.Projects {
+		if project.IsDeleted {
+			continue
+		}
+
+		result := Result{Name: project.Name}
+		indexes := map[todoistID]int{}
+
+		// Top level tasks go first so their subtasks can be nested under them
+		for _, pass := range []bool{false, true} {
+			for _, i := range order {
+				source := backup.Items[i]
+				if source.ProjectID != project.ID || source.IsDeleted || (source.ParentID != "") != pass {
+					continue
+				}
+
+				item := listItemModels.ListItem{
+					Description: source.Description,
+					IsDone:      bool(source.Checked),
+					Category:    sections[source.SectionID],
+					Priority:    todoistPriorities[source.Priority],
+					Tags:        source.Labels,
+				}
+				item.Title, _ = todoistLabels(source.Content)
+
+				for _, note := range notes[source.ID] {
+					appendDescription(&item, note)
+				}
+
+				if source.Due != nil && source.Due.Date != "" {
+					location := now.Location()
+					if loaded, err := time.LoadLocation(source.Due.Timezone); err == nil && source.Due.Timezone != "" {
+						location = loaded
+					}
+
+					if dueAt, allDay, ok := todoistDueAt(source.Due.Date, location); ok {
+						item.DueAt, item.AllDay, item.TimeZone = &dueAt, allDay, location.String()
+					}
+				}
+
+				if !pass {
+					if index := result.addExportItem(file.name, i+1, item); index >= 0 {
+						indexes[source.ID] = index
+					}
+					continue
+				}
+
+				parent, ok := indexes[topLevel(source.ID)]
+				if !ok {
+					result.addFileError(file.name, i+1, "subtask of a task that was not imported")
+					continue
+				}
+				result.addExportSubtask(file.name, i+1, parent, item)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listItemRepository "SuperListsAPI/cmd/listItems/repository"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ImportJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) ImportJobRepository {
+	return ImportJobRepository{db: db}
+}
+
+func (ijr *ImportJobRepository) Create(job models.ImportJob) (*models.ImportJob, error) {
+
+	if result := ijr.db.Create(&job); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &job, nil
+}
+
+func (ijr *ImportJobRepository) Get(jobID string) (*models.ImportJob, error) {
+
+	var job models.ImportJob
+
+	if result := ijr.db.First(&job, jobID); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &job, nil
+}
+
+// GetJobsByUserID returns the latest limit jobs of the user, newest first.
+func (ijr *ImportJobRepository) GetJobsByUserID(userID string, limit int) (*[]models.ImportJob, error) {
+
+	var jobs []models.ImportJob
+
+	if result := ijr.db.Where("user_id = ?", userID).Order("id DESC").Limit(limit).Find(&jobs); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &jobs, nil
+}
+
+// ClaimJob leases the oldest pending job, or a running one whose lease expired, to claimedBy until leaseUntil. It
+// returns nil when there is no job to run. Jobs are locked while claimed, so each is only claimed by one replica.
+func (ijr *ImportJobRepository) ClaimJob(claimedBy string, now time.Time, leaseUntil time.Time) (*models.ImportJob, error) {
+
+	var jobs []models.ImportJob
+
+	result := ijr.db.Raw(`UPDATE import_jobs SET status = ?, claimed_by = ?, claimed_until = ?, attempts = attempts + 1,
+started_at = COALESCE(started_at, ?), updated_at = ?
+WHERE id IN (
+SELECT id FROM import_jobs
+WHERE status = ? OR (status = ? AND claimed_until <= ?)
+ORDER BY id LIMIT 1 FOR UPDATE SKIP LOCKED)
+RETURNING *`,
+		models.JobRunning, claimedBy, leaseUntil, now, now,
+		models.JobPending, models.JobRunning, now).Scan(&jobs)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if len(jobs) == 0 {
+		return nil, nil
+	}
+
+	return &jobs[0], nil
+}
+
+// SaveProgress saves the progress of a job claimed by claimedBy and extends its lease until leaseUntil.
+func (ijr *ImportJobRepository) SaveProgress(job models.ImportJob, claimedBy string, leaseUntil time.Time) error {
+	return update(ijr.db, job.ID, claimedBy, progress(job, leaseUntil))
+}
+
+// ImportList creates list along with its items, owned by its creator, and saves the progress of job counting it as
+// processed, both on a single transaction: a list is only created by a job still claimed by claimedBy, and a job
+// claimed again never creates it twice. itemCount is the amount of items the list is reported with.
+func (ijr *ImportJobRepository) ImportList(ctx context.Context, job models.ImportJob, claimedBy string, leaseUntil time.Time,
+	list listModels.List, items []listItemModels.ListItem, itemCount int) (*models.ImportJob, error) {
+
+	err := ijr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if _, err := listItemRepository.CreateOnNewList(tx, &list, items); err != nil {
+			return err
+		}
+
+		job.Lists = append(job.Lists, models.ImportedList{ListID: list.ID, Name: list.Name, ItemCount: itemCount})
+		job.ItemCount += itemCount
+		job.ProcessedLists++
+
+		return update(tx, job.ID, claimedBy, progress(job, leaseUntil))
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// Finish ends a job claimed by claimedBy with status, done or failed, releasing its lease.
+func (ijr *ImportJobRepository) Finish(jobID uint, claimedBy string, status string, lastError string, finishedAt time.Time) error {
+	return update(ijr.db, jobID, claimedBy, map[string]interface{}{
+		"status":        status,
+		"last_error":    lastError,
+		"finished_at":   finishedAt,
+		"claimed_by":    "",
+		"claimed_until": nil,
+	})
+}
+
+// progress is the update saving the progress of job, extending its lease until leaseUntil.
+func progress(job models.ImportJob, leaseUntil time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"claimed_until":   leaseUntil,
+		"total_lists":     job.TotalLists,
+		"processed_lists": job.ProcessedLists,
+		"item_count":      job.ItemCount,
+		"lists":           job.Lists,
+		"errors":          job.Errors,
+	}
+}
+
+// update changes a running job claimed by claimedBy on db, failing with models.ErrJobLost when it isn't.
+func update(db *gorm.DB, jobID uint, claimedBy string, updates map[string]interface{}) error {
+	result := db.Model(&models.ImportJob{}).
+		Where("id = ? AND status = ? AND claimed_by = ?", jobID, models.JobRunning, claimedBy).
+		Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return models.ErrJobLost
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestImportJobRepository_Create(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `import_jobs` (`user_id`,`source`,`file_name`,`storage_key`,`time_zone`,`status`,`claimed_by`,`claimed_until`,`attempts`,`total_lists`,`processed_lists`,`item_count`,`lists`,`errors`,`last_error`,`started_at`,`finished_at`,`created_at`,`updated_at`)")).
+		WithArgs(7, models.SourceKeep, "takeout.zip", "imports/7/key", "", models.JobPending, "", nil, 0, 0, 0, 0, "[]", "[]", "", nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectCommit()
+
+	result, err := importJobRepository.Create(models.ImportJob{
+		UserID: 7, Source: models.SourceKeep, FileName: "takeout.zip", StorageKey: "imports/7/key", Status: models.JobPending,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), result.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_Get(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `import_jobs` WHERE `import_jobs`.`id` = ? ORDER BY `import_jobs`.`id` LIMIT 1")).
+		WithArgs("3").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "lists", "errors"}).
+			AddRow(3, 7, models.JobDone, `[{"list_id":9,"name":"Groceries","item_count":2}]`, `[{"file":"a.json","line":0,"msg":"not a Keep note"}]`))
+
+	result, err := importJobRepository.Get("3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.ImportedLists{{ListID: 9, Name: "Groceries", ItemCount: 2}}, result.Lists)
+	assert.Equal(t, models.LineErrors{{File: "a.json", Msg: "not a Keep note"}}, result.Errors)
+}
+
+func TestImportJobRepository_GetJobsByUserID(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `import_jobs` WHERE user_id = ? ORDER BY id DESC LIMIT 20")).
+		WithArgs("7").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "lists", "errors"}).AddRow(4, 7, nil, nil).AddRow(3, 7, "", ""))
+
+	result, err := importJobRepository.GetJobsByUserID("7", 20)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 2)
+	assert.Empty(t, (*result)[0].Lists)
+	assert.Empty(t, (*result)[1].Errors)
+}
+
+func TestImportJobRepository_ClaimJob(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(5 * time.Minute)
+
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE import_jobs SET status = ?")).
+		WithArgs(models.JobRunning, "replica-1", leaseUntil, now, now, models.JobPending, models.JobRunning, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "status", "attempts", "processed_lists"}).AddRow(3, 7, models.JobRunning, 2, 1))
+
+	result, err := importJobRepository.ClaimJob("replica-1", now, leaseUntil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), result.ID)
+	assert.Equal(t, 2, result.Attempts)
+	assert.Equal(t, 1, result.ProcessedLists)
+}
+
+func TestImportJobRepository_ClaimJob_None(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	now := time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE import_jobs SET status = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result, err := importJobRepository.ClaimJob("replica-1", now, now.Add(5*time.Minute))
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestImportJobRepository_SaveProgress_Lost_Job(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	leaseUntil := time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `import_jobs` SET `claimed_until`=?,`errors`=?,`item_count`=?,`lists`=?,`processed_lists`=?,`total_lists`=?,`updated_at`=? WHERE id = ? AND status = ? AND claimed_by = ?")).
+		WithArgs(leaseUntil, "[]", 2, `[{"list_id":9,"name":"Groceries","item_count":2}]`, 1, 3, sqlmock.AnyArg(), 3, models.JobRunning, "replica-1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	err := importJobRepository.SaveProgress(models.ImportJob{
+		ID: 3, TotalLists: 3, ProcessedLists: 1, ItemCount: 2, Lists: models.ImportedLists{{ListID: 9, Name: "Groceries", ItemCount: 2}},
+	}, "replica-1", leaseUntil)
+
+	assert.ErrorIs(t, err, models.ErrJobLost)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_ImportList(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	leaseUntil := time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists`")).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_lists`")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 12, 4).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items`")).
+		WillReturnResult(sqlmock.NewResult(20, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `import_jobs` SET `claimed_until`=?,`errors`=?,`item_count`=?,`lists`=?,`processed_lists`=?,`total_lists`=?,`updated_at`=? WHERE id = ? AND status = ? AND claimed_by = ?")).
+		WithArgs(leaseUntil, "[]", 3, `[{"list_id":11,"name":"Groceries","item_count":2},{"list_id":12,"name":"Chores","item_count":1}]`, 2, 2,
+			sqlmock.AnyArg(), 3, models.JobRunning, "replica-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := importJobRepository.ImportList(context.Background(), models.ImportJob{
+		ID: 3, UserID: 4, TotalLists: 2, ProcessedLists: 1, ItemCount: 2, Lists: models.ImportedLists{{ListID: 11, Name: "Groceries", ItemCount: 2}},
+	}, "replica-1", leaseUntil, listModels.List{Name: "Chores", UserCreatorID: 4},
+		[]listItemModels.ListItem{{UserID: 4, Title: "Water the plants"}}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.ProcessedLists)
+	assert.Equal(t, 3, result.ItemCount)
+	assert.Equal(t, uint(12), result.Lists[1].ListID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_ImportList_Lost_Job(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists`")).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_lists`")).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `import_jobs` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	result, err := importJobRepository.ImportList(context.Background(), models.ImportJob{ID: 3, UserID: 4, TotalLists: 1},
+		"replica-1", time.Now(), listModels.List{Name: "Chores", UserCreatorID: 4}, []listItemModels.ListItem{}, 0)
+
+	assert.ErrorIs(t, err, models.ErrJobLost)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestImportJobRepository_Finish(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	importJobRepository := NewImportJobRepository(gormDb)
+
+	finishedAt := time.Date(2026, 10, 19, 10, 5, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `import_jobs` SET `claimed_by`=?,`claimed_until`=?,`finished_at`=?,`last_error`=?,`status`=?,`updated_at`=? WHERE id = ? AND status = ? AND claimed_by = ?")).
+		WithArgs("", nil, finishedAt, "", models.JobDone, sqlmock.AnyArg(), 3, models.JobRunning, "replica-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := importJobRepository.Finish(3, "replica-1", models.JobDone, "", finishedAt)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	"SuperListsAPI/cmd/imports/parse"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/storage"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+)
+
+const (
+	// jobLease is how long a job stays claimed without progress before another replica takes it over.
+	jobLease       = 5 * time.Minute
+	maxJobAttempts = 3
+)
+
+// ArchiveImportJob runs the pending import jobs, it implements scheduler.Job.
+//
+// Jobs are leased to a single replica at a time and save their progress along with every list they create, a job
+// claimed again after its replica stopped goes on from the first list not created.
+type ArchiveImportJob struct {
+	repository      IImportJobRepository
+	storage         storage.Storage
+	listItemService IListItemService
+	replicaID       string
+	now             func() time.Time
+}
+
+func NewArchiveImportJob(repository IImportJobRepository, storage storage.Storage, listItemService IListItemService,
+	replicaID string) ArchiveImportJob {
+	return ArchiveImportJob{
+		repository:      repository,
+		storage:         storage,
+		listItemService: listItemService,
+		replicaID:       replicaID,
+		now:             time.Now,
+	}
+}
+
+func (aij *ArchiveImportJob) Name() string {
+	return "archive-imports"
+}
+
+func (aij *ArchiveImportJob) Run(ctx context.Context) error {
+	for ctx.Err() == nil {
+		now := aij.now()
+
+		job, err := aij.repository.ClaimJob(aij.replicaID, now, now.Add(jobLease))
+		if err != nil {
+			return err
+		}
+
+		if job == nil {
+			return nil
+		}
+
+		if err := aij.run(ctx, *job); err != nil && !errors.Is(err, models.ErrJobLost) {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (aij *ArchiveImportJob) run(ctx context.Context, job models.ImportJob) error {
+	if job.Attempts > maxJobAttempts {
+		return aij.finish(ctx, job, models.JobFailed, fmt.Sprintf("gave up after %d attempts", maxJobAttempts))
+	}
+
+	content, err := aij.read(ctx, job)
+	if errors.Is(err, storage.ErrNotFound) {
+		return aij.finish(ctx, job, models.JobFailed, "the uploaded file is gone")
+	}
+	if err != nil {
+		return err
+	}
+
+	location := time.UTC
+	if job.TimeZone != "" {
+		if loaded, err := time.LoadLocation(job.TimeZone); err == nil {
+			location = loaded
+		}
+	}
+
+	export, err := parse.ParseExport(job.Source, job.FileName, content, aij.now().In(location))
+	if errors.Is(err, models.ErrUnreadableExport) || errors.Is(err, models.ErrArchiveTooLarge) {
+		return aij.finish(ctx, job, models.JobFailed, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	// Parsing gives the same result on every attempt, so lists already imported are skipped by their count
+	job.TotalLists = len(export.Lists)
+	job.Errors = append(models.LineErrors{}, export.Errors...)
+	for _, list := range export.Lists {
+		job.Errors = append(job.Errors, list.Errors...)
+	}
+
+	if err := aij.repository.SaveProgress(job, aij.replicaID, aij.now().Add(jobLease)); err != nil {
+		return err
+	}
+
+	ctx = activity.WithActor(ctx, job.UserID)
+
+	for i := job.ProcessedLists; i < len(export.Lists); i++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		saved, err := aij.importList(ctx, job, export.Lists[i])
+		if err != nil {
+			return err
+		}
+
+		job = *saved
+	}
+
+	return aij.finish(ctx, job, models.JobDone, "")
+}
+
+// read loads the uploaded export, refusing files past models.MaxArchiveSize.
+func (aij *ArchiveImportJob) read(ctx context.Context, job models.ImportJob) ([]byte, error) {
+	file, err := aij.storage.Get(ctx, job.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, models.MaxArchiveSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(content) > models.MaxArchiveSize {
+		return nil, models.ErrArchiveTooLarge
+	}
+
+	return content, nil
+}
+
+// importList creates a list of the export owned by the job user along with its items, saving the job progress on
+// the same transaction.
+func (aij *ArchiveImportJob) importList(ctx context.Context, job models.ImportJob, result parse.Result) (*models.ImportJob, error) {
+	for i := range result.Items {
+		result.Items[i].UserID = int(job.UserID)
+		for j := range result.Items[i].Children {
+			result.Items[i].Children[j].UserID = int(job.UserID)
+		}
+	}
+
+	if err := aij.listItemService.PrepareNewItems(result.Items); err != nil {
+		return nil, err
+	}
+
+	return aij.repository.ImportList(ctx, job, aij.replicaID, aij.now().Add(jobLease), listModels.List{
+		Name:          result.Name,
+		Description:   result.Description,
+		UserCreatorID: job.UserID,
+	}, result.Items, result.Count())
+}
+
+// finish ends the job and removes its file, a file that can't be removed is only logged.
+func (aij *ArchiveImportJob) finish(ctx context.Context, job models.ImportJob, status string, lastError string) error {
+	if err := aij.repository.Finish(job.ID, aij.replicaID, status, lastError, aij.now()); err != nil {
+		return err
+	}
+
+	if err := aij.storage.Delete(ctx, job.StorageKey); err != nil {
+		log.Print(fmt.Sprintf("Error removing file of import job %d: %s", job.ID, err.Error()))
+	}
+
+	return nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/storage"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const toDoExport = `{"value": [
+	{"displayName": "Groceries", "tasks": [{"title": "Milk", "status": "notStarted"}, {"title": "Bread", "status": "completed"}]},
+	{"displayName": "Chores", "tasks": [{"title": "Water the plants", "status": "notStarted"}]}
+]}`
+
+func TestArchiveImportJob_Run(t *testing.T) {
+	fileStorage := getStoredExport(t, "imports/4/key", toDoExport)
+	controller := gomock.NewController(t)
+
+	mockedRepo := NewMockIImportJobRepository(controller)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(&models.ImportJob{
+		ID: 1, UserID: 4, Source: models.SourceMSToDo, FileName: "todo.json", StorageKey: "imports/4/key", Attempts: 1,
+	}, nil)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	mockedRepo.EXPECT().SaveProgress(gomock.Any(), "replica", gomock.Any()).DoAndReturn(
+		func(job models.ImportJob, claimedBy string, leaseUntil time.Time) error {
+			assert.Equal(t, 2, job.TotalLists)
+			assert.Equal(t, 0, job.ProcessedLists)
+			return nil
+		})
+
+	var saved []models.ImportJob
+	mockedRepo.EXPECT().ImportList(gomock.Any(), gomock.Any(), "replica", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, job models.ImportJob, claimedBy string, leaseUntil time.Time, list listModels.List,
+			items []listItemModels.ListItem, itemCount int) (*models.ImportJob, error) {
+			assert.Equal(t, uint(4), list.UserCreatorID)
+			assert.Equal(t, 4, items[0].UserID)
+			job.Lists = append(job.Lists, models.ImportedList{ListID: uint(11 + len(saved)), Name: list.Name, ItemCount: itemCount})
+			job.ItemCount += itemCount
+			job.ProcessedLists++
+			saved = append(saved, job)
+			return &job, nil
+		}).Times(2)
+	mockedRepo.EXPECT().Finish(uint(1), "replica", models.JobDone, "", gomock.Any()).Return(nil)
+
+	mockedListItemService := NewMockIListItemService(controller)
+	mockedListItemService.EXPECT().PrepareNewItems(gomock.Any()).Return(nil).Times(2)
+
+	job := NewArchiveImportJob(mockedRepo, fileStorage, mockedListItemService, "replica")
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+	last := saved[len(saved)-1]
+	assert.Equal(t, 2, last.TotalLists)
+	assert.Equal(t, 2, last.ProcessedLists)
+	assert.Equal(t, 3, last.ItemCount)
+	assert.Equal(t, models.ImportedLists{
+		{ListID: 11, Name: "Groceries", ItemCount: 2},
+		{ListID: 12, Name: "Chores", ItemCount: 1},
+	}, last.Lists)
+
+	_, err = fileStorage.Get(context.Background(), "imports/4/key")
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestArchiveImportJob_Run_Resumes_After_Processed_Lists(t *testing.T) {
+	fileStorage := getStoredExport(t, "imports/4/key", toDoExport)
+	controller := gomock.NewController(t)
+
+	mockedRepo := NewMockIImportJobRepository(controller)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(&models.ImportJob{
+		ID: 1, UserID: 4, Source: models.SourceMSToDo, FileName: "todo.json", StorageKey: "imports/4/key", Attempts: 2,
+		ProcessedLists: 1, ItemCount: 2, Lists: models.ImportedLists{{ListID: 11, Name: "Groceries", ItemCount: 2}},
+	}, nil)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockedRepo.EXPECT().SaveProgress(gomock.Any(), "replica", gomock.Any()).Return(nil)
+	mockedRepo.EXPECT().ImportList(gomock.Any(), gomock.Any(), "replica", gomock.Any(), gomock.Any(), gomock.Any(), 1).DoAndReturn(
+		func(ctx context.Context, job models.ImportJob, claimedBy string, leaseUntil time.Time, list listModels.List,
+			items []listItemModels.ListItem, itemCount int) (*models.ImportJob, error) {
+			assert.Equal(t, 1, job.ProcessedLists)
+			assert.Equal(t, "Chores", list.Name)
+			job.ProcessedLists++
+			return &job, nil
+		})
+	mockedRepo.EXPECT().Finish(uint(1), "replica", models.JobDone, "", gomock.Any()).Return(nil)
+
+	mockedListItemService := NewMockIListItemService(controller)
+	mockedListItemService.EXPECT().PrepareNewItems(gomock.Any()).Return(nil)
+
+	job := NewArchiveImportJob(mockedRepo, fileStorage, mockedListItemService, "replica")
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestArchiveImportJob_Run_Fails_Unreadable_Export(t *testing.T) {
+	fileStorage := getStoredExport(t, "imports/4/key", `{"foo": "bar"}`)
+	controller := gomock.NewController(t)
+
+	mockedRepo := NewMockIImportJobRepository(controller)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(&models.ImportJob{
+		ID: 1, UserID: 4, Source: models.SourceKeep, FileName: "takeout.zip", StorageKey: "imports/4/key", Attempts: 1,
+	}, nil)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockedRepo.EXPECT().Finish(uint(1), "replica", models.JobFailed, models.ErrUnreadableExport.Error(), gomock.Any()).Return(nil)
+
+	job := NewArchiveImportJob(mockedRepo, fileStorage, NewMockIListItemService(controller), "replica")
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestArchiveImportJob_Run_Gives_Up_After_Max_Attempts(t *testing.T) {
+	controller := gomock.NewController(t)
+
+	mockedRepo := NewMockIImportJobRepository(controller)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(&models.ImportJob{
+		ID: 1, UserID: 4, StorageKey: "imports/4/key", Attempts: maxJobAttempts + 1,
+	}, nil)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockedRepo.EXPECT().Finish(uint(1), "replica", models.JobFailed, gomock.Any(), gomock.Any()).Return(nil)
+
+	job := NewArchiveImportJob(mockedRepo, getLocalStorage(t), NewMockIListItemService(controller), "replica")
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestArchiveImportJob_Run_Lost_Job(t *testing.T) {
+	fileStorage := getStoredExport(t, "imports/4/key", toDoExport)
+	controller := gomock.NewController(t)
+
+	mockedRepo := NewMockIImportJobRepository(controller)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(&models.ImportJob{
+		ID: 1, UserID: 4, Source: models.SourceMSToDo, FileName: "todo.json", StorageKey: "imports/4/key", Attempts: 1,
+	}, nil)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockedRepo.EXPECT().SaveProgress(gomock.Any(), "replica", gomock.Any()).Return(models.ErrJobLost)
+
+	job := NewArchiveImportJob(mockedRepo, fileStorage, NewMockIListItemService(controller), "replica")
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+}
+
+func TestArchiveImportJob_Run_Lost_Job_While_Importing_A_List(t *testing.T) {
+	fileStorage := getStoredExport(t, "imports/4/key", toDoExport)
+	controller := gomock.NewController(t)
+
+	mockedRepo := NewMockIImportJobRepository(controller)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(&models.ImportJob{
+		ID: 1, UserID: 4, Source: models.SourceMSToDo, FileName: "todo.json", StorageKey: "imports/4/key", Attempts: 1,
+	}, nil)
+	mockedRepo.EXPECT().ClaimJob("replica", gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockedRepo.EXPECT().SaveProgress(gomock.Any(), "replica", gomock.Any()).Return(nil)
+	mockedRepo.EXPECT().ImportList(gomock.Any(), gomock.Any(), "replica", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, models.ErrJobLost)
+
+	mockedListItemService := NewMockIListItemService(controller)
+	mockedListItemService.EXPECT().PrepareNewItems(gomock.Any()).Return(nil)
+
+	job := NewArchiveImportJob(mockedRepo, fileStorage, mockedListItemService, "replica")
+
+	err := job.Run(context.Background())
+
+	assert.NoError(t, err)
+
+	_, err = fileStorage.Get(context.Background(), "imports/4/key")
+	assert.NoError(t, err)
+}
+
+func getStoredExport(t *testing.T, key string, content string) storage.Storage {
+	fileStorage := getLocalStorage(t)
+	if err := fileStorage.Put(context.Background(), key, strings.NewReader(content), int64(len(content)), "application/json"); err != nil {
+		t.Fatal(err.Error())
+	}
+	return fileStorage
+}
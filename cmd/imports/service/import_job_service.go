@@ -0,0 +1,79 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/storage"
+	"context"
+	"fmt"
+	"github.com/gofrs/uuid"
+	"io"
+	"time"
+)
+
+//go:generate mockgen -source=import_job_service.go -destination import_job_service_mock.go -package service
+
+type IImportJobRepository interface {
+	Create(job models.ImportJob) (*models.ImportJob, error)
+	Get(jobID string) (*models.ImportJob, error)
+	GetJobsByUserID(userID string, limit int) (*[]models.ImportJob, error)
+	ClaimJob(claimedBy string, now time.Time, leaseUntil time.Time) (*models.ImportJob, error)
+	SaveProgress(job models.ImportJob, claimedBy string, leaseUntil time.Time) error
+	ImportList(ctx context.Context, job models.ImportJob, claimedBy string, leaseUntil time.Time,
+		list listModels.List, items []listItemModels.ListItem, itemCount int) (*models.ImportJob, error)
+	Finish(jobID uint, claimedBy string, status string, lastError string, finishedAt time.Time) error
+}
+
+type IListItemService interface {
+	PrepareNewItems(items []listItemModels.ListItem) error
+}
+
+// recentJobs is how many jobs of a user are listed.
+const recentJobs = 20
+
+type ImportJobService struct {
+	repository IImportJobRepository
+	storage    storage.Storage
+}
+
+func NewImportJobService(repository IImportJobRepository, storage storage.Storage) ImportJobService {
+	return ImportJobService{repository: repository, storage: storage}
+}
+
+// Create stores size bytes of the uploaded export and saves the job as pending, the ArchiveImportJob runs it later.
+func (ijs *ImportJobService) Create(ctx context.Context, job models.ImportJob, content io.Reader, size int64) (*models.ImportJob, error) {
+
+	if size > models.MaxArchiveSize {
+		return nil, models.ErrArchiveTooLarge
+	}
+
+	key, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	job.StorageKey = fmt.Sprintf("imports/%d/%s", job.UserID, key)
+	job.Status = models.JobPending
+
+	if err := ijs.storage.Put(ctx, job.StorageKey, content, size, "application/octet-stream"); err != nil {
+		return nil, err
+	}
+
+	result, err := ijs.repository.Create(job)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (ijs *ImportJobService) Get(jobID string) (*models.ImportJob, error) {
+	return ijs.repository.Get(jobID)
+}
+
+// GetJobsByUserID returns the latest jobs of the user, newest first.
+func (ijs *ImportJobService) GetJobsByUserID(userID string) (*[]models.ImportJob, error) {
+	return ijs.repository.GetJobsByUserID(userID, recentJobs)
+}
@@ -0,0 +1,179 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import_job_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/imports/models"
+	models0 "SuperListsAPI/cmd/listItems/models"
+	models1 "SuperListsAPI/cmd/lists/models"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIImportJobRepository is a mock of IImportJobRepository interface.
+type MockIImportJobRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIImportJobRepositoryMockRecorder
+}
+
+// MockIImportJobRepositoryMockRecorder is the mock recorder for MockIImportJobRepository.
+type MockIImportJobRepositoryMockRecorder struct {
+	mock *MockIImportJobRepository
+}
+
+// NewMockIImportJobRepository creates a new mock instance.
+func NewMockIImportJobRepository(ctrl *gomock.Controller) *MockIImportJobRepository {
+	mock := &MockIImportJobRepository{ctrl: ctrl}
+	mock.recorder = &MockIImportJobRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIImportJobRepository) EXPECT() *MockIImportJobRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimJob mocks base method.
+func (m *MockIImportJobRepository) ClaimJob(claimedBy string, now, leaseUntil time.Time) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimJob", claimedBy, now, leaseUntil)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimJob indicates an expected call of ClaimJob.
+func (mr *MockIImportJobRepositoryMockRecorder) ClaimJob(claimedBy, now, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimJob", reflect.TypeOf((*MockIImportJobRepository)(nil).ClaimJob), claimedBy, now, leaseUntil)
+}
+
+// Create mocks base method.
+func (m *MockIImportJobRepository) Create(job models.ImportJob) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", job)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIImportJobRepositoryMockRecorder) Create(job interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIImportJobRepository)(nil).Create), job)
+}
+
+// Finish mocks base method.
+func (m *MockIImportJobRepository) Finish(jobID uint, claimedBy, status, lastError string, finishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", jobID, claimedBy, status, lastError, finishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockIImportJobRepositoryMockRecorder) Finish(jobID, claimedBy, status, lastError, finishedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockIImportJobRepository)(nil).Finish), jobID, claimedBy, status, lastError, finishedAt)
+}
+
+// Get mocks base method.
+func (m *MockIImportJobRepository) Get(jobID string) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", jobID)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIImportJobRepositoryMockRecorder) Get(jobID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIImportJobRepository)(nil).Get), jobID)
+}
+
+// GetJobsByUserID mocks base method.
+func (m *MockIImportJobRepository) GetJobsByUserID(userID string, limit int) (*[]models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJobsByUserID", userID, limit)
+	ret0, _ := ret[0].(*[]models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJobsByUserID indicates an expected call of GetJobsByUserID.
+func (mr *MockIImportJobRepositoryMockRecorder) GetJobsByUserID(userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJobsByUserID", reflect.TypeOf((*MockIImportJobRepository)(nil).GetJobsByUserID), userID, limit)
+}
+
+// ImportList mocks base method.
+func (m *MockIImportJobRepository) ImportList(ctx context.Context, job models.ImportJob, claimedBy string, leaseUntil time.Time, list models1.List, items []models0.ListItem, itemCount int) (*models.ImportJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportList", ctx, job, claimedBy, leaseUntil, list, items, itemCount)
+	ret0, _ := ret[0].(*models.ImportJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportList indicates an expected call of ImportList.
+func (mr *MockIImportJobRepositoryMockRecorder) ImportList(ctx, job, claimedBy, leaseUntil, list, items, itemCount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportList", reflect.TypeOf((*MockIImportJobRepository)(nil).ImportList), ctx, job, claimedBy, leaseUntil, list, items, itemCount)
+}
+
+// SaveProgress mocks base method.
+func (m *MockIImportJobRepository) SaveProgress(job models.ImportJob, claimedBy string, leaseUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProgress", job, claimedBy, leaseUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveProgress indicates an expected call of SaveProgress.
+func (mr *MockIImportJobRepositoryMockRecorder) SaveProgress(job, claimedBy, leaseUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProgress", reflect.TypeOf((*MockIImportJobRepository)(nil).SaveProgress), job, claimedBy, leaseUntil)
+}
+
+// MockIListItemService is a mock of IListItemService interface.
+type MockIListItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListItemServiceMockRecorder
+}
+
+// MockIListItemServiceMockRecorder is the mock recorder for MockIListItemService.
+type MockIListItemServiceMockRecorder struct {
+	mock *MockIListItemService
+}
+
+// NewMockIListItemService creates a new mock instance.
+func NewMockIListItemService(ctrl *gomock.Controller) *MockIListItemService {
+	mock := &MockIListItemService{ctrl: ctrl}
+	mock.recorder = &MockIListItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
+	return m.recorder
+}
+
+// PrepareNewItems mocks base method.
+func (m *MockIListItemService) PrepareNewItems(items []models0.ListItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareNewItems", items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PrepareNewItems indicates an expected call of PrepareNewItems.
+func (mr *MockIListItemServiceMockRecorder) PrepareNewItems(items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareNewItems", reflect.TypeOf((*MockIListItemService)(nil).PrepareNewItems), items)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/imports/models"
+	"SuperListsAPI/internal/storage"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestImportJobService_Create(t *testing.T) {
+	fileStorage := getLocalStorage(t)
+	content := `{"value": []}`
+
+	mockedRepo := NewMockIImportJobRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(job models.ImportJob) (*models.ImportJob, error) {
+		job.ID = 1
+		return &job, nil
+	})
+
+	service := NewImportJobService(mockedRepo, fileStorage)
+
+	job, err := service.Create(context.Background(), models.ImportJob{UserID: 4, Source: models.SourceMSToDo},
+		strings.NewReader(content), int64(len(content)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobPending, job.Status)
+	assert.True(t, strings.HasPrefix(job.StorageKey, "imports/4/"))
+
+	file, err := fileStorage.Get(context.Background(), job.StorageKey)
+	assert.NoError(t, err)
+	defer file.Close()
+	stored, _ := io.ReadAll(file)
+	assert.Equal(t, content, string(stored))
+}
+
+func TestImportJobService_Create_Too_Large(t *testing.T) {
+	service := NewImportJobService(NewMockIImportJobRepository(gomock.NewController(t)), getLocalStorage(t))
+
+	_, err := service.Create(context.Background(), models.ImportJob{UserID: 4}, strings.NewReader(""), models.MaxArchiveSize+1)
+
+	assert.ErrorIs(t, err, models.ErrArchiveTooLarge)
+}
+
+func TestImportJobService_Create_Repository_Error(t *testing.T) {
+	mockedRepo := NewMockIImportJobRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("insert failed"))
+
+	service := NewImportJobService(mockedRepo, getLocalStorage(t))
+
+	_, err := service.Create(context.Background(), models.ImportJob{UserID: 4}, strings.NewReader("{}"), 2)
+
+	assert.Error(t, err)
+}
+
+func TestImportJobService_GetJobsByUserID(t *testing.T) {
+	mockedRepo := NewMockIImportJobRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetJobsByUserID("4", recentJobs).Return(&[]models.ImportJob{{ID: 1}}, nil)
+
+	service := NewImportJobService(mockedRepo, getLocalStorage(t))
+
+	jobs, err := service.GetJobsByUserID("4")
+
+	assert.NoError(t, err)
+	assert.Len(t, *jobs, 1)
+}
+
+func getLocalStorage(t *testing.T) storage.Storage {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	return fileStorage
+}
@@ -2,33 +2,83 @@ package handler
 
 import (
 	"SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	productModels "SuperListsAPI/cmd/products/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/httputil"
+	"SuperListsAPI/internal/quickadd"
+	"SuperListsAPI/internal/recurrence"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 	"net/http"
+	"reflect"
 	"strconv"
+	"strings"
+	"time"
 )
 
 //go:generate mockgen -source=list_item.go -destination list_item_mock.go -package handler
 
 type IListItemService interface {
-	Create(item models.ListItem) (*models.ListItem, error)
+	Create(ctx context.Context, item models.ListItem) (*models.ListItem, error)
+	CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error)
 	Get(listItemID string) (*models.ListItem, error)
-	Update(item models.ListItem) (*models.ListItem, error)
-	Delete(listItemID string) (*int, error)
+	Update(ctx context.Context, item models.ListItem) (*models.ListItem, error)
+	Delete(ctx context.Context, listItemID string) (*int, error)
 	GetItemsListByListID(listId string) (*[]models.ListItem, error)
-	DeleteListItemsByListID(listId string) (*int, error)
-	BulkDelete(tasksToDelete []models.ListItem) (*int, error)
-	MarkAsCompleted(tasksToDelete []models.ListItem) (*int, error)
-	MarkAsPending(tasksToDelete []models.ListItem) (*int, error)
+	DeleteListItemsByListID(ctx context.Context, listId string) (*int, error)
+	BulkDelete(ctx context.Context, listItemIDs []uint) (*bulk.Result, *versions.UndoToken, error)
+	MarkAsCompleted(ctx context.Context, listItemIDs []uint) (*bulk.Result, error)
+	MarkAsPending(ctx context.Context, listItemIDs []uint) (*bulk.Result, error)
+	CompleteChildren(ctx context.Context, parentIDs []uint) (*int, error)
+	Reorder(ctx context.Context, listId string, moves []models.ItemMove) (*[]models.ListItem, error)
+	MergeDuplicates(ctx context.Context, listId string) (*[]models.ListItem, error)
+	GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error)
+	GetItemsByIDs(listItemIDs []uint) (*[]models.ListItem, error)
+	MoveItems(ctx context.Context, listItemIDs []uint, listID uint) (*[]models.ListItem, error)
+	CopyItems(ctx context.Context, listItemIDs []uint, listID uint, userID uint) (*[]models.ListItem, error)
+	Assign(ctx context.Context, listItemID uint, userID uint, assignedBy uint) (*models.ListItemAssignee, error)
+	Unassign(ctx context.Context, listItemID string, userID string) (*int, error)
+	GetDueToday(userID string, now time.Time) (*[]models.ListItem, error)
+	GetUpcoming(userID string, now time.Time, days int) (*[]models.ListItem, error)
+	GetOverdue(userID string, now time.Time) (*[]models.ListItem, error)
+}
+
+const (
+	defaultUpcomingDays = 7
+	maxUpcomingDays     = 90
+)
+
+type IProductService interface {
+	Get(productID string) (*productModels.Product, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+	GetMembers(listID string) (*[]userListModels.UserList, error)
+}
+
+type IListService interface {
+	Get(listId string) (*listModels.List, error)
 }
 
 type ListItemHandler struct {
 	listItemService IListItemService
+	productService  IProductService
+	userListService IUserListService
+	listService     IListService
 }
 
-func NewListItemHandler(service IListItemService) ListItemHandler {
-	return ListItemHandler{service}
+func NewListItemHandler(service IListItemService, productService IProductService, userListService IUserListService,
+	listService IListService) ListItemHandler {
+	return ListItemHandler{listItemService: service, productService: productService, userListService: userListService,
+		listService: listService}
 }
 
 func (lih *ListItemHandler) Create(c *gin.Context) {
@@ -53,17 +103,270 @@ func (lih *ListItemHandler) Create(c *gin.Context) {
 		return
 	}
 
-	result, err := lih.listItemService.Create(listItem)
+	if !httputil.ValidRecurrence(c, listItem.Recurrence) {
+		return
+	}
+
+	if listItem.ProductID != nil {
+		product, err := lih.productService.Get(fmt.Sprint(*listItem.ProductID))
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": fmt.Sprintf("product with id %d not found", *listItem.ProductID),
+			})
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		// Items linked to the catalog always use the product name and category so they look the same on every list
+		listItem.Title = product.Name
+		if product.Category != "" {
+			listItem.Category = product.Category
+		}
+	}
+
+	result, err := lih.listItemService.Create(c.Request.Context(), listItem)
+
+	if errors.Is(err, models.ErrInvalidParent) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+// BulkCreate creates the items of a models.BulkCreateRequest on a single transaction. Every item is checked like on
+// Create, and the caller must be a member of each list the items go to. Invalid items are answered with 422 along
+// with the reason for each of them, unless the request is partial and some item can be created.
+func (lih *ListItemHandler) BulkCreate(c *gin.Context) {
+	var bulkRequest models.BulkCreateRequest
+
+	parsedUserID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	err = c.ShouldBindJSON(&bulkRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(bulkRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": fmt.Sprintf("items must hold between 1 and %d items", models.MaxBulkItems),
+		})
+		c.Abort()
+		return
+	}
+
+	checker, err := lih.newBulkChecker(uint(parsedUserID), bulkRequest.Items)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	result := models.BulkCreateResult{Created: []models.ListItem{}, Errors: []models.ItemError{}}
+	var items []models.ListItem
+
+	for i, item := range bulkRequest.Items {
+		msg, err := checker.check(&item, false)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		if msg != "" {
+			result.Errors = append(result.Errors, models.ItemError{Index: i, Msg: msg})
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	if len(result.Errors) > 0 && (!bulkRequest.Partial || len(items) == 0) {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+
+	created, err := lih.listItemService.CreateItems(c.Request.Context(), items)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
 		return
 	}
 
+	result.Created = *created
+
 	c.JSON(http.StatusCreated, result)
 	return
 }
 
+// bulkChecker checks the items of a bulk request for userID, remembering the lists, products and parents it already
+// looked up.
+type bulkChecker struct {
+	handler  *ListItemHandler
+	userID   uint
+	validate *validator.Validate
+	members  map[int]bool
+	products map[uint]*productModels.Product
+	parents  map[uint]models.ListItem
+}
+
+// newBulkChecker loads the parents the items are nested under with a single query.
+func (lih *ListItemHandler) newBulkChecker(userID uint, items []models.ListItem) (*bulkChecker, error) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return strings.Split(field.Tag.Get("json"), ",")[0]
+	})
+
+	checker := bulkChecker{
+		handler:  lih,
+		userID:   userID,
+		validate: validate,
+		members:  map[int]bool{},
+		products: map[uint]*productModels.Product{},
+		parents:  map[uint]models.ListItem{},
+	}
+
+	var parentIDs []uint
+	for _, item := range items {
+		if item.ParentID != nil {
+			parentIDs = append(parentIDs, *item.ParentID)
+		}
+	}
+
+	if len(parentIDs) == 0 {
+		return &checker, nil
+	}
+
+	parents, err := lih.listItemService.GetItemsByIDs(parentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, parent := range *parents {
+		checker.parents[parent.ID] = parent
+	}
+
+	return &checker, nil
+}
+
+// check prepares item to be created by the caller, answering why it can't be created when it is not valid.
+// Subtasks are checked along with their parent item.
+func (bc *bulkChecker) check(item *models.ListItem, subtask bool) (string, error) {
+	item.Model = gorm.Model{}
+	item.UserID = int(bc.userID)
+
+	if err := bc.validate.Struct(item); err != nil {
+		var fieldErrors validator.ValidationErrors
+		if errors.As(err, &fieldErrors) {
+			return fmt.Sprintf("%s is invalid", fieldErrors[0].Field()), nil
+		}
+		return err.Error(), nil
+	}
+
+	if !subtask {
+		member, err := bc.isMember(item.ListID)
+		if err != nil || !member {
+			return models.ErrNotListMember.Error(), err
+		}
+	}
+
+	if item.Recurrence != "" {
+		if _, err := recurrence.Parse(item.Recurrence); err != nil {
+			return err.Error(), nil
+		}
+	}
+
+	if item.ProductID != nil {
+		product, err := bc.product(*item.ProductID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Sprintf("product with id %d not found", *item.ProductID), nil
+		}
+		if err != nil {
+			return "", err
+		}
+
+		item.Title = product.Name
+		if product.Category != "" {
+			item.Category = product.Category
+		}
+	}
+
+	if item.ParentID != nil {
+		parent, ok := bc.parents[*item.ParentID]
+		if subtask || len(item.Children) > 0 || !ok || parent.ListID != item.ListID || parent.ParentID != nil {
+			return models.ErrInvalidParent.Error(), nil
+		}
+	}
+
+	for i := range item.Children {
+		if subtask {
+			return models.ErrInvalidParent.Error(), nil
+		}
+
+		item.Children[i].ListID = item.ListID
+		if msg, err := bc.check(&item.Children[i], true); msg != "" || err != nil {
+			return fmt.Sprintf("subtask %d: %s", i, msg), err
+		}
+	}
+
+	return "", nil
+}
+
+func (bc *bulkChecker) isMember(listID int) (bool, error) {
+	if member, ok := bc.members[listID]; ok {
+		return member, nil
+	}
+
+	members, err := bc.handler.userListService.GetUserListsByListID(fmt.Sprint(listID))
+	if err != nil {
+		return false, err
+	}
+
+	bc.members[listID] = userListModels.IsMember(*members, bc.userID)
+
+	return bc.members[listID], nil
+}
+
+func (bc *bulkChecker) product(productID uint) (*productModels.Product, error) {
+	if product, ok := bc.products[productID]; ok {
+		return product, nil
+	}
+
+	product, err := bc.handler.productService.Get(fmt.Sprint(productID))
+	if err != nil {
+		return nil, err
+	}
+
+	bc.products[productID] = product
+
+	return product, nil
+}
+
 func (lih *ListItemHandler) Get(c *gin.Context) {
 	listItemID := c.Param("id")
 
@@ -132,13 +435,61 @@ func (lih *ListItemHandler) Update(c *gin.Context) {
 		return
 	}
 
-	result, err := lih.listItemService.Update(listItemUpdateRequest)
+	if !httputil.ValidRecurrence(c, listItemUpdateRequest.Recurrence) {
+		return
+	}
+
+	parsedUserID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	listItem, members, ok := lih.itemWithMembers(c, listItemID)
+	if !ok {
+		return
+	}
+
+	if !userListModels.IsMember(*members, uint(parsedUserID)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": models.ErrNotListMember.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	if listItem.ListID != listItemUpdateRequest.ListID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": models.ErrListChangeNotAllowed.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := lih.listItemService.Update(c.Request.Context(), listItemUpdateRequest)
+
+	if errors.Is(err, models.ErrInvalidParent) || errors.Is(err, models.ErrListChangeNotAllowed) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
 		return
 	}
 
+	if result.IsDone && completeChildren(c) {
+		if _, err := lih.listItemService.CompleteChildren(c.Request.Context(), []uint{result.ID}); err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, result)
 	return
 }
@@ -154,7 +505,7 @@ func (lih *ListItemHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	result, err := lih.listItemService.Delete(listItemID)
+	result, err := lih.listItemService.Delete(c.Request.Context(), listItemID)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -166,42 +517,177 @@ func (lih *ListItemHandler) Delete(c *gin.Context) {
 
 }
 
+// BulkDelete deletes the items on a bulk.Request along with their subtasks, answering what happened to each of them.
 func (lih *ListItemHandler) BulkDelete(c *gin.Context) {
-	var listItemsToDelete []models.ListItem
+	result, listItemIDs, ok := lih.bulkTargets(c)
+	if !ok {
+		return
+	}
+
+	if len(listItemIDs) > 0 {
+		deleted, undoToken, err := lih.listItemService.BulkDelete(c.Request.Context(), listItemIDs)
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		if undoToken != nil {
+			c.Header(versions.UndoTokenHeader, undoToken.Token)
+			c.Header(versions.UndoExpiresAtHeader, undoToken.ExpiresAt.UTC().Format(time.RFC3339))
+		}
+
+		result.Add(deleted)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+
+}
+
+// MarkAsCompleted completes the items on a bulk.Request, and their subtasks when asked with ?complete_children=true.
+func (lih *ListItemHandler) MarkAsCompleted(c *gin.Context) {
+	result, listItemIDs, ok := lih.bulkTargets(c)
+	if !ok {
+		return
+	}
+
+	if len(listItemIDs) > 0 {
+		completed, err := lih.listItemService.MarkAsCompleted(c.Request.Context(), listItemIDs)
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		if completeChildren(c) {
+			if _, err := lih.listItemService.CompleteChildren(c.Request.Context(), listItemIDs); err != nil {
+				c.JSON(http.StatusInternalServerError, err)
+				return
+			}
+		}
+
+		result.Add(completed)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// completeChildren tells if completing an item should also complete its subtasks, asked with ?complete_children=true.
+func completeChildren(c *gin.Context) bool {
+	complete, _ := strconv.ParseBool(c.Query("complete_children"))
+	return complete
+}
+
+func (lih *ListItemHandler) MarkAsPending(c *gin.Context) {
+	result, listItemIDs, ok := lih.bulkTargets(c)
+	if !ok {
+		return
+	}
+
+	if len(listItemIDs) > 0 {
+		reopened, err := lih.listItemService.MarkAsPending(c.Request.Context(), listItemIDs)
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		result.Add(reopened)
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// bulkTargets reads a bulk.Request and sorts out the items that don't exist or belong to lists the caller is not a
+// member of, answering the request when it can't be read. The returned result holds those ids, the ids left are
+// the items the caller can change.
+func (lih *ListItemHandler) bulkTargets(c *gin.Context) (*bulk.Result, []uint, bool) {
+	var bulkRequest bulk.Request
+
+	parsedUserID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return nil, nil, false
+	}
 
-	err := c.ShouldBindJSON(&listItemsToDelete)
+	err = c.ShouldBindJSON(&bulkRequest)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"msg": "invalid json",
 		})
 		c.Abort()
-		return
+		return nil, nil, false
 	}
 
-	if len(listItemsToDelete) < 1 {
+	err = bulkRequest.Validate()
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"msg": "no tasks received for delete",
+			"msg": fmt.Sprintf("ids must hold between 1 and %d list item ids", bulk.MaxIDs),
 		})
 		c.Abort()
-		return
+		return nil, nil, false
 	}
 
-	result, err := lih.listItemService.BulkDelete(listItemsToDelete)
+	listItems, err := lih.listItemService.GetItemsByIDs(bulkRequest.IDs)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
-		return
+		return nil, nil, false
 	}
 
-	c.JSON(http.StatusOK, result)
-	return
+	result := bulk.NewResult()
+	found := make(map[uint]bool, len(*listItems))
+	members := map[int]bool{}
+	var listItemIDs []uint
+
+	for _, listItem := range *listItems {
+		found[listItem.ID] = true
+
+		member, checked := members[listItem.ListID]
+		if !checked {
+			userLists, err := lih.userListService.GetUserListsByListID(fmt.Sprint(listItem.ListID))
+
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, err)
+				return nil, nil, false
+			}
+
+			member = userListModels.IsMember(*userLists, uint(parsedUserID))
+			members[listItem.ListID] = member
+		}
+
+		if member {
+			listItemIDs = append(listItemIDs, listItem.ID)
+		} else {
+			result.Forbidden = append(result.Forbidden, listItem.ID)
+		}
+	}
+
+	result.NotFound = bulk.Missing(bulkRequest.IDs, found)
 
+	return result, listItemIDs, true
 }
 
-func (lih *ListItemHandler) MarkAsCompleted(c *gin.Context) {
-	var listItemsToUpdate []models.ListItem
+func (lih *ListItemHandler) Reorder(c *gin.Context) {
+	var reorderRequest models.ReorderRequest
+
+	listID := c.Param("id")
+
+	if _, err := strconv.Atoi(listID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list id",
+		})
+		c.Abort()
+		return
+	}
 
-	err := c.ShouldBindJSON(&listItemsToUpdate)
+	err := c.ShouldBindJSON(&reorderRequest)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"msg": "invalid json",
@@ -210,15 +696,30 @@ func (lih *ListItemHandler) MarkAsCompleted(c *gin.Context) {
 		return
 	}
 
-	if len(listItemsToUpdate) < 1 {
+	validate := validator.New()
+
+	err = validate.Struct(reorderRequest)
+
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"msg": "no tasks received for delete",
+			"msg": "invalid json",
 		})
 		c.Abort()
 		return
 	}
 
-	result, err := lih.listItemService.MarkAsCompleted(listItemsToUpdate)
+	if !lih.checkListMember(c, listID) {
+		return
+	}
+
+	result, err := lih.listItemService.Reorder(c.Request.Context(), listID, reorderRequest.Moves)
+
+	if errors.Is(err, models.ErrItemNotInList) || errors.Is(err, models.ErrMoveAfterItself) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -229,27 +730,41 @@ func (lih *ListItemHandler) MarkAsCompleted(c *gin.Context) {
 	return
 }
 
-func (lih *ListItemHandler) MarkAsPending(c *gin.Context) {
-	var listItemsToUpdate []models.ListItem
+func (lih *ListItemHandler) MergeDuplicates(c *gin.Context) {
+	listID := c.Param("id")
 
-	err := c.ShouldBindJSON(&listItemsToUpdate)
-	if err != nil {
+	if _, err := strconv.Atoi(listID); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"msg": "invalid json",
+			"msg": "invalid list id",
 		})
 		c.Abort()
 		return
 	}
 
-	if len(listItemsToUpdate) < 1 {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"msg": "no tasks received for delete",
-		})
-		c.Abort()
+	if !lih.checkListMember(c, listID) {
+		return
+	}
+
+	result, err := lih.listItemService.MergeDuplicates(c.Request.Context(), listID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// Move moves items, along with their subtasks, to the end of another list. The caller must own the destination list
+// and every list the items come from.
+func (lih *ListItemHandler) Move(c *gin.Context) {
+	transferRequest, _, ok := lih.transferRequest(c, true)
+	if !ok {
 		return
 	}
 
-	result, err := lih.listItemService.MarkAsPending(listItemsToUpdate)
+	result, err := lih.listItemService.MoveItems(c.Request.Context(), transferRequest.IDs, transferRequest.ListID)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -259,3 +774,535 @@ func (lih *ListItemHandler) MarkAsPending(c *gin.Context) {
 	c.JSON(http.StatusOK, result)
 	return
 }
+
+// Copy copies items, along with their subtasks, to the end of another list or of their own list. The caller must be a
+// member of the destination list and of every list the items come from.
+func (lih *ListItemHandler) Copy(c *gin.Context) {
+	transferRequest, userID, ok := lih.transferRequest(c, false)
+	if !ok {
+		return
+	}
+
+	result, err := lih.listItemService.CopyItems(c.Request.Context(), transferRequest.IDs, transferRequest.ListID, userID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+// transferRequest reads a move or copy request and checks the caller can take the items out of their lists and
+// into the destination one, answering the request when any of it fails. With owner the caller must also own each of
+// those lists, not only be a member.
+func (lih *ListItemHandler) transferRequest(c *gin.Context, owner bool) (*models.TransferRequest, uint, bool) {
+	var transferRequest models.TransferRequest
+
+	parsedUserID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return nil, 0, false
+	}
+
+	err = c.ShouldBindJSON(&transferRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return nil, 0, false
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(transferRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": fmt.Sprintf("ids must hold between 1 and %d list item ids and list_id is required", models.MaxTransferItems),
+		})
+		c.Abort()
+		return nil, 0, false
+	}
+
+	listItems, err := lih.listItemService.GetItemsByIDs(transferRequest.IDs)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return nil, 0, false
+	}
+
+	found := make(map[uint]bool, len(*listItems))
+	listIDs := []uint{transferRequest.ListID}
+	for _, listItem := range *listItems {
+		found[listItem.ID] = true
+		listIDs = append(listIDs, uint(listItem.ListID))
+	}
+
+	for _, listItemID := range transferRequest.IDs {
+		if !found[listItemID] {
+			c.JSON(http.StatusNotFound, fmt.Sprintf("ListItem with id %d not found", listItemID))
+			return nil, 0, false
+		}
+	}
+
+	checked := map[uint]bool{}
+	for _, listID := range listIDs {
+		if checked[listID] {
+			continue
+		}
+		checked[listID] = true
+
+		if !httputil.CheckListMember(c, lih.userListService, fmt.Sprint(listID), uint(parsedUserID)) {
+			return nil, 0, false
+		}
+
+		if !owner {
+			continue
+		}
+
+		list, err := lih.listService.Get(fmt.Sprint(listID))
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return nil, 0, false
+		}
+
+		if list.UserCreatorID != uint(parsedUserID) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"msg": models.ErrNotListOwner.Error(),
+			})
+			c.Abort()
+			return nil, 0, false
+		}
+	}
+
+	return &transferRequest, uint(parsedUserID), true
+}
+
+// QuickAdd creates an item out of a free text line, see the quickadd package for what can be written on it.
+// The response carries the created item and everything the parser understood.
+func (lih *ListItemHandler) QuickAdd(c *gin.Context) {
+	var quickAddRequest models.QuickAddRequest
+
+	listID := c.Param("id")
+	userID := c.Request.Header.Get("user_id")
+
+	parsedListID, err := strconv.Atoi(listID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list id",
+		})
+		c.Abort()
+		return
+	}
+
+	parsedUserID, err := strconv.Atoi(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	err = c.ShouldBindJSON(&quickAddRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(quickAddRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	now := time.Now()
+	if quickAddRequest.TimeZone != "" {
+		location, err := time.LoadLocation(quickAddRequest.TimeZone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid time zone",
+			})
+			c.Abort()
+			return
+		}
+		now = now.In(location)
+	}
+
+	parsed := quickadd.Parse(quickAddRequest.Text, now)
+
+	if parsed.Title == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing item title",
+		})
+		c.Abort()
+		return
+	}
+
+	members, err := lih.userListService.GetMembers(listID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if !userListModels.IsMember(*members, uint(parsedUserID)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": models.ErrNotListMember.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	listItem := models.ListItem{
+		ListID:   parsedListID,
+		UserID:   parsedUserID,
+		Title:    parsed.Title,
+		Quantity: parsed.Quantity,
+		Unit:     parsed.Unit,
+		Tags:     parsed.Tags,
+		DueAt:    parsed.DueAt,
+		AllDay:   parsed.AllDay,
+		Priority: parsed.Priority,
+	}
+
+	if parsed.DueAt != nil {
+		listItem.TimeZone = now.Location().String()
+	}
+
+	if parsed.Assignee != "" {
+		assignee, ok := userListModels.FindMember(*members, parsed.Assignee)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": models.ErrAssigneeNotMember.Error(),
+			})
+			c.Abort()
+			return
+		}
+		listItem.Assignments = []models.ListItemAssignee{{UserID: assignee.UserID, AssignedBy: uint(parsedUserID)}}
+	}
+
+	result, err := lih.listItemService.Create(c.Request.Context(), listItem)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"item":   result,
+		"parsed": parsed,
+	})
+	return
+}
+
+// Assign makes a member of the item list responsible for it. Only members of the list can assign.
+func (lih *ListItemHandler) Assign(c *gin.Context) {
+	var assignRequest models.AssignRequest
+
+	listItemID := c.Param("id")
+	userID := c.Request.Header.Get("user_id")
+
+	if _, err := strconv.Atoi(listItemID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list item id",
+		})
+		c.Abort()
+		return
+	}
+
+	parsedUserID, err := strconv.Atoi(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	err = c.ShouldBindJSON(&assignRequest)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(assignRequest)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	listItem, members, ok := lih.itemWithMembers(c, listItemID)
+	if !ok {
+		return
+	}
+
+	if !userListModels.IsMember(*members, uint(parsedUserID)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": models.ErrNotListMember.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	if !userListModels.IsMember(*members, assignRequest.UserID) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": models.ErrAssigneeNotMember.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := lih.listItemService.Assign(c.Request.Context(), listItem.ID, assignRequest.UserID, uint(parsedUserID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+func (lih *ListItemHandler) Unassign(c *gin.Context) {
+	listItemID := c.Param("id")
+	assigneeID := c.Param("userId")
+	userID := c.Request.Header.Get("user_id")
+
+	if _, err := strconv.Atoi(listItemID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid list item id",
+		})
+		c.Abort()
+		return
+	}
+
+	if _, err := strconv.Atoi(assigneeID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid assignee id",
+		})
+		c.Abort()
+		return
+	}
+
+	parsedUserID, err := strconv.Atoi(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	_, members, ok := lih.itemWithMembers(c, listItemID)
+	if !ok {
+		return
+	}
+
+	if !userListModels.IsMember(*members, uint(parsedUserID)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": models.ErrNotListMember.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := lih.listItemService.Unassign(c.Request.Context(), listItemID, assigneeID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result == nil || *result < 1 {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("User %s is not assigned to list item %s", assigneeID, listItemID))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// GetAssigned lists the open items assigned to the caller on every list they belong to.
+func (lih *ListItemHandler) GetAssigned(c *gin.Context) {
+	userID := c.Request.Header.Get("user_id")
+
+	if _, err := strconv.Atoi(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := lih.listItemService.GetItemsByFilter(models.ItemFilter{
+		AssigneeID: userID,
+		MemberID:   userID,
+		Pending:    true,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// itemWithMembers loads an item and the members of its list, answering the request when any of them fails.
+func (lih *ListItemHandler) itemWithMembers(c *gin.Context, listItemID string) (*models.ListItem, *[]userListModels.UserList, bool) {
+	listItem, err := lih.listItemService.Get(listItemID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("ListItem with id %s not found", listItemID))
+		return nil, nil, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+
+	members, err := lih.userListService.GetUserListsByListID(fmt.Sprint(listItem.ListID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return nil, nil, false
+	}
+
+	return listItem, members, true
+}
+
+// checkListMember makes sure the caller is a member of the list listID, answering the request when they are not.
+func (lih *ListItemHandler) checkListMember(c *gin.Context, listID string) bool {
+	parsedUserID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return false
+	}
+
+	return httputil.CheckListMember(c, lih.userListService, listID, uint(parsedUserID))
+}
+
+// Today lists the pending items due today on the caller lists. The day is taken on the ?tz= time zone, UTC by default.
+func (lih *ListItemHandler) Today(c *gin.Context) {
+	userID, now, ok := agendaRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := lih.listItemService.GetDueToday(userID, now)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// Upcoming lists the pending items due after today, for the next ?days= days.
+func (lih *ListItemHandler) Upcoming(c *gin.Context) {
+	userID, now, ok := agendaRequest(c)
+	if !ok {
+		return
+	}
+
+	days := defaultUpcomingDays
+	if rawDays := c.Query("days"); rawDays != "" {
+		parsedDays, err := strconv.Atoi(rawDays)
+		if err != nil || parsedDays < 1 || parsedDays > maxUpcomingDays {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": fmt.Sprintf("days must be a number between 1 and %d", maxUpcomingDays),
+			})
+			c.Abort()
+			return
+		}
+		days = parsedDays
+	}
+
+	result, err := lih.listItemService.GetUpcoming(userID, now, days)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (lih *ListItemHandler) Overdue(c *gin.Context) {
+	userID, now, ok := agendaRequest(c)
+	if !ok {
+		return
+	}
+
+	result, err := lih.listItemService.GetOverdue(userID, now)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// agendaRequest reads the caller and the current time on the requested time zone, answering with 400 when invalid.
+func agendaRequest(c *gin.Context) (string, time.Time, bool) {
+	userID := c.Request.Header.Get("user_id")
+
+	if _, err := strconv.Atoi(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return "", time.Time{}, false
+	}
+
+	location := time.UTC
+	if timeZone := c.Query("tz"); timeZone != "" {
+		loaded, err := time.LoadLocation(timeZone)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid time zone",
+			})
+			c.Abort()
+			return "", time.Time{}, false
+		}
+		location = loaded
+	}
+
+	return userID, time.Now().In(location), true
+}
@@ -6,7 +6,14 @@ package handler
 
 import (
 	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/lists/models"
+	models1 "SuperListsAPI/cmd/products/models"
+	models2 "SuperListsAPI/cmd/userLists/models"
+	bulk "SuperListsAPI/internal/bulk"
+	versions "SuperListsAPI/internal/versions"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,64 +41,125 @@ func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
 	return m.recorder
 }
 
+// Assign mocks base method.
+func (m *MockIListItemService) Assign(ctx context.Context, listItemID, userID, assignedBy uint) (*models.ListItemAssignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Assign", ctx, listItemID, userID, assignedBy)
+	ret0, _ := ret[0].(*models.ListItemAssignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Assign indicates an expected call of Assign.
+func (mr *MockIListItemServiceMockRecorder) Assign(ctx, listItemID, userID, assignedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Assign", reflect.TypeOf((*MockIListItemService)(nil).Assign), ctx, listItemID, userID, assignedBy)
+}
+
 // BulkDelete mocks base method.
-func (m *MockIListItemService) BulkDelete(tasksToDelete []models.ListItem) (*int, error) {
+func (m *MockIListItemService) BulkDelete(ctx context.Context, listItemIDs []uint) (*bulk.Result, *versions.UndoToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, listItemIDs)
+	ret0, _ := ret[0].(*bulk.Result)
+	ret1, _ := ret[1].(*versions.UndoToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockIListItemServiceMockRecorder) BulkDelete(ctx, listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListItemService)(nil).BulkDelete), ctx, listItemIDs)
+}
+
+// CompleteChildren mocks base method.
+func (m *MockIListItemService) CompleteChildren(ctx context.Context, parentIDs []uint) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkDelete", tasksToDelete)
+	ret := m.ctrl.Call(m, "CompleteChildren", ctx, parentIDs)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// BulkDelete indicates an expected call of BulkDelete.
-func (mr *MockIListItemServiceMockRecorder) BulkDelete(tasksToDelete interface{}) *gomock.Call {
+// CompleteChildren indicates an expected call of CompleteChildren.
+func (mr *MockIListItemServiceMockRecorder) CompleteChildren(ctx, parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteChildren", reflect.TypeOf((*MockIListItemService)(nil).CompleteChildren), ctx, parentIDs)
+}
+
+// CopyItems mocks base method.
+func (m *MockIListItemService) CopyItems(ctx context.Context, listItemIDs []uint, listID, userID uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyItems", ctx, listItemIDs, listID, userID)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CopyItems indicates an expected call of CopyItems.
+func (mr *MockIListItemServiceMockRecorder) CopyItems(ctx, listItemIDs, listID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListItemService)(nil).BulkDelete), tasksToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyItems", reflect.TypeOf((*MockIListItemService)(nil).CopyItems), ctx, listItemIDs, listID, userID)
 }
 
 // Create mocks base method.
-func (m *MockIListItemService) Create(item models.ListItem) (*models.ListItem, error) {
+func (m *MockIListItemService) Create(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", item)
+	ret := m.ctrl.Call(m, "Create", ctx, item)
 	ret0, _ := ret[0].(*models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIListItemServiceMockRecorder) Create(item interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) Create(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListItemService)(nil).Create), ctx, item)
+}
+
+// CreateItems mocks base method.
+func (m *MockIListItemService) CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItems", ctx, items)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItems indicates an expected call of CreateItems.
+func (mr *MockIListItemServiceMockRecorder) CreateItems(ctx, items interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListItemService)(nil).Create), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItems", reflect.TypeOf((*MockIListItemService)(nil).CreateItems), ctx, items)
 }
 
 // Delete mocks base method.
-func (m *MockIListItemService) Delete(listItemID string) (*int, error) {
+func (m *MockIListItemService) Delete(ctx context.Context, listItemID string) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", listItemID)
+	ret := m.ctrl.Call(m, "Delete", ctx, listItemID)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIListItemServiceMockRecorder) Delete(listItemID interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) Delete(ctx, listItemID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListItemService)(nil).Delete), listItemID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListItemService)(nil).Delete), ctx, listItemID)
 }
 
 // DeleteListItemsByListID mocks base method.
-func (m *MockIListItemService) DeleteListItemsByListID(listId string) (*int, error) {
+func (m *MockIListItemService) DeleteListItemsByListID(ctx context.Context, listId string) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteListItemsByListID", listId)
+	ret := m.ctrl.Call(m, "DeleteListItemsByListID", ctx, listId)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteListItemsByListID indicates an expected call of DeleteListItemsByListID.
-func (mr *MockIListItemServiceMockRecorder) DeleteListItemsByListID(listId interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) DeleteListItemsByListID(ctx, listId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListItemsByListID", reflect.TypeOf((*MockIListItemService)(nil).DeleteListItemsByListID), listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListItemsByListID", reflect.TypeOf((*MockIListItemService)(nil).DeleteListItemsByListID), ctx, listId)
 }
 
 // Get mocks base method.
@@ -109,6 +177,51 @@ func (mr *MockIListItemServiceMockRecorder) Get(listItemID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListItemService)(nil).Get), listItemID)
 }
 
+// GetDueToday mocks base method.
+func (m *MockIListItemService) GetDueToday(userID string, now time.Time) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueToday", userID, now)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueToday indicates an expected call of GetDueToday.
+func (mr *MockIListItemServiceMockRecorder) GetDueToday(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueToday", reflect.TypeOf((*MockIListItemService)(nil).GetDueToday), userID, now)
+}
+
+// GetItemsByFilter mocks base method.
+func (m *MockIListItemService) GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByFilter", filter)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByFilter indicates an expected call of GetItemsByFilter.
+func (mr *MockIListItemServiceMockRecorder) GetItemsByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByFilter", reflect.TypeOf((*MockIListItemService)(nil).GetItemsByFilter), filter)
+}
+
+// GetItemsByIDs mocks base method.
+func (m *MockIListItemService) GetItemsByIDs(listItemIDs []uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByIDs", listItemIDs)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByIDs indicates an expected call of GetItemsByIDs.
+func (mr *MockIListItemServiceMockRecorder) GetItemsByIDs(listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByIDs", reflect.TypeOf((*MockIListItemService)(nil).GetItemsByIDs), listItemIDs)
+}
+
 // GetItemsListByListID mocks base method.
 func (m *MockIListItemService) GetItemsListByListID(listId string) (*[]models.ListItem, error) {
 	m.ctrl.T.Helper()
@@ -124,47 +237,266 @@ func (mr *MockIListItemServiceMockRecorder) GetItemsListByListID(listId interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsListByListID", reflect.TypeOf((*MockIListItemService)(nil).GetItemsListByListID), listId)
 }
 
+// GetOverdue mocks base method.
+func (m *MockIListItemService) GetOverdue(userID string, now time.Time) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOverdue", userID, now)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOverdue indicates an expected call of GetOverdue.
+func (mr *MockIListItemServiceMockRecorder) GetOverdue(userID, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOverdue", reflect.TypeOf((*MockIListItemService)(nil).GetOverdue), userID, now)
+}
+
+// GetUpcoming mocks base method.
+func (m *MockIListItemService) GetUpcoming(userID string, now time.Time, days int) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcoming", userID, now, days)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUpcoming indicates an expected call of GetUpcoming.
+func (mr *MockIListItemServiceMockRecorder) GetUpcoming(userID, now, days interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcoming", reflect.TypeOf((*MockIListItemService)(nil).GetUpcoming), userID, now, days)
+}
+
 // MarkAsCompleted mocks base method.
-func (m *MockIListItemService) MarkAsCompleted(tasksToDelete []models.ListItem) (*int, error) {
+func (m *MockIListItemService) MarkAsCompleted(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsCompleted", tasksToDelete)
-	ret0, _ := ret[0].(*int)
+	ret := m.ctrl.Call(m, "MarkAsCompleted", ctx, listItemIDs)
+	ret0, _ := ret[0].(*bulk.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkAsCompleted indicates an expected call of MarkAsCompleted.
-func (mr *MockIListItemServiceMockRecorder) MarkAsCompleted(tasksToDelete interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) MarkAsCompleted(ctx, listItemIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsCompleted", reflect.TypeOf((*MockIListItemService)(nil).MarkAsCompleted), tasksToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsCompleted", reflect.TypeOf((*MockIListItemService)(nil).MarkAsCompleted), ctx, listItemIDs)
 }
 
 // MarkAsPending mocks base method.
-func (m *MockIListItemService) MarkAsPending(tasksToDelete []models.ListItem) (*int, error) {
+func (m *MockIListItemService) MarkAsPending(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsPending", tasksToDelete)
-	ret0, _ := ret[0].(*int)
+	ret := m.ctrl.Call(m, "MarkAsPending", ctx, listItemIDs)
+	ret0, _ := ret[0].(*bulk.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkAsPending indicates an expected call of MarkAsPending.
-func (mr *MockIListItemServiceMockRecorder) MarkAsPending(tasksToDelete interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) MarkAsPending(ctx, listItemIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsPending", reflect.TypeOf((*MockIListItemService)(nil).MarkAsPending), tasksToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsPending", reflect.TypeOf((*MockIListItemService)(nil).MarkAsPending), ctx, listItemIDs)
+}
+
+// MergeDuplicates mocks base method.
+func (m *MockIListItemService) MergeDuplicates(ctx context.Context, listId string) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeDuplicates", ctx, listId)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeDuplicates indicates an expected call of MergeDuplicates.
+func (mr *MockIListItemServiceMockRecorder) MergeDuplicates(ctx, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeDuplicates", reflect.TypeOf((*MockIListItemService)(nil).MergeDuplicates), ctx, listId)
+}
+
+// MoveItems mocks base method.
+func (m *MockIListItemService) MoveItems(ctx context.Context, listItemIDs []uint, listID uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItems", ctx, listItemIDs, listID)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItems indicates an expected call of MoveItems.
+func (mr *MockIListItemServiceMockRecorder) MoveItems(ctx, listItemIDs, listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItems", reflect.TypeOf((*MockIListItemService)(nil).MoveItems), ctx, listItemIDs, listID)
+}
+
+// Reorder mocks base method.
+func (m *MockIListItemService) Reorder(ctx context.Context, listId string, moves []models.ItemMove) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reorder", ctx, listId, moves)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reorder indicates an expected call of Reorder.
+func (mr *MockIListItemServiceMockRecorder) Reorder(ctx, listId, moves interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reorder", reflect.TypeOf((*MockIListItemService)(nil).Reorder), ctx, listId, moves)
+}
+
+// Unassign mocks base method.
+func (m *MockIListItemService) Unassign(ctx context.Context, listItemID, userID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unassign", ctx, listItemID, userID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unassign indicates an expected call of Unassign.
+func (mr *MockIListItemServiceMockRecorder) Unassign(ctx, listItemID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unassign", reflect.TypeOf((*MockIListItemService)(nil).Unassign), ctx, listItemID, userID)
 }
 
 // Update mocks base method.
-func (m *MockIListItemService) Update(item models.ListItem) (*models.ListItem, error) {
+func (m *MockIListItemService) Update(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", item)
+	ret := m.ctrl.Call(m, "Update", ctx, item)
 	ret0, _ := ret[0].(*models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockIListItemServiceMockRecorder) Update(item interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) Update(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListItemService)(nil).Update), ctx, item)
+}
+
+// MockIProductService is a mock of IProductService interface.
+type MockIProductService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIProductServiceMockRecorder
+}
+
+// MockIProductServiceMockRecorder is the mock recorder for MockIProductService.
+type MockIProductServiceMockRecorder struct {
+	mock *MockIProductService
+}
+
+// NewMockIProductService creates a new mock instance.
+func NewMockIProductService(ctrl *gomock.Controller) *MockIProductService {
+	mock := &MockIProductService{ctrl: ctrl}
+	mock.recorder = &MockIProductServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIProductService) EXPECT() *MockIProductServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIProductService) Get(productID string) (*models1.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", productID)
+	ret0, _ := ret[0].(*models1.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIProductServiceMockRecorder) Get(productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIProductService)(nil).Get), productID)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetMembers mocks base method.
+func (m *MockIUserListService) GetMembers(listID string) (*[]models2.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", listID)
+	ret0, _ := ret[0].(*[]models2.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockIUserListServiceMockRecorder) GetMembers(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockIUserListService)(nil).GetMembers), listID)
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models2.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models2.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
+
+// MockIListService is a mock of IListService interface.
+type MockIListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListServiceMockRecorder
+}
+
+// MockIListServiceMockRecorder is the mock recorder for MockIListService.
+type MockIListServiceMockRecorder struct {
+	mock *MockIListService
+}
+
+// NewMockIListService creates a new mock instance.
+func NewMockIListService(ctrl *gomock.Controller) *MockIListService {
+	mock := &MockIListService{ctrl: ctrl}
+	mock.recorder = &MockIListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListService) EXPECT() *MockIListServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIListService) Get(listId string) (*models0.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", listId)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIListServiceMockRecorder) Get(listId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListItemService)(nil).Update), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListService)(nil).Get), listId)
 }
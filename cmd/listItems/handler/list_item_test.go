@@ -2,21 +2,32 @@ package handler
 
 import (
 	"SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	productModels "SuperListsAPI/cmd/products/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/bulk"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewLisItemHandler(t *testing.T) {
 	type args struct {
-		service IListItemService
+		service         IListItemService
+		productService  IProductService
+		userListService IUserListService
+		listService     IListService
 	}
 	tests := []struct {
 		name string
@@ -25,18 +36,18 @@ func TestNewLisItemHandler(t *testing.T) {
 	}{
 		{
 			name: "Test with nil service should pass",
-			args: args{nil},
-			want: NewListItemHandler(nil),
+			args: args{nil, nil, nil, nil},
+			want: NewListItemHandler(nil, nil, nil, nil),
 		},
 		{
 			name: "Test with no nil service should pass",
-			args: args{NewMockIListItemService(gomock.NewController(t))},
-			want: NewListItemHandler(NewMockIListItemService(gomock.NewController(t))),
+			args: args{NewMockIListItemService(gomock.NewController(t)), NewMockIProductService(gomock.NewController(t)), NewMockIUserListService(gomock.NewController(t)), NewMockIListService(gomock.NewController(t))},
+			want: NewListItemHandler(NewMockIListItemService(gomock.NewController(t)), NewMockIProductService(gomock.NewController(t)), NewMockIUserListService(gomock.NewController(t)), NewMockIListService(gomock.NewController(t))),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewListItemHandler(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+			if got := NewListItemHandler(tt.args.service, tt.args.productService, tt.args.userListService, tt.args.listService); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewLisItemHandler() = %v, want %v", got, tt.want)
 			}
 		})
@@ -46,9 +57,9 @@ func TestNewLisItemHandler(t *testing.T) {
 func TestListItemHandler_Create(t *testing.T) {
 	listItem := GetValidListItem()
 	mockedService := NewMockIListItemService(gomock.NewController(t))
-	mockedService.EXPECT().Create(gomock.Any()).Return(&listItem, nil)
+	mockedService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&listItem, nil)
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -73,9 +84,9 @@ func TestListItemHandler_Create(t *testing.T) {
 func TestListItemHandler_Create_Error(t *testing.T) {
 	listItem := GetValidListItem()
 	mockedService := NewMockIListItemService(gomock.NewController(t))
-	mockedService.EXPECT().Create(gomock.Any()).Return(nil, errors.New("Error from itemListService "))
+	mockedService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error from itemListService "))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -102,7 +113,37 @@ func TestListItemHandler_Create_Invalid_Struct(t *testing.T) {
 		"title": 1,
 	}
 	mockedService := NewMockIListItemService(gomock.NewController(t))
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	jsonDto, _ := json.Marshal(listItem)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/", listItemHandler.Create)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+
+}
+
+func TestListItemHandler_Create_Invalid_Recurrence(t *testing.T) {
+	listItem := map[string]interface{}{
+		"list_id":    1,
+		"user_id":    1,
+		"title":      "Water the plants",
+		"recurrence": "FREQ=WEEKLY;BYDAY=XX",
+	}
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -129,7 +170,7 @@ func TestListItemHandler_Create_Missing_Mandatory_Values(t *testing.T) {
 		"title": "titulo",
 	}
 	mockedService := NewMockIListItemService(gomock.NewController(t))
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -156,7 +197,7 @@ func TestListItemHandler_Get(t *testing.T) {
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 	mockedService.EXPECT().Get(gomock.Any()).Return(&listItem, nil)
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -182,7 +223,7 @@ func TestListItemHandler_Get_No_Results(t *testing.T) {
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 	mockedService.EXPECT().Get(gomock.Any()).Return(nil, nil)
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -208,7 +249,7 @@ func TestListItemHandler_Get_Error(t *testing.T) {
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 	mockedService.EXPECT().Get(gomock.Any()).Return(&listItem, errors.New("error from list item service"))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -233,7 +274,7 @@ func TestListItemHandler_Get_Invalid_ID(t *testing.T) {
 	listItem := GetValidListItem()
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	jsonDto, _ := json.Marshal(listItem)
 
@@ -257,9 +298,9 @@ func TestListItemHandler_Get_Invalid_ID(t *testing.T) {
 func TestListItemHandler_Delete(t *testing.T) {
 	idDeleted := 1
 	mockedService := NewMockIListItemService(gomock.NewController(t))
-	mockedService.EXPECT().Delete(gomock.Any()).Return(&idDeleted, nil)
+	mockedService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&idDeleted, nil)
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -281,7 +322,7 @@ func TestListItemHandler_Delete(t *testing.T) {
 func TestListItemHandler_Delete_Invalid_ID(t *testing.T) {
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -303,9 +344,9 @@ func TestListItemHandler_Delete_Invalid_ID(t *testing.T) {
 func TestListItemHandler_Delete_Error(t *testing.T) {
 
 	mockedService := NewMockIListItemService(gomock.NewController(t))
-	mockedService.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from item list service"))
+	mockedService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from item list service"))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -328,10 +369,14 @@ func TestListItemHandler_Update(t *testing.T) {
 	validListItem := GetValidListItem()
 	validListItem.ID = 1
 	jsonDto, _ := json.Marshal(validListItem)
-	mockedService := NewMockIListItemService(gomock.NewController(t))
-	mockedService.EXPECT().Update(gomock.Any()).Return(&validListItem, nil)
+	ctrl := gomock.NewController(t)
+	mockedService := NewMockIListItemService(ctrl)
+	userListService := NewMockIUserListService(ctrl)
+	mockedService.EXPECT().Get("1").Return(&validListItem, nil)
+	userListService.EXPECT().GetUserListsByListID("1").Return(&[]userListModels.UserList{{ListID: 1, UserID: 1}}, nil)
+	mockedService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&validListItem, nil)
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, userListService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -344,6 +389,7 @@ func TestListItemHandler_Update(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPut, "/v1/listItems/1", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "1")
 
 	c.ServeHTTP(w, req)
 
@@ -355,7 +401,7 @@ func TestListItemHandler_Update_ID_Mismatch(t *testing.T) {
 	jsonDto, _ := json.Marshal(validListItem)
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -378,10 +424,14 @@ func TestListItemHandler_Update_Error(t *testing.T) {
 	validListItem := GetValidListItem()
 	validListItem.ID = 1
 	jsonDto, _ := json.Marshal(validListItem)
-	mockedService := NewMockIListItemService(gomock.NewController(t))
-	mockedService.EXPECT().Update(gomock.Any()).Return(nil, errors.New("error from list item service"))
+	ctrl := gomock.NewController(t)
+	mockedService := NewMockIListItemService(ctrl)
+	userListService := NewMockIUserListService(ctrl)
+	mockedService.EXPECT().Get("1").Return(&validListItem, nil)
+	userListService.EXPECT().GetUserListsByListID("1").Return(&[]userListModels.UserList{{ListID: 1, UserID: 1}}, nil)
+	mockedService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item service"))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, userListService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -394,6 +444,7 @@ func TestListItemHandler_Update_Error(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	req := httptest.NewRequest(http.MethodPut, "/v1/listItems/1", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "1")
 
 	c.ServeHTTP(w, req)
 
@@ -405,7 +456,7 @@ func TestListItemHandler_Update_Invalid_ID(t *testing.T) {
 	jsonDto, _ := json.Marshal(validListItem)
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -431,7 +482,7 @@ func TestListItemHandler_Update_Cant_Bind_JSON(t *testing.T) {
 	jsonDto, _ := json.Marshal(validListItem)
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -458,7 +509,7 @@ func TestListItemHandler_Update_Invalid_JSON(t *testing.T) {
 	jsonDto, _ := json.Marshal(validListItem)
 	mockedService := NewMockIListItemService(gomock.NewController(t))
 
-	listItemHandler := NewListItemHandler(mockedService)
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -477,12 +528,1503 @@ func TestListItemHandler_Update_Invalid_JSON(t *testing.T) {
 	assert.Equal(t, w.Code, http.StatusBadRequest)
 }
 
-func GetValidListItem() models.ListItem {
-	return models.ListItem{
-		ListID:      1,
-		UserID:      1,
-		Title:       "titulo",
-		Description: "description",
-		IsDone:      false,
+func TestListItemHandler_Reorder(t *testing.T) {
+	reorderRequest := models.ReorderRequest{Moves: []models.ItemMove{{ItemID: 2, AfterID: 0}}}
+	jsonDto, _ := json.Marshal(reorderRequest)
+	items := []models.ListItem{GetValidListItem()}
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Reorder(gomock.Any(), "1", reorderRequest.Moves).Return(&items, nil)
+
+	members := []userListModels.UserList{{ListID: 1, UserID: 7}}
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/reorder", listItemHandler.Reorder)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/1/items/reorder", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusOK)
+}
+
+func TestListItemHandler_Reorder_Item_Not_In_List(t *testing.T) {
+	reorderRequest := models.ReorderRequest{Moves: []models.ItemMove{{ItemID: 2, AfterID: 0}}}
+	jsonDto, _ := json.Marshal(reorderRequest)
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Reorder(gomock.Any(), "1", gomock.Any()).Return(nil, models.ErrItemNotInList)
+
+	members := []userListModels.UserList{{ListID: 1, UserID: 7}}
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/reorder", listItemHandler.Reorder)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/1/items/reorder", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+}
+
+func TestListItemHandler_Reorder_Error(t *testing.T) {
+	reorderRequest := models.ReorderRequest{Moves: []models.ItemMove{{ItemID: 2, AfterID: 0}}}
+	jsonDto, _ := json.Marshal(reorderRequest)
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Reorder(gomock.Any(), "1", gomock.Any()).Return(nil, errors.New("error from list item service"))
+
+	members := []userListModels.UserList{{ListID: 1, UserID: 7}}
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/reorder", listItemHandler.Reorder)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/1/items/reorder", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+}
+
+func TestListItemHandler_Reorder_Invalid_JSON(t *testing.T) {
+	reorderRequest := map[string]interface{}{
+		"moves": []interface{}{},
+	}
+	jsonDto, _ := json.Marshal(reorderRequest)
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/reorder", listItemHandler.Reorder)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/1/items/reorder", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+}
+
+func TestListItemHandler_Reorder_Invalid_List_ID(t *testing.T) {
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/reorder", listItemHandler.Reorder)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/a/items/reorder", strings.NewReader("{}"))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+}
+
+func TestListItemHandler_Reorder_And_Merge_Not_A_Member(t *testing.T) {
+	jsonDto, _ := json.Marshal(models.ReorderRequest{Moves: []models.ItemMove{{ItemID: 2, AfterID: 0}}})
+
+	tests := []struct {
+		name   string
+		path   string
+		body   string
+		userID string
+		status int
+	}{
+		{name: "Reorder", path: "/v1/lists/1/items/reorder", body: string(jsonDto), userID: "9", status: http.StatusForbidden},
+		{name: "Merge", path: "/v1/lists/1/items/merge", userID: "9", status: http.StatusForbidden},
+		{name: "Reorder without user id", path: "/v1/lists/1/items/reorder", body: string(jsonDto), status: http.StatusBadRequest},
+		{name: "Merge without user id", path: "/v1/lists/1/items/merge", status: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := []userListModels.UserList{{ListID: 1, UserID: 7}}
+			mockedService := NewMockIListItemService(gomock.NewController(t))
+			mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+			if tt.userID != "" {
+				mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&members, nil)
+			}
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/lists")
+			{
+				v1.POST("/:id/items/reorder", listItemHandler.Reorder)
+				v1.POST("/:id/items/merge", listItemHandler.MergeDuplicates)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("user_id", tt.userID)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_MergeDuplicates(t *testing.T) {
+	items := []models.ListItem{GetValidListItem()}
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().MergeDuplicates(gomock.Any(), "1").Return(&items, nil)
+
+	members := []userListModels.UserList{{ListID: 1, UserID: 7}}
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/merge", listItemHandler.MergeDuplicates)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/1/items/merge", nil)
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusOK)
+}
+
+func TestListItemHandler_MergeDuplicates_Error(t *testing.T) {
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().MergeDuplicates(gomock.Any(), "1").Return(nil, errors.New("error from list item service"))
+
+	members := []userListModels.UserList{{ListID: 1, UserID: 7}}
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/merge", listItemHandler.MergeDuplicates)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/1/items/merge", nil)
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+}
+
+func TestListItemHandler_MergeDuplicates_Invalid_List_ID(t *testing.T) {
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/merge", listItemHandler.MergeDuplicates)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/a/items/merge", nil)
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+}
+
+func TestListItemHandler_Create_Unknown_Unit(t *testing.T) {
+	listItem := GetValidListItem()
+	listItem.Unit = "cups"
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	jsonDto, _ := json.Marshal(listItem)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/", listItemHandler.Create)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+}
+
+func TestListItemHandler_Create_With_Product(t *testing.T) {
+	productID := uint(7)
+	listItem := map[string]interface{}{
+		"list_id":    1,
+		"user_id":    1,
+		"product_id": productID,
+	}
+	product := productModels.Product{ID: productID, Name: "Tomate perita", Category: "verduleria"}
+
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+		assert.Equal(t, "Tomate perita", item.Title)
+		assert.Equal(t, "verduleria", item.Category)
+		return &item, nil
+	})
+	mockedProductService := NewMockIProductService(gomock.NewController(t))
+	mockedProductService.EXPECT().Get("7").Return(&product, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, mockedProductService, nil, nil)
+
+	jsonDto, _ := json.Marshal(listItem)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/", listItemHandler.Create)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusCreated)
+}
+
+func TestListItemHandler_Create_With_Product_Not_Found(t *testing.T) {
+	listItem := map[string]interface{}{
+		"list_id":    1,
+		"user_id":    1,
+		"product_id": 7,
+	}
+
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedProductService := NewMockIProductService(gomock.NewController(t))
+	mockedProductService.EXPECT().Get("7").Return(nil, gorm.ErrRecordNotFound)
+
+	listItemHandler := NewListItemHandler(mockedService, mockedProductService, nil, nil)
+
+	jsonDto, _ := json.Marshal(listItem)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/", listItemHandler.Create)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusBadRequest)
+}
+
+func TestListItemHandler_Create_With_Product_Error(t *testing.T) {
+	listItem := map[string]interface{}{
+		"list_id":    1,
+		"user_id":    1,
+		"product_id": 7,
+	}
+
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedProductService := NewMockIProductService(gomock.NewController(t))
+	mockedProductService.EXPECT().Get("7").Return(nil, errors.New("error from product service"))
+
+	listItemHandler := NewListItemHandler(mockedService, mockedProductService, nil, nil)
+
+	jsonDto, _ := json.Marshal(listItem)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/", listItemHandler.Create)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, w.Code, http.StatusInternalServerError)
+}
+
+func GetValidListItem() models.ListItem {
+	return models.ListItem{
+		ListID:      1,
+		UserID:      1,
+		Title:       "titulo",
+		Description: "description",
+		IsDone:      false,
+	}
+}
+
+func TestListItemHandler_QuickAdd(t *testing.T) {
+	quickAddRequest := models.QuickAddRequest{Text: "2 kg tomates mañana #verdura", TimeZone: "America/Argentina/Buenos_Aires"}
+	jsonDto, _ := json.Marshal(quickAddRequest)
+
+	members := []userListModels.UserList{{ListID: 3, UserID: 7}}
+
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+		return &item, nil
+	})
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetMembers("3").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/quick", listItemHandler.QuickAdd)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/3/items/quick", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	var response struct {
+		Item   models.ListItem `json:"item"`
+		Parsed struct {
+			DueAt *string `json:"due_at"`
+		} `json:"parsed"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 3, response.Item.ListID)
+	assert.Equal(t, 7, response.Item.UserID)
+	assert.Equal(t, "tomates", response.Item.Title)
+	assert.Equal(t, models.UnitKilogram, response.Item.Unit)
+	assert.Equal(t, "2", response.Item.Quantity.String())
+	assert.Equal(t, []string{"verdura"}, []string(response.Item.Tags))
+	assert.NotNil(t, response.Parsed.DueAt)
+	assert.NotNil(t, response.Item.DueAt)
+	assert.True(t, response.Item.AllDay)
+	assert.Equal(t, "America/Argentina/Buenos_Aires", response.Item.TimeZone)
+	assert.Empty(t, response.Item.Assignments)
+}
+
+func TestListItemHandler_QuickAdd_Assignee(t *testing.T) {
+	members := []userListModels.UserList{
+		{ListID: 3, UserID: 7, User: &userListModels.Member{ID: 7, Name: "Ana Perez", Email: "ana@mail.com"}},
+		{ListID: 3, UserID: 9, User: &userListModels.Member{ID: 9, Name: "Juan Gomez", Email: "juan@mail.com"}},
+	}
+
+	tests := []struct {
+		name       string
+		text       string
+		userID     string
+		setup      func(service *MockIListItemService)
+		wantStatus int
+	}{
+		{
+			name:   "Member is assigned",
+			text:   "pan @juan",
+			userID: "7",
+			setup: func(service *MockIListItemService) {
+				service.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+					assert.Equal(t, []models.ListItemAssignee{{UserID: 9, AssignedBy: 7}}, item.Assignments)
+					return &item, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Assignee is not a member",
+			text:       "pan @pedro",
+			userID:     "7",
+			setup:      func(service *MockIListItemService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Caller is not a member",
+			text:       "pan @juan",
+			userID:     "4",
+			setup:      func(service *MockIListItemService) {},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonDto, _ := json.Marshal(models.QuickAddRequest{Text: tt.text})
+
+			mockedService := NewMockIListItemService(gomock.NewController(t))
+			tt.setup(mockedService)
+			mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+			mockedUserListService.EXPECT().GetMembers("3").Return(&members, nil)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/lists")
+			{
+				v1.POST("/:id/items/quick", listItemHandler.QuickAdd)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/lists/3/items/quick", strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", tt.userID)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_QuickAdd_Bad_Requests(t *testing.T) {
+	tests := []struct {
+		name   string
+		listID string
+		userID string
+		body   interface{}
+	}{
+		{
+			name:   "Invalid list id",
+			listID: "abc",
+			userID: "7",
+			body:   models.QuickAddRequest{Text: "pan"},
+		},
+		{
+			name:   "Missing user id",
+			listID: "3",
+			body:   models.QuickAddRequest{Text: "pan"},
+		},
+		{
+			name:   "Missing text",
+			listID: "3",
+			userID: "7",
+			body:   models.QuickAddRequest{},
+		},
+		{
+			name:   "Unknown time zone",
+			listID: "3",
+			userID: "7",
+			body:   models.QuickAddRequest{Text: "pan", TimeZone: "Mars/Olympus"},
+		},
+		{
+			name:   "Nothing left for the title",
+			listID: "3",
+			userID: "7",
+			body:   models.QuickAddRequest{Text: "tomorrow #misc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonDto, _ := json.Marshal(tt.body)
+
+			mockedService := NewMockIListItemService(gomock.NewController(t))
+
+			listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/lists")
+			{
+				v1.POST("/:id/items/quick", listItemHandler.QuickAdd)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/lists/"+tt.listID+"/items/quick", strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", tt.userID)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_QuickAdd_Error(t *testing.T) {
+	jsonDto, _ := json.Marshal(models.QuickAddRequest{Text: "pan"})
+
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item service"))
+	members := []userListModels.UserList{{ListID: 3, UserID: 7}}
+	mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+	mockedUserListService.EXPECT().GetMembers("3").Return(&members, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.POST("/:id/items/quick", listItemHandler.QuickAdd)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/lists/3/items/quick", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestListItemHandler_Assign(t *testing.T) {
+	listItem := GetValidListItem()
+	listItem.ID = 4
+	listItem.ListID = 2
+	members := []userListModels.UserList{{ListID: 2, UserID: 1}, {ListID: 2, UserID: 7}}
+
+	tests := []struct {
+		name       string
+		itemID     string
+		body       interface{}
+		setup      func(service *MockIListItemService, userListService *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name:   "Member is assigned",
+			itemID: "4",
+			body:   models.AssignRequest{UserID: 7},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().Assign(gomock.Any(), uint(4), uint(7), uint(1)).Return(&models.ListItemAssignee{ListItemID: 4, UserID: 7, AssignedBy: 1}, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:   "Assignee is not a member",
+			itemID: "4",
+			body:   models.AssignRequest{UserID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Caller is not a member",
+			itemID: "4",
+			body:   models.AssignRequest{UserID: 7},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				others := []userListModels.UserList{{ListID: 2, UserID: 7}}
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&others, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Missing item",
+			itemID: "4",
+			body:   models.AssignRequest{UserID: 7},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Missing assignee",
+			itemID:     "4",
+			body:       map[string]interface{}{},
+			setup:      func(service *MockIListItemService, userListService *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service error",
+			itemID: "4",
+			body:   models.AssignRequest{UserID: 7},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().Assign(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIListItemService(gomock.NewController(t))
+			mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+			tt.setup(mockedService, mockedUserListService)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+			jsonDto, _ := json.Marshal(tt.body)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/listItems")
+			{
+				v1.POST("/:id/assignees", listItemHandler.Assign)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/listItems/"+tt.itemID+"/assignees", strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_Unassign(t *testing.T) {
+	listItem := GetValidListItem()
+	listItem.ID = 4
+	listItem.ListID = 2
+	members := []userListModels.UserList{{ListID: 2, UserID: 1}, {ListID: 2, UserID: 7}}
+	deleted := 1
+	notDeleted := 0
+
+	tests := []struct {
+		name       string
+		setup      func(service *MockIListItemService, userListService *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name: "Assignment is removed",
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().Unassign(gomock.Any(), "4", "7").Return(&deleted, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "User was not assigned",
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().Unassign(gomock.Any(), "4", "7").Return(&notDeleted, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Members lookup error",
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().Get("4").Return(&listItem, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(nil, errors.New("error from user list service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIListItemService(gomock.NewController(t))
+			mockedUserListService := NewMockIUserListService(gomock.NewController(t))
+			tt.setup(mockedService, mockedUserListService)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/listItems")
+			{
+				v1.DELETE("/:id/assignees/:userId", listItemHandler.Unassign)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/listItems/4/assignees/7", nil)
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_GetAssigned(t *testing.T) {
+	items := []models.ListItem{GetValidListItem()}
+
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().GetItemsByFilter(models.ItemFilter{AssigneeID: "7", MemberID: "7", Pending: true}).Return(&items, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/me")
+	{
+		v1.GET("/assigned", listItemHandler.GetAssigned)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/assigned", nil)
+	req.Header.Set("user_id", "7")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListItemHandler_GetAssigned_Invalid_User(t *testing.T) {
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/me")
+	{
+		v1.GET("/assigned", listItemHandler.GetAssigned)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/me/assigned", nil)
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListItemHandler_Agenda(t *testing.T) {
+	items := []models.ListItem{GetValidListItem()}
+
+	tests := []struct {
+		name       string
+		url        string
+		setup      func(service *MockIListItemService)
+		wantStatus int
+	}{
+		{
+			name: "Today on the requested time zone",
+			url:  "/v1/me/today?tz=America/Argentina/Buenos_Aires",
+			setup: func(service *MockIListItemService) {
+				service.EXPECT().GetDueToday("7", gomock.Any()).DoAndReturn(func(userID string, now time.Time) (*[]models.ListItem, error) {
+					if now.Location().String() != "America/Argentina/Buenos_Aires" {
+						return nil, errors.New("unexpected time zone")
+					}
+					return &items, nil
+				})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Upcoming with default window",
+			url:  "/v1/me/upcoming",
+			setup: func(service *MockIListItemService) {
+				service.EXPECT().GetUpcoming("7", gomock.Any(), 7).Return(&items, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Upcoming with custom window",
+			url:  "/v1/me/upcoming?days=30",
+			setup: func(service *MockIListItemService) {
+				service.EXPECT().GetUpcoming("7", gomock.Any(), 30).Return(&items, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Upcoming with invalid window",
+			url:        "/v1/me/upcoming?days=365",
+			setup:      func(service *MockIListItemService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Overdue",
+			url:  "/v1/me/overdue",
+			setup: func(service *MockIListItemService) {
+				service.EXPECT().GetOverdue("7", gomock.Any()).Return(&items, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Overdue service error",
+			url:  "/v1/me/overdue",
+			setup: func(service *MockIListItemService) {
+				service.EXPECT().GetOverdue("7", gomock.Any()).Return(nil, errors.New("error from list item service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "Invalid time zone",
+			url:        "/v1/me/today?tz=Mars/Olympus",
+			setup:      func(service *MockIListItemService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIListItemService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/me")
+			{
+				v1.GET("/today", listItemHandler.Today)
+				v1.GET("/upcoming", listItemHandler.Upcoming)
+				v1.GET("/overdue", listItemHandler.Overdue)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			req.Header.Set("user_id", "7")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_Create_Invalid_Parent(t *testing.T) {
+	validListItem := GetValidListItem()
+	jsonDto, _ := json.Marshal(validListItem)
+	mockedService := NewMockIListItemService(gomock.NewController(t))
+	mockedService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, models.ErrInvalidParent)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/", listItemHandler.Create)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListItemHandler_Update_Complete_Children(t *testing.T) {
+	validListItem := GetValidListItem()
+	validListItem.ID = 1
+	validListItem.IsDone = true
+	jsonDto, _ := json.Marshal(validListItem)
+	ctrl := gomock.NewController(t)
+	mockedService := NewMockIListItemService(ctrl)
+	userListService := NewMockIUserListService(ctrl)
+	mockedService.EXPECT().Get("1").Return(&validListItem, nil)
+	userListService.EXPECT().GetUserListsByListID("1").Return(&[]userListModels.UserList{{ListID: 1, UserID: 1}}, nil)
+	mockedService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&validListItem, nil)
+	mockedService.EXPECT().CompleteChildren(gomock.Any(), []uint{1}).Return(new(int), nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, userListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.PUT("/:id", listItemHandler.Update)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/v1/listItems/1?complete_children=true", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListItemHandler_MarkAsCompleted_Complete_Children(t *testing.T) {
+	jsonDto, _ := json.Marshal([]models.ListItem{{Model: gorm.Model{ID: 1}}, {Model: gorm.Model{ID: 2}}})
+	ctrl := gomock.NewController(t)
+	mockedService := NewMockIListItemService(ctrl)
+	mockedUserListService := NewMockIUserListService(ctrl)
+	mockedService.EXPECT().GetItemsByIDs([]uint{1, 2}).Return(&[]models.ListItem{{Model: gorm.Model{ID: 1}, ListID: 3}, {Model: gorm.Model{ID: 2}, ListID: 3}}, nil)
+	mockedUserListService.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{UserID: 1}}, nil)
+	mockedService.EXPECT().MarkAsCompleted(gomock.Any(), []uint{1, 2}).Return(bulk.NewResult(), nil)
+	mockedService.EXPECT().CompleteChildren(gomock.Any(), []uint{1, 2}).Return(nil, errors.New("error from list item service"))
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/complete", listItemHandler.MarkAsCompleted)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/complete?complete_children=true", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestListItemHandler_Update_Locked_Down(t *testing.T) {
+	storedItem := GetValidListItem()
+	storedItem.ID = 1
+
+	tests := []struct {
+		name       string
+		listID     int
+		members    []userListModels.UserList
+		wantStatus int
+	}{
+		{
+			name:       "Caller is not a list member",
+			listID:     1,
+			members:    []userListModels.UserList{{ListID: 1, UserID: 2}},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "List change",
+			listID:     5,
+			members:    []userListModels.UserList{{ListID: 1, UserID: 1}},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockedService := NewMockIListItemService(ctrl)
+			mockedUserListService := NewMockIUserListService(ctrl)
+			mockedService.EXPECT().Get("1").Return(&storedItem, nil)
+			mockedUserListService.EXPECT().GetUserListsByListID("1").Return(&tt.members, nil)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+			updateRequest := storedItem
+			updateRequest.ListID = tt.listID
+			jsonDto, _ := json.Marshal(updateRequest)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/listItems")
+			{
+				v1.PUT("/:id", listItemHandler.Update)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/v1/listItems/1", strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_Move(t *testing.T) {
+	listItems := []models.ListItem{{Model: gorm.Model{ID: 4}, ListID: 2}, {Model: gorm.Model{ID: 5}, ListID: 3}}
+	members := []userListModels.UserList{{UserID: 1}}
+	others := []userListModels.UserList{{UserID: 7}, {UserID: 1}}
+	owned := func(listID uint) *listModels.List {
+		return &listModels.List{Model: gorm.Model{ID: listID}, UserCreatorID: 1}
+	}
+	notOwned := &listModels.List{Model: gorm.Model{ID: 3}, UserCreatorID: 7}
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		setup      func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService)
+		wantStatus int
+	}{
+		{
+			name: "Moved",
+			body: models.TransferRequest{IDs: []uint{4, 5}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 5}).Return(&listItems, nil)
+				userListService.EXPECT().GetUserListsByListID("9").Return(&members, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&members, nil)
+				listService.EXPECT().Get("9").Return(owned(9), nil)
+				listService.EXPECT().Get("2").Return(owned(2), nil)
+				listService.EXPECT().Get("3").Return(owned(3), nil)
+				service.EXPECT().MoveItems(gomock.Any(), []uint{4, 5}, uint(9)).Return(&listItems, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Caller is not a member of the destination list",
+			body: models.TransferRequest{IDs: []uint{4, 5}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 5}).Return(&listItems, nil)
+				userListService.EXPECT().GetUserListsByListID("9").Return(&[]userListModels.UserList{{UserID: 7}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "Caller is not a member of a source list",
+			body: models.TransferRequest{IDs: []uint{4, 5}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 5}).Return(&listItems, nil)
+				userListService.EXPECT().GetUserListsByListID("9").Return(&members, nil)
+				listService.EXPECT().Get("9").Return(owned(9), nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				listService.EXPECT().Get("2").Return(owned(2), nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{{UserID: 7}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "Caller is a plain member of a source list",
+			body: models.TransferRequest{IDs: []uint{4, 5}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 5}).Return(&listItems, nil)
+				userListService.EXPECT().GetUserListsByListID("9").Return(&members, nil)
+				listService.EXPECT().Get("9").Return(owned(9), nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				listService.EXPECT().Get("2").Return(owned(2), nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&others, nil)
+				listService.EXPECT().Get("3").Return(notOwned, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "Caller is a plain member of the destination list",
+			body: models.TransferRequest{IDs: []uint{4}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4}).Return(&[]models.ListItem{listItems[0]}, nil)
+				userListService.EXPECT().GetUserListsByListID("9").Return(&others, nil)
+				listService.EXPECT().Get("9").Return(&listModels.List{Model: gorm.Model{ID: 9}, UserCreatorID: 7}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "Missing item",
+			body: models.TransferRequest{IDs: []uint{4, 6}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 6}).Return(&[]models.ListItem{listItems[0]}, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Missing destination list",
+			body:       models.TransferRequest{IDs: []uint{4}},
+			setup:      func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "No items",
+			body:       models.TransferRequest{IDs: []uint{}, ListID: 9},
+			setup:      func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			body: models.TransferRequest{IDs: []uint{4}, ListID: 9},
+			setup: func(service *MockIListItemService, userListService *MockIUserListService, listService *MockIListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4}).Return(&[]models.ListItem{listItems[0]}, nil)
+				userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&members, nil).Times(2)
+				listService.EXPECT().Get("9").Return(owned(9), nil)
+				listService.EXPECT().Get("2").Return(owned(2), nil)
+				service.EXPECT().MoveItems(gomock.Any(), []uint{4}, uint(9)).Return(nil, errors.New("error from list item service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockedService := NewMockIListItemService(ctrl)
+			mockedUserListService := NewMockIUserListService(ctrl)
+			mockedListService := NewMockIListService(ctrl)
+			tt.setup(mockedService, mockedUserListService, mockedListService)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, mockedListService)
+
+			jsonDto, _ := json.Marshal(tt.body)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/listItems")
+			{
+				v1.POST("/move", listItemHandler.Move)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/listItems/move", strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListItemHandler_Copy(t *testing.T) {
+	listItems := []models.ListItem{{Model: gorm.Model{ID: 4}, ListID: 2, Title: "buy paint"}}
+	members := []userListModels.UserList{{UserID: 1}}
+
+	ctrl := gomock.NewController(t)
+	mockedService := NewMockIListItemService(ctrl)
+	mockedUserListService := NewMockIUserListService(ctrl)
+	mockedService.EXPECT().GetItemsByIDs([]uint{4}).Return(&listItems, nil)
+	mockedUserListService.EXPECT().GetUserListsByListID("9").Return(&members, nil)
+	mockedUserListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+	mockedService.EXPECT().CopyItems(gomock.Any(), []uint{4}, uint(9), uint(1)).Return(&[]models.ListItem{{Model: gorm.Model{ID: 10}, ListID: 9, Title: "buy paint"}}, nil)
+
+	listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+	jsonDto, _ := json.Marshal(models.TransferRequest{IDs: []uint{4}, ListID: 9})
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/listItems")
+	{
+		v1.POST("/copy", listItemHandler.Copy)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/listItems/copy", strings.NewReader(string(jsonDto)))
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"list_id":9`)
+}
+
+func TestListItemHandler_BulkCreate(t *testing.T) {
+	members := []userListModels.UserList{{UserID: 1}}
+	others := []userListModels.UserList{{UserID: 7}}
+	parentID := uint(4)
+
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService)
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name: "Created on several lists",
+			body: `{"items":[{"list_id":2,"title":"buy paint","children":[{"title":"white"}]},{"list_id":3,"product_id":8},{"list_id":2,"title":"brushes"}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&members, nil)
+				productService.EXPECT().Get("8").Return(&productModels.Product{Name: "Milk", Category: "dairy"}, nil)
+				service.EXPECT().CreateItems(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
+						assert.Len(t, items, 3)
+						assert.Equal(t, 1, items[0].UserID)
+						assert.Equal(t, 2, items[0].Children[0].ListID)
+						assert.Equal(t, 1, items[0].Children[0].UserID)
+						assert.Equal(t, "Milk", items[1].Title)
+						return &items, nil
+					})
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `"errors":[]`,
+		},
+		{
+			name: "Nothing created when an item is invalid",
+			body: `{"items":[{"list_id":2,"title":"buy paint"},{"list_id":2,"unit":"bucket","title":"water"},{"list_id":2,"title":"gym","recurrence":"FREQ=HOURLY"}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `{"index":1,"msg":"unit is invalid"}`,
+		},
+		{
+			name: "Partial creates the valid items",
+			body: `{"partial":true,"items":[{"list_id":2,"title":"buy paint"},{"list_id":5,"title":"call the plumber"}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				userListService.EXPECT().GetUserListsByListID("5").Return(&others, nil)
+				service.EXPECT().CreateItems(gomock.Any(), []models.ListItem{{ListID: 2, UserID: 1, Title: "buy paint"}}).
+					Return(&[]models.ListItem{{Model: gorm.Model{ID: 10}, ListID: 2, UserID: 1, Title: "buy paint"}}, nil)
+			},
+			wantStatus: http.StatusCreated,
+			wantBody:   `{"index":1,"msg":"you are not a member of this list"}`,
+		},
+		{
+			name: "Partial without valid items",
+			body: `{"partial":true,"items":[{"list_id":5,"title":"call the plumber"}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				userListService.EXPECT().GetUserListsByListID("5").Return(&others, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "Parents are checked",
+			body: `{"items":[{"list_id":2,"title":"white","parent_id":4},{"list_id":3,"title":"black","parent_id":4},{"list_id":2,"title":"red","parent_id":6}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 4, 6}).Return(&[]models.ListItem{{Model: gorm.Model{ID: parentID}, ListID: 2}}, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&members, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `"errors":[{"index":1,"msg":"parent must be a top level item of the same list"},{"index":2,"msg":"parent must be a top level item of the same list"}]`,
+		},
+		{
+			name: "Subtasks can't have subtasks",
+			body: `{"items":[{"list_id":2,"title":"buy paint","children":[{"title":"white","children":[{"title":"matte"}]}]}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   `subtask 0: parent must be a top level item of the same list`,
+		},
+		{
+			name:       "No items",
+			body:       `{"items":[]}`,
+			setup:      func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			body: `{"items":[{"list_id":2,"title":"buy paint"}]}`,
+			setup: func(service *MockIListItemService, productService *MockIProductService, userListService *MockIUserListService) {
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().CreateItems(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockedService := NewMockIListItemService(ctrl)
+			mockedProductService := NewMockIProductService(ctrl)
+			mockedUserListService := NewMockIUserListService(ctrl)
+			tt.setup(mockedService, mockedProductService, mockedUserListService)
+
+			listItemHandler := NewListItemHandler(mockedService, mockedProductService, mockedUserListService, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/listItems")
+			{
+				v1.POST("/bulk", listItemHandler.BulkCreate)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/listItems/bulk", strings.NewReader(tt.body))
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestListItemHandler_Bulk_Operations(t *testing.T) {
+	listItems := []models.ListItem{{Model: gorm.Model{ID: 4}, ListID: 2}, {Model: gorm.Model{ID: 5}, ListID: 3}, {Model: gorm.Model{ID: 6}, ListID: 2}}
+	members := []userListModels.UserList{{UserID: 1}}
+	others := []userListModels.UserList{{UserID: 7}}
+
+	tooManyIDs := make([]string, bulk.MaxIDs+1)
+	for i := range tooManyIDs {
+		tooManyIDs[i] = strconv.Itoa(i + 1)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		setup      func(service *MockIListItemService, userListService *MockIUserListService)
+		wantStatus int
+		wantResult bulk.Result
+	}{
+		{
+			name: "Delete",
+			path: "/bulkDelete",
+			body: `{"ids":[4,5,6,7]}`,
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 5, 6, 7}).Return(&listItems, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&others, nil)
+				service.EXPECT().BulkDelete(gomock.Any(), []uint{4, 6}).Return(&bulk.Result{Succeeded: []uint{4, 6}}, nil, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResult: bulk.Result{Succeeded: []uint{4, 6}, NotFound: []uint{7}, Forbidden: []uint{5}, Unchanged: []uint{}},
+		},
+		{
+			name: "Complete with the former body",
+			path: "/markAsCompleted",
+			body: `[{"ID":4},{"ID":6}]`,
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4, 6}).Return(&[]models.ListItem{listItems[0], listItems[2]}, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().MarkAsCompleted(gomock.Any(), []uint{4, 6}).Return(&bulk.Result{Succeeded: []uint{4}, Unchanged: []uint{6}}, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResult: bulk.Result{Succeeded: []uint{4}, NotFound: []uint{}, Forbidden: []uint{}, Unchanged: []uint{6}},
+		},
+		{
+			name: "Reopen only forbidden items",
+			path: "/markAsPending",
+			body: `{"ids":[5]}`,
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().GetItemsByIDs([]uint{5}).Return(&[]models.ListItem{listItems[1]}, nil)
+				userListService.EXPECT().GetUserListsByListID("3").Return(&others, nil)
+			},
+			wantStatus: http.StatusOK,
+			wantResult: bulk.Result{Succeeded: []uint{}, NotFound: []uint{}, Forbidden: []uint{5}, Unchanged: []uint{}},
+		},
+		{
+			name:       "Too many ids",
+			path:       "/bulkDelete",
+			body:       `{"ids":[` + strings.Join(tooManyIDs, ",") + `]}`,
+			setup:      func(service *MockIListItemService, userListService *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "No ids",
+			path:       "/markAsPending",
+			body:       `{"ids":[]}`,
+			setup:      func(service *MockIListItemService, userListService *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			path: "/markAsPending",
+			body: `{"ids":[4]}`,
+			setup: func(service *MockIListItemService, userListService *MockIUserListService) {
+				service.EXPECT().GetItemsByIDs([]uint{4}).Return(&[]models.ListItem{listItems[0]}, nil)
+				userListService.EXPECT().GetUserListsByListID("2").Return(&members, nil)
+				service.EXPECT().MarkAsPending(gomock.Any(), []uint{4}).Return(nil, errors.New("error from list item service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockedService := NewMockIListItemService(ctrl)
+			mockedUserListService := NewMockIUserListService(ctrl)
+			tt.setup(mockedService, mockedUserListService)
+
+			listItemHandler := NewListItemHandler(mockedService, nil, mockedUserListService, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/listItems")
+			{
+				v1.POST("/bulkDelete", listItemHandler.BulkDelete)
+				v1.POST("/markAsCompleted", listItemHandler.MarkAsCompleted)
+				v1.POST("/markAsPending", listItemHandler.MarkAsPending)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/listItems"+tt.path, strings.NewReader(tt.body))
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+
+			if tt.wantResult.Succeeded != nil {
+				var result bulk.Result
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+				assert.Equal(t, tt.wantResult, result)
+			}
+		})
 	}
 }
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrAssigneeNotMember = errors.New("user is not a member of this list")
+	ErrNotListMember     = errors.New("you are not a member of this list")
+)
+
+// ListItemAssignee links an item with a member of its list who is responsible for it.
+type ListItemAssignee struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	ListItemID uint      `json:"list_item_id"`
+	UserID     uint      `json:"user_id"`
+	AssignedBy uint      `json:"assigned_by"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// CommentCount is the size of the comment thread of an item.
+type CommentCount struct {
+	ListItemID uint
+	Count      int
+}
+
+type AssignRequest struct {
+	UserID uint `json:"user_id" validate:"required"`
+}
+
+// ItemFilter narrows item queries. Empty fields are not applied.
+type ItemFilter struct {
+	ListID     string
+	AssigneeID string
+	// MemberID keeps only items from lists the user still belongs to.
+	MemberID string
+	Pending  bool
+	// DueFrom and DueBefore keep items due on [DueFrom, DueBefore).
+	DueFrom   *time.Time
+	DueBefore *time.Time
+	// Overdue keeps items whose due moment already passed.
+	Overdue *OverdueCutoff
+}
+
+// OverdueCutoff tells when an item became overdue: timed items once Now passed their due time,
+// all day items once their whole day is over, that is when their due date is before DayStart.
+type OverdueCutoff struct {
+	Now      time.Time
+	DayStart time.Time
+}
@@ -0,0 +1,23 @@
+package models
+
+// MaxBulkItems is the most items that can be created on a single request, subtasks not counted.
+const MaxBulkItems = 200
+
+// BulkCreateRequest creates Items, along with the subtasks on their Children, on one or several lists. Items are
+// created by the caller, so they don't need a user_id. Unless Partial is set nothing is created when any item is
+// invalid, otherwise the valid items are created and the invalid ones reported.
+type BulkCreateRequest struct {
+	Items   []ListItem `json:"items" validate:"required,min=1,max=200"`
+	Partial bool       `json:"partial"`
+}
+
+// ItemError tells why the item at Index of a bulk request was not created.
+type ItemError struct {
+	Index int    `json:"index"`
+	Msg   string `json:"msg"`
+}
+
+type BulkCreateResult struct {
+	Created []ListItem  `json:"created"`
+	Errors  []ItemError `json:"errors"`
+}
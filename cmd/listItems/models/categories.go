@@ -0,0 +1,188 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	CategoryProduce      = "produce"
+	CategoryDairy        = "dairy"
+	CategoryBakery       = "bakery"
+	CategoryMeat         = "meat"
+	CategoryFish         = "fish"
+	CategoryPantry       = "pantry"
+	CategoryFrozen       = "frozen"
+	CategoryBeverages    = "beverages"
+	CategorySnacks       = "snacks"
+	CategoryCleaning     = "cleaning"
+	CategoryPersonalCare = "personal_care"
+	CategoryPets         = "pets"
+)
+
+// categoryKeywords maps words found on item titles, in Spanish and English, to the category they belong to.
+// Keywords are stored without accents and in singular, titles are normalized the same way before matching.
+var categoryKeywords = map[string]string{
+	"tomate": CategoryProduce, "tomato": CategoryProduce, "lechuga": CategoryProduce, "lettuce": CategoryProduce,
+	"papa": CategoryProduce, "patata": CategoryProduce, "potato": CategoryProduce, "cebolla": CategoryProduce,
+	"onion": CategoryProduce, "zanahoria": CategoryProduce, "carrot": CategoryProduce, "manzana": CategoryProduce,
+	"apple": CategoryProduce, "banana": CategoryProduce, "platano": CategoryProduce, "naranja": CategoryProduce,
+	"orange": CategoryProduce, "limon": CategoryProduce, "lemon": CategoryProduce, "palta": CategoryProduce,
+	"aguacate": CategoryProduce, "avocado": CategoryProduce, "ajo": CategoryProduce, "garlic": CategoryProduce,
+	"fruta": CategoryProduce, "fruit": CategoryProduce, "verdura": CategoryProduce, "vegetable": CategoryProduce,
+	"veggie": CategoryProduce,
+
+	"leche": CategoryDairy, "milk": CategoryDairy, "queso": CategoryDairy, "cheese": CategoryDairy,
+	"yogur": CategoryDairy, "yogurt": CategoryDairy, "manteca": CategoryDairy, "mantequilla": CategoryDairy,
+	"butter": CategoryDairy, "crema": CategoryDairy, "cream": CategoryDairy, "huevo": CategoryDairy, "egg": CategoryDairy,
+
+	"pan": CategoryBakery, "bread": CategoryBakery, "factura": CategoryBakery, "medialuna": CategoryBakery,
+	"croissant": CategoryBakery, "torta": CategoryBakery, "cake": CategoryBakery, "galleta": CategoryBakery,
+
+	"carne": CategoryMeat, "meat": CategoryMeat, "pollo": CategoryMeat, "chicken": CategoryMeat,
+	"cerdo": CategoryMeat, "pork": CategoryMeat, "jamon": CategoryMeat, "ham": CategoryMeat,
+	"chorizo": CategoryMeat, "sausage": CategoryMeat, "salchicha": CategoryMeat, "beef": CategoryMeat,
+	"milanesa": CategoryMeat, "asado": CategoryMeat,
+
+	"pescado": CategoryFish, "fish": CategoryFish, "atun": CategoryFish, "tuna": CategoryFish,
+	"salmon": CategoryFish, "merluza": CategoryFish, "camaron": CategoryFish, "shrimp": CategoryFish,
+
+	"arroz": CategoryPantry, "rice": CategoryPantry, "fideo": CategoryPantry, "pasta": CategoryPantry,
+	"harina": CategoryPantry, "flour": CategoryPantry, "azucar": CategoryPantry, "sugar": CategoryPantry,
+	"sal": CategoryPantry, "salt": CategoryPantry, "aceite": CategoryPantry, "oil": CategoryPantry,
+	"yerba": CategoryPantry, "cafe": CategoryPantry, "coffee": CategoryPantry, "te": CategoryPantry,
+	"tea": CategoryPantry, "lenteja": CategoryPantry, "lentil": CategoryPantry, "poroto": CategoryPantry,
+	"bean": CategoryPantry, "vinagre": CategoryPantry, "vinegar": CategoryPantry,
+
+	"helado": CategoryFrozen, "ice": CategoryFrozen, "congelado": CategoryFrozen, "frozen": CategoryFrozen,
+	"hamburguesa": CategoryFrozen, "pizza": CategoryFrozen,
+
+	"agua": CategoryBeverages, "water": CategoryBeverages, "gaseosa": CategoryBeverages, "soda": CategoryBeverages,
+	"jugo": CategoryBeverages, "juice": CategoryBeverages, "cerveza": CategoryBeverages, "beer": CategoryBeverages,
+	"vino": CategoryBeverages, "wine": CategoryBeverages,
+
+	"galletita": CategorySnacks, "cookie": CategorySnacks, "chocolate": CategorySnacks, "papita": CategorySnacks,
+	"chip": CategorySnacks, "mani": CategorySnacks, "peanut": CategorySnacks, "alfajor": CategorySnacks,
+
+	"detergente": CategoryCleaning, "detergent": CategoryCleaning, "lavandina": CategoryCleaning,
+	"lejia": CategoryCleaning, "bleach": CategoryCleaning, "esponja": CategoryCleaning, "sponge": CategoryCleaning,
+	"jabon": CategoryCleaning, "soap": CategoryCleaning, "suavizante": CategoryCleaning, "trapo": CategoryCleaning,
+
+	"shampoo": CategoryPersonalCare, "champu": CategoryPersonalCare, "acondicionador": CategoryPersonalCare,
+	"conditioner": CategoryPersonalCare, "desodorante": CategoryPersonalCare, "deodorant": CategoryPersonalCare,
+	"pasta dental": CategoryPersonalCare, "dentifrico": CategoryPersonalCare, "toothpaste": CategoryPersonalCare,
+	"papel higienico": CategoryPersonalCare, "toilet paper": CategoryPersonalCare,
+
+	"alimento para perro": CategoryPets, "alimento para gato": CategoryPets, "dog food": CategoryPets,
+	"cat food": CategoryPets, "piedritas": CategoryPets,
+}
+
+type ItemGroup struct {
+	Category string     `json:"category"`
+	Items    []ListItem `json:"items"`
+}
+
+// GuessCategory looks the words of title up on the keyword dictionary. Multi word keywords
+// ("pasta dental") win over single words ("pasta"). It returns an empty string when nothing matches.
+func GuessCategory(title string) string {
+
+	words := strings.Fields(normalizeWord(title))
+
+	for size := 3; size > 0; size-- {
+		for i := 0; i+size <= len(words); i++ {
+			phrase := make([]string, size)
+			for j, word := range words[i : i+size] {
+				phrase[j] = singular(word)
+			}
+			if category, ok := categoryKeywords[strings.Join(phrase, " ")]; ok {
+				return category
+			}
+		}
+	}
+
+	return ""
+}
+
+// GroupByCategory groups items following categoryOrder. Categories missing on categoryOrder go after the
+// ordered ones alphabetically, and items without category go last. Items keep their relative order.
+func GroupByCategory(items []ListItem, categoryOrder []string) []ItemGroup {
+
+	rank := map[string]int{}
+	for i, category := range categoryOrder {
+		if _, ok := rank[category]; !ok {
+			rank[category] = i
+		}
+	}
+
+	var categories []string
+	itemsByCategory := map[string][]ListItem{}
+
+	for _, item := range items {
+		if _, ok := itemsByCategory[item.Category]; !ok {
+			categories = append(categories, item.Category)
+		}
+		itemsByCategory[item.Category] = append(itemsByCategory[item.Category], item)
+	}
+
+	sort.SliceStable(categories, func(i, j int) bool {
+		first, second := categories[i], categories[j]
+		if (first == "") != (second == "") {
+			return second == ""
+		}
+
+		firstRank, firstRanked := rank[first]
+		secondRank, secondRanked := rank[second]
+
+		switch {
+		case firstRanked && secondRanked:
+			return firstRank < secondRank
+		case firstRanked != secondRanked:
+			return firstRanked
+		default:
+			return first < second
+		}
+	})
+
+	groups := []ItemGroup{}
+	for _, category := range categories {
+		groups = append(groups, ItemGroup{Category: category, Items: itemsByCategory[category]})
+	}
+
+	return groups
+}
+
+func normalizeWord(text string) string {
+	withoutAccents, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		withoutAccents = text
+	}
+
+	return strings.ToLower(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsSpace(r) {
+			return r
+		}
+		return ' '
+	}, withoutAccents))
+}
+
+// singular returns the first singular form of word found on the dictionary, trying the plural endings used in
+// Spanish and English. Words not found are returned untouched.
+func singular(word string) string {
+	candidates := []string{word, strings.TrimSuffix(word, "s"), strings.TrimSuffix(word, "es")}
+	if strings.HasSuffix(word, "ies") {
+		candidates = append(candidates, strings.TrimSuffix(word, "ies")+"y")
+	}
+
+	for _, candidate := range candidates {
+		if _, ok := categoryKeywords[candidate]; ok {
+			return candidate
+		}
+	}
+
+	return word
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGuessCategory(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Tomates", want: CategoryProduce},
+		{title: "2 limones", want: CategoryProduce},
+		{title: "Leche descremada", want: CategoryDairy},
+		{title: "Huevos", want: CategoryDairy},
+		{title: "Pan lactal", want: CategoryBakery},
+		{title: "Pasta dental", want: CategoryPersonalCare},
+		{title: "Pasta", want: CategoryPantry},
+		{title: "Papel higiénico", want: CategoryPersonalCare},
+		{title: "Atún en lata", want: CategoryFish},
+		{title: "Chocolate cookies", want: CategorySnacks},
+		{title: "Strawberries", want: ""},
+		{title: "Dog food", want: CategoryPets},
+		{title: "Llamar al plomero", want: ""},
+		{title: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, GuessCategory(tt.title))
+		})
+	}
+}
+
+func TestGroupByCategory(t *testing.T) {
+	items := []ListItem{
+		{Title: "Lavandina", Category: CategoryCleaning},
+		{Title: "Tomate", Category: CategoryProduce},
+		{Title: "Llamar al plomero"},
+		{Title: "Leche", Category: CategoryDairy},
+		{Title: "Cebolla", Category: CategoryProduce},
+		{Title: "Pan", Category: CategoryBakery},
+	}
+
+	groups := GroupByCategory(items, []string{CategoryDairy, CategoryProduce})
+
+	var categories []string
+	for _, group := range groups {
+		categories = append(categories, group.Category)
+	}
+
+	assert.Equal(t, []string{CategoryDairy, CategoryProduce, CategoryBakery, CategoryCleaning, ""}, categories)
+	assert.Equal(t, "Tomate", groups[1].Items[0].Title)
+	assert.Equal(t, "Cebolla", groups[1].Items[1].Title)
+}
+
+func TestGroupByCategory_Without_Items(t *testing.T) {
+	assert.Empty(t, GroupByCategory(nil, []string{CategoryDairy}))
+}
@@ -1,12 +1,93 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"SuperListsAPI/internal/database"
+	"errors"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"time"
+)
 
+// PositionGap is the distance left between consecutive items when they are placed at the end of a list
+// or when a list has to be rebalanced. Moving an item only rewrites its own position.
+const PositionGap float64 = 1024
+
+var ErrItemNotInList = errors.New("item does not belong to this list")
+
+// ErrMoveAfterItself is returned when an item is asked to be placed right after itself.
+var ErrMoveAfterItself = errors.New("an item can not be placed after itself")
+
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// priorityRanks sorts priorities from the most to the least urgent, items without priority go last.
+var priorityRanks = map[string]int{
+	PriorityUrgent: 0,
+	PriorityHigh:   1,
+	PriorityMedium: 2,
+	PriorityLow:    3,
+	"":             4,
+}
+
+// ListItem is an entry of a list. DueAt is an instant, for AllDay items only its date on TimeZone matters.
+// Completing an item with a Recurrence creates its next occurrence, all occurrences share the SeriesID of the first one.
+// Items with a ParentID are subtasks of another item of the same list.
+// Assignments and CommentCount are only read along with the items of a list, Assignees holds the assigned user ids.
 type ListItem struct {
 	gorm.Model
-	ListID      int    `json:"list_id" validate:"required"`
-	UserID      int    `json:"user_id" validate:"required"`
-	Title       string `json:"title" validate:"required"`
-	Description string `json:"description"`
-	IsDone      bool   `json:"is_done"`
+	ListID       int                 `json:"list_id" validate:"required"`
+	UserID       int                 `json:"user_id" validate:"required"`
+	Title        string              `json:"title" validate:"required_without=ProductID"`
+	Description  string              `json:"description"`
+	IsDone       bool                `json:"is_done"`
+	Position     float64             `json:"position"`
+	Quantity     *decimal.Decimal    `json:"quantity,omitempty" gorm:"type:numeric(12,3)"`
+	Unit         string              `json:"unit,omitempty" validate:"omitempty,oneof=mg g kg ml cl l pcs dozen"`
+	UnitPrice    *decimal.Decimal    `json:"unit_price,omitempty" gorm:"type:numeric(12,2)"`
+	Currency     string              `json:"currency,omitempty" validate:"omitempty,iso4217"`
+	ProductID    *uint               `json:"product_id,omitempty"`
+	Category     string              `json:"category,omitempty"`
+	Tags         database.StringList `json:"tags" gorm:"type:text"`
+	Assignees    []uint              `json:"assignees" gorm:"-"`
+	Assignments  []ListItemAssignee  `json:"-" gorm:"foreignKey:ListItemID"`
+	CommentCount int                 `json:"comment_count" gorm:"->"`
+	DueAt        *time.Time          `json:"due_at,omitempty"`
+	AllDay       bool                `json:"all_day"`
+	TimeZone     string              `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+	Priority     string              `json:"priority,omitempty" validate:"omitempty,oneof=low medium high urgent"`
+	CompletedAt  *time.Time          `json:"completed_at,omitempty"`
+	Recurrence   string              `json:"recurrence,omitempty" validate:"omitempty,max=200"`
+	SeriesID     *uint               `json:"series_id,omitempty"`
+	ParentID     *uint               `json:"parent_id,omitempty"`
+	Children     []ListItem          `json:"children,omitempty" gorm:"-"`
+	Progress     *ItemProgress       `json:"progress,omitempty" gorm:"-"`
+}
+
+// PriorityRank is 0 for the most urgent priority and grows as priority drops.
+func (li ListItem) PriorityRank() int {
+	if rank, ok := priorityRanks[li.Priority]; ok {
+		return rank
+	}
+	return priorityRanks[""]
+}
+
+// ItemMove places the item ItemID right after the item AfterID. An AfterID of 0 moves the item to the top of the list.
+type ItemMove struct {
+	ItemID  uint `json:"item_id" validate:"required"`
+	AfterID uint `json:"after_id"`
+}
+
+type ReorderRequest struct {
+	Moves []ItemMove `json:"moves" validate:"required,min=1,dive"`
+}
+
+// QuickAddRequest is a free text line such as "2 kg tomatoes tomorrow #veggies". TimeZone is the IANA zone used to
+// resolve relative dates, the server zone is used when missing.
+type QuickAddRequest struct {
+	Text     string `json:"text" validate:"required,max=500"`
+	TimeZone string `json:"time_zone"`
 }
@@ -0,0 +1,51 @@
+package models
+
+import "errors"
+
+// ErrInvalidParent is returned when an item is nested under an item of another list, under a subtask or under itself.
+// Subtasks only go one level deep.
+var ErrInvalidParent = errors.New("parent must be a top level item of the same list")
+
+// ItemProgress counts the done subtasks of an item.
+type ItemProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// NestItems moves subtasks under the Children of their parent and sets the parent Progress. Items keep their
+// relative order. Subtasks whose parent is not among items stay at the top level.
+func NestItems(items []ListItem) []ListItem {
+
+	present := make(map[uint]bool, len(items))
+	for _, item := range items {
+		present[item.ID] = true
+	}
+
+	children := map[uint][]ListItem{}
+	for _, item := range items {
+		if item.ParentID != nil && present[*item.ParentID] && *item.ParentID != item.ID {
+			children[*item.ParentID] = append(children[*item.ParentID], item)
+		}
+	}
+
+	nested := make([]ListItem, 0, len(items))
+	for _, item := range items {
+		if item.ParentID != nil && present[*item.ParentID] && *item.ParentID != item.ID {
+			continue
+		}
+
+		if subtasks, ok := children[item.ID]; ok {
+			item.Children = subtasks
+			item.Progress = &ItemProgress{Total: len(subtasks)}
+			for _, subtask := range subtasks {
+				if subtask.IsDone {
+					item.Progress.Done++
+				}
+			}
+		}
+
+		nested = append(nested, item)
+	}
+
+	return nested
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func subtask(id uint, parentID uint, done bool) ListItem {
+	return ListItem{Model: gorm.Model{ID: id}, ParentID: &parentID, IsDone: done}
+}
+
+func TestNestItems(t *testing.T) {
+	party := ListItem{Model: gorm.Model{ID: 1}, Title: "Prepare birthday party"}
+	milk := ListItem{Model: gorm.Model{ID: 2}, Title: "Milk"}
+
+	items := []ListItem{party, subtask(3, 1, true), milk, subtask(4, 1, false), subtask(5, 1, true)}
+
+	nested := NestItems(items)
+
+	assert.Len(t, nested, 2)
+	assert.Equal(t, uint(1), nested[0].ID)
+	assert.Equal(t, uint(2), nested[1].ID)
+
+	assert.Len(t, nested[0].Children, 3)
+	assert.Equal(t, []uint{3, 4, 5}, []uint{nested[0].Children[0].ID, nested[0].Children[1].ID, nested[0].Children[2].ID})
+	assert.Equal(t, &ItemProgress{Done: 2, Total: 3}, nested[0].Progress)
+
+	assert.Nil(t, nested[1].Children)
+	assert.Nil(t, nested[1].Progress)
+}
+
+func TestNestItems_Keeps_Orphans_On_Top_Level(t *testing.T) {
+	items := []ListItem{subtask(3, 1, false), subtask(4, 4, false)}
+
+	nested := NestItems(items)
+
+	assert.Len(t, nested, 2)
+	assert.Nil(t, nested[0].Children)
+	assert.Nil(t, nested[1].Children)
+}
+
+func TestNestItems_Empty(t *testing.T) {
+	assert.Empty(t, NestItems(nil))
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"sort"
+)
+
+// ListTotals holds the money figures of a list for a single currency. Items without unit price are not counted.
+type ListTotals struct {
+	Currency  string          `json:"currency"`
+	Estimated decimal.Decimal `json:"estimated"`
+	Purchased decimal.Decimal `json:"purchased"`
+	Remaining decimal.Decimal `json:"remaining"`
+}
+
+// Cost returns quantity times unit price. Items without quantity count as a single unit.
+func (item ListItem) Cost() (decimal.Decimal, bool) {
+	if item.UnitPrice == nil {
+		return decimal.Zero, false
+	}
+
+	quantity := decimal.New(1, 0)
+	if item.Quantity != nil {
+		quantity = *item.Quantity
+	}
+
+	return quantity.Mul(*item.UnitPrice), true
+}
+
+// ComputeTotals returns the estimated, purchased and remaining amounts of items, one entry per currency.
+func ComputeTotals(items []ListItem) []ListTotals {
+
+	totalsByCurrency := map[string]*ListTotals{}
+
+	for _, item := range items {
+		cost, ok := item.Cost()
+		if !ok {
+			continue
+		}
+
+		totals, ok := totalsByCurrency[item.Currency]
+		if !ok {
+			totals = &ListTotals{Currency: item.Currency}
+			totalsByCurrency[item.Currency] = totals
+		}
+
+		totals.Estimated = totals.Estimated.Add(cost)
+		if item.IsDone {
+			totals.Purchased = totals.Purchased.Add(cost)
+		}
+		totals.Remaining = totals.Estimated.Sub(totals.Purchased)
+	}
+
+	result := []ListTotals{}
+	for _, totals := range totalsByCurrency {
+		result = append(result, *totals)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Currency < result[j].Currency
+	})
+
+	return result
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestComputeTotals(t *testing.T) {
+	quantity := decimal.RequireFromString("3")
+	price := decimal.RequireFromString("0.10")
+	otherPrice := decimal.RequireFromString("2.35")
+	dollarPrice := decimal.RequireFromString("1.99")
+
+	items := []ListItem{
+		{Title: "huevos", Quantity: &quantity, UnitPrice: &price, Currency: "ARS"},
+		{Title: "leche", UnitPrice: &otherPrice, Currency: "ARS", IsDone: true},
+		{Title: "pan", Currency: "ARS"},
+		{Title: "coffee", UnitPrice: &dollarPrice, Currency: "USD"},
+	}
+
+	totals := ComputeTotals(items)
+
+	assert.Len(t, totals, 2)
+
+	assert.Equal(t, "ARS", totals[0].Currency)
+	assert.Equal(t, "2.65", totals[0].Estimated.String())
+	assert.Equal(t, "2.35", totals[0].Purchased.String())
+	assert.Equal(t, "0.3", totals[0].Remaining.String())
+
+	assert.Equal(t, "USD", totals[1].Currency)
+	assert.Equal(t, "1.99", totals[1].Estimated.String())
+	assert.Equal(t, "0", totals[1].Purchased.String())
+	assert.Equal(t, "1.99", totals[1].Remaining.String())
+}
+
+func TestComputeTotals_Without_Prices(t *testing.T) {
+	totals := ComputeTotals([]ListItem{{Title: "pan"}})
+
+	assert.Empty(t, totals)
+}
@@ -0,0 +1,20 @@
+package models
+
+import "errors"
+
+// MaxTransferItems is the most items that can be moved or copied on a single request, subtasks not counted.
+const MaxTransferItems = 100
+
+// ErrListChangeNotAllowed is returned when an update tries to change the list of an item, items change lists by
+// being moved.
+var ErrListChangeNotAllowed = errors.New("the list of an item can only be changed by moving it")
+
+// ErrNotListOwner is returned when items are moved out of or into a list the caller doesn't own.
+var ErrNotListOwner = errors.New("items can only be moved between lists you own")
+
+// TransferRequest moves or copies the items IDs to the end of the list ListID. Subtasks go along with their
+// parent, a subtask sent without its parent becomes a top level item of the destination list.
+type TransferRequest struct {
+	IDs    []uint `json:"ids" validate:"required,min=1,max=100,dive,required"`
+	ListID uint   `json:"list_id" validate:"required"`
+}
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"github.com/shopspring/decimal"
+)
+
+// Units accepted on list items. Every unit belongs to a dimension and knows how many base units
+// (grams, millilitres or pieces) it holds, so quantities of the same dimension can be added together.
+const (
+	UnitMilligram  = "mg"
+	UnitGram       = "g"
+	UnitKilogram   = "kg"
+	UnitMillilitre = "ml"
+	UnitCentilitre = "cl"
+	UnitLitre      = "l"
+	UnitPiece      = "pcs"
+	UnitDozen      = "dozen"
+)
+
+const (
+	DimensionMass   = "mass"
+	DimensionVolume = "volume"
+	DimensionCount  = "count"
+)
+
+var ErrUnknownUnit = errors.New("unknown unit")
+var ErrIncompatibleUnits = errors.New("units can not be converted between each other")
+
+type unitDefinition struct {
+	dimension string
+	factor    decimal.Decimal
+}
+
+var units = map[string]unitDefinition{
+	UnitMilligram:  {DimensionMass, decimal.New(1, -3)},
+	UnitGram:       {DimensionMass, decimal.New(1, 0)},
+	UnitKilogram:   {DimensionMass, decimal.New(1, 3)},
+	UnitMillilitre: {DimensionVolume, decimal.New(1, 0)},
+	UnitCentilitre: {DimensionVolume, decimal.New(1, 1)},
+	UnitLitre:      {DimensionVolume, decimal.New(1, 3)},
+	UnitPiece:      {DimensionCount, decimal.New(1, 0)},
+	UnitDozen:      {DimensionCount, decimal.New(12, 0)},
+}
+
+// preferredUnits lists, for each dimension, the units used to display a normalized quantity, biggest first.
+var preferredUnits = map[string][]string{
+	DimensionMass:   {UnitKilogram, UnitGram, UnitMilligram},
+	DimensionVolume: {UnitLitre, UnitMillilitre},
+	DimensionCount:  {UnitPiece},
+}
+
+func IsKnownUnit(unit string) bool {
+	_, ok := units[unit]
+	return ok
+}
+
+// UnitDimension returns the dimension of the unit. Items without unit are counted in pieces.
+func UnitDimension(unit string) (string, error) {
+	if unit == "" {
+		return DimensionCount, nil
+	}
+
+	definition, ok := units[unit]
+	if !ok {
+		return "", ErrUnknownUnit
+	}
+
+	return definition.dimension, nil
+}
+
+// ConvertQuantity converts quantity expressed in unit "from" to unit "to".
+func ConvertQuantity(quantity decimal.Decimal, from string, to string) (decimal.Decimal, error) {
+	if from == "" {
+		from = UnitPiece
+	}
+	if to == "" {
+		to = UnitPiece
+	}
+
+	fromDefinition, ok := units[from]
+	if !ok {
+		return decimal.Zero, ErrUnknownUnit
+	}
+
+	toDefinition, ok := units[to]
+	if !ok {
+		return decimal.Zero, ErrUnknownUnit
+	}
+
+	if fromDefinition.dimension != toDefinition.dimension {
+		return decimal.Zero, ErrIncompatibleUnits
+	}
+
+	return quantity.Mul(fromDefinition.factor).Div(toDefinition.factor), nil
+}
+
+// NormalizeQuantity expresses quantity in the biggest unit of its dimension that keeps it at or above one,
+// e.g. 1500 g becomes 1.5 kg and 0.25 l becomes 250 ml.
+func NormalizeQuantity(quantity decimal.Decimal, unit string) (decimal.Decimal, string, error) {
+	dimension, err := UnitDimension(unit)
+	if err != nil {
+		return decimal.Zero, "", err
+	}
+
+	candidates := preferredUnits[dimension]
+	for _, candidate := range candidates {
+		converted, err := ConvertQuantity(quantity, unit, candidate)
+		if err != nil {
+			return decimal.Zero, "", err
+		}
+		if converted.Abs().GreaterThanOrEqual(decimal.New(1, 0)) {
+			return converted, candidate, nil
+		}
+	}
+
+	smallest := candidates[len(candidates)-1]
+	converted, err := ConvertQuantity(quantity, unit, smallest)
+
+	return converted, smallest, err
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConvertQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity string
+		from     string
+		to       string
+		want     string
+		wantErr  error
+	}{
+		{name: "Grams to kilograms", quantity: "1500", from: UnitGram, to: UnitKilogram, want: "1.5"},
+		{name: "Litres to millilitres", quantity: "0.25", from: UnitLitre, to: UnitMillilitre, want: "250"},
+		{name: "Dozens to pieces", quantity: "2", from: UnitDozen, to: UnitPiece, want: "24"},
+		{name: "No unit counts as pieces", quantity: "3", from: "", to: UnitPiece, want: "3"},
+		{name: "Mass to volume fails", quantity: "1", from: UnitKilogram, to: UnitLitre, wantErr: ErrIncompatibleUnits},
+		{name: "Unknown unit fails", quantity: "1", from: "cups", to: UnitLitre, wantErr: ErrUnknownUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertQuantity(decimal.RequireFromString(tt.quantity), tt.from, tt.to)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, decimal.RequireFromString(tt.want).Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestNormalizeQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity string
+		unit     string
+		want     string
+		wantUnit string
+	}{
+		{name: "Big mass goes to kilograms", quantity: "1500", unit: UnitGram, want: "1.5", wantUnit: UnitKilogram},
+		{name: "Small mass stays in grams", quantity: "0.5", unit: UnitKilogram, want: "500", wantUnit: UnitGram},
+		{name: "Tiny mass goes to milligrams", quantity: "0.0005", unit: UnitKilogram, want: "500", wantUnit: UnitMilligram},
+		{name: "Centilitres go to litres", quantity: "150", unit: UnitCentilitre, want: "1.5", wantUnit: UnitLitre},
+		{name: "Dozens go to pieces", quantity: "1", unit: UnitDozen, want: "12", wantUnit: UnitPiece},
+		{name: "Zero keeps the smallest unit", quantity: "0", unit: UnitLitre, want: "0", wantUnit: UnitMillilitre},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, gotUnit, err := NormalizeQuantity(decimal.RequireFromString(tt.quantity), tt.unit)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnit, gotUnit)
+			assert.True(t, decimal.RequireFromString(tt.want).Equal(got), "got %s, want %s", got, tt.want)
+		})
+	}
+}
+
+func TestNormalizeQuantity_Unknown_Unit(t *testing.T) {
+	_, _, err := NormalizeQuantity(decimal.New(1, 0), "cups")
+
+	assert.ErrorIs(t, err, ErrUnknownUnit)
+}
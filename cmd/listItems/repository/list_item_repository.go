@@ -2,8 +2,19 @@ package repository
 
 import (
 	"SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	listRepository "SuperListsAPI/cmd/lists/repository"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	userListRepository "SuperListsAPI/cmd/userLists/repository"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"strconv"
+	"time"
 )
 
 type ListItemRepository struct {
@@ -14,10 +25,37 @@ func NewListItemRepository(db *gorm.DB) ListItemRepository {
 	return ListItemRepository{db: db}
 }
 
-func (lir *ListItemRepository) Create(item models.ListItem) (*models.ListItem, error) {
-
-	if result := lir.db.Create(&item); result.Error != nil {
-		return nil, result.Error
+// Create saves item along with its Assignments, so an item is never left without the members it was created for.
+func (lir *ListItemRepository) Create(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Omit(clause.Associations).Create(&item); result.Error != nil {
+			return result.Error
+		}
+
+		if err := activity.Record(tx, itemEntry(item, activity.ActionCreated, activity.Diff(nil, item))); err != nil {
+			return err
+		}
+
+		for i := range item.Assignments {
+			assignment := &item.Assignments[i]
+			assignment.ListItemID = item.ID
+			if result := tx.Create(assignment); result.Error != nil {
+				return result.Error
+			}
+
+			changes := activity.Diff(nil, models.ListItemAssignee{UserID: assignment.UserID, AssignedBy: assignment.AssignedBy})
+			if err := activity.Record(tx, itemEntry(item, activity.ActionAssigned, changes)); err != nil {
+				return err
+			}
+			item.Assignees = append(item.Assignees, assignment.UserID)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &item, nil
@@ -35,21 +73,68 @@ func (lir *ListItemRepository) Get(listItemID string) (*models.ListItem, error)
 
 }
 
-func (lir *ListItemRepository) Update(item models.ListItem) (*models.ListItem, error) {
+// Update saves item, telling as well whether it completed an item that was pending.
+func (lir *ListItemRepository) Update(ctx context.Context, item models.ListItem) (*models.ListItem, bool, error) {
 
-	if result := lir.db.Save(&item); result.Error != nil {
-		return nil, result.Error
+	var completed bool
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.ListItem
+		if result := tx.First(&before, item.ID); result.Error != nil {
+			return result.Error
+		}
+
+		if before.ListID != item.ListID {
+			return models.ErrListChangeNotAllowed
+		}
+
+		if err := versions.Save(tx, activity.TargetListItem, itemStates(before)...); err != nil {
+			return err
+		}
+
+		// Items are only placed by reordering them, an update keeps the position whatever it carries
+		item.Position = before.Position
+
+		// The completion time only changes when the item gets done
+		if before.IsDone && item.IsDone {
+			item.CompletedAt = before.CompletedAt
+		}
+
+		if result := tx.Save(&item); result.Error != nil {
+			return result.Error
+		}
+
+		completed = !before.IsDone && item.IsDone
+
+		action := activity.ActionUpdated
+		if completed {
+			action = activity.ActionCompleted
+		} else if before.IsDone && !item.IsDone {
+			action = activity.ActionReopened
+		}
+
+		return activity.Record(tx, itemEntry(item, action, activity.Diff(before, item)))
+	})
+
+	if err != nil {
+		return nil, false, err
 	}
 
-	return &item, nil
+	return &item, completed, nil
 }
 
-func (lir *ListItemRepository) Delete(listItemID string) (*int, error) {
+func (lir *ListItemRepository) Delete(ctx context.Context, listItemID string) (*int, error) {
 
 	parsedID, _ := strconv.Atoi(listItemID)
 
-	if result := lir.db.Delete(&models.ListItem{}, listItemID); result.Error != nil {
-		return nil, result.Error
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Subtasks go away along with their parent
+		_, _, err := deleteWhere(tx, "id = ? OR parent_id = ?", listItemID, listItemID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &parsedID, nil
@@ -60,7 +145,7 @@ func (lir *ListItemRepository) GetItemsListByListID(listId string) (*[]models.Li
 
 	var listItems []models.ListItem
 
-	if result := lir.db.Where("list_id = ?", listId).Find(&listItems); result.Error != nil {
+	if result := lir.db.Where("list_id = ?", listId).Order("position").Order("id").Find(&listItems); result.Error != nil {
 		return nil, result.Error
 	}
 
@@ -68,74 +153,778 @@ func (lir *ListItemRepository) GetItemsListByListID(listId string) (*[]models.Li
 
 }
 
-func (lir *ListItemRepository) DeleteListItemsByListID(listId string) (*int, error) {
+func (lir *ListItemRepository) DeleteListItemsByListID(ctx context.Context, listId string) (*int, error) {
 	//TODO Probar esto funcionalmente
 
-	var listItem models.ListItem
+	var rowsDeleted int
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		// Subtasks always share their parent list, the parent_id condition also catches any that got out of sync
+		_, rowsDeleted, err = deleteWhere(tx, "list_id = ? OR parent_id IN (?)", &listId, lir.db.Model(&models.ListItem{}).Select("id").Where("list_id = ?", &listId))
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rowsDeleted, nil
+}
+
+// BulkDelete deletes the items on listItemIDs along with their subtasks. The returned token undoes the whole deletion.
+func (lir *ListItemRepository) BulkDelete(ctx context.Context, listItemIDs []uint) (*bulk.Result, *versions.UndoToken, error) {
+
+	result := bulk.NewResult()
+	var undoToken *versions.UndoToken
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deletedIDs, _, err := deleteWhere(tx, "id IN ? OR parent_id IN ?", listItemIDs, listItemIDs)
+		if err != nil {
+			return err
+		}
+
+		deleted := make(map[uint]bool, len(deletedIDs))
+		for _, id := range deletedIDs {
+			deleted[id] = true
+		}
+
+		for _, id := range listItemIDs {
+			if deleted[id] {
+				result.Succeeded = append(result.Succeeded, id)
+			}
+		}
+		result.NotFound = bulk.Missing(listItemIDs, deleted)
+
+		undoToken, err = versions.NewUndoToken(tx, activity.TargetListItem, deletedIDs)
+		return err
+	})
 
-	result := lir.db.Where("list_id = ? ", &listId).Delete(&listItem)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, undoToken, nil
+
+}
+
+// deleteWhere deletes the items matching the condition on tx, keeping the last version and recording the deletion
+// of each of them. It returns the ids of the deleted items.
+func deleteWhere(tx *gorm.DB, query string, args ...interface{}) ([]uint, int, error) {
+
+	var listItems []models.ListItem
+	if result := tx.Where(query, args...).Find(&listItems); result.Error != nil {
+		return nil, 0, result.Error
+	}
 
+	if err := versions.Save(tx, activity.TargetListItem, itemStates(listItems...)...); err != nil {
+		return nil, 0, err
+	}
+
+	result := tx.Where(query, args...).Delete(&models.ListItem{})
 	if result.Error != nil {
-		return nil, result.Error
+		return nil, 0, result.Error
 	}
 
-	rowsDeleted := int(result.RowsAffected)
+	deletedIDs := make([]uint, 0, len(listItems))
+	entries := make([]activity.Entry, 0, len(listItems))
+	for _, listItem := range listItems {
+		deletedIDs = append(deletedIDs, listItem.ID)
+		entries = append(entries, itemEntry(listItem, activity.ActionDeleted, activity.Diff(listItem, nil)))
+	}
 
-	return &rowsDeleted, nil
+	if err := activity.Record(tx, entries...); err != nil {
+		return nil, 0, err
+	}
+
+	return deletedIDs, int(result.RowsAffected), nil
+}
+
+func (lir *ListItemRepository) MarkAsCompleted(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
+
+	// Items that were already done keep the moment they were first completed
+	return lir.setDoneByIDs(ctx, true, listItemIDs)
+}
+
+func (lir *ListItemRepository) MarkAsPending(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
+
+	return lir.setDoneByIDs(ctx, false, listItemIDs)
+}
+
+// setDoneByIDs completes or reopens the items on listItemIDs, telling apart the ones that were already in that state.
+func (lir *ListItemRepository) setDoneByIDs(ctx context.Context, done bool, listItemIDs []uint) (*bulk.Result, error) {
+
+	_, listItems, err := lir.setDone(ctx, done, "id IN ?", listItemIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := bulk.NewResult()
+	found := make(map[uint]bool, len(listItems))
+	for _, listItem := range listItems {
+		found[listItem.ID] = true
+		if listItem.IsDone == done {
+			result.Unchanged = append(result.Unchanged, listItem.ID)
+		} else {
+			result.Succeeded = append(result.Succeeded, listItem.ID)
+		}
+	}
+	result.NotFound = bulk.Missing(listItemIDs, found)
+
+	return result, nil
 }
 
-func (lir *ListItemRepository) BulkDelete(tasksToDelete []models.ListItem) (*int, error) {
+// setDone completes or reopens the items matching the condition, returning them as they were before. Only the items
+// whose state actually changes are recorded, and completed items keep the moment they were first completed.
+func (lir *ListItemRepository) setDone(ctx context.Context, done bool, query string, args ...interface{}) (int, []models.ListItem, error) {
 
-	//db.Delete(&users, []int{1,2,3})
-	//// DELETE FROM users WHERE id IN (1,2,3);
-	idsToDelete := extractIdsFromTasksToDelete(tasksToDelete)
+	var rowsUpdated int
+	var listItems []models.ListItem
+	now := time.Now()
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if result := tx.Where(query, args...).Find(&listItems); result.Error != nil {
+			return result.Error
+		}
+
+		updates := map[string]interface{}{"is_done": false, "completed_at": nil}
+		action := activity.ActionReopened
+		if done {
+			updates = map[string]interface{}{"is_done": true, "completed_at": gorm.Expr("COALESCE(completed_at, ?)", now)}
+			action = activity.ActionCompleted
+		}
+
+		result := tx.Model(&models.ListItem{}).Where(query, args...).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsUpdated = int(result.RowsAffected)
+
+		var changed []models.ListItem
+		var entries []activity.Entry
+		for _, before := range listItems {
+			if before.IsDone == done {
+				continue
+			}
+			changed = append(changed, before)
+
+			after := before
+			after.IsDone = done
+			after.CompletedAt = nil
+			if done {
+				after.CompletedAt = &now
+			}
+
+			entries = append(entries, itemEntry(after, action, activity.Diff(before, after)))
+		}
+
+		if err := versions.Save(tx, activity.TargetListItem, itemStates(changed...)...); err != nil {
+			return err
+		}
+
+		return activity.Record(tx, entries...)
+	})
+
+	return rowsUpdated, listItems, err
+}
+
+func (lir *ListItemRepository) GetLastPosition(listId string) (*float64, error) {
+
+	var lastPosition float64
 
-	result := lir.db.Delete(&models.ListItem{}, idsToDelete)
+	result := lir.db.Model(&models.ListItem{}).
+		Select("COALESCE(MAX(position), 0)").
+		Where("list_id = ?", listId).
+		Scan(&lastPosition)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	rowsDeleted := int(result.RowsAffected)
+	return &lastPosition, nil
+}
 
-	return &rowsDeleted, nil
+func (lir *ListItemRepository) UpdatePositions(ctx context.Context, items []models.ListItem) error {
+
+	return lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var listItems []models.ListItem
+		if result := tx.Find(&listItems, extractIdsFromTasksToDelete(items)); result.Error != nil {
+			return result.Error
+		}
+
+		positions := make(map[uint]float64, len(items))
+		for _, item := range items {
+			if result := tx.Model(&models.ListItem{}).Where("id = ?", item.ID).Update("position", item.Position); result.Error != nil {
+				return result.Error
+			}
+			positions[item.ID] = item.Position
+		}
+
+		entries := make([]activity.Entry, 0, len(listItems))
+		for _, before := range listItems {
+			after := before
+			after.Position = positions[before.ID]
+			entries = append(entries, itemEntry(after, activity.ActionMoved, activity.Diff(before, after)))
+		}
+
+		return activity.Record(tx, entries...)
+	})
+}
+
+// MergeItems saves the item that absorbed its duplicates and deletes the duplicates in a single transaction.
+func (lir *ListItemRepository) MergeItems(ctx context.Context, survivor models.ListItem, mergedIDs []uint) (*models.ListItem, error) {
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.ListItem
+		if result := tx.First(&before, survivor.ID); result.Error != nil {
+			return result.Error
+		}
+
+		var duplicates []models.ListItem
+		if result := tx.Find(&duplicates, mergedIDs); result.Error != nil {
+			return result.Error
+		}
+
+		if err := versions.Save(tx, activity.TargetListItem, itemStates(append([]models.ListItem{before}, duplicates...)...)...); err != nil {
+			return err
+		}
+
+		if result := tx.Save(&survivor); result.Error != nil {
+			return result.Error
+		}
+
+		// Subtasks of the merged items move under the survivor
+		if result := tx.Model(&models.ListItem{}).Where("parent_id IN ?", mergedIDs).Update("parent_id", survivor.ID); result.Error != nil {
+			return result.Error
+		}
+
+		if result := tx.Delete(&models.ListItem{}, mergedIDs); result.Error != nil {
+			return result.Error
+		}
+
+		entries := []activity.Entry{itemEntry(survivor, activity.ActionMerged, activity.Diff(before, survivor))}
+		for _, duplicate := range duplicates {
+			entries = append(entries, itemEntry(duplicate, activity.ActionDeleted, activity.Diff(duplicate, nil)))
+		}
 
+		return activity.Record(tx, entries...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &survivor, nil
 }
 
-func (lir *ListItemRepository) MarkAsCompleted(tasksToDelete []models.ListItem) (*int, error) {
+// GetItemsByIDs returns the items on listItemIDs, grouped by list and in list order.
+func (lir *ListItemRepository) GetItemsByIDs(listItemIDs []uint) (*[]models.ListItem, error) {
 
-	//db.Delete(&users, []int{1,2,3})
-	//// DELETE FROM users WHERE id IN (1,2,3);
-	idsToUpdate := extractIdsFromTasksToUpdate(tasksToDelete)
+	var listItems []models.ListItem
 
-	result := lir.db.Table("list_items").Where("id IN ?", idsToUpdate).Updates(map[string]interface{}{"is_done": true})
-	//result := lir.db.Model(models.ListItem{}).Where("id in ?", idsToDelete).Updates(map[string]interface{}{"is_done": true})
-	if result.Error != nil {
+	if result := lir.db.Where("id IN ?", listItemIDs).Order("list_id").Order("position").Order("id").Find(&listItems); result.Error != nil {
 		return nil, result.Error
 	}
 
-	rowsDeleted := int(result.RowsAffected)
+	return &listItems, nil
+}
+
+// MoveItems moves the items on listItemIDs, along with their subtasks, to the end of the list listID keeping their
+// order. Items keep their id, so their comments, attachments, versions and activity go with them. Assignees who are
+// not members of the destination list are unassigned. Items already on listID are left where they are.
+func (lir *ListItemRepository) MoveItems(ctx context.Context, listItemIDs []uint, listID uint) (*[]models.ListItem, error) {
+
+	moved := []models.ListItem{}
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		listItems, lastPosition, err := transferSource(tx, listItemIDs, listID)
+		if err != nil {
+			return err
+		}
+
+		var befores []models.ListItem
+		for _, listItem := range listItems {
+			if uint(listItem.ListID) != listID {
+				befores = append(befores, listItem)
+			}
+		}
+
+		if len(befores) == 0 {
+			return nil
+		}
+
+		if err := versions.Save(tx, activity.TargetListItem, itemStates(befores...)...); err != nil {
+			return err
+		}
+
+		movedIDs := make([]uint, 0, len(befores))
+		for _, before := range befores {
+			movedIDs = append(movedIDs, before.ID)
+		}
+
+		entries := make([]activity.Entry, 0, 2*len(befores))
+		for i, before := range befores {
+			after := before
+			after.ListID = int(listID)
+			after.Position = lastPosition + models.PositionGap*float64(i+1)
+			if after.ParentID != nil && !containsID(movedIDs, *after.ParentID) {
+				after.ParentID = nil
+			}
+
+			updates := map[string]interface{}{"list_id": after.ListID, "position": after.Position, "parent_id": after.ParentID}
+			if result := tx.Model(&models.ListItem{}).Where("id = ?", after.ID).Updates(updates); result.Error != nil {
+				return result.Error
+			}
+
+			// The move shows up on the feed of both lists
+			changes := activity.Diff(before, after)
+			entries = append(entries, itemEntry(before, activity.ActionMoved, changes), itemEntry(after, activity.ActionMoved, changes))
+			moved = append(moved, after)
+		}
+
+		for _, table := range []string{"comments", "attachments"} {
+			if result := tx.Table(table).Where("list_item_id IN ?", movedIDs).Update("list_id", listID); result.Error != nil {
+				return result.Error
+			}
+		}
+
+		if err := activity.Record(tx, entries...); err != nil {
+			return err
+		}
+
+		members := tx.Table("user_lists").Select("user_id").Where("list_id = ? AND deleted_at IS NULL", listID)
+
+		var assignees []models.ListItemAssignee
+		if result := tx.Where("list_item_id IN ? AND user_id NOT IN (?)", movedIDs, members).Find(&assignees); result.Error != nil {
+			return result.Error
+		}
+
+		for _, assignee := range assignees {
+			if result := tx.Delete(&assignee); result.Error != nil {
+				return result.Error
+			}
+
+			if err := recordAssignee(tx, assignee, activity.ActionUnassigned); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &moved, nil
+}
 
-	return &rowsDeleted, nil
+// CopyItems copies the items on listItemIDs, along with their subtasks, to the end of the list listID keeping their
+// order. Copies are pending, created by userID and have no assignees.
+func (lir *ListItemRepository) CopyItems(ctx context.Context, listItemIDs []uint, listID uint, userID uint) (*[]models.ListItem, error) {
+
+	var copies []models.ListItem
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		listItems, lastPosition, err := transferSource(tx, listItemIDs, listID)
+		if err != nil {
+			return err
+		}
+
+		if len(listItems) == 0 {
+			return nil
+		}
+
+		copiedIDs := make([]uint, 0, len(listItems))
+		for _, listItem := range listItems {
+			copiedIDs = append(copiedIDs, listItem.ID)
+		}
+
+		copies = make([]models.ListItem, len(listItems))
+		for i, listItem := range listItems {
+			copies[i] = models.ListItem{
+				ListID:      int(listID),
+				UserID:      int(userID),
+				Title:       listItem.Title,
+				Description: listItem.Description,
+				Position:    lastPosition + models.PositionGap*float64(i+1),
+				Quantity:    listItem.Quantity,
+				Unit:        listItem.Unit,
+				UnitPrice:   listItem.UnitPrice,
+				Currency:    listItem.Currency,
+				ProductID:   listItem.ProductID,
+				Category:    listItem.Category,
+				Tags:        listItem.Tags,
+				DueAt:       listItem.DueAt,
+				AllDay:      listItem.AllDay,
+				TimeZone:    listItem.TimeZone,
+				Priority:    listItem.Priority,
+				Recurrence:  listItem.Recurrence,
+			}
+		}
+
+		// Parents are created first so their subtasks can point to the copies
+		copyIDs := map[uint]uint{}
+		for _, pass := range []bool{false, true} {
+			for i, listItem := range listItems {
+				isSubtask := listItem.ParentID != nil && containsID(copiedIDs, *listItem.ParentID)
+				if isSubtask != pass {
+					continue
+				}
+
+				if isSubtask {
+					parentID := copyIDs[*listItem.ParentID]
+					copies[i].ParentID = &parentID
+				}
+
+				if result := tx.Create(&copies[i]); result.Error != nil {
+					return result.Error
+				}
+				copyIDs[listItem.ID] = copies[i].ID
+			}
+		}
+
+		entries := make([]activity.Entry, 0, len(copies))
+		for _, copied := range copies {
+			entries = append(entries, itemEntry(copied, activity.ActionCopied, activity.Diff(nil, copied)))
+		}
+
+		return activity.Record(tx, entries...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if copies == nil {
+		copies = []models.ListItem{}
+	}
+
+	return &copies, nil
+}
+
+// createBatchSize bounds the rows of a single insert when many items are created at once.
+const createBatchSize = 100
+
+// CreateItems creates items at the end of their lists on a single transaction, keeping their order. Items may belong
+// to different lists and carry their subtasks on Children, which are created right after their parent.
+func (lir *ListItemRepository) CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
+
+	created := []models.ListItem{}
+	if len(items) == 0 {
+		return &created, nil
+	}
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		created, err = createItems(tx, items)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// CreateItemsOnNewList creates list, makes its creator a member and adds items to it, all on a single transaction:
+// nothing is left behind when any of them fails. Items are created as on CreateItems.
+func (lir *ListItemRepository) CreateItemsOnNewList(ctx context.Context, list listModels.List, items []models.ListItem) (*listModels.List, *[]models.ListItem, error) {
+
+	created := []models.ListItem{}
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		created, err = CreateOnNewList(tx, &list, items)
+		return err
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &list, &created, nil
+}
+
+// CreateOnNewList creates list, makes its creator a member and adds items to it on the transaction tx, see
+// CreateItemsOnNewList.
+func CreateOnNewList(tx *gorm.DB, list *listModels.List, items []models.ListItem) ([]models.ListItem, error) {
+	if err := listRepository.CreateList(tx, list); err != nil {
+		return nil, err
+	}
+
+	if err := userListRepository.CreateMember(tx, &userListModels.UserList{ListID: list.ID, UserID: list.UserCreatorID}); err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		items[i].ListID = int(list.ID)
+		for j := range items[i].Children {
+			items[i].Children[j].ListID = int(list.ID)
+		}
+	}
+
+	return createItems(tx, items)
+}
+
+// createItems creates items at the end of their lists on the transaction tx, see CreateItems.
+func createItems(tx *gorm.DB, items []models.ListItem) ([]models.ListItem, error) {
+	created := []models.ListItem{}
+	if len(items) == 0 {
+		return created, nil
+	}
 
+	positions := map[int]float64{}
+
+	parents := make([]models.ListItem, len(items))
+	var subtasks []models.ListItem
+	var subtaskParents []int
+
+	for i, item := range items {
+		position, ok := positions[item.ListID]
+		if !ok {
+			if result := tx.Model(&models.ListItem{}).Select("COALESCE(MAX(position), 0)").Where("list_id = ?", item.ListID).Scan(&position); result.Error != nil {
+				return nil, result.Error
+			}
+		}
+
+		position += models.PositionGap
+		item.Position = position
+		item.Children = nil
+		parents[i] = item
+
+		for _, child := range items[i].Children {
+			position += models.PositionGap
+			child.ListID = item.ListID
+			child.Position = position
+			subtasks = append(subtasks, child)
+			subtaskParents = append(subtaskParents, i)
+		}
+
+		positions[item.ListID] = position
+	}
+
+	if err := createInBatches(tx, parents); err != nil {
+		return nil, err
+	}
+
+	for i := range subtasks {
+		parentID := parents[subtaskParents[i]].ID
+		subtasks[i].ParentID = &parentID
+	}
+
+	if err := createInBatches(tx, subtasks); err != nil {
+		return nil, err
+	}
+
+	entries := make([]activity.Entry, 0, len(parents)+len(subtasks))
+	for _, item := range append(append([]models.ListItem{}, parents...), subtasks...) {
+		entries = append(entries, itemEntry(item, activity.ActionCreated, activity.Diff(nil, item)))
+	}
+
+	if err := activity.Record(tx, entries...); err != nil {
+		return nil, err
+	}
+
+	created = parents
+	for i, subtask := range subtasks {
+		created[subtaskParents[i]].Children = append(created[subtaskParents[i]].Children, subtask)
+	}
+
+	return created, nil
+}
+
+// createInBatches inserts items createBatchSize rows at a time. gorm's own CreateInBatches would open a savepoint
+// on the running transaction for every call.
+func createInBatches(tx *gorm.DB, items []models.ListItem) error {
+	for start := 0; start < len(items); start += createBatchSize {
+		end := start + createBatchSize
+		if end > len(items) {
+			end = len(items)
+		}
+
+		batch := items[start:end]
+		if result := tx.Create(&batch); result.Error != nil {
+			return result.Error
+		}
+	}
+
+	return nil
+}
+
+// transferSource loads the items on listItemIDs and their subtasks in list order, along with the last position of
+// the list listID.
+func transferSource(tx *gorm.DB, listItemIDs []uint, listID uint) ([]models.ListItem, float64, error) {
+
+	var listItems []models.ListItem
+	if result := tx.Where("id IN ? OR parent_id IN ?", listItemIDs, listItemIDs).Order("list_id").Order("position").Order("id").Find(&listItems); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	var lastPosition float64
+	if result := tx.Model(&models.ListItem{}).Select("COALESCE(MAX(position), 0)").Where("list_id = ?", listID).Scan(&lastPosition); result.Error != nil {
+		return nil, 0, result.Error
+	}
+
+	return listItems, lastPosition, nil
+}
+
+func containsID(ids []uint, id uint) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GetItemsByFilter returns the items matching every field set on filter, grouped by list and in list order.
+func (lir *ListItemRepository) GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error) {
+
+	var listItems []models.ListItem
+
+	query := lir.db.Model(&models.ListItem{})
+
+	if filter.ListID != "" {
+		query = query.Where("list_id = ?", filter.ListID)
+	}
+
+	if filter.AssigneeID != "" {
+		query = query.Where("id IN (?)", lir.db.Model(&models.ListItemAssignee{}).Select("list_item_id").Where("user_id = ?", filter.AssigneeID))
+	}
+
+	if filter.MemberID != "" {
+		query = query.Where("list_id IN (?)", lir.db.Table("user_lists").Select("list_id").Where("user_id = ? AND deleted_at IS NULL", filter.MemberID))
+	}
+
+	if filter.Pending {
+		query = query.Where("is_done = ?", false)
+	}
+
+	if filter.DueFrom != nil {
+		query = query.Where("due_at >= ?", *filter.DueFrom)
+	}
+
+	if filter.DueBefore != nil {
+		query = query.Where("due_at < ?", *filter.DueBefore)
+	}
+
+	if filter.Overdue != nil {
+		query = query.Where("(all_day = ? AND due_at < ?) OR (all_day = ? AND due_at < ?)",
+			false, filter.Overdue.Now, true, filter.Overdue.DayStart)
+	}
+
+	if result := query.Order("list_id").Order("position").Order("id").Find(&listItems); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &listItems, nil
+}
+
+func (lir *ListItemRepository) GetAssignees(listItemIDs []uint) (*[]models.ListItemAssignee, error) {
+
+	var assignees []models.ListItemAssignee
+
+	if result := lir.db.Where("list_item_id IN ?", listItemIDs).Order("id").Find(&assignees); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &assignees, nil
 }
 
-func (lir *ListItemRepository) MarkAsPending(tasksToDelete []models.ListItem) (*int, error) {
+// GetCommentCounts counts the comments of every item on listItemIDs. Items without comments are left out.
+func (lir *ListItemRepository) GetCommentCounts(listItemIDs []uint) (*[]models.CommentCount, error) {
 
-	//db.Delete(&users, []int{1,2,3})
-	//// DELETE FROM users WHERE id IN (1,2,3);
-	idsToUpdate := extractIdsFromTasksToUpdate(tasksToDelete)
+	var counts []models.CommentCount
 
-	result := lir.db.Table("list_items").Where("id IN ?", idsToUpdate).Updates(map[string]interface{}{"is_done": false})
+	result := lir.db.Table("comments").
+		Select("list_item_id, count(*) AS count").
+		Where("list_item_id IN ? AND deleted_at IS NULL", listItemIDs).
+		Group("list_item_id").
+		Scan(&counts)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	rowsDeleted := int(result.RowsAffected)
+	return &counts, nil
+}
+
+// CreateAssignee is idempotent, assigning somebody who is already assigned returns the existing assignment.
+func (lir *ListItemRepository) CreateAssignee(ctx context.Context, assignee models.ListItemAssignee) (*models.ListItemAssignee, error) {
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.ListItemAssignee
+		result := tx.Where(models.ListItemAssignee{ListItemID: assignee.ListItemID, UserID: assignee.UserID}).First(&existing)
+
+		if result.Error == nil {
+			assignee = existing
+			return nil
+		}
+
+		if !errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return result.Error
+		}
+
+		if result := tx.Create(&assignee); result.Error != nil {
+			return result.Error
+		}
+
+		return recordAssignee(tx, assignee, activity.ActionAssigned)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &assignee, nil
+}
+
+func (lir *ListItemRepository) DeleteAssignee(ctx context.Context, listItemID string, userID string) (*int, error) {
+
+	var rowsDeleted int
+
+	err := lir.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var assignees []models.ListItemAssignee
+		if result := tx.Where("list_item_id = ? AND user_id = ?", listItemID, userID).Find(&assignees); result.Error != nil {
+			return result.Error
+		}
+
+		result := tx.Where("list_item_id = ? AND user_id = ?", listItemID, userID).Delete(&models.ListItemAssignee{})
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsDeleted = int(result.RowsAffected)
+
+		for _, assignee := range assignees {
+			if err := recordAssignee(tx, assignee, activity.ActionUnassigned); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
 	return &rowsDeleted, nil
+}
 
+// recordAssignee records somebody being assigned to or unassigned from an item, on the list the item belongs to.
+func recordAssignee(tx *gorm.DB, assignee models.ListItemAssignee, action string) error {
+	var item models.ListItem
+	if result := tx.Select("id", "list_id").First(&item, assignee.ListItemID); result.Error != nil {
+		return result.Error
+	}
+
+	assignment := models.ListItemAssignee{UserID: assignee.UserID, AssignedBy: assignee.AssignedBy}
+	changes := activity.Diff(nil, assignment)
+	if action == activity.ActionUnassigned {
+		changes = activity.Diff(assignment, nil)
+	}
+
+	return activity.Record(tx, itemEntry(item, action, changes))
 }
 
 func extractIdsFromTasksToDelete(tasksToDelete []models.ListItem) *[]uint {
@@ -149,13 +938,68 @@ func extractIdsFromTasksToDelete(tasksToDelete []models.ListItem) *[]uint {
 	return &idsToDelete
 }
 
-func extractIdsFromTasksToUpdate(tasksToDelete []models.ListItem) []int {
+// GetRecurringItems returns the items among listItemIDs that have a recurrence.
+func (lir *ListItemRepository) GetRecurringItems(listItemIDs []uint) (*[]models.ListItem, error) {
 
-	var idsToUpdate []int
+	var listItems []models.ListItem
 
-	for _, task := range tasksToDelete {
-		idsToUpdate = append(idsToUpdate, int(task.ID))
+	if result := lir.db.Where("id IN ? AND recurrence <> ''", listItemIDs).Find(&listItems); result.Error != nil {
+		return nil, result.Error
 	}
 
-	return idsToUpdate
+	return &listItems, nil
+}
+
+// GetOccurrence returns the item of the series due at dueAt.
+func (lir *ListItemRepository) GetOccurrence(seriesID uint, dueAt time.Time) (*models.ListItem, error) {
+
+	var listItem models.ListItem
+
+	if result := lir.db.Where("series_id = ? AND due_at = ?", seriesID, dueAt).First(&listItem); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &listItem, nil
+}
+
+// MarkChildrenAsCompleted completes the pending subtasks of every item on parentIDs.
+func (lir *ListItemRepository) MarkChildrenAsCompleted(ctx context.Context, parentIDs []uint) (*int, error) {
+
+	rowsUpdated, _, err := lir.setDone(ctx, true, "parent_id IN ? AND is_done = false", parentIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rowsUpdated, nil
+}
+
+func (lir *ListItemRepository) CountChildren(listItemID uint) (*int64, error) {
+
+	var children int64
+
+	if result := lir.db.Model(&models.ListItem{}).Where("parent_id = ?", listItemID).Count(&children); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &children, nil
+}
+
+func itemEntry(item models.ListItem, action string, changes activity.Changes) activity.Entry {
+	return activity.Entry{
+		ListID:     uint(item.ListID),
+		Action:     action,
+		TargetType: activity.TargetListItem,
+		TargetID:   item.ID,
+		Changes:    changes,
+	}
+}
+
+// itemStates describes items before they change, to keep them as versions.
+func itemStates(items ...models.ListItem) []versions.State {
+	states := make([]versions.State, 0, len(items))
+	for _, item := range items {
+		states = append(states, versions.State{ListID: uint(item.ListID), TargetID: item.ID, Value: item})
+	}
+	return states
 }
@@ -2,6 +2,10 @@ package repository
 
 import (
 	"SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/bulk"
+	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -11,6 +15,7 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func TestNewListItemRepository(t *testing.T) {
@@ -67,15 +72,62 @@ func TestListItemRepository_Create(t *testing.T) {
 	listItemRepository := NewListItemRepository(gormDb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`) " +
-		"VALUES (?,?,?,?,?,?,?,?)")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`,`position`,`quantity`,`unit`,`unit_price`,`currency`,`product_id`,`category`,`tags`,`due_at`,`all_day`,`time_zone`,`priority`,`completed_at`,`recurrence`,`series_id`,`parent_id`) " +
+		"VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(1, 4, activity.ActionCreated, activity.TargetListItem, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	result, err := listItemRepository.Create(validListItem)
+	result, err := listItemRepository.Create(activity.WithActor(context.Background(), 4), validListItem)
 
 	assert.NotNil(t, result)
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+}
+
+func TestListItemRepository_Create_With_Assignment(t *testing.T) {
+
+	validListItem := GetValidListItem()
+	validListItem.Assignments = []models.ListItemAssignee{{UserID: 7, AssignedBy: 4}}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
 
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepository := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items`")).WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(1, 4, activity.ActionCreated, activity.TargetListItem, 3, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_item_assignees` (`list_item_id`,`user_id`,`assigned_by`,`created_at`) VALUES (?,?,?,?)")).
+		WithArgs(3, 7, 4, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(1, 4, activity.ActionAssigned, activity.TargetListItem, 3, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectCommit()
+
+	result, err := listItemRepository.Create(activity.WithActor(context.Background(), 4), validListItem)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{7}, result.Assignees)
+	assert.Equal(t, uint(3), result.Assignments[0].ListItemID)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListItemRepository_Create_Error(t *testing.T) {
@@ -103,12 +155,12 @@ func TestListItemRepository_Create_Error(t *testing.T) {
 	listItemRepository := NewListItemRepository(gormDb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`) " +
-		"VALUES (?,?,?,?,?,?,?,?)")).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`,`position`,`quantity`,`unit`,`unit_price`,`currency`,`product_id`,`category`,`tags`,`due_at`,`all_day`,`time_zone`,`priority`,`completed_at`,`recurrence`,`series_id`,`parent_id`) " +
+		"VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
 		WillReturnError(errors.New("Error from DB"))
 	mock.ExpectCommit()
 
-	result, err := listItemRepository.Create(validListItem)
+	result, err := listItemRepository.Create(context.Background(), validListItem)
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -139,15 +191,99 @@ func TestListItemRepository_Update(t *testing.T) {
 
 	listItemRepository := NewListItemRepository(gormDb)
 
+	validListItem.ID = 1
+	validListItem.IsDone = true
+
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`) VALUES (?,?,?,?,?,?,?,?)")).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ? AND `list_items`.`deleted_at` IS NULL ORDER BY `list_items`.`id` LIMIT 1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "title", "description", "is_done"}).
+			AddRow(1, 1, 1, validListItem.Title, validListItem.Description, false))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WithArgs(activity.TargetListItem, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}).AddRow(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WithArgs(activity.TargetListItem, 1, 1, 3, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(1, nil, activity.ActionCompleted, activity.TargetListItem, 1, `{"is_done":{"after":true}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	result, err := listItemRepository.Update(validListItem)
+	result, completed, err := listItemRepository.Update(context.Background(), validListItem)
 
 	assert.NotNil(t, result)
 	assert.NoError(t, err)
+	assert.True(t, completed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+}
+
+func TestListItemRepository_Update_Keeps_Position(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepository := NewListItemRepository(gormDb)
+
+	validListItem := GetValidListItem()
+	validListItem.ID = 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "title", "description", "position"}).
+			AddRow(1, 1, 1, validListItem.Title, validListItem.Description, 2048))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(1, nil, activity.ActionUpdated, activity.TargetListItem, 1, `{}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, _, err := listItemRepository.Update(context.Background(), validListItem)
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2048), result.Position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_Update_Keeps_Completion_Time(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
 
+	listItemRepository := NewListItemRepository(gormDb)
+
+	completedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+	now := time.Now()
+
+	validListItem := GetValidListItem()
+	validListItem.ID = 1
+	validListItem.IsDone = true
+	validListItem.CompletedAt = &now
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "title", "description", "is_done", "completed_at"}).
+			AddRow(1, 1, 1, validListItem.Title, validListItem.Description, true, completedAt))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(1, nil, activity.ActionUpdated, activity.TargetListItem, 1, `{}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, completed, err := listItemRepository.Update(context.Background(), validListItem)
+
+	assert.NoError(t, err)
+	assert.False(t, completed)
+	assert.Equal(t, completedAt, *result.CompletedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListItemRepository_Update_Error(t *testing.T) {
@@ -174,12 +310,20 @@ func TestListItemRepository_Update_Error(t *testing.T) {
 
 	listItemRepository := NewListItemRepository(gormDb)
 
+	validListItem.ID = 1
+
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`) VALUES (?,?,?,?,?,?,?,?)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).
 		WillReturnError(errors.New("Error from DB"))
-	mock.ExpectCommit()
+	mock.ExpectRollback()
 
-	result, err := listItemRepository.Update(validListItem)
+	result, _, err := listItemRepository.Update(context.Background(), validListItem)
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -275,11 +419,21 @@ func TestListItemRepository_Delete(t *testing.T) {
 	listItemRepo := NewListItemRepository(gormDb)
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (id = ? OR parent_id = ?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs("1", "1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title"}).AddRow(1, 3, "Milk"))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE")).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(3, nil, activity.ActionDeleted, activity.TargetListItem, 1, `{"list_id":{"before":3},"title":{"before":"Milk"}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	result, err := listItemRepo.Delete("1")
+	result, err := listItemRepo.Delete(context.Background(), "1")
 
 	assert.NotNil(t, result)
 	assert.NoError(t, err)
@@ -308,11 +462,17 @@ func TestListItemRepository_Delete_Error(t *testing.T) {
 	listItemRepo := NewListItemRepository(gormDb)
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 3))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE")).
 		WillReturnError(errors.New("error from db"))
-	mock.ExpectCommit()
+	mock.ExpectRollback()
 
-	result, err := listItemRepo.Delete("1")
+	result, err := listItemRepo.Delete(context.Background(), "1")
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -403,14 +563,23 @@ func TestListItemRepository_DeleteListItemsByListID(t *testing.T) {
 	listItemRepo := NewListItemRepository(gormDb)
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (list_id = ? OR parent_id IN (SELECT `id` FROM `list_items` WHERE list_id = ? AND `list_items`.`deleted_at` IS NULL)) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 1).AddRow(2, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions + ",(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
 	mock.ExpectExec((regexp.QuoteMeta("UPDATE"))).WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities + ",(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
 
 	mock.ExpectCommit()
 
-	result, err := listItemRepo.DeleteListItemsByListID("1")
+	result, err := listItemRepo.DeleteListItemsByListID(context.Background(), "1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListItemRepository_DeleteListItemsByListID_Error(t *testing.T) {
@@ -436,12 +605,257 @@ func TestListItemRepository_DeleteListItemsByListID_Error(t *testing.T) {
 	listItemRepo := NewListItemRepository(gormDb)
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec((regexp.QuoteMeta("UPDATE"))).
 		WillReturnError(errors.New("error from db"))
 
+	mock.ExpectRollback()
+
+	result, err := listItemRepo.DeleteListItemsByListID(context.Background(), "1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemRepository_GetLastPosition(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"max"}).AddRow(2048))
+
+	result, err := listItemRepo.GetLastPosition("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2048), *result)
+}
+
+func TestListItemRepository_GetLastPosition_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0)")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := listItemRepo.GetLastPosition("1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemRepository_UpdatePositions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	items := []models.ListItem{GetValidListItem(), GetValidListItem()}
+	items[0].ID, items[1].ID = 1, 2
+
+	items[0].Position, items[1].Position = 2048, 3072
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` IN (?,?) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "position"}).AddRow(1, 1, 1024).AddRow(2, 1, 3072))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `position`=?,`updated_at`=? WHERE id = ?")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `position`=?,`updated_at`=? WHERE id = ?")).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?)")).
+		WithArgs(1, nil, activity.ActionMoved, activity.TargetListItem, 1, `{"position":{"before":1024,"after":2048}}`, sqlmock.AnyArg(),
+			1, nil, activity.ActionMoved, activity.TargetListItem, 2, "{}", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	err = listItemRepo.UpdatePositions(context.Background(), items)
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_UpdatePositions_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `position`=?")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	err = listItemRepo.UpdatePositions(context.Background(), []models.ListItem{GetValidListItem()})
+
+	assert.Error(t, err)
+}
+
+func TestListItemRepository_MergeItems(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	survivor := GetValidListItem()
+	survivor.ID = 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "title", "description"}).
+			AddRow(1, 1, 1, survivor.Title, survivor.Description))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` IN (?,?)")).
+		WithArgs(2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title"}).AddRow(2, 1, survivor.Title).AddRow(3, 1, survivor.Title))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WithArgs(activity.TargetListItem, 1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}).AddRow(1, 4))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs(activity.TargetListItem, 1, 1, 5, nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			activity.TargetListItem, 2, 1, 1, nil, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			activity.TargetListItem, 3, 1, 1, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `parent_id`=?,`updated_at`=? WHERE parent_id IN (?,?)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `deleted_at`=? WHERE `list_items`.`id` IN (?,?)")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs(1, nil, activity.ActionMerged, activity.TargetListItem, 1, "{}", sqlmock.AnyArg(),
+			1, nil, activity.ActionDeleted, activity.TargetListItem, 2, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			1, nil, activity.ActionDeleted, activity.TargetListItem, 3, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 3))
 	mock.ExpectCommit()
 
-	result, err := listItemRepo.DeleteListItemsByListID("1")
+	result, err := listItemRepo.MergeItems(context.Background(), survivor, []uint{2, 3})
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_MergeItems_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	survivor := GetValidListItem()
+	survivor.ID = 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` IN (?,?)")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(2, 1).AddRow(3, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `parent_id`=?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `deleted_at`=?")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := listItemRepo.MergeItems(context.Background(), survivor, []uint{2, 3})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -456,3 +870,742 @@ func GetValidListItem() models.ListItem {
 		IsDone:      false,
 	}
 }
+
+func TestListItemRepository_GetItemsByFilter(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE id IN (SELECT `list_item_id` FROM `list_item_assignees` WHERE user_id = ?) " +
+		"AND list_id IN (SELECT list_id FROM `user_lists` WHERE user_id = ? AND deleted_at IS NULL) AND is_done = ? AND `list_items`.`deleted_at` IS NULL " +
+		"ORDER BY list_id,position,id")).
+		WithArgs("7", "7", false).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title"}).AddRow(1, 1, "Leche").AddRow(2, 3, "Pan"))
+
+	result, err := listItemRepo.GetItemsByFilter(models.ItemFilter{AssigneeID: "7", MemberID: "7", Pending: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 2)
+}
+
+func TestListItemRepository_GetItemsByFilter_By_List(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE list_id = ? AND id IN (SELECT `list_item_id` FROM `list_item_assignees` WHERE user_id = ?) AND `list_items`.`deleted_at` IS NULL ORDER BY list_id,position,id")).
+		WithArgs("1", "7").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title"}).AddRow(1, 1, "Leche"))
+
+	result, err := listItemRepo.GetItemsByFilter(models.ItemFilter{ListID: "1", AssigneeID: "7"})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestListItemRepository_GetItemsByFilter_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items`")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := listItemRepo.GetItemsByFilter(models.ItemFilter{ListID: "1"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemRepository_GetAssignees(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_item_assignees` WHERE list_item_id IN (?,?) ORDER BY id")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_item_id", "user_id"}).AddRow(1, 1, 7).AddRow(2, 2, 8))
+
+	result, err := listItemRepo.GetAssignees([]uint{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 2)
+}
+
+func TestListItemRepository_GetRecurringItems(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (id IN (?,?) AND recurrence <> '') AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recurrence"}).AddRow(2, "FREQ=DAILY"))
+
+	result, err := listItemRepo.GetRecurringItems([]uint{1, 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestListItemRepository_GetOccurrence(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	dueAt := time.Date(2022, 3, 8, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (series_id = ? AND due_at = ?) AND `list_items`.`deleted_at` IS NULL ORDER BY `list_items`.`id` LIMIT 1")).
+		WithArgs(1, dueAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result, err := listItemRepo.GetOccurrence(1, dueAt)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func TestListItemRepository_CreateAssignee(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_item_assignees` WHERE `list_item_assignees`.`list_item_id` = ? AND `list_item_assignees`.`user_id` = ? ORDER BY `list_item_assignees`.`id` LIMIT 1")).
+		WithArgs(1, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_item_assignees` (`list_item_id`,`user_id`,`assigned_by`,`created_at`) VALUES (?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`list_id` FROM `list_items` WHERE `list_items`.`id` = ? AND `list_items`.`deleted_at` IS NULL ORDER BY `list_items`.`id` LIMIT 1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 5))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(5, 3, activity.ActionAssigned, activity.TargetListItem, 1, `{"assigned_by":{"after":3},"user_id":{"after":7}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.CreateAssignee(activity.WithActor(context.Background(), 3), models.ListItemAssignee{ListItemID: 1, UserID: 7, AssignedBy: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CreateAssignee_Already_Assigned(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_item_assignees`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_item_id", "user_id", "assigned_by"}).AddRow(5, 1, 7, 2))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.CreateAssignee(context.Background(), models.ListItemAssignee{ListItemID: 1, UserID: 7, AssignedBy: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(5), result.ID)
+	assert.Equal(t, uint(2), result.AssignedBy)
+}
+
+func TestListItemRepository_DeleteAssignee(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_item_assignees` WHERE list_item_id = ? AND user_id = ?")).
+		WithArgs("1", "7").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_item_id", "user_id", "assigned_by"}).AddRow(5, 1, 7, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `list_item_assignees` WHERE list_item_id = ? AND user_id = ?")).
+		WithArgs("1", "7").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`list_id` FROM `list_items`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 5))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(5, nil, activity.ActionUnassigned, activity.TargetListItem, 1, `{"assigned_by":{"before":2},"user_id":{"before":7}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.DeleteAssignee(context.Background(), "1", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_DeleteAssignee_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_item_assignees`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `list_item_assignees`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := listItemRepo.DeleteAssignee(context.Background(), "1", "7")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+// insertActivities is the statement saving a single activity entry, batches add a group of placeholders per entry.
+const insertActivities = "INSERT INTO `activities` (`list_id`,`actor_id`,`action`,`target_type`,`target_id`,`changes`,`created_at`) VALUES (?,?,?,?,?,?,?)"
+
+func TestListItemRepository_BulkDelete(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (id IN (?) OR parent_id IN (?)) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "parent_id"}).AddRow(1, 3, nil).AddRow(2, 3, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions+",(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `deleted_at`=?")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `undo_tokens` WHERE user_id = ? AND expires_at < ?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `undo_tokens`")).
+		WithArgs(sqlmock.AnyArg(), 4, activity.TargetListItem, "[1,2]", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, undoToken, err := listItemRepo.BulkDelete(activity.WithActor(context.Background(), 4), []uint{1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &bulk.Result{Succeeded: []uint{1}, NotFound: []uint{}, Forbidden: []uint{}, Unchanged: []uint{}}, result)
+	assert.NotNil(t, undoToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_BulkDelete_Nothing_Deleted(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `deleted_at`=?")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	result, undoToken, err := listItemRepo.BulkDelete(context.Background(), []uint{9})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Succeeded)
+	assert.Equal(t, []uint{9}, result.NotFound)
+	assert.Nil(t, undoToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// selectVersions and insertVersions are the statements keeping the previous versions of the changed items.
+const (
+	selectVersions = "SELECT target_id, MAX(number) AS number FROM `versions` WHERE target_type = ? AND target_id IN "
+	insertVersions = "INSERT INTO `versions` (`target_type`,`target_id`,`list_id`,`number`,`actor_id`,`snapshot`,`created_at`) VALUES (?,?,?,?,?,?,?)"
+)
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func TestListItemRepository_GetItemsByFilter_Due_Range(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	from := time.Date(2024, time.March, 13, 0, 0, 0, 0, time.UTC)
+	before := from.AddDate(0, 0, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE list_id IN (SELECT list_id FROM `user_lists` WHERE user_id = ? AND deleted_at IS NULL) " +
+		"AND is_done = ? AND due_at >= ? AND due_at < ? AND `list_items`.`deleted_at` IS NULL ORDER BY list_id,position,id")).
+		WithArgs("7", false, from, before).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title", "due_at"}).AddRow(1, 1, "Leche", from))
+
+	result, err := listItemRepo.GetItemsByFilter(models.ItemFilter{MemberID: "7", Pending: true, DueFrom: &from, DueBefore: &before})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestListItemRepository_GetItemsByFilter_Overdue(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	now := time.Date(2024, time.March, 13, 15, 30, 0, 0, time.UTC)
+	dayStart := time.Date(2024, time.March, 13, 0, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE list_id IN (SELECT list_id FROM `user_lists` WHERE user_id = ? AND deleted_at IS NULL) " +
+		"AND is_done = ? AND ((all_day = ? AND due_at < ?) OR (all_day = ? AND due_at < ?)) AND `list_items`.`deleted_at` IS NULL ORDER BY list_id,position,id")).
+		WithArgs("7", false, false, now, true, dayStart).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title"}).AddRow(1, 1, "Pagar la luz"))
+
+	result, err := listItemRepo.GetItemsByFilter(models.ItemFilter{MemberID: "7", Pending: true, Overdue: &models.OverdueCutoff{Now: now, DayStart: dayStart}})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestListItemRepository_MarkAsCompleted(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	completedAt := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE id IN (?,?,?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs(1, 2, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "is_done", "completed_at"}).AddRow(1, 3, false, nil).AddRow(2, 3, true, completedAt))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `completed_at`=COALESCE(completed_at, ?),`is_done`=?,`updated_at`=? WHERE id IN (?,?,?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), true, sqlmock.AnyArg(), 1, 2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WithArgs(activity.TargetListItem, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WithArgs(activity.TargetListItem, 1, 3, 1, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(3, nil, activity.ActionCompleted, activity.TargetListItem, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.MarkAsCompleted(context.Background(), []uint{1, 2, 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, result.Succeeded)
+	assert.Equal(t, []uint{2}, result.Unchanged)
+	assert.Equal(t, []uint{5}, result.NotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_MarkAsPending(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	completedAt := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE id IN (?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "is_done", "completed_at"}).AddRow(1, 3, true, completedAt))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `completed_at`=?,`is_done`=?,`updated_at`=? WHERE id IN (?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs(nil, false, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(3, nil, activity.ActionReopened, activity.TargetListItem, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.MarkAsPending(context.Background(), []uint{1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, result.Succeeded)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_Delete_Cascades_To_Subtasks(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (id = ? OR parent_id = ?) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "parent_id"}).AddRow(1, 3, nil).AddRow(2, 3, 1).AddRow(3, 3, 1))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `deleted_at`=? WHERE (id = ? OR parent_id = ?) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.Delete(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_MarkChildrenAsCompleted(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE (parent_id IN (?,?) AND is_done = false) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "parent_id"}).AddRow(3, 1, 1).AddRow(4, 1, 1).AddRow(5, 1, 2).AddRow(6, 1, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `completed_at`=COALESCE(completed_at, ?),`is_done`=?,`updated_at`=? WHERE (parent_id IN (?,?) AND is_done = false) AND `list_items`.`deleted_at` IS NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 4))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 4))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.MarkChildrenAsCompleted(context.Background(), []uint{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, *result)
+}
+
+func TestListItemRepository_CountChildren(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `list_items` WHERE parent_id = ? AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	result, err := listItemRepo.CountChildren(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), *result)
+}
+
+func TestListItemRepository_GetCommentCounts(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT list_item_id, count(*) AS count FROM `comments` WHERE list_item_id IN (?,?) AND deleted_at IS NULL GROUP BY `list_item_id`")).
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"list_item_id", "count"}).AddRow(2, 3))
+
+	result, err := listItemRepo.GetCommentCounts([]uint{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CommentCount{{ListItemID: 2, Count: 3}}, *result)
+}
+
+func TestListItemRepository_Update_List_Change(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepository := NewListItemRepository(gormDb)
+
+	validListItem := GetValidListItem()
+	validListItem.ID = 1
+	validListItem.ListID = 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 1))
+	mock.ExpectRollback()
+
+	result, _, err := listItemRepository.Update(context.Background(), validListItem)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, models.ErrListChangeNotAllowed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// selectTransferSource is the statement loading the items to move or copy along with their subtasks.
+const selectTransferSource = "SELECT * FROM `list_items` WHERE (id IN (?,?) OR parent_id IN (?,?)) AND `list_items`.`deleted_at` IS NULL ORDER BY list_id,position,id"
+
+func TestListItemRepository_MoveItems(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransferSource)).
+		WithArgs(1, 5, 1, 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title", "position", "parent_id"}).
+			AddRow(1, 3, "buy paint", 1024, nil).
+			AddRow(2, 3, "white", 2048, 1).
+			AddRow(5, 4, "brushes", 1024, 8))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2048))
+	mock.ExpectQuery(regexp.QuoteMeta(selectVersions)).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta(insertVersions+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `list_id`=?,`parent_id`=?,`position`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(9, nil, float64(3072), sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `list_id`=?,`parent_id`=?,`position`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(9, 1, float64(4096), sqlmock.AnyArg(), 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `list_id`=?,`parent_id`=?,`position`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(9, nil, float64(5120), sqlmock.AnyArg(), 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `comments` SET `list_id`=? WHERE list_item_id IN (?,?,?)")).
+		WithArgs(9, 1, 2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `attachments` SET `list_id`=? WHERE list_item_id IN (?,?,?)")).
+		WithArgs(9, 1, 2, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?),(?,?,?,?,?,?,?),(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 6))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_item_assignees` WHERE list_item_id IN (?,?,?) AND user_id NOT IN (SELECT user_id FROM `user_lists` WHERE list_id = ? AND deleted_at IS NULL)")).
+		WithArgs(1, 2, 5, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_item_id", "user_id"}).AddRow(7, 2, 12))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `list_item_assignees` WHERE `list_item_assignees`.`id` = ?")).
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`list_id` FROM `list_items` WHERE `list_items`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(2, 9))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(9, nil, activity.ActionUnassigned, activity.TargetListItem, 2, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.MoveItems(context.Background(), []uint{1, 5}, 9)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 3)
+	for _, moved := range *result {
+		assert.Equal(t, 9, moved.ListID)
+	}
+	assert.Equal(t, uint(1), *(*result)[1].ParentID)
+	assert.Nil(t, (*result)[2].ParentID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_MoveItems_Already_On_List(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransferSource)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 9).AddRow(5, 9))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(2048))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.MoveItems(context.Background(), []uint{1, 5}, 9)
+
+	assert.NoError(t, err)
+	assert.Empty(t, *result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CopyItems(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	insertListItem := "INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`,`position`,`quantity`,`unit`,`unit_price`,`currency`,`product_id`,`category`,`tags`,`due_at`,`all_day`,`time_zone`,`priority`,`completed_at`,`recurrence`,`series_id`,`parent_id`)"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta(selectTransferSource)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title", "is_done", "position", "parent_id"}).
+			AddRow(2, 3, "white", true, 512, 1).
+			AddRow(1, 3, "buy paint", false, 1024, nil))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta(insertListItem)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 9, 4, "buy paint", "", false, float64(2048), nil, "", nil, "", nil, "", sqlmock.AnyArg(), nil, false, "", "", nil, "", nil, nil).
+		WillReturnResult(sqlmock.NewResult(10, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertListItem)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 9, 4, "white", "", false, float64(1024), nil, "", nil, "", nil, "", sqlmock.AnyArg(), nil, false, "", "", nil, "", nil, 10).
+		WillReturnResult(sqlmock.NewResult(11, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?)")).
+		WithArgs(9, nil, activity.ActionCopied, activity.TargetListItem, 11, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			9, nil, activity.ActionCopied, activity.TargetListItem, 10, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.CopyItems(context.Background(), []uint{1, 2}, 9, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(11), (*result)[0].ID)
+	assert.Equal(t, uint(10), *(*result)[0].ParentID)
+	assert.False(t, (*result)[0].IsDone)
+	assert.Equal(t, uint(10), (*result)[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CreateItems(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	insertListItem := "INSERT INTO `list_items` (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`,`title`,`description`,`is_done`,`position`,`quantity`,`unit`,`unit_price`,`currency`,`product_id`,`category`,`tags`,`due_at`,`all_day`,`time_zone`,`priority`,`completed_at`,`recurrence`,`series_id`,`parent_id`)"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(1024))
+	mock.ExpectExec(regexp.QuoteMeta(insertListItem+" VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?),(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(10, 2))
+	mock.ExpectExec(regexp.QuoteMeta(insertListItem)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 9, 4, "white", "", true, float64(3072), nil, "", nil, "", nil, "", sqlmock.AnyArg(), nil, false, "", "", nil, "", nil, 10).
+		WillReturnResult(sqlmock.NewResult(12, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities+",(?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs(9, nil, activity.ActionCreated, activity.TargetListItem, 10, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			9, nil, activity.ActionCreated, activity.TargetListItem, 11, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			9, nil, activity.ActionCreated, activity.TargetListItem, 12, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.CreateItems(context.Background(), []models.ListItem{
+		{ListID: 9, UserID: 4, Title: "buy paint", Children: []models.ListItem{{UserID: 4, Title: "white", IsDone: true}}},
+		{ListID: 9, UserID: 4, Title: "brushes"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 2)
+	assert.Equal(t, uint(10), (*result)[0].ID)
+	assert.Equal(t, float64(2048), (*result)[0].Position)
+	assert.Equal(t, uint(12), (*result)[0].Children[0].ID)
+	assert.Equal(t, uint(10), *(*result)[0].Children[0].ParentID)
+	assert.Equal(t, uint(11), (*result)[1].ID)
+	assert.Equal(t, float64(4096), (*result)[1].Position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CreateItems_Several_Lists(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(1024))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items`")).
+		WillReturnResult(sqlmock.NewResult(10, 3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectCommit()
+
+	result, err := listItemRepo.CreateItems(context.Background(), []models.ListItem{
+		{ListID: 9, UserID: 4, Title: "buy paint"},
+		{ListID: 3, UserID: 4, Title: "call the plumber"},
+		{ListID: 9, UserID: 4, Title: "brushes"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(2048), (*result)[0].Position)
+	assert.Equal(t, float64(1024), (*result)[1].Position)
+	assert.Equal(t, float64(3072), (*result)[2].Position)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CreateItemsOnNewList(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists`")).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(9, nil, activity.ActionCreated, activity.TargetList, 9, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_lists`")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 9, 4).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WithArgs(9, nil, activity.ActionJoined, activity.TargetMember, 4, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WithArgs(9).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items`")).
+		WillReturnResult(sqlmock.NewResult(10, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(3, 2))
+	mock.ExpectCommit()
+
+	list, result, err := listItemRepo.CreateItemsOnNewList(context.Background(), listModels.List{Name: "Compras", UserCreatorID: 4},
+		[]models.ListItem{{UserID: 4, Title: "buy paint"}, {UserID: 4, Title: "brushes"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), list.ID)
+	assert.NotEmpty(t, list.InviteCode)
+	assert.Len(t, *result, 2)
+	assert.Equal(t, 9, (*result)[0].ListID)
+	assert.Equal(t, 9, (*result)[1].ListID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CreateItemsOnNewList_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists`")).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_lists`")).
+		WillReturnResult(sqlmock.NewResult(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta(insertActivities)).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items`")).
+		WillReturnError(errors.New("connection refused"))
+	mock.ExpectRollback()
+
+	list, result, err := listItemRepo.CreateItemsOnNewList(context.Background(), listModels.List{Name: "Compras", UserCreatorID: 4},
+		[]models.ListItem{{UserID: 4, Title: "buy paint"}})
+
+	assert.Error(t, err)
+	assert.Nil(t, list)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListItemRepository_CreateItems_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	listItemRepo := NewListItemRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0) FROM `list_items` WHERE list_id = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `list_items`")).
+		WillReturnError(errors.New("connection refused"))
+	mock.ExpectRollback()
+
+	result, err := listItemRepo.CreateItems(context.Background(), []models.ListItem{{ListID: 9, UserID: 4, Title: "buy paint"}})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/listItems/models"
+	"SuperListsAPI/internal/cache"
+	"context"
+	"strconv"
+	"time"
+)
+
+// CachedListItemService keeps the items of each list on a cache for up to ttl. Entries are dropped by the cache
+// invalidator when the activity of a list changes its items.
+type CachedListItemService struct {
+	ListItemService
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedListItemService(listItemService ListItemService, readCache cache.Cache, ttl time.Duration) CachedListItemService {
+	return CachedListItemService{ListItemService: listItemService, cache: readCache, ttl: ttl}
+}
+
+func (clis *CachedListItemService) GetItemsListByListID(listId string) (*[]models.ListItem, error) {
+	listID, err := strconv.ParseUint(listId, 10, 32)
+	if err != nil {
+		return clis.ListItemService.GetItemsListByListID(listId)
+	}
+
+	var items *[]models.ListItem
+	err = cache.Fetch(context.Background(), clis.cache, cache.ListItemsKey(uint(listID)), clis.ttl, &items, func() (err error) {
+		items, err = clis.ListItemService.GetItemsListByListID(listId)
+		return err
+	})
+
+	return items, err
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/listItems/models"
+	"SuperListsAPI/internal/cache"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCachedListItemService_GetItemsListByListID(t *testing.T) {
+	validListItem := GetValidListItem()
+	validListItem.ID = 3
+	items := []models.ListItem{validListItem}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil).Times(2)
+	mockedRepo.EXPECT().GetAssignees(gomock.Any()).Return(&[]models.ListItemAssignee{}, nil).Times(2)
+	mockedRepo.EXPECT().GetCommentCounts(gomock.Any()).Return(&[]models.CommentCount{}, nil).Times(2)
+
+	readCache := cache.NewLRU(10)
+	listItemService := NewCachedListItemService(NewListItemService(mockedRepo), readCache, time.Minute)
+
+	first, err := listItemService.GetItemsListByListID("1")
+	assert.NoError(t, err)
+	cached, err := listItemService.GetItemsListByListID("1")
+	assert.NoError(t, err)
+
+	assert.Len(t, *first, 1)
+	assert.Len(t, *cached, 1)
+	assert.Equal(t, uint(3), (*cached)[0].ID)
+	assert.Equal(t, validListItem.Title, (*cached)[0].Title)
+
+	// Once invalidated the items are read again
+	assert.NoError(t, readCache.Delete(context.Background(), cache.ListItemsKey(1)))
+	_, err = listItemService.GetItemsListByListID("1")
+	assert.NoError(t, err)
+}
+
+func TestCachedListItemService_GetItemsListByListID_Error(t *testing.T) {
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(nil, errors.New("error from list item repo"))
+
+	readCache := cache.NewLRU(10)
+	listItemService := NewCachedListItemService(NewListItemService(mockedRepo), readCache, time.Minute)
+
+	result, err := listItemService.GetItemsListByListID("1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, 0, readCache.Len())
+}
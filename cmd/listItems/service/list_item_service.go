@@ -1,21 +1,55 @@
 package service
 
-import "SuperListsAPI/cmd/listItems/models"
+import (
+	"SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/recurrence"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"sort"
+	"strings"
+	"time"
+)
 
 //go:generate mockgen -source=list_item_service.go -destination list_item_service_mock.go -package service
 
 type IListItemRepository interface {
-	Create(item models.ListItem) (*models.ListItem, error)
+	Create(ctx context.Context, item models.ListItem) (*models.ListItem, error)
 	Get(listItemID string) (*models.ListItem, error)
-	Update(item models.ListItem) (*models.ListItem, error)
-	Delete(listItemID string) (*int, error)
+	Update(ctx context.Context, item models.ListItem) (*models.ListItem, bool, error)
+	Delete(ctx context.Context, listItemID string) (*int, error)
 	GetItemsListByListID(listId string) (*[]models.ListItem, error)
-	DeleteListItemsByListID(listId string) (*int, error)
-	BulkDelete(tasksToDelete []models.ListItem) (*int, error)
-	MarkAsCompleted(tasksToDelete []models.ListItem) (*int, error)
-	MarkAsPending(tasksToDelete []models.ListItem) (*int, error)
+	DeleteListItemsByListID(ctx context.Context, listId string) (*int, error)
+	BulkDelete(ctx context.Context, listItemIDs []uint) (*bulk.Result, *versions.UndoToken, error)
+	MarkAsCompleted(ctx context.Context, listItemIDs []uint) (*bulk.Result, error)
+	MarkAsPending(ctx context.Context, listItemIDs []uint) (*bulk.Result, error)
+	GetLastPosition(listId string) (*float64, error)
+	UpdatePositions(ctx context.Context, items []models.ListItem) error
+	MergeItems(ctx context.Context, survivor models.ListItem, mergedIDs []uint) (*models.ListItem, error)
+	GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error)
+	GetItemsByIDs(listItemIDs []uint) (*[]models.ListItem, error)
+	MoveItems(ctx context.Context, listItemIDs []uint, listID uint) (*[]models.ListItem, error)
+	CopyItems(ctx context.Context, listItemIDs []uint, listID uint, userID uint) (*[]models.ListItem, error)
+	CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error)
+	CreateItemsOnNewList(ctx context.Context, list listModels.List, items []models.ListItem) (*listModels.List, *[]models.ListItem, error)
+	GetAssignees(listItemIDs []uint) (*[]models.ListItemAssignee, error)
+	GetCommentCounts(listItemIDs []uint) (*[]models.CommentCount, error)
+	CreateAssignee(ctx context.Context, assignee models.ListItemAssignee) (*models.ListItemAssignee, error)
+	DeleteAssignee(ctx context.Context, listItemID string, userID string) (*int, error)
+	GetRecurringItems(listItemIDs []uint) (*[]models.ListItem, error)
+	GetOccurrence(seriesID uint, dueAt time.Time) (*models.ListItem, error)
+	MarkChildrenAsCompleted(ctx context.Context, parentIDs []uint) (*int, error)
+	CountChildren(listItemID uint) (*int64, error)
 }
 
+// minPositionGap is the smallest distance allowed between two neighbours before the whole list gets rebalanced.
+const minPositionGap = 1e-6
+
 type ListItemService struct {
 	repository IListItemRepository
 }
@@ -24,9 +58,27 @@ func NewListItemService(repository IListItemRepository) ListItemService {
 	return ListItemService{repository: repository}
 }
 
-func (lis *ListItemService) Create(item models.ListItem) (*models.ListItem, error) {
+func (lis *ListItemService) Create(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
+
+	if item.Position == 0 {
+		lastPosition, err := lis.repository.GetLastPosition(fmt.Sprint(item.ListID))
 
-	result, err := lis.repository.Create(item)
+		if err != nil {
+			return nil, err
+		}
+
+		item.Position = *lastPosition + models.PositionGap
+	}
+
+	if err := prepareNewItem(&item); err != nil {
+		return nil, err
+	}
+
+	if err := lis.checkParent(item); err != nil {
+		return nil, err
+	}
+
+	result, err := lis.repository.Create(ctx, item)
 
 	if err != nil {
 		return nil, err
@@ -43,22 +95,90 @@ func (lis *ListItemService) Get(listItemID string) (*models.ListItem, error) {
 		return nil, err
 	}
 
-	return result, nil
+	items := []models.ListItem{*result}
+	if err := lis.attachAssignees(items); err != nil {
+		return nil, err
+	}
+
+	return &items[0], nil
 }
 
-func (lis *ListItemService) Update(item models.ListItem) (*models.ListItem, error) {
-	result, err := lis.repository.Update(item)
+func (lis *ListItemService) Update(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
+	if err := normalizeRecurrence(&item); err != nil {
+		return nil, err
+	}
+
+	if err := lis.checkParent(item); err != nil {
+		return nil, err
+	}
+
+	// The completion time is never taken from the request. An item that was already done keeps the stored one, see
+	// ListItemRepository.Update
+	if !item.IsDone {
+		item.CompletedAt = nil
+	} else {
+		completedAt := time.Now()
+		item.CompletedAt = &completedAt
+	}
+
+	result, completed, err := lis.repository.Update(ctx, item)
 
 	if err != nil {
 		return nil, err
 	}
 
+	// Like MarkAsCompleted, only completing a pending item brings the next occurrence, editing a done one doesn't
+	if completed && result.Recurrence != "" {
+		if err := lis.createNextOccurrence(ctx, *result, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	return result, nil
 }
 
-func (lis *ListItemService) Delete(listItemID string) (*int, error) {
+// checkParent makes sure the item is nested under a top level item of its own list, and that an item with
+// subtasks doesn't become a subtask itself.
+func (lis *ListItemService) checkParent(item models.ListItem) error {
+	if item.ParentID == nil {
+		return nil
+	}
 
-	result, err := lis.repository.Delete(listItemID)
+	if *item.ParentID == item.ID {
+		return models.ErrInvalidParent
+	}
+
+	parent, err := lis.repository.Get(fmt.Sprint(*item.ParentID))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.ErrInvalidParent
+	}
+	if err != nil {
+		return err
+	}
+
+	if parent.ListID != item.ListID || parent.ParentID != nil {
+		return models.ErrInvalidParent
+	}
+
+	if item.ID == 0 {
+		return nil
+	}
+
+	children, err := lis.repository.CountChildren(item.ID)
+	if err != nil {
+		return err
+	}
+
+	if *children > 0 {
+		return models.ErrInvalidParent
+	}
+
+	return nil
+}
+
+func (lis *ListItemService) Delete(ctx context.Context, listItemID string) (*int, error) {
+
+	result, err := lis.repository.Delete(ctx, listItemID)
 
 	if err != nil {
 		return nil, err
@@ -75,13 +195,61 @@ func (lis *ListItemService) GetItemsListByListID(listId string) (*[]models.ListI
 		return nil, err
 	}
 
+	if err := lis.attachAssignees(*result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+
+}
+
+func (lis *ListItemService) GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error) {
+
+	result, err := lis.repository.GetItemsByFilter(filter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lis.attachAssignees(*result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (lis *ListItemService) GetItemsByIDs(listItemIDs []uint) (*[]models.ListItem, error) {
+
+	result, err := lis.repository.GetItemsByIDs(listItemIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return result, nil
+}
+
+// MoveItems moves the items, with their subtasks, to the end of the list listID. The moved items are returned with
+// the assignees they kept.
+func (lis *ListItemService) MoveItems(ctx context.Context, listItemIDs []uint, listID uint) (*[]models.ListItem, error) {
+
+	result, err := lis.repository.MoveItems(ctx, listItemIDs, listID)
+
+	if err != nil {
+		return nil, err
+	}
 
+	if err := lis.attachAssignees(*result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-func (lis *ListItemService) DeleteListItemsByListID(listId string) (*int, error) {
+// CopyItems copies the items, with their subtasks, to the end of the list listID on behalf of userID.
+func (lis *ListItemService) CopyItems(ctx context.Context, listItemIDs []uint, listID uint, userID uint) (*[]models.ListItem, error) {
 
-	result, err := lis.repository.DeleteListItemsByListID(listId)
+	result, err := lis.repository.CopyItems(ctx, listItemIDs, listID, userID)
 
 	if err != nil {
 		return nil, err
@@ -90,9 +258,15 @@ func (lis *ListItemService) DeleteListItemsByListID(listId string) (*int, error)
 	return result, nil
 }
 
-func (lis *ListItemService) BulkDelete(tasksToDelete []models.ListItem) (*int, error) {
+// CreateItems adds many items at the end of their list at once, see ListItemRepository.CreateItems. Items get the same
+// defaults as on Create.
+func (lis *ListItemService) CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
 
-	result, err := lis.repository.BulkDelete(tasksToDelete)
+	if err := lis.PrepareNewItems(items); err != nil {
+		return nil, err
+	}
+
+	result, err := lis.repository.CreateItems(ctx, items)
 
 	if err != nil {
 		return nil, err
@@ -101,20 +275,149 @@ func (lis *ListItemService) BulkDelete(tasksToDelete []models.ListItem) (*int, e
 	return result, nil
 }
 
-func (lis *ListItemService) MarkAsCompleted(tasksToDelete []models.ListItem) (*int, error) {
+// CreateItemsOnNewList creates list owned by its creator along with items at once, see
+// ListItemRepository.CreateItemsOnNewList. Items get the same defaults as on Create.
+func (lis *ListItemService) CreateItemsOnNewList(ctx context.Context, list listModels.List, items []models.ListItem) (*listModels.List, *[]models.ListItem, error) {
+
+	if err := lis.PrepareNewItems(items); err != nil {
+		return nil, nil, err
+	}
+
+	return lis.repository.CreateItemsOnNewList(ctx, list, items)
+}
+
+// PrepareNewItems runs prepareNewItem on items and their subtasks, for items created along with something else.
+func (lis *ListItemService) PrepareNewItems(items []models.ListItem) error {
+	for i := range items {
+		if err := prepareNewItem(&items[i]); err != nil {
+			return err
+		}
+
+		for j := range items[i].Children {
+			if err := prepareNewItem(&items[i].Children[j]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareNewItem guesses the category of an item and normalizes its recurrence before it is created.
+func prepareNewItem(item *models.ListItem) error {
+	if item.Category == "" {
+		item.Category = models.GuessCategory(item.Title)
+	}
+
+	return normalizeRecurrence(item)
+}
+
+// GetDueToday returns the pending items due on now's day, on every list userID belongs to.
+// The day boundaries are taken on now's location, so callers pass now on the user time zone.
+func (lis *ListItemService) GetDueToday(userID string, now time.Time) (*[]models.ListItem, error) {
+	dayStart := startOfDay(now)
+	nextDayStart := dayStart.AddDate(0, 0, 1)
+
+	return lis.getAgenda(models.ItemFilter{MemberID: userID, Pending: true, DueFrom: &dayStart, DueBefore: &nextDayStart})
+}
+
+// GetUpcoming returns the pending items due from tomorrow on, for the given amount of days.
+func (lis *ListItemService) GetUpcoming(userID string, now time.Time, days int) (*[]models.ListItem, error) {
+	from := startOfDay(now).AddDate(0, 0, 1)
+	until := from.AddDate(0, 0, days)
+
+	return lis.getAgenda(models.ItemFilter{MemberID: userID, Pending: true, DueFrom: &from, DueBefore: &until})
+}
 
-	result, err := lis.repository.MarkAsCompleted(tasksToDelete)
+// GetOverdue returns the pending items whose due time already passed.
+func (lis *ListItemService) GetOverdue(userID string, now time.Time) (*[]models.ListItem, error) {
+	cutoff := models.OverdueCutoff{Now: now, DayStart: startOfDay(now)}
+
+	return lis.getAgenda(models.ItemFilter{MemberID: userID, Pending: true, Overdue: &cutoff})
+}
+
+// getAgenda sorts items by due time and then by priority, the order they should be worked on.
+func (lis *ListItemService) getAgenda(filter models.ItemFilter) (*[]models.ListItem, error) {
+	result, err := lis.GetItemsByFilter(filter)
 
 	if err != nil {
 		return nil, err
 	}
 
+	items := *result
+	sort.SliceStable(items, func(i, j int) bool {
+		first, second := items[i].DueAt, items[j].DueAt
+		if first != nil && second != nil && !first.Equal(*second) {
+			return first.Before(*second)
+		}
+		if (first == nil) != (second == nil) {
+			return second == nil
+		}
+		return items[i].PriorityRank() < items[j].PriorityRank()
+	})
+
 	return result, nil
 }
 
-func (lis *ListItemService) MarkAsPending(tasksToDelete []models.ListItem) (*int, error) {
+func startOfDay(moment time.Time) time.Time {
+	return time.Date(moment.Year(), moment.Month(), moment.Day(), 0, 0, 0, 0, moment.Location())
+}
+
+// Assign makes userID responsible for the item. Checking that userID is a member of the item list is up to the caller.
+func (lis *ListItemService) Assign(ctx context.Context, listItemID uint, userID uint, assignedBy uint) (*models.ListItemAssignee, error) {
+	return lis.repository.CreateAssignee(ctx, models.ListItemAssignee{
+		ListItemID: listItemID,
+		UserID:     userID,
+		AssignedBy: assignedBy,
+	})
+}
+
+func (lis *ListItemService) Unassign(ctx context.Context, listItemID string, userID string) (*int, error) {
+	return lis.repository.DeleteAssignee(ctx, listItemID, userID)
+}
 
-	result, err := lis.repository.MarkAsPending(tasksToDelete)
+// attachAssignees fills the Assignees and the comment count of every item, with a single query for each.
+func (lis *ListItemService) attachAssignees(items []models.ListItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	itemIDs := make([]uint, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+
+	assignees, err := lis.repository.GetAssignees(itemIDs)
+	if err != nil {
+		return err
+	}
+
+	byItem := make(map[uint][]uint, len(*assignees))
+	for _, assignee := range *assignees {
+		byItem[assignee.ListItemID] = append(byItem[assignee.ListItemID], assignee.UserID)
+	}
+
+	counts, err := lis.repository.GetCommentCounts(itemIDs)
+	if err != nil {
+		return err
+	}
+
+	comments := make(map[uint]int, len(*counts))
+	for _, count := range *counts {
+		comments[count.ListItemID] = count.Count
+	}
+
+	for i := range items {
+		items[i].Assignees = byItem[items[i].ID]
+		items[i].CommentCount = comments[items[i].ID]
+	}
+
+	return nil
+}
+
+func (lis *ListItemService) DeleteListItemsByListID(ctx context.Context, listId string) (*int, error) {
+
+	result, err := lis.repository.DeleteListItemsByListID(ctx, listId)
 
 	if err != nil {
 		return nil, err
@@ -122,3 +425,383 @@ func (lis *ListItemService) MarkAsPending(tasksToDelete []models.ListItem) (*int
 
 	return result, nil
 }
+
+func (lis *ListItemService) BulkDelete(ctx context.Context, listItemIDs []uint) (*bulk.Result, *versions.UndoToken, error) {
+
+	result, undoToken, err := lis.repository.BulkDelete(ctx, listItemIDs)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return result, undoToken, nil
+}
+
+// MarkAsCompleted completes the items, creating the next occurrence of the recurring ones that were pending.
+func (lis *ListItemService) MarkAsCompleted(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
+
+	result, err := lis.repository.MarkAsCompleted(ctx, listItemIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.Succeeded) == 0 {
+		return result, nil
+	}
+
+	recurring, err := lis.repository.GetRecurringItems(result.Succeeded)
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, item := range *recurring {
+		if err := lis.createNextOccurrence(ctx, item, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// normalizeRecurrence stores the item rule on its canonical form.
+func normalizeRecurrence(item *models.ListItem) error {
+	if item.Recurrence == "" {
+		return nil
+	}
+
+	rule, err := recurrence.Parse(item.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	item.Recurrence = rule.String()
+	return nil
+}
+
+// createNextOccurrence adds the occurrence that follows a completed recurring item, keeping its place on the list
+// and its assignees. Occurrences already past when the item is completed late are skipped, and completing the
+// same occurrence twice does not add another one.
+func (lis *ListItemService) createNextOccurrence(ctx context.Context, item models.ListItem, now time.Time) error {
+	rule, err := recurrence.Parse(item.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	loc, err := recurrence.Location(item.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	start := now
+	if item.DueAt != nil {
+		start = *item.DueAt
+	}
+
+	after := start
+	if now.After(after) {
+		after = now
+	}
+
+	next, ok := rule.Next(start, after, loc)
+	if !ok {
+		return nil
+	}
+
+	seriesID := item.ID
+	if item.SeriesID != nil {
+		seriesID = *item.SeriesID
+	}
+
+	_, err = lis.repository.GetOccurrence(seriesID, next)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	occurrence := item
+	occurrence.Model = gorm.Model{}
+	occurrence.IsDone = false
+	occurrence.CompletedAt = nil
+	occurrence.DueAt = &next
+	occurrence.SeriesID = &seriesID
+	occurrence.Assignees = nil
+
+	created, err := lis.Create(ctx, occurrence)
+	if err != nil {
+		return err
+	}
+
+	assignees, err := lis.repository.GetAssignees([]uint{item.ID})
+	if err != nil {
+		return err
+	}
+
+	for _, assignee := range *assignees {
+		if _, err := lis.Assign(ctx, created.ID, assignee.UserID, assignee.AssignedBy); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CompleteChildren marks the pending subtasks of the given items as done.
+func (lis *ListItemService) CompleteChildren(ctx context.Context, parentIDs []uint) (*int, error) {
+
+	result, err := lis.repository.MarkChildrenAsCompleted(ctx, parentIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (lis *ListItemService) MarkAsPending(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
+
+	result, err := lis.repository.MarkAsPending(ctx, listItemIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (lis *ListItemService) Reorder(ctx context.Context, listId string, moves []models.ItemMove) (*[]models.ListItem, error) {
+
+	items, err := lis.repository.GetItemsListByListID(listId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := *items
+	changed := map[uint]bool{}
+
+	for _, move := range moves {
+		if move.AfterID == move.ItemID {
+			return nil, fmt.Errorf("%w: %d", models.ErrMoveAfterItself, move.ItemID)
+		}
+
+		from := indexOfItem(ordered, move.ItemID)
+		if from < 0 || (move.AfterID != 0 && indexOfItem(ordered, move.AfterID) < 0) {
+			return nil, fmt.Errorf("%w: %d", models.ErrItemNotInList, move.ItemID)
+		}
+
+		moved := ordered[from]
+		ordered = append(ordered[:from:from], ordered[from+1:]...)
+
+		to := 0
+		if move.AfterID != 0 {
+			to = indexOfItem(ordered, move.AfterID) + 1
+		}
+
+		ordered = append(ordered[:to], append([]models.ListItem{moved}, ordered[to:]...)...)
+
+		if !placeBetweenNeighbours(ordered, to) {
+			rebalancePositions(ordered)
+			for _, item := range ordered {
+				changed[item.ID] = true
+			}
+			continue
+		}
+		changed[moved.ID] = true
+	}
+
+	var itemsToUpdate []models.ListItem
+	for _, item := range ordered {
+		if changed[item.ID] {
+			itemsToUpdate = append(itemsToUpdate, item)
+		}
+	}
+
+	if err := lis.repository.UpdatePositions(ctx, itemsToUpdate); err != nil {
+		return nil, err
+	}
+
+	return &ordered, nil
+}
+
+func indexOfItem(items []models.ListItem, itemID uint) int {
+	for i, item := range items {
+		if item.ID == itemID {
+			return i
+		}
+	}
+	return -1
+}
+
+// placeBetweenNeighbours gives the item at index a position halfway between its neighbours.
+// It returns false when there is no room left between them and the list needs to be rebalanced.
+func placeBetweenNeighbours(items []models.ListItem, index int) bool {
+
+	hasPrevious := index > 0
+	hasNext := index < len(items)-1
+
+	switch {
+	case hasPrevious && hasNext:
+		previous, next := items[index-1].Position, items[index+1].Position
+		if next-previous < minPositionGap {
+			return false
+		}
+		items[index].Position = previous + (next-previous)/2
+	case hasPrevious:
+		items[index].Position = items[index-1].Position + models.PositionGap
+	case hasNext:
+		items[index].Position = items[index+1].Position - models.PositionGap
+	default:
+		items[index].Position = models.PositionGap
+	}
+
+	return true
+}
+
+func rebalancePositions(items []models.ListItem) {
+	for i := range items {
+		items[i].Position = float64(i+1) * models.PositionGap
+	}
+}
+
+// MergeDuplicates folds pending items with the same title into the first one of them, adding up their
+// quantities. Quantities are normalized first so that "500 g" and "1 kg" of the same product become "1.5 kg".
+// Items whose units can't be converted between each other or that are priced in different currencies are kept apart.
+func (lis *ListItemService) MergeDuplicates(ctx context.Context, listId string) (*[]models.ListItem, error) {
+
+	items, err := lis.repository.GetItemsListByListID(listId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var groupKeys []string
+	groups := map[string][]models.ListItem{}
+
+	for _, item := range *items {
+		dimension, err := models.UnitDimension(item.Unit)
+		if item.IsDone || err != nil {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimSpace(item.Title)) + "|" + dimension + "|" + item.Currency + "|" + parentKey(item)
+		if _, ok := groups[key]; !ok {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	merged := map[uint]models.ListItem{}
+	removed := map[uint]bool{}
+
+	for _, key := range groupKeys {
+		duplicates := groups[key]
+		if len(duplicates) < 2 {
+			continue
+		}
+
+		survivor, err := mergeItems(duplicates)
+		if err != nil {
+			return nil, err
+		}
+
+		var mergedIDs []uint
+		for _, duplicate := range duplicates[1:] {
+			mergedIDs = append(mergedIDs, duplicate.ID)
+			removed[duplicate.ID] = true
+		}
+
+		result, err := lis.repository.MergeItems(ctx, survivor, mergedIDs)
+		if err != nil {
+			return nil, err
+		}
+		merged[result.ID] = *result
+	}
+
+	remaining := []models.ListItem{}
+	for _, item := range *items {
+		if removed[item.ID] {
+			continue
+		}
+		if mergedItem, ok := merged[item.ID]; ok {
+			item = mergedItem
+		}
+		remaining = append(remaining, item)
+	}
+
+	return &remaining, nil
+}
+
+// parentKey keeps subtasks apart from top level items and from the subtasks of other items when merging.
+func parentKey(item models.ListItem) string {
+	if item.ParentID == nil {
+		return ""
+	}
+	return fmt.Sprint(*item.ParentID)
+}
+
+// mergeItems adds the quantities of duplicates up into the first of them. The unit price is converted to the
+// resulting unit so the total cost of the item doesn't change.
+func mergeItems(duplicates []models.ListItem) (models.ListItem, error) {
+
+	survivor := duplicates[0]
+	baseUnit := survivor.Unit
+
+	total := decimal.Zero
+	for _, duplicate := range duplicates {
+		quantity := decimal.New(1, 0)
+		if duplicate.Quantity != nil {
+			quantity = *duplicate.Quantity
+		}
+
+		converted, err := models.ConvertQuantity(quantity, duplicate.Unit, baseUnit)
+		if err != nil {
+			return models.ListItem{}, err
+		}
+		total = total.Add(converted)
+
+		if survivor.Description == "" {
+			survivor.Description = duplicate.Description
+		}
+		if survivor.UnitPrice == nil && duplicate.UnitPrice != nil {
+			price, err := pricePerUnit(*duplicate.UnitPrice, duplicate.Unit, baseUnit)
+			if err != nil {
+				return models.ListItem{}, err
+			}
+			survivor.UnitPrice = &price
+		}
+	}
+
+	quantity, unit, err := models.NormalizeQuantity(total, baseUnit)
+	if err != nil {
+		return models.ListItem{}, err
+	}
+
+	if survivor.UnitPrice != nil {
+		price, err := pricePerUnit(*survivor.UnitPrice, baseUnit, unit)
+		if err != nil {
+			return models.ListItem{}, err
+		}
+		survivor.UnitPrice = &price
+	}
+
+	survivor.Quantity = &quantity
+	survivor.Unit = unit
+
+	return survivor, nil
+}
+
+// pricePerUnit converts a price expressed per "from" unit into a price per "to" unit.
+func pricePerUnit(price decimal.Decimal, from string, to string) (decimal.Decimal, error) {
+
+	unitsPerTarget, err := models.ConvertQuantity(decimal.New(1, 0), to, from)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return price.Mul(unitsPerTarget), nil
+}
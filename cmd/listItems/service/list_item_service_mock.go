@@ -6,7 +6,12 @@ package service
 
 import (
 	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/lists/models"
+	bulk "SuperListsAPI/internal/bulk"
+	versions "SuperListsAPI/internal/versions"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,63 +40,155 @@ func (m *MockIListItemRepository) EXPECT() *MockIListItemRepositoryMockRecorder
 }
 
 // BulkDelete mocks base method.
-func (m *MockIListItemRepository) BulkDelete(tasksToDelete []models.ListItem) (*int, error) {
+func (m *MockIListItemRepository) BulkDelete(ctx context.Context, listItemIDs []uint) (*bulk.Result, *versions.UndoToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkDelete", tasksToDelete)
-	ret0, _ := ret[0].(*int)
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, listItemIDs)
+	ret0, _ := ret[0].(*bulk.Result)
+	ret1, _ := ret[1].(*versions.UndoToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// BulkDelete indicates an expected call of BulkDelete.
+func (mr *MockIListItemRepositoryMockRecorder) BulkDelete(ctx, listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListItemRepository)(nil).BulkDelete), ctx, listItemIDs)
+}
+
+// CopyItems mocks base method.
+func (m *MockIListItemRepository) CopyItems(ctx context.Context, listItemIDs []uint, listID, userID uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyItems", ctx, listItemIDs, listID, userID)
+	ret0, _ := ret[0].(*[]models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// BulkDelete indicates an expected call of BulkDelete.
-func (mr *MockIListItemRepositoryMockRecorder) BulkDelete(tasksToDelete interface{}) *gomock.Call {
+// CopyItems indicates an expected call of CopyItems.
+func (mr *MockIListItemRepositoryMockRecorder) CopyItems(ctx, listItemIDs, listID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListItemRepository)(nil).BulkDelete), tasksToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyItems", reflect.TypeOf((*MockIListItemRepository)(nil).CopyItems), ctx, listItemIDs, listID, userID)
+}
+
+// CountChildren mocks base method.
+func (m *MockIListItemRepository) CountChildren(listItemID uint) (*int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountChildren", listItemID)
+	ret0, _ := ret[0].(*int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockIListItemRepositoryMockRecorder) CountChildren(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockIListItemRepository)(nil).CountChildren), listItemID)
 }
 
 // Create mocks base method.
-func (m *MockIListItemRepository) Create(item models.ListItem) (*models.ListItem, error) {
+func (m *MockIListItemRepository) Create(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", item)
+	ret := m.ctrl.Call(m, "Create", ctx, item)
 	ret0, _ := ret[0].(*models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIListItemRepositoryMockRecorder) Create(item interface{}) *gomock.Call {
+func (mr *MockIListItemRepositoryMockRecorder) Create(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListItemRepository)(nil).Create), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListItemRepository)(nil).Create), ctx, item)
+}
+
+// CreateAssignee mocks base method.
+func (m *MockIListItemRepository) CreateAssignee(ctx context.Context, assignee models.ListItemAssignee) (*models.ListItemAssignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAssignee", ctx, assignee)
+	ret0, _ := ret[0].(*models.ListItemAssignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAssignee indicates an expected call of CreateAssignee.
+func (mr *MockIListItemRepositoryMockRecorder) CreateAssignee(ctx, assignee interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAssignee", reflect.TypeOf((*MockIListItemRepository)(nil).CreateAssignee), ctx, assignee)
+}
+
+// CreateItems mocks base method.
+func (m *MockIListItemRepository) CreateItems(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItems", ctx, items)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateItems indicates an expected call of CreateItems.
+func (mr *MockIListItemRepositoryMockRecorder) CreateItems(ctx, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItems", reflect.TypeOf((*MockIListItemRepository)(nil).CreateItems), ctx, items)
+}
+
+// CreateItemsOnNewList mocks base method.
+func (m *MockIListItemRepository) CreateItemsOnNewList(ctx context.Context, list models0.List, items []models.ListItem) (*models0.List, *[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateItemsOnNewList", ctx, list, items)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(*[]models.ListItem)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateItemsOnNewList indicates an expected call of CreateItemsOnNewList.
+func (mr *MockIListItemRepositoryMockRecorder) CreateItemsOnNewList(ctx, list, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateItemsOnNewList", reflect.TypeOf((*MockIListItemRepository)(nil).CreateItemsOnNewList), ctx, list, items)
 }
 
 // Delete mocks base method.
-func (m *MockIListItemRepository) Delete(listItemID string) (*int, error) {
+func (m *MockIListItemRepository) Delete(ctx context.Context, listItemID string) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", listItemID)
+	ret := m.ctrl.Call(m, "Delete", ctx, listItemID)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIListItemRepositoryMockRecorder) Delete(listItemID interface{}) *gomock.Call {
+func (mr *MockIListItemRepositoryMockRecorder) Delete(ctx, listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListItemRepository)(nil).Delete), ctx, listItemID)
+}
+
+// DeleteAssignee mocks base method.
+func (m *MockIListItemRepository) DeleteAssignee(ctx context.Context, listItemID, userID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAssignee", ctx, listItemID, userID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAssignee indicates an expected call of DeleteAssignee.
+func (mr *MockIListItemRepositoryMockRecorder) DeleteAssignee(ctx, listItemID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListItemRepository)(nil).Delete), listItemID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAssignee", reflect.TypeOf((*MockIListItemRepository)(nil).DeleteAssignee), ctx, listItemID, userID)
 }
 
 // DeleteListItemsByListID mocks base method.
-func (m *MockIListItemRepository) DeleteListItemsByListID(listId string) (*int, error) {
+func (m *MockIListItemRepository) DeleteListItemsByListID(ctx context.Context, listId string) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteListItemsByListID", listId)
+	ret := m.ctrl.Call(m, "DeleteListItemsByListID", ctx, listId)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteListItemsByListID indicates an expected call of DeleteListItemsByListID.
-func (mr *MockIListItemRepositoryMockRecorder) DeleteListItemsByListID(listId interface{}) *gomock.Call {
+func (mr *MockIListItemRepositoryMockRecorder) DeleteListItemsByListID(ctx, listId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListItemsByListID", reflect.TypeOf((*MockIListItemRepository)(nil).DeleteListItemsByListID), listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListItemsByListID", reflect.TypeOf((*MockIListItemRepository)(nil).DeleteListItemsByListID), ctx, listId)
 }
 
 // Get mocks base method.
@@ -109,6 +206,66 @@ func (mr *MockIListItemRepositoryMockRecorder) Get(listItemID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListItemRepository)(nil).Get), listItemID)
 }
 
+// GetAssignees mocks base method.
+func (m *MockIListItemRepository) GetAssignees(listItemIDs []uint) (*[]models.ListItemAssignee, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssignees", listItemIDs)
+	ret0, _ := ret[0].(*[]models.ListItemAssignee)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssignees indicates an expected call of GetAssignees.
+func (mr *MockIListItemRepositoryMockRecorder) GetAssignees(listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssignees", reflect.TypeOf((*MockIListItemRepository)(nil).GetAssignees), listItemIDs)
+}
+
+// GetCommentCounts mocks base method.
+func (m *MockIListItemRepository) GetCommentCounts(listItemIDs []uint) (*[]models.CommentCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentCounts", listItemIDs)
+	ret0, _ := ret[0].(*[]models.CommentCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentCounts indicates an expected call of GetCommentCounts.
+func (mr *MockIListItemRepositoryMockRecorder) GetCommentCounts(listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCounts", reflect.TypeOf((*MockIListItemRepository)(nil).GetCommentCounts), listItemIDs)
+}
+
+// GetItemsByFilter mocks base method.
+func (m *MockIListItemRepository) GetItemsByFilter(filter models.ItemFilter) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByFilter", filter)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByFilter indicates an expected call of GetItemsByFilter.
+func (mr *MockIListItemRepositoryMockRecorder) GetItemsByFilter(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByFilter", reflect.TypeOf((*MockIListItemRepository)(nil).GetItemsByFilter), filter)
+}
+
+// GetItemsByIDs mocks base method.
+func (m *MockIListItemRepository) GetItemsByIDs(listItemIDs []uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemsByIDs", listItemIDs)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemsByIDs indicates an expected call of GetItemsByIDs.
+func (mr *MockIListItemRepositoryMockRecorder) GetItemsByIDs(listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsByIDs", reflect.TypeOf((*MockIListItemRepository)(nil).GetItemsByIDs), listItemIDs)
+}
+
 // GetItemsListByListID mocks base method.
 func (m *MockIListItemRepository) GetItemsListByListID(listId string) (*[]models.ListItem, error) {
 	m.ctrl.T.Helper()
@@ -124,47 +281,152 @@ func (mr *MockIListItemRepositoryMockRecorder) GetItemsListByListID(listId inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsListByListID", reflect.TypeOf((*MockIListItemRepository)(nil).GetItemsListByListID), listId)
 }
 
+// GetLastPosition mocks base method.
+func (m *MockIListItemRepository) GetLastPosition(listId string) (*float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastPosition", listId)
+	ret0, _ := ret[0].(*float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastPosition indicates an expected call of GetLastPosition.
+func (mr *MockIListItemRepositoryMockRecorder) GetLastPosition(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastPosition", reflect.TypeOf((*MockIListItemRepository)(nil).GetLastPosition), listId)
+}
+
+// GetOccurrence mocks base method.
+func (m *MockIListItemRepository) GetOccurrence(seriesID uint, dueAt time.Time) (*models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccurrence", seriesID, dueAt)
+	ret0, _ := ret[0].(*models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccurrence indicates an expected call of GetOccurrence.
+func (mr *MockIListItemRepositoryMockRecorder) GetOccurrence(seriesID, dueAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccurrence", reflect.TypeOf((*MockIListItemRepository)(nil).GetOccurrence), seriesID, dueAt)
+}
+
+// GetRecurringItems mocks base method.
+func (m *MockIListItemRepository) GetRecurringItems(listItemIDs []uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecurringItems", listItemIDs)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecurringItems indicates an expected call of GetRecurringItems.
+func (mr *MockIListItemRepositoryMockRecorder) GetRecurringItems(listItemIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecurringItems", reflect.TypeOf((*MockIListItemRepository)(nil).GetRecurringItems), listItemIDs)
+}
+
 // MarkAsCompleted mocks base method.
-func (m *MockIListItemRepository) MarkAsCompleted(tasksToDelete []models.ListItem) (*int, error) {
+func (m *MockIListItemRepository) MarkAsCompleted(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsCompleted", tasksToDelete)
-	ret0, _ := ret[0].(*int)
+	ret := m.ctrl.Call(m, "MarkAsCompleted", ctx, listItemIDs)
+	ret0, _ := ret[0].(*bulk.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkAsCompleted indicates an expected call of MarkAsCompleted.
-func (mr *MockIListItemRepositoryMockRecorder) MarkAsCompleted(tasksToDelete interface{}) *gomock.Call {
+func (mr *MockIListItemRepositoryMockRecorder) MarkAsCompleted(ctx, listItemIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsCompleted", reflect.TypeOf((*MockIListItemRepository)(nil).MarkAsCompleted), tasksToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsCompleted", reflect.TypeOf((*MockIListItemRepository)(nil).MarkAsCompleted), ctx, listItemIDs)
 }
 
 // MarkAsPending mocks base method.
-func (m *MockIListItemRepository) MarkAsPending(tasksToDelete []models.ListItem) (*int, error) {
+func (m *MockIListItemRepository) MarkAsPending(ctx context.Context, listItemIDs []uint) (*bulk.Result, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkAsPending", tasksToDelete)
-	ret0, _ := ret[0].(*int)
+	ret := m.ctrl.Call(m, "MarkAsPending", ctx, listItemIDs)
+	ret0, _ := ret[0].(*bulk.Result)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MarkAsPending indicates an expected call of MarkAsPending.
-func (mr *MockIListItemRepositoryMockRecorder) MarkAsPending(tasksToDelete interface{}) *gomock.Call {
+func (mr *MockIListItemRepositoryMockRecorder) MarkAsPending(ctx, listItemIDs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsPending", reflect.TypeOf((*MockIListItemRepository)(nil).MarkAsPending), tasksToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsPending", reflect.TypeOf((*MockIListItemRepository)(nil).MarkAsPending), ctx, listItemIDs)
 }
 
-// Update mocks base method.
-func (m *MockIListItemRepository) Update(item models.ListItem) (*models.ListItem, error) {
+// MarkChildrenAsCompleted mocks base method.
+func (m *MockIListItemRepository) MarkChildrenAsCompleted(ctx context.Context, parentIDs []uint) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkChildrenAsCompleted", ctx, parentIDs)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkChildrenAsCompleted indicates an expected call of MarkChildrenAsCompleted.
+func (mr *MockIListItemRepositoryMockRecorder) MarkChildrenAsCompleted(ctx, parentIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkChildrenAsCompleted", reflect.TypeOf((*MockIListItemRepository)(nil).MarkChildrenAsCompleted), ctx, parentIDs)
+}
+
+// MergeItems mocks base method.
+func (m *MockIListItemRepository) MergeItems(ctx context.Context, survivor models.ListItem, mergedIDs []uint) (*models.ListItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", item)
+	ret := m.ctrl.Call(m, "MergeItems", ctx, survivor, mergedIDs)
 	ret0, _ := ret[0].(*models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
+// MergeItems indicates an expected call of MergeItems.
+func (mr *MockIListItemRepositoryMockRecorder) MergeItems(ctx, survivor, mergedIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeItems", reflect.TypeOf((*MockIListItemRepository)(nil).MergeItems), ctx, survivor, mergedIDs)
+}
+
+// MoveItems mocks base method.
+func (m *MockIListItemRepository) MoveItems(ctx context.Context, listItemIDs []uint, listID uint) (*[]models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItems", ctx, listItemIDs, listID)
+	ret0, _ := ret[0].(*[]models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveItems indicates an expected call of MoveItems.
+func (mr *MockIListItemRepositoryMockRecorder) MoveItems(ctx, listItemIDs, listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItems", reflect.TypeOf((*MockIListItemRepository)(nil).MoveItems), ctx, listItemIDs, listID)
+}
+
+// Update mocks base method.
+func (m *MockIListItemRepository) Update(ctx context.Context, item models.ListItem) (*models.ListItem, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(*models.ListItem)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
 // Update indicates an expected call of Update.
-func (mr *MockIListItemRepositoryMockRecorder) Update(item interface{}) *gomock.Call {
+func (mr *MockIListItemRepositoryMockRecorder) Update(ctx, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListItemRepository)(nil).Update), ctx, item)
+}
+
+// UpdatePositions mocks base method.
+func (m *MockIListItemRepository) UpdatePositions(ctx context.Context, items []models.ListItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePositions", ctx, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePositions indicates an expected call of UpdatePositions.
+func (mr *MockIListItemRepositoryMockRecorder) UpdatePositions(ctx, items interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListItemRepository)(nil).Update), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePositions", reflect.TypeOf((*MockIListItemRepository)(nil).UpdatePositions), ctx, items)
 }
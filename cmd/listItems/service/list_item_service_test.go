@@ -2,11 +2,17 @@ package service
 
 import (
 	"SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/bulk"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewListItemService(t *testing.T) {
@@ -42,12 +48,18 @@ func TestListItemService_Create(t *testing.T) {
 
 	validListItem := GetValidListItem()
 
+	lastPosition := float64(2048)
+
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Create(gomock.Any()).Return(&validListItem, nil)
+	mockedRepo.EXPECT().GetLastPosition("1").Return(&lastPosition, nil)
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+		assert.Equal(t, lastPosition+models.PositionGap, item.Position)
+		return &item, nil
+	})
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.Create(validListItem)
+	result, err := listItemService.Create(context.Background(), validListItem)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -58,12 +70,15 @@ func TestListItemService_Create_Error(t *testing.T) {
 
 	validListItem := GetValidListItem()
 
+	lastPosition := float64(0)
+
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from list item repo"))
+	mockedRepo.EXPECT().GetLastPosition(gomock.Any()).Return(&lastPosition, nil)
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item repo"))
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.Create(validListItem)
+	result, err := listItemService.Create(context.Background(), validListItem)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -76,6 +91,8 @@ func TestListItemService_Get(t *testing.T) {
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
 	mockedRepo.EXPECT().Get(gomock.Any()).Return(&validListItem, nil)
+	mockedRepo.EXPECT().GetAssignees(gomock.Any()).Return(&[]models.ListItemAssignee{}, nil)
+	mockedRepo.EXPECT().GetCommentCounts(gomock.Any()).Return(&[]models.CommentCount{}, nil)
 
 	listItemService := NewListItemService(mockedRepo)
 
@@ -103,11 +120,11 @@ func TestListItemService_Delete(t *testing.T) {
 	deletedListItemID := 1
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Delete(gomock.Any()).Return(&deletedListItemID, nil)
+	mockedRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedListItemID, nil)
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.Delete("1")
+	result, err := listItemService.Delete(context.Background(), "1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -116,11 +133,11 @@ func TestListItemService_Delete(t *testing.T) {
 func TestListItemService_Delete_Error(t *testing.T) {
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from list item repo"))
+	mockedRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item repo"))
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.Delete("1")
+	result, err := listItemService.Delete(context.Background(), "1")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -130,11 +147,11 @@ func TestListItemService_Update(t *testing.T) {
 	validListItem := GetValidListItem()
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Update(gomock.Any()).Return(&validListItem, nil)
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&validListItem, false, nil)
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.Update(validListItem)
+	result, err := listItemService.Update(context.Background(), validListItem)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -144,11 +161,11 @@ func TestListItemService_Update_Error(t *testing.T) {
 	validListItem := GetValidListItem()
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Update(gomock.Any()).Return(nil, errors.New("error from list item repo"))
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, false, errors.New("error from list item repo"))
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.Update(validListItem)
+	result, err := listItemService.Update(context.Background(), validListItem)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -160,6 +177,8 @@ func TestListItemService_GetItemsListByListID(t *testing.T) {
 	items := []models.ListItem{validListItem}
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
 	mockedRepo.EXPECT().GetItemsListByListID(gomock.Any()).Return(&items, nil)
+	mockedRepo.EXPECT().GetAssignees(gomock.Any()).Return(&[]models.ListItemAssignee{}, nil)
+	mockedRepo.EXPECT().GetCommentCounts(gomock.Any()).Return(&[]models.CommentCount{}, nil)
 
 	listItemService := NewListItemService(mockedRepo)
 
@@ -186,11 +205,11 @@ func TestListItemService_DeleteListItemsByListID(t *testing.T) {
 	deletedListItemsQty := 1
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().DeleteListItemsByListID(gomock.Any()).Return(&deletedListItemsQty, nil)
+	mockedRepo.EXPECT().DeleteListItemsByListID(gomock.Any(), gomock.Any()).Return(&deletedListItemsQty, nil)
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.DeleteListItemsByListID("1")
+	result, err := listItemService.DeleteListItemsByListID(context.Background(), "1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -199,11 +218,280 @@ func TestListItemService_DeleteListItemsByListID(t *testing.T) {
 func TestListItemService_DeleteListItemsByListID_Error(t *testing.T) {
 
 	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().DeleteListItemsByListID(gomock.Any()).Return(nil, errors.New("error from list item repository"))
+	mockedRepo.EXPECT().DeleteListItemsByListID(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item repository"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.DeleteListItemsByListID(context.Background(), "1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_Create_With_Position(t *testing.T) {
+
+	validListItem := GetValidListItem()
+	validListItem.Position = 10
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validListItem, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Create(context.Background(), validListItem)
+
+	assert.NoError(t, err)
+	assert.Equal(t, float64(10), result.Position)
+}
+
+func TestListItemService_Create_Guesses_Category(t *testing.T) {
+
+	validListItem := GetValidListItem()
+	validListItem.Title = "Tomates"
+	validListItem.Position = 10
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+		return &item, nil
+	})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Create(context.Background(), validListItem)
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.CategoryProduce, result.Category)
+}
+
+func TestListItemService_Create_Keeps_Category(t *testing.T) {
+
+	validListItem := GetValidListItem()
+	validListItem.Title = "Tomates"
+	validListItem.Category = "verduleria"
+	validListItem.Position = 10
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+		return &item, nil
+	})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Create(context.Background(), validListItem)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "verduleria", result.Category)
+}
+
+func TestListItemService_Create_LastPosition_Error(t *testing.T) {
+
+	validListItem := GetValidListItem()
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetLastPosition(gomock.Any()).Return(nil, errors.New("error from list item repo"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Create(context.Background(), validListItem)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_Reorder(t *testing.T) {
+	tests := []struct {
+		name          string
+		positions     []float64
+		moves         []models.ItemMove
+		wantOrder     []uint
+		wantPositions []float64
+		wantUpdated   int
+	}{
+		{
+			name:          "Move to the top",
+			positions:     []float64{1024, 2048, 3072},
+			moves:         []models.ItemMove{{ItemID: 3, AfterID: 0}},
+			wantOrder:     []uint{3, 1, 2},
+			wantPositions: []float64{0, 1024, 2048},
+			wantUpdated:   1,
+		},
+		{
+			name:          "Move to the bottom",
+			positions:     []float64{1024, 2048, 3072},
+			moves:         []models.ItemMove{{ItemID: 1, AfterID: 3}},
+			wantOrder:     []uint{2, 3, 1},
+			wantPositions: []float64{2048, 3072, 4096},
+			wantUpdated:   1,
+		},
+		{
+			name:          "Move between two items",
+			positions:     []float64{1024, 2048, 3072},
+			moves:         []models.ItemMove{{ItemID: 3, AfterID: 1}},
+			wantOrder:     []uint{1, 3, 2},
+			wantPositions: []float64{1024, 1536, 2048},
+			wantUpdated:   1,
+		},
+		{
+			name:          "Move without room rebalances the list",
+			positions:     []float64{1, 1 + 1e-7, 3},
+			moves:         []models.ItemMove{{ItemID: 3, AfterID: 1}},
+			wantOrder:     []uint{1, 3, 2},
+			wantPositions: []float64{1024, 2048, 3072},
+			wantUpdated:   3,
+		},
+		{
+			name:          "Several moves",
+			positions:     []float64{1024, 2048, 3072},
+			moves:         []models.ItemMove{{ItemID: 1, AfterID: 3}, {ItemID: 2, AfterID: 1}},
+			wantOrder:     []uint{3, 1, 2},
+			wantPositions: []float64{3072, 4096, 5120},
+			wantUpdated:   2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var items []models.ListItem
+			for i, position := range tt.positions {
+				item := GetValidListItem()
+				item.ID = uint(i + 1)
+				item.Position = position
+				items = append(items, item)
+			}
+
+			mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+			mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil)
+			mockedRepo.EXPECT().UpdatePositions(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, updated []models.ListItem) error {
+				assert.Len(t, updated, tt.wantUpdated)
+				return nil
+			})
+
+			listItemService := NewListItemService(mockedRepo)
+
+			result, err := listItemService.Reorder(context.Background(), "1", tt.moves)
+
+			assert.NoError(t, err)
+			for i, item := range *result {
+				assert.Equal(t, tt.wantOrder[i], item.ID)
+				assert.Equal(t, tt.wantPositions[i], item.Position)
+			}
+		})
+	}
+}
+
+func TestListItemService_Reorder_Item_Not_In_List(t *testing.T) {
+
+	items := []models.ListItem{GetValidListItem()}
+	items[0].ID = 1
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Reorder(context.Background(), "1", []models.ItemMove{{ItemID: 5}})
+
+	assert.ErrorIs(t, err, models.ErrItemNotInList)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_Reorder_After_Itself(t *testing.T) {
+
+	items := []models.ListItem{GetValidListItem()}
+	items[0].ID = 1
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Reorder(context.Background(), "1", []models.ItemMove{{ItemID: 1, AfterID: 1}})
+
+	assert.ErrorIs(t, err, models.ErrMoveAfterItself)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_Reorder_Error(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(nil, errors.New("error from list item repository"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Reorder(context.Background(), "1", []models.ItemMove{{ItemID: 1}})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_MergeDuplicates(t *testing.T) {
+
+	grams := decimal.RequireFromString("500")
+	kilos := decimal.RequireFromString("1")
+	pricePerKilo := decimal.RequireFromString("1200")
+	litres := decimal.RequireFromString("1")
+
+	items := []models.ListItem{
+		{Title: "Tomates", Quantity: &grams, Unit: models.UnitGram},
+		{Title: "Leche", Quantity: &litres, Unit: models.UnitLitre},
+		{Title: "tomates ", Quantity: &kilos, Unit: models.UnitKilogram, UnitPrice: &pricePerKilo, Description: "perita"},
+		{Title: "Leche", IsDone: true},
+		{Title: "Pan"},
+		{Title: "pan"},
+	}
+	for i := range items {
+		items[i].ID = uint(i + 1)
+	}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil)
+	mockedRepo.EXPECT().MergeItems(gomock.Any(), gomock.Any(), []uint{3}).DoAndReturn(func(_ context.Context, survivor models.ListItem, mergedIDs []uint) (*models.ListItem, error) {
+		assert.Equal(t, uint(1), survivor.ID)
+		assert.Equal(t, models.UnitKilogram, survivor.Unit)
+		assert.Equal(t, "1.5", survivor.Quantity.String())
+		assert.Equal(t, "1200", survivor.UnitPrice.String())
+		assert.Equal(t, "perita", survivor.Description)
+		return &survivor, nil
+	})
+	mockedRepo.EXPECT().MergeItems(gomock.Any(), gomock.Any(), []uint{6}).DoAndReturn(func(_ context.Context, survivor models.ListItem, mergedIDs []uint) (*models.ListItem, error) {
+		assert.Equal(t, uint(5), survivor.ID)
+		assert.Equal(t, models.UnitPiece, survivor.Unit)
+		assert.Equal(t, "2", survivor.Quantity.String())
+		return &survivor, nil
+	})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.MergeDuplicates(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 4)
+	assert.Equal(t, "1.5", (*result)[0].Quantity.String())
+}
+
+func TestListItemService_MergeDuplicates_Error(t *testing.T) {
+
+	items := []models.ListItem{{Title: "Pan"}, {Title: "Pan"}}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil)
+	mockedRepo.EXPECT().MergeItems(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list item repository"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.MergeDuplicates(context.Background(), "1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_MergeDuplicates_Get_Error(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(nil, errors.New("error from list item repository"))
 
 	listItemService := NewListItemService(mockedRepo)
 
-	result, err := listItemService.DeleteListItemsByListID("1")
+	result, err := listItemService.MergeDuplicates(context.Background(), "1")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -218,3 +506,577 @@ func GetValidListItem() models.ListItem {
 		IsDone:      false,
 	}
 }
+
+func TestListItemService_GetItemsByFilter_Attaches_Assignees(t *testing.T) {
+
+	first := GetValidListItem()
+	first.ID = 1
+	second := GetValidListItem()
+	second.ID = 2
+	items := []models.ListItem{first, second}
+	filter := models.ItemFilter{AssigneeID: "7", MemberID: "7", Pending: true}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsByFilter(filter).Return(&items, nil)
+	mockedRepo.EXPECT().GetAssignees([]uint{1, 2}).Return(&[]models.ListItemAssignee{
+		{ListItemID: 1, UserID: 7},
+		{ListItemID: 1, UserID: 8},
+		{ListItemID: 2, UserID: 7},
+	}, nil)
+	mockedRepo.EXPECT().GetCommentCounts([]uint{1, 2}).Return(&[]models.CommentCount{{ListItemID: 2, Count: 3}}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.GetItemsByFilter(filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{7, 8}, (*result)[0].Assignees)
+	assert.Equal(t, []uint{7}, (*result)[1].Assignees)
+	assert.Equal(t, 0, (*result)[0].CommentCount)
+	assert.Equal(t, 3, (*result)[1].CommentCount)
+}
+
+func TestListItemService_GetItemsByFilter_Assignees_Error(t *testing.T) {
+
+	items := []models.ListItem{GetValidListItem()}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsByFilter(gomock.Any()).Return(&items, nil)
+	mockedRepo.EXPECT().GetAssignees(gomock.Any()).Return(nil, errors.New("error from list item repo"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.GetItemsByFilter(models.ItemFilter{ListID: "1"})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_GetItemsByFilter_Empty(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsByFilter(gomock.Any()).Return(&[]models.ListItem{}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.GetItemsByFilter(models.ItemFilter{ListID: "1"})
+
+	assert.NoError(t, err)
+	assert.Empty(t, *result)
+}
+
+func TestListItemService_Assign(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().CreateAssignee(gomock.Any(), models.ListItemAssignee{ListItemID: 1, UserID: 7, AssignedBy: 3}).
+		Return(&models.ListItemAssignee{ID: 1, ListItemID: 1, UserID: 7, AssignedBy: 3}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Assign(context.Background(), 1, 7, 3)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), result.UserID)
+}
+
+func TestListItemService_Unassign(t *testing.T) {
+	deleted := 1
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().DeleteAssignee(gomock.Any(), "1", "7").Return(&deleted, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Unassign(context.Background(), "1", "7")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestListItemService_Update_Completion_Time(t *testing.T) {
+	completedAt := time.Date(2024, time.March, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		isDone      bool
+		completedAt *time.Time
+		check       func(t *testing.T, item models.ListItem)
+	}{
+		{
+			name:   "Done item gets its completion time",
+			isDone: true,
+			check: func(t *testing.T, item models.ListItem) {
+				assert.NotNil(t, item.CompletedAt)
+			},
+		},
+		{
+			name:        "Completion time is not taken from the request",
+			isDone:      true,
+			completedAt: &completedAt,
+			check: func(t *testing.T, item models.ListItem) {
+				assert.NotEqual(t, completedAt, *item.CompletedAt)
+			},
+		},
+		{
+			name:        "Pending item has no completion time",
+			completedAt: &completedAt,
+			check: func(t *testing.T, item models.ListItem) {
+				assert.Nil(t, item.CompletedAt)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := GetValidListItem()
+			item.IsDone = tt.isDone
+			item.CompletedAt = tt.completedAt
+
+			mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+			mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, bool, error) {
+				return &item, false, nil
+			})
+
+			listItemService := NewListItemService(mockedRepo)
+
+			result, err := listItemService.Update(context.Background(), item)
+
+			assert.NoError(t, err)
+			tt.check(t, *result)
+		})
+	}
+}
+
+func TestListItemService_GetDueToday(t *testing.T) {
+	buenosAires := time.FixedZone("ART", -3*60*60)
+	now := time.Date(2024, time.March, 13, 22, 0, 0, 0, buenosAires)
+	dayStart := time.Date(2024, time.March, 13, 0, 0, 0, 0, buenosAires)
+	nextDayStart := dayStart.AddDate(0, 0, 1)
+
+	evening := time.Date(2024, time.March, 13, 20, 0, 0, 0, buenosAires)
+	morning := time.Date(2024, time.March, 13, 9, 0, 0, 0, buenosAires)
+
+	low := GetValidListItem()
+	low.ID, low.DueAt, low.Priority = 1, &evening, models.PriorityLow
+	urgent := GetValidListItem()
+	urgent.ID, urgent.DueAt, urgent.Priority = 2, &evening, models.PriorityUrgent
+	early := GetValidListItem()
+	early.ID, early.DueAt = 3, &morning
+	items := []models.ListItem{low, urgent, early}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsByFilter(models.ItemFilter{MemberID: "7", Pending: true, DueFrom: &dayStart, DueBefore: &nextDayStart}).Return(&items, nil)
+	mockedRepo.EXPECT().GetAssignees(gomock.Any()).Return(&[]models.ListItemAssignee{}, nil)
+	mockedRepo.EXPECT().GetCommentCounts(gomock.Any()).Return(&[]models.CommentCount{}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.GetDueToday("7", now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), (*result)[0].ID)
+	assert.Equal(t, uint(2), (*result)[1].ID)
+	assert.Equal(t, uint(1), (*result)[2].ID)
+}
+
+func TestListItemService_GetUpcoming(t *testing.T) {
+	now := time.Date(2024, time.March, 13, 15, 30, 0, 0, time.UTC)
+	from := time.Date(2024, time.March, 14, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2024, time.March, 17, 0, 0, 0, 0, time.UTC)
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsByFilter(models.ItemFilter{MemberID: "7", Pending: true, DueFrom: &from, DueBefore: &until}).Return(&[]models.ListItem{}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.GetUpcoming("7", now, 3)
+
+	assert.NoError(t, err)
+	assert.Empty(t, *result)
+}
+
+func TestListItemService_GetOverdue(t *testing.T) {
+	now := time.Date(2024, time.March, 13, 15, 30, 0, 0, time.UTC)
+	cutoff := models.OverdueCutoff{Now: now, DayStart: time.Date(2024, time.March, 13, 0, 0, 0, 0, time.UTC)}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsByFilter(models.ItemFilter{MemberID: "7", Pending: true, Overdue: &cutoff}).Return(nil, errors.New("error from list item repo"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.GetOverdue("7", now)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_Update_Creates_Next_Occurrence(t *testing.T) {
+
+	// Due on a past tuesday, weekly on tuesdays and fridays. Completing it late skips the past occurrences.
+	dueAt := time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+	assignee := models.ListItemAssignee{ListItemID: 5, UserID: 7, AssignedBy: 1}
+
+	item := GetValidListItem()
+	item.ID = 5
+	item.IsDone = true
+	item.DueAt = &dueAt
+	item.Position = 2048
+	item.Recurrence = "FREQ=WEEKLY;BYDAY=TU,FR"
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, bool, error) {
+		return &item, true, nil
+	})
+	mockedRepo.EXPECT().GetOccurrence(uint(5), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, next models.ListItem) (*models.ListItem, error) {
+		assert.False(t, next.IsDone)
+		assert.Nil(t, next.CompletedAt)
+		assert.Equal(t, uint(5), *next.SeriesID)
+		assert.Equal(t, float64(2048), next.Position)
+		assert.True(t, next.DueAt.After(time.Now()))
+		assert.Contains(t, []time.Weekday{time.Tuesday, time.Friday}, next.DueAt.Weekday())
+		assert.Equal(t, 9, next.DueAt.Hour())
+		next.ID = 6
+		return &next, nil
+	})
+	mockedRepo.EXPECT().GetAssignees([]uint{5}).Return(&[]models.ListItemAssignee{assignee}, nil)
+	mockedRepo.EXPECT().CreateAssignee(gomock.Any(), models.ListItemAssignee{ListItemID: 6, UserID: 7, AssignedBy: 1}).Return(&assignee, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	_, err := listItemService.Update(context.Background(), item)
+
+	assert.NoError(t, err)
+}
+
+func TestListItemService_Update_Done_Item_After_Next_Due_Time(t *testing.T) {
+
+	seriesID := uint(3)
+	dueAt := time.Now().UTC().AddDate(0, 0, -3).Truncate(time.Second)
+
+	item := GetValidListItem()
+	item.ID = 5
+	item.IsDone = true
+	item.DueAt = &dueAt
+	item.SeriesID = &seriesID
+	item.Recurrence = "FREQ=DAILY"
+	item.Description = "Fixed description"
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, bool, error) {
+		return &item, false, nil
+	})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Update(context.Background(), item)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Fixed description", result.Description)
+}
+
+func TestListItemService_Update_Existing_Occurrence_Is_Kept(t *testing.T) {
+
+	seriesID := uint(3)
+	dueAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	item := GetValidListItem()
+	item.ID = 5
+	item.IsDone = true
+	item.DueAt = &dueAt
+	item.SeriesID = &seriesID
+	item.Recurrence = "FREQ=DAILY"
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, bool, error) {
+		return &item, true, nil
+	})
+	mockedRepo.EXPECT().GetOccurrence(uint(3), dueAt.AddDate(0, 0, 1)).Return(&models.ListItem{}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	_, err := listItemService.Update(context.Background(), item)
+
+	assert.NoError(t, err)
+}
+
+func TestListItemService_MarkAsCompleted_Creates_Next_Occurrences(t *testing.T) {
+
+	completed := bulk.Result{Succeeded: []uint{2, 3}, Unchanged: []uint{1}}
+	dueAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+
+	recurring := GetValidListItem()
+	recurring.ID = 2
+	recurring.IsDone = true
+	recurring.DueAt = &dueAt
+	recurring.Position = 1024
+	recurring.Recurrence = "FREQ=MONTHLY;UNTIL=20000101"
+
+	ended := recurring
+	ended.ID = 3
+
+	recurring.Recurrence = "FREQ=DAILY"
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().MarkAsCompleted(gomock.Any(), gomock.Any()).Return(&completed, nil)
+	mockedRepo.EXPECT().GetRecurringItems([]uint{2, 3}).Return(&[]models.ListItem{recurring, ended}, nil)
+	mockedRepo.EXPECT().GetOccurrence(uint(2), dueAt.AddDate(0, 0, 1)).Return(nil, gorm.ErrRecordNotFound)
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, next models.ListItem) (*models.ListItem, error) {
+		next.ID = 4
+		return &next, nil
+	})
+	mockedRepo.EXPECT().GetAssignees([]uint{2}).Return(&[]models.ListItemAssignee{}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.MarkAsCompleted(context.Background(), []uint{1, 2, 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &completed, result)
+}
+
+func TestListItemService_MarkAsCompleted_Nothing_Completed(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().MarkAsCompleted(gomock.Any(), []uint{1}).Return(&bulk.Result{Unchanged: []uint{1}}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.MarkAsCompleted(context.Background(), []uint{1})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{1}, result.Unchanged)
+}
+
+func TestListItemService_Create_Subtask(t *testing.T) {
+
+	parentID := uint(3)
+	subtask := GetValidListItem()
+	subtask.ParentID = &parentID
+	subtask.Position = 1024
+
+	parent := GetValidListItem()
+	parent.ID = parentID
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("3").Return(&parent, nil)
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, item models.ListItem) (*models.ListItem, error) {
+		return &item, nil
+	})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Create(context.Background(), subtask)
+
+	assert.NoError(t, err)
+	assert.Equal(t, parentID, *result.ParentID)
+}
+
+func TestListItemService_Create_Invalid_Parent(t *testing.T) {
+
+	grandParentID := uint(1)
+	otherList := GetValidListItem()
+	otherList.ListID = 2
+	nested := GetValidListItem()
+	nested.ParentID = &grandParentID
+
+	tests := []struct {
+		name   string
+		parent *models.ListItem
+		err    error
+	}{
+		{name: "Parent on another list", parent: &otherList},
+		{name: "Parent is a subtask", parent: &nested},
+		{name: "Parent does not exist", err: gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parentID := uint(3)
+			subtask := GetValidListItem()
+			subtask.ParentID = &parentID
+			subtask.Position = 1024
+
+			mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+			mockedRepo.EXPECT().Get("3").Return(tt.parent, tt.err)
+
+			listItemService := NewListItemService(mockedRepo)
+
+			result, err := listItemService.Create(context.Background(), subtask)
+
+			assert.ErrorIs(t, err, models.ErrInvalidParent)
+			assert.Nil(t, result)
+		})
+	}
+}
+
+func TestListItemService_Update_Parent_Of_Itself(t *testing.T) {
+
+	item := GetValidListItem()
+	item.ID = 3
+	item.ParentID = &item.ID
+
+	listItemService := NewListItemService(NewMockIListItemRepository(gomock.NewController(t)))
+
+	result, err := listItemService.Update(context.Background(), item)
+
+	assert.ErrorIs(t, err, models.ErrInvalidParent)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_Update_Item_With_Subtasks_Cannot_Be_Nested(t *testing.T) {
+
+	parentID := uint(3)
+	item := GetValidListItem()
+	item.ID = 4
+	item.ParentID = &parentID
+
+	parent := GetValidListItem()
+	parent.ID = parentID
+	children := int64(2)
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("3").Return(&parent, nil)
+	mockedRepo.EXPECT().CountChildren(uint(4)).Return(&children, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.Update(context.Background(), item)
+
+	assert.ErrorIs(t, err, models.ErrInvalidParent)
+	assert.Nil(t, result)
+}
+
+func TestListItemService_CompleteChildren(t *testing.T) {
+
+	completed := 2
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().MarkChildrenAsCompleted(gomock.Any(), []uint{1, 2}).Return(&completed, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.CompleteChildren(context.Background(), []uint{1, 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *result)
+}
+
+func TestListItemService_MergeDuplicates_Keeps_Subtasks_Apart(t *testing.T) {
+
+	parentID := uint(1)
+	items := []models.ListItem{
+		{Title: "Asado"},
+		{Title: "Pan"},
+		{Title: "Pan", ParentID: &parentID},
+	}
+	for i := range items {
+		items[i].ID = uint(i + 1)
+	}
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemsListByListID("1").Return(&items, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.MergeDuplicates(context.Background(), "1")
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 3)
+}
+
+func TestListItemService_MoveItems_Attaches_Assignees(t *testing.T) {
+
+	moved := GetValidListItem()
+	moved.ID = 1
+	moved.ListID = 9
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().MoveItems(gomock.Any(), []uint{1}, uint(9)).Return(&[]models.ListItem{moved}, nil)
+	mockedRepo.EXPECT().GetAssignees([]uint{1}).Return(&[]models.ListItemAssignee{{ListItemID: 1, UserID: 7}}, nil)
+	mockedRepo.EXPECT().GetCommentCounts([]uint{1}).Return(&[]models.CommentCount{{ListItemID: 1, Count: 2}}, nil)
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.MoveItems(context.Background(), []uint{1}, 9)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{7}, (*result)[0].Assignees)
+	assert.Equal(t, 2, (*result)[0].CommentCount)
+}
+
+func TestListItemService_CopyItems_Error(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().CopyItems(gomock.Any(), []uint{1}, uint(9), uint(4)).Return(nil, errors.New("error from repository"))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.CopyItems(context.Background(), []uint{1}, 9, 4)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestListItemService_CreateItems_Prepares_Items(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().CreateItems(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, items []models.ListItem) (*[]models.ListItem, error) {
+			return &items, nil
+		})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.CreateItems(context.Background(), []models.ListItem{
+		{ListID: 9, Title: "Tomates", Children: []models.ListItem{{ListID: 9, Title: "Leche", Recurrence: "rrule:freq=weekly"}}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.CategoryProduce, (*result)[0].Category)
+	assert.Equal(t, models.CategoryDairy, (*result)[0].Children[0].Category)
+	assert.Equal(t, "FREQ=WEEKLY", (*result)[0].Children[0].Recurrence)
+}
+
+func TestListItemService_CreateItemsOnNewList(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().CreateItemsOnNewList(gomock.Any(), listModels.List{Name: "Compras", UserCreatorID: 4}, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, list listModels.List, items []models.ListItem) (*listModels.List, *[]models.ListItem, error) {
+			list.ID = 9
+			return &list, &items, nil
+		})
+
+	listItemService := NewListItemService(mockedRepo)
+
+	list, result, err := listItemService.CreateItemsOnNewList(context.Background(), listModels.List{Name: "Compras", UserCreatorID: 4},
+		[]models.ListItem{{Title: "Tomates"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(9), list.ID)
+	assert.Equal(t, models.CategoryProduce, (*result)[0].Category)
+}
+
+func TestListItemService_CreateItemsOnNewList_Invalid_Recurrence(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	list, result, err := listItemService.CreateItemsOnNewList(context.Background(), listModels.List{Name: "Compras", UserCreatorID: 4},
+		[]models.ListItem{{Title: "Paint", Recurrence: "every day"}})
+
+	assert.Nil(t, list)
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestListItemService_CreateItems_Invalid_Recurrence(t *testing.T) {
+
+	mockedRepo := NewMockIListItemRepository(gomock.NewController(t))
+
+	listItemService := NewListItemService(mockedRepo)
+
+	result, err := listItemService.CreateItems(context.Background(), []models.ListItem{{ListID: 9, Title: "Paint", Recurrence: "every day"}})
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
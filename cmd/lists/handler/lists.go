@@ -3,53 +3,66 @@ package handler
 import (
 	listItemModels "SuperListsAPI/cmd/listItems/models"
 	"SuperListsAPI/cmd/lists/models"
+	storeProfileModels "SuperListsAPI/cmd/storeProfiles/models"
 	userListsModel "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/httputil"
+	"SuperListsAPI/internal/versions"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 //go:generate mockgen -source=lists.go -destination lists_mock.go -package handler
 
 type IListService interface {
-	Create(list models.List) (*models.List, error)
-	GetLists(userId string) (*[]models.List, error)
+	Create(ctx context.Context, list models.List) (*models.List, error)
+	GetLists(userId string, includes models.ListIncludes) (*[]models.List, error)
 	Get(listId string) (*models.List, error)
-	Update(list models.List) (*models.List, error)
-	Delete(listID string) (*string, error)
+	GetWithIncludes(listID string, includes models.ListIncludes) (*models.List, error)
+	Update(ctx context.Context, list models.List) (*models.List, error)
+	Delete(ctx context.Context, listID string) (*string, error)
 	GetListByInvitationCode(invitationCode string) (*models.List, error)
-	BulkDelete(listsToDelete []models.List) (*int, error)
+	BulkDelete(ctx context.Context, listIDs []uint, userID uint) (*bulk.Result, *versions.UndoToken, error)
+	GetCompletionStats(userID string, now time.Time, days int, interval string) (*models.CompletionStats, error)
 }
 
 type IUserListService interface {
-	Create(list userListsModel.UserList) (*userListsModel.UserList, error)
+	Create(ctx context.Context, list userListsModel.UserList) (*userListsModel.UserList, error)
 	Get(userListID string) (*userListsModel.UserList, error)
-	Delete(userListID *[]uint) (*int, error)
+	Delete(ctx context.Context, userListID *[]uint) (*int, error)
 	GetUserListsByUserID(userId string) (*[]userListsModel.UserList, error)
 	GetUserListsByListID(listID string) (*[]userListsModel.UserList, error)
 }
 
 type IListItemService interface {
-	Create(item listItemModels.ListItem) (*listItemModels.ListItem, error)
+	Create(ctx context.Context, item listItemModels.ListItem) (*listItemModels.ListItem, error)
 	Get(listItemID string) (*listItemModels.ListItem, error)
-	Update(item listItemModels.ListItem) (*listItemModels.ListItem, error)
-	Delete(listItemID string) (*int, error)
-	GetItemsListByListID(listId string) (*[]listItemModels.ListItem, error)
-	DeleteListItemsByListID(listId string) (*int, error)
+	Update(ctx context.Context, item listItemModels.ListItem) (*listItemModels.ListItem, error)
+	Delete(ctx context.Context, listItemID string) (*int, error)
+	DeleteListItemsByListID(ctx context.Context, listId string) (*int, error)
+}
+
+type IStoreProfileService interface {
+	Find(userID string, reference string) (*storeProfileModels.StoreProfile, error)
 }
 
 type ListHandler struct {
-	listService      IListService
-	userListsService IUserListService
-	listItemsService IListItemService
+	listService         IListService
+	userListsService    IUserListService
+	listItemsService    IListItemService
+	storeProfileService IStoreProfileService
 }
 
-func NewListHandler(service IListService, userListService IUserListService, listItemsService IListItemService) ListHandler {
-	return ListHandler{listService: service, userListsService: userListService, listItemsService: listItemsService}
+func NewListHandler(service IListService, userListService IUserListService, listItemsService IListItemService, storeProfileService IStoreProfileService) ListHandler {
+	return ListHandler{listService: service, userListsService: userListService, listItemsService: listItemsService, storeProfileService: storeProfileService}
 }
 
 func (lh *ListHandler) Create(c *gin.Context) {
@@ -75,7 +88,11 @@ func (lh *ListHandler) Create(c *gin.Context) {
 		return
 	}
 
-	result, err := lh.listService.Create(list)
+	if !httputil.ValidRecurrence(c, list.Recurrence) {
+		return
+	}
+
+	result, err := lh.listService.Create(c.Request.Context(), list)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -87,7 +104,7 @@ func (lh *ListHandler) Create(c *gin.Context) {
 		UserID: result.UserCreatorID,
 	}
 
-	_, err = lh.userListsService.Create(userList)
+	_, err = lh.userListsService.Create(c.Request.Context(), userList)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -119,7 +136,12 @@ func (lh *ListHandler) GetLists(c *gin.Context) {
 		return
 	}
 
-	lists, err := lh.listService.GetLists(userID)
+	includes, ok := requestIncludes(c, models.ListIncludes{})
+	if !ok {
+		return
+	}
+
+	lists, err := lh.listService.GetLists(userID, includes)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -147,26 +169,101 @@ func (lh *ListHandler) Get(c *gin.Context) {
 		return
 	}
 
-	list, err := lh.listService.Get(listID)
+	// With ?store=<profile id or name> items are also returned grouped by aisle, following that store profile.
+	var storeProfile *storeProfileModels.StoreProfile
+	if store := c.Query("store"); store != "" {
+		userID := c.Request.Header.Get("user_id")
 
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, err)
+		if _, err := strconv.Atoi(userID); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid user id",
+			})
+			c.Abort()
+			return
+		}
+
+		profile, err := lh.storeProfileService.Find(userID, store)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, fmt.Sprintf("Store profile %s not found", store))
+			return
+		}
+
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, err)
+			return
+		}
+
+		storeProfile = profile
+	}
+
+	// Items are read unless ?include= leaves them out
+	includes, ok := requestIncludes(c, models.ListIncludes{Items: true})
+	if !ok {
 		return
 	}
 
-	if list == nil {
+	// ?assignee=<user id> or ?assignee=me only returns the items assigned to that user
+	if assignee := c.Query("assignee"); assignee != "" {
+		if assignee == "me" {
+			assignee = c.Request.Header.Get("user_id")
+		}
+
+		if _, err := strconv.Atoi(assignee); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid assignee",
+			})
+			c.Abort()
+			return
+		}
+
+		includes.ItemAssignee = assignee
+	}
+
+	// Members are only shown to the other members of the list
+	var callerID int
+	if includes.Members {
+		userID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid user id",
+			})
+			c.Abort()
+			return
+		}
+		callerID = userID
+	}
+
+	list, err := lh.listService.GetWithIncludes(listID, includes)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
 		c.JSON(http.StatusNotFound, fmt.Sprintf("List with id %s not found", listID))
 		return
 	}
 
-	listItems, err := lh.listItemsService.GetItemsListByListID(fmt.Sprint(list.ID))
-
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
 		return
 	}
 
-	list.ListItems = *listItems
+	if includes.Members && !userListsModel.IsMember(list.Members, uint(callerID)) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": listItemModels.ErrNotListMember.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	if includes.Items {
+		// Totals count every item, subtasks only show up nested under their parent
+		listItems := list.ListItems
+		list.ListItems = listItemModels.NestItems(listItems)
+		list.Totals = listItemModels.ComputeTotals(listItems)
+
+		if storeProfile != nil {
+			list.ItemGroups = listItemModels.GroupByCategory(list.ListItems, storeProfile.CategoryOrder)
+		}
+	}
 
 	c.JSON(http.StatusOK, list)
 	return
@@ -215,7 +312,11 @@ func (lh *ListHandler) Update(c *gin.Context) {
 		return
 	}
 
-	list, err := lh.listService.Update(listUpdateRequest)
+	if !httputil.ValidRecurrence(c, listUpdateRequest.Recurrence) {
+		return
+	}
+
+	list, err := lh.listService.Update(c.Request.Context(), listUpdateRequest)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -272,7 +373,7 @@ func (lh *ListHandler) Delete(c *gin.Context) {
 	idsToDelete = UserListsToDelete(*userListsByListID, parsedUserID, list.UserCreatorID == uint(parsedUserID))
 	//Esto borra el list si sos el owner
 	if list.UserCreatorID == uint(parsedUserID) {
-		_, err := lh.listService.Delete(listID)
+		_, err := lh.listService.Delete(c.Request.Context(), listID)
 
 		if err != nil {
 			log.Print(fmt.Sprintf("Error deleting list with id: %s", listID))
@@ -282,7 +383,7 @@ func (lh *ListHandler) Delete(c *gin.Context) {
 	}
 
 	//Borro los userLists correspondientes
-	deletedUserListsQty, err := lh.userListsService.Delete(&idsToDelete)
+	deletedUserListsQty, err := lh.userListsService.Delete(c.Request.Context(), &idsToDelete)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -295,7 +396,7 @@ func (lh *ListHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	listItemsDeleted, err := lh.listItemsService.DeleteListItemsByListID(listID)
+	listItemsDeleted, err := lh.listItemsService.DeleteListItemsByListID(c.Request.Context(), listID)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -346,7 +447,7 @@ func (lh *ListHandler) JoinList(c *gin.Context) {
 		ListID: recoveredList.ID,
 		UserID: uint(parsedUserID),
 	}
-	ul, err := lh.userListsService.Create(userList)
+	ul, err := lh.userListsService.Create(c.Request.Context(), userList)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err.Error())
@@ -362,10 +463,20 @@ func (lh *ListHandler) JoinList(c *gin.Context) {
 	return
 }
 
+// BulkDelete deletes the lists on a bulk.Request created by the caller, answering what happened to each of them.
 func (lh *ListHandler) BulkDelete(c *gin.Context) {
-	var listToDelete []models.List
+	var bulkRequest bulk.Request
+
+	parsedUserID, err := strconv.Atoi(c.Request.Header.Get("user_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
 
-	err := c.ShouldBindJSON(&listToDelete)
+	err = c.ShouldBindJSON(&bulkRequest)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"msg": "invalid json",
@@ -374,25 +485,91 @@ func (lh *ListHandler) BulkDelete(c *gin.Context) {
 		return
 	}
 
-	if len(listToDelete) < 1 {
+	err = bulkRequest.Validate()
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
-			"msg": "no lists received",
+			"msg": fmt.Sprintf("ids must hold between 1 and %d list ids", bulk.MaxIDs),
 		})
 		c.Abort()
 		return
 	}
 
-	result, err := lh.listService.BulkDelete(listToDelete)
+	result, undoToken, err := lh.listService.BulkDelete(c.Request.Context(), bulkRequest.IDs, uint(parsedUserID))
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
 		return
 	}
 
+	if undoToken != nil {
+		c.Header(versions.UndoTokenHeader, undoToken.Token)
+		c.Header(versions.UndoExpiresAtHeader, undoToken.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+
 	c.JSON(http.StatusOK, result)
 	return
 }
 
+const (
+	defaultStatsDays = 30
+	maxStatsDays     = 365
+)
+
+// GetStats answers with the items created and completed on the lists of the caller over the last ?days (30 by
+// default), grouped by ?interval (day or week) on the ?tz time zone (UTC by default).
+func (lh *ListHandler) GetStats(c *gin.Context) {
+	userID := c.Request.Header.Get("user_id")
+
+	if _, err := strconv.Atoi(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return
+	}
+
+	days := defaultStatsDays
+	if param := c.Query("days"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil || parsed < 1 || parsed > maxStatsDays {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": fmt.Sprintf("days must be a number between 1 and %d", maxStatsDays),
+			})
+			c.Abort()
+			return
+		}
+		days = parsed
+	}
+
+	interval := c.DefaultQuery("interval", models.IntervalDay)
+	if interval != models.IntervalDay && interval != models.IntervalWeek {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "interval must be day or week",
+		})
+		c.Abort()
+		return
+	}
+
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", "UTC"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid time zone",
+		})
+		c.Abort()
+		return
+	}
+
+	stats, err := lh.listService.GetCompletionStats(userID, time.Now().In(loc), days, interval)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+	return
+}
+
 func UserListsToDelete(userListsRecovered []userListsModel.UserList, userID int, isOwner bool) []uint {
 
 	var idListToDelete []uint
@@ -411,3 +588,23 @@ func UserListsToDelete(userListsRecovered []userListsModel.UserList, userID int,
 	}
 	return idListToDelete
 }
+
+// requestIncludes reads the associations asked for with ?include=, answering the request with 400 when it names an
+// unknown one. Without ?include= the associations on defaults are read.
+func requestIncludes(c *gin.Context, defaults models.ListIncludes) (models.ListIncludes, bool) {
+	param, ok := c.GetQuery("include")
+	if !ok {
+		return defaults, true
+	}
+
+	includes, err := models.ParseIncludes(param)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		c.Abort()
+		return models.ListIncludes{}, false
+	}
+
+	return includes, true
+}
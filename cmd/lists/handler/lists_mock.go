@@ -7,8 +7,13 @@ package handler
 import (
 	models "SuperListsAPI/cmd/listItems/models"
 	models0 "SuperListsAPI/cmd/lists/models"
-	models1 "SuperListsAPI/cmd/userLists/models"
+	models1 "SuperListsAPI/cmd/storeProfiles/models"
+	models2 "SuperListsAPI/cmd/userLists/models"
+	bulk "SuperListsAPI/internal/bulk"
+	versions "SuperListsAPI/internal/versions"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -37,48 +42,49 @@ func (m *MockIListService) EXPECT() *MockIListServiceMockRecorder {
 }
 
 // BulkDelete mocks base method.
-func (m *MockIListService) BulkDelete(listsToDelete []models0.List) (*int, error) {
+func (m *MockIListService) BulkDelete(ctx context.Context, listIDs []uint, userID uint) (*bulk.Result, *versions.UndoToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkDelete", listsToDelete)
-	ret0, _ := ret[0].(*int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, listIDs, userID)
+	ret0, _ := ret[0].(*bulk.Result)
+	ret1, _ := ret[1].(*versions.UndoToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // BulkDelete indicates an expected call of BulkDelete.
-func (mr *MockIListServiceMockRecorder) BulkDelete(listsToDelete interface{}) *gomock.Call {
+func (mr *MockIListServiceMockRecorder) BulkDelete(ctx, listIDs, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListService)(nil).BulkDelete), listsToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListService)(nil).BulkDelete), ctx, listIDs, userID)
 }
 
 // Create mocks base method.
-func (m *MockIListService) Create(list models0.List) (*models0.List, error) {
+func (m *MockIListService) Create(ctx context.Context, list models0.List) (*models0.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", list)
+	ret := m.ctrl.Call(m, "Create", ctx, list)
 	ret0, _ := ret[0].(*models0.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIListServiceMockRecorder) Create(list interface{}) *gomock.Call {
+func (mr *MockIListServiceMockRecorder) Create(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListService)(nil).Create), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListService)(nil).Create), ctx, list)
 }
 
 // Delete mocks base method.
-func (m *MockIListService) Delete(listID string) (*string, error) {
+func (m *MockIListService) Delete(ctx context.Context, listID string) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", listID)
+	ret := m.ctrl.Call(m, "Delete", ctx, listID)
 	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIListServiceMockRecorder) Delete(listID interface{}) *gomock.Call {
+func (mr *MockIListServiceMockRecorder) Delete(ctx, listID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListService)(nil).Delete), listID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListService)(nil).Delete), ctx, listID)
 }
 
 // Get mocks base method.
@@ -96,6 +102,21 @@ func (mr *MockIListServiceMockRecorder) Get(listId interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListService)(nil).Get), listId)
 }
 
+// GetCompletionStats mocks base method.
+func (m *MockIListService) GetCompletionStats(userID string, now time.Time, days int, interval string) (*models0.CompletionStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletionStats", userID, now, days, interval)
+	ret0, _ := ret[0].(*models0.CompletionStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletionStats indicates an expected call of GetCompletionStats.
+func (mr *MockIListServiceMockRecorder) GetCompletionStats(userID, now, days, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletionStats", reflect.TypeOf((*MockIListService)(nil).GetCompletionStats), userID, now, days, interval)
+}
+
 // GetListByInvitationCode mocks base method.
 func (m *MockIListService) GetListByInvitationCode(invitationCode string) (*models0.List, error) {
 	m.ctrl.T.Helper()
@@ -112,33 +133,48 @@ func (mr *MockIListServiceMockRecorder) GetListByInvitationCode(invitationCode i
 }
 
 // GetLists mocks base method.
-func (m *MockIListService) GetLists(userId string) (*[]models0.List, error) {
+func (m *MockIListService) GetLists(userId string, includes models0.ListIncludes) (*[]models0.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLists", userId)
+	ret := m.ctrl.Call(m, "GetLists", userId, includes)
 	ret0, _ := ret[0].(*[]models0.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLists indicates an expected call of GetLists.
-func (mr *MockIListServiceMockRecorder) GetLists(userId interface{}) *gomock.Call {
+func (mr *MockIListServiceMockRecorder) GetLists(userId, includes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockIListService)(nil).GetLists), userId, includes)
+}
+
+// GetWithIncludes mocks base method.
+func (m *MockIListService) GetWithIncludes(listID string, includes models0.ListIncludes) (*models0.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithIncludes", listID, includes)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithIncludes indicates an expected call of GetWithIncludes.
+func (mr *MockIListServiceMockRecorder) GetWithIncludes(listID, includes interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockIListService)(nil).GetLists), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithIncludes", reflect.TypeOf((*MockIListService)(nil).GetWithIncludes), listID, includes)
 }
 
 // Update mocks base method.
-func (m *MockIListService) Update(list models0.List) (*models0.List, error) {
+func (m *MockIListService) Update(ctx context.Context, list models0.List) (*models0.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", list)
+	ret := m.ctrl.Call(m, "Update", ctx, list)
 	ret0, _ := ret[0].(*models0.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockIListServiceMockRecorder) Update(list interface{}) *gomock.Call {
+func (mr *MockIListServiceMockRecorder) Update(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListService)(nil).Update), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListService)(nil).Update), ctx, list)
 }
 
 // MockIUserListService is a mock of IUserListService interface.
@@ -165,40 +201,40 @@ func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockIUserListService) Create(list models1.UserList) (*models1.UserList, error) {
+func (m *MockIUserListService) Create(ctx context.Context, list models2.UserList) (*models2.UserList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", list)
-	ret0, _ := ret[0].(*models1.UserList)
+	ret := m.ctrl.Call(m, "Create", ctx, list)
+	ret0, _ := ret[0].(*models2.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIUserListServiceMockRecorder) Create(list interface{}) *gomock.Call {
+func (mr *MockIUserListServiceMockRecorder) Create(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserListService)(nil).Create), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserListService)(nil).Create), ctx, list)
 }
 
 // Delete mocks base method.
-func (m *MockIUserListService) Delete(userListID *[]uint) (*int, error) {
+func (m *MockIUserListService) Delete(ctx context.Context, userListID *[]uint) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userListID)
+	ret := m.ctrl.Call(m, "Delete", ctx, userListID)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIUserListServiceMockRecorder) Delete(userListID interface{}) *gomock.Call {
+func (mr *MockIUserListServiceMockRecorder) Delete(ctx, userListID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserListService)(nil).Delete), userListID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserListService)(nil).Delete), ctx, userListID)
 }
 
 // Get mocks base method.
-func (m *MockIUserListService) Get(userListID string) (*models1.UserList, error) {
+func (m *MockIUserListService) Get(userListID string) (*models2.UserList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Get", userListID)
-	ret0, _ := ret[0].(*models1.UserList)
+	ret0, _ := ret[0].(*models2.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -210,10 +246,10 @@ func (mr *MockIUserListServiceMockRecorder) Get(userListID interface{}) *gomock.
 }
 
 // GetUserListsByListID mocks base method.
-func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models2.UserList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
-	ret0, _ := ret[0].(*[]models1.UserList)
+	ret0, _ := ret[0].(*[]models2.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -225,10 +261,10 @@ func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interfac
 }
 
 // GetUserListsByUserID mocks base method.
-func (m *MockIUserListService) GetUserListsByUserID(userId string) (*[]models1.UserList, error) {
+func (m *MockIUserListService) GetUserListsByUserID(userId string) (*[]models2.UserList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserListsByUserID", userId)
-	ret0, _ := ret[0].(*[]models1.UserList)
+	ret0, _ := ret[0].(*[]models2.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -263,48 +299,48 @@ func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockIListItemService) Create(item models.ListItem) (*models.ListItem, error) {
+func (m *MockIListItemService) Create(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", item)
+	ret := m.ctrl.Call(m, "Create", ctx, item)
 	ret0, _ := ret[0].(*models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIListItemServiceMockRecorder) Create(item interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) Create(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListItemService)(nil).Create), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListItemService)(nil).Create), ctx, item)
 }
 
 // Delete mocks base method.
-func (m *MockIListItemService) Delete(listItemID string) (*int, error) {
+func (m *MockIListItemService) Delete(ctx context.Context, listItemID string) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", listItemID)
+	ret := m.ctrl.Call(m, "Delete", ctx, listItemID)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIListItemServiceMockRecorder) Delete(listItemID interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) Delete(ctx, listItemID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListItemService)(nil).Delete), listItemID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListItemService)(nil).Delete), ctx, listItemID)
 }
 
 // DeleteListItemsByListID mocks base method.
-func (m *MockIListItemService) DeleteListItemsByListID(listId string) (*int, error) {
+func (m *MockIListItemService) DeleteListItemsByListID(ctx context.Context, listId string) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteListItemsByListID", listId)
+	ret := m.ctrl.Call(m, "DeleteListItemsByListID", ctx, listId)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteListItemsByListID indicates an expected call of DeleteListItemsByListID.
-func (mr *MockIListItemServiceMockRecorder) DeleteListItemsByListID(listId interface{}) *gomock.Call {
+func (mr *MockIListItemServiceMockRecorder) DeleteListItemsByListID(ctx, listId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListItemsByListID", reflect.TypeOf((*MockIListItemService)(nil).DeleteListItemsByListID), listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteListItemsByListID", reflect.TypeOf((*MockIListItemService)(nil).DeleteListItemsByListID), ctx, listId)
 }
 
 // Get mocks base method.
@@ -322,32 +358,55 @@ func (mr *MockIListItemServiceMockRecorder) Get(listItemID interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListItemService)(nil).Get), listItemID)
 }
 
-// GetItemsListByListID mocks base method.
-func (m *MockIListItemService) GetItemsListByListID(listId string) (*[]models.ListItem, error) {
+// Update mocks base method.
+func (m *MockIListItemService) Update(ctx context.Context, item models.ListItem) (*models.ListItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetItemsListByListID", listId)
-	ret0, _ := ret[0].(*[]models.ListItem)
+	ret := m.ctrl.Call(m, "Update", ctx, item)
+	ret0, _ := ret[0].(*models.ListItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetItemsListByListID indicates an expected call of GetItemsListByListID.
-func (mr *MockIListItemServiceMockRecorder) GetItemsListByListID(listId interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockIListItemServiceMockRecorder) Update(ctx, item interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemsListByListID", reflect.TypeOf((*MockIListItemService)(nil).GetItemsListByListID), listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListItemService)(nil).Update), ctx, item)
 }
 
-// Update mocks base method.
-func (m *MockIListItemService) Update(item models.ListItem) (*models.ListItem, error) {
+// MockIStoreProfileService is a mock of IStoreProfileService interface.
+type MockIStoreProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStoreProfileServiceMockRecorder
+}
+
+// MockIStoreProfileServiceMockRecorder is the mock recorder for MockIStoreProfileService.
+type MockIStoreProfileServiceMockRecorder struct {
+	mock *MockIStoreProfileService
+}
+
+// NewMockIStoreProfileService creates a new mock instance.
+func NewMockIStoreProfileService(ctrl *gomock.Controller) *MockIStoreProfileService {
+	mock := &MockIStoreProfileService{ctrl: ctrl}
+	mock.recorder = &MockIStoreProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStoreProfileService) EXPECT() *MockIStoreProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockIStoreProfileService) Find(userID, reference string) (*models1.StoreProfile, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", item)
-	ret0, _ := ret[0].(*models.ListItem)
+	ret := m.ctrl.Call(m, "Find", userID, reference)
+	ret0, _ := ret[0].(*models1.StoreProfile)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockIListItemServiceMockRecorder) Update(item interface{}) *gomock.Call {
+// Find indicates an expected call of Find.
+func (mr *MockIStoreProfileServiceMockRecorder) Find(userID, reference interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListItemService)(nil).Update), item)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIStoreProfileService)(nil).Find), userID, reference)
 }
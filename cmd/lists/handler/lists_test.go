@@ -3,18 +3,23 @@ package handler
 import (
 	listItemModels "SuperListsAPI/cmd/listItems/models"
 	"SuperListsAPI/cmd/lists/models"
+	storeProfileModels "SuperListsAPI/cmd/storeProfiles/models"
 	userListsModel "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/versions"
 	"encoding/json"
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestListHandler_Create(t *testing.T) {
@@ -27,13 +32,13 @@ func TestListHandler_Create(t *testing.T) {
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Create(gomock.Any()).Return(&validList, nil)
+	listService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validList, nil)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Create(gomock.Any()).Return(&validUserList, nil)
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validUserList, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -66,10 +71,10 @@ func TestListHandler_Create_Returns_Service_Error(t *testing.T) {
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from list service"))
+	listService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list service"))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -101,13 +106,13 @@ func TestListHandler_Create_Error_On_User_List_Service(t *testing.T) {
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Create(gomock.Any()).Return(&validList, nil)
+	listService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validList, nil)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error on userList service"))
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error on userList service"))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -139,7 +144,7 @@ func TestListHandler_Create_Missing_Required_Value(t *testing.T) {
 	listService := NewMockIListService(gomock.NewController(t))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -171,7 +176,7 @@ func TestListHandler_Create_Invalid_Request_Body(t *testing.T) {
 	listService := NewMockIListService(gomock.NewController(t))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -203,10 +208,10 @@ func TestListHandler_GetLists(t *testing.T) {
 	lists := []models.List{validList, validList}
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().GetLists(gomock.Any()).Return(&lists, nil)
+	listService.EXPECT().GetLists(gomock.Any(), models.ListIncludes{}).Return(&lists, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -236,10 +241,10 @@ func TestListHandler_GetLists_Returns_Service_Error(t *testing.T) {
 	lists := []models.List{validList, validList}
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().GetLists(gomock.Any()).Return(&lists, errors.New("error from list service"))
+	listService.EXPECT().GetLists(gomock.Any(), models.ListIncludes{}).Return(&lists, errors.New("error from list service"))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -270,7 +275,7 @@ func TestListHandler_GetLists_Returns_No_Header_ID_Error(t *testing.T) {
 	listService := NewMockIListService(gomock.NewController(t))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -301,7 +306,7 @@ func TestListHandler_GetLists_Returns_Invalid_ID_Error(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -329,11 +334,11 @@ func TestListHandler_GetLists_Returns_No_Content(t *testing.T) {
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().GetLists(gomock.Any()).Return(nil, nil)
+	listService.EXPECT().GetLists(gomock.Any(), models.ListIncludes{}).Return(nil, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -362,11 +367,11 @@ func TestListHandler_GetLists_Returns_No_Content_With_Lists_Not_Nil(t *testing.T
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().GetLists(gomock.Any()).Return(&[]models.List{}, nil)
+	listService.EXPECT().GetLists(gomock.Any(), models.ListIncludes{}).Return(&[]models.List{}, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -399,12 +404,12 @@ func TestListHandler_Get(t *testing.T) {
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
+	validList.ListItems = listItemsReturned
+	listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Items: true}).Return(&validList, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listItemService.EXPECT().GetItemsListByListID(gomock.Any()).Return(&listItemsReturned, nil)
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -424,20 +429,31 @@ func TestListHandler_Get(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
-func TestListHandler_Get_Error_On_ListItem_Service(t *testing.T) {
+func TestListHandler_Get_With_Totals(t *testing.T) {
 	validList := GetValidList()
 
-	inviteCode, _ := uuid.NewV4()
-	validList.InviteCode = inviteCode.String()
-	validList.UserCreatorID = 1
+	price := decimal.RequireFromString("150.50")
+	quantity := decimal.RequireFromString("2")
+
+	pendingItem := GetValidListItem()
+	pendingItem.UnitPrice = &price
+	pendingItem.Quantity = &quantity
+	pendingItem.Currency = "ARS"
+
+	doneItem := GetValidListItem()
+	doneItem.UnitPrice = &price
+	doneItem.Currency = "ARS"
+	doneItem.IsDone = true
+
+	listItemsReturned := []listItemModels.ListItem{pendingItem, doneItem}
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
+	validList.ListItems = listItemsReturned
+	listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Items: true}).Return(&validList, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listItemService.EXPECT().GetItemsListByListID(gomock.Any()).Return(nil, errors.New("error from list item service"))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -454,17 +470,185 @@ func TestListHandler_Get_Error_On_ListItem_Service(t *testing.T) {
 
 	c.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	var result models.List
+	_ = json.Unmarshal(w.Body.Bytes(), &result)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, result.Totals, 1)
+	assert.Equal(t, "451.5", result.Totals[0].Estimated.String())
+	assert.Equal(t, "150.5", result.Totals[0].Purchased.String())
+	assert.Equal(t, "301", result.Totals[0].Remaining.String())
+}
+
+func TestListHandler_Get_Grouped_By_Store(t *testing.T) {
+	validList := GetValidList()
+
+	milk := GetValidListItem()
+	milk.Title = "Leche"
+	milk.Category = listItemModels.CategoryDairy
+
+	tomatoes := GetValidListItem()
+	tomatoes.Title = "Tomates"
+	tomatoes.Category = listItemModels.CategoryProduce
+
+	listItemsReturned := []listItemModels.ListItem{milk, tomatoes}
+
+	profile := storeProfileModels.StoreProfile{
+		Name:          "Coto",
+		CategoryOrder: []string{listItemModels.CategoryProduce, listItemModels.CategoryDairy},
+	}
+
+	listService := NewMockIListService(gomock.NewController(t))
+	validList.ListItems = listItemsReturned
+	listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Items: true}).Return(&validList, nil)
+	userListService := NewMockIUserListService(gomock.NewController(t))
+	listItemService := NewMockIListItemService(gomock.NewController(t))
+	storeProfileService := NewMockIStoreProfileService(gomock.NewController(t))
+	storeProfileService.EXPECT().Find("1", "Coto").Return(&profile, nil)
+	listHandler := NewListHandler(listService, userListService, listItemService, storeProfileService)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.GET("/:id", listHandler.Get)
+	}
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/lists/1?store=Coto", nil)
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	var result models.List
+	_ = json.Unmarshal(w.Body.Bytes(), &result)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, result.ItemGroups, 2)
+	assert.Equal(t, listItemModels.CategoryProduce, result.ItemGroups[0].Category)
+	assert.Equal(t, "Tomates", result.ItemGroups[0].Items[0].Title)
+	assert.Equal(t, listItemModels.CategoryDairy, result.ItemGroups[1].Category)
+}
+
+func TestListHandler_Get_Filtered_By_Assignee(t *testing.T) {
+	validList := GetValidList()
+	validList.ID = 1
+
+	listItemsReturned := []listItemModels.ListItem{GetValidListItem()}
+
+	listService := NewMockIListService(gomock.NewController(t))
+	validList.ListItems = listItemsReturned
+	listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Items: true, ItemAssignee: "5"}).Return(&validList, nil)
+	userListService := NewMockIUserListService(gomock.NewController(t))
+	listItemService := NewMockIListItemService(gomock.NewController(t))
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.GET("/:id", listHandler.Get)
+	}
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/lists/1?assignee=me", nil)
+	req.Header.Set("user_id", "5")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListHandler_Get_Invalid_Assignee(t *testing.T) {
+	listService := NewMockIListService(gomock.NewController(t))
+	userListService := NewMockIUserListService(gomock.NewController(t))
+	listItemService := NewMockIListItemService(gomock.NewController(t))
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.GET("/:id", listHandler.Get)
+	}
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/lists/1?assignee=ana", nil)
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListHandler_Get_Store_Profile_Not_Found(t *testing.T) {
+	listService := NewMockIListService(gomock.NewController(t))
+	userListService := NewMockIUserListService(gomock.NewController(t))
+	listItemService := NewMockIListItemService(gomock.NewController(t))
+	storeProfileService := NewMockIStoreProfileService(gomock.NewController(t))
+	storeProfileService.EXPECT().Find("1", "9").Return(nil, gorm.ErrRecordNotFound)
+	listHandler := NewListHandler(listService, userListService, listItemService, storeProfileService)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.GET("/:id", listHandler.Get)
+	}
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/lists/1?store=9", nil)
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListHandler_Get_Invalid_Include(t *testing.T) {
+	listService := NewMockIListService(gomock.NewController(t))
+	userListService := NewMockIUserListService(gomock.NewController(t))
+
+	listItemService := NewMockIListItemService(gomock.NewController(t))
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.GET("/:id", listHandler.Get)
+	}
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/lists/1?include=items,owner", nil)
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
 func TestListHandler_Get_Returns_Not_Found(t *testing.T) {
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Get(gomock.Any()).Return(nil, nil)
+	listService.EXPECT().GetWithIncludes(gomock.Any(), gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -492,11 +676,11 @@ func TestListHandler_Get_Returns_Service_Error(t *testing.T) {
 	validList.UserCreatorID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Get(gomock.Any()).Return(&validList, errors.New("list service error"))
+	listService.EXPECT().GetWithIncludes(gomock.Any(), gomock.Any()).Return(nil, errors.New("list service error"))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -527,7 +711,7 @@ func TestListHandler_Get_Missing_ID_On_URL(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -557,11 +741,11 @@ func TestListHandler_Update(t *testing.T) {
 	validList.ID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Update(gomock.Any()).Return(&validList, nil)
+	listService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&validList, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -593,11 +777,11 @@ func TestListHandler_Update_Returns_Service_Error(t *testing.T) {
 	validList.ID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Update(gomock.Any()).Return(&validList, errors.New("error from list service"))
+	listService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&validList, errors.New("error from list service"))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -632,7 +816,7 @@ func TestListHandler_Update_Invalid_ID(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -667,7 +851,7 @@ func TestListHandler_Update_Mismatch_ID(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -699,7 +883,7 @@ func TestListHandler_Update_Invalid_Request_Body(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -732,7 +916,7 @@ func TestListHandler_Update_Missing_Request_Body_Mandatory_Values(t *testing.T)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -764,11 +948,11 @@ func TestListHandler_Update_Returns_Not_Found_List_To_Update(t *testing.T) {
 	validList.ID = 1
 
 	listService := NewMockIListService(gomock.NewController(t))
-	listService.EXPECT().Update(gomock.Any()).Return(nil, nil)
+	listService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, nil)
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -813,18 +997,18 @@ func TestListHandler_Delete(t *testing.T) {
 
 	listService := NewMockIListService(gomock.NewController(t))
 
-	listService.EXPECT().Delete(gomock.Any()).Return(&deletedID, nil)
+	listService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedID, nil)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
-	userListService.EXPECT().Delete(gomock.Any()).Return(&deletedUserListID, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedUserListID, nil)
 
 	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any()).Return(&deletedListItemQty, nil)
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any(), gomock.Any()).Return(&deletedListItemQty, nil)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -866,18 +1050,18 @@ func TestListHandler_Delete_List_Item_Service_Error(t *testing.T) {
 
 	listService := NewMockIListService(gomock.NewController(t))
 
-	listService.EXPECT().Delete(gomock.Any()).Return(&deletedID, nil)
+	listService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedID, nil)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
-	userListService.EXPECT().Delete(gomock.Any()).Return(&deletedUserListID, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedUserListID, nil)
 
 	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any()).Return(nil, errors.New("Error on listItemService"))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any(), gomock.Any()).Return(nil, errors.New("Error on listItemService"))
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -917,17 +1101,17 @@ func TestListHandler_Delete_Error_On_User_List_Service(t *testing.T) {
 
 	listService := NewMockIListService(gomock.NewController(t))
 
-	listService.EXPECT().Delete(gomock.Any()).Return(&deletedID, nil)
+	listService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedID, nil)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
-	userListService.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from user list service"))
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from user list service"))
 
 	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -967,17 +1151,17 @@ func TestListHandler_Delete_Error_On_User_List_Service_Nil_Results(t *testing.T)
 
 	listService := NewMockIListService(gomock.NewController(t))
 
-	listService.EXPECT().Delete(gomock.Any()).Return(&deletedID, nil)
+	listService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedID, nil)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
-	userListService.EXPECT().Delete(gomock.Any()).Return(nil, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1021,11 +1205,11 @@ func TestListHandler_Delete_Not_Owner_List(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
-	userListService.EXPECT().Delete(gomock.Any()).Return(&deletedUserListQty, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedUserListQty, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any()).Return(&listItemDeletedQty, nil)
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any(), gomock.Any()).Return(&listItemDeletedQty, nil)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1060,7 +1244,7 @@ func TestListHandler_Delete_Error_Getting_List(t *testing.T) {
 	listService.EXPECT().Get(gomock.Any()).Return(nil, errors.New("error from list service executing get"))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1090,14 +1274,14 @@ func TestListHandler_Delete_Returns_Service_Error(t *testing.T) {
 
 	listService := NewMockIListService(gomock.NewController(t))
 
-	listService.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from list service"))
+	listService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list service"))
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
 	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1123,7 +1307,7 @@ func TestListHandler_Delete_Invalid_ID(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1149,7 +1333,7 @@ func TestListHandler_Delete_Invalid_User_ID(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1175,7 +1359,7 @@ func TestListHandler_Delete_Missing_User_ID(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1207,11 +1391,11 @@ func TestListHandler_Delete_Returns_Error_On_User_Lists_Delete(t *testing.T) {
 	userListService.EXPECT().GetUserListsByListID(gomock.Any()).Return(&userLists, nil)
 	listService.EXPECT().Get(gomock.Any()).Return(&validList, nil)
 
-	userListService.EXPECT().Delete(gomock.Any()).Return(&rowsQtyDeleted, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&rowsQtyDeleted, nil)
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any()).Return(nil, errors.New("error from list items service"))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listItemService.EXPECT().DeleteListItemsByListID(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list items service"))
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1267,9 +1451,9 @@ func TestListHandler_JoinList(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
-	userListService.EXPECT().Create(gomock.Any()).Return(&getValidUserList, nil)
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&getValidUserList, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1297,10 +1481,10 @@ func TestListHandler_JoinList_No_Cant_Join(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	listService.EXPECT().GetListByInvitationCode(gomock.Any()).Return(&validList, nil)
-	userListService.EXPECT().Create(gomock.Any()).Return(nil, nil)
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1326,9 +1510,9 @@ func TestListHandler_JoinList_Error(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 	listService.EXPECT().GetListByInvitationCode(gomock.Any()).Return(&validList, nil)
-	userListService.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from userList service"))
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from userList service"))
 
 	gin.SetMode(gin.TestMode)
 
@@ -1354,7 +1538,7 @@ func TestListHandler_JoinList_Invalid_Invitation_Code(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 	gin.SetMode(gin.TestMode)
 
 	c := gin.Default()
@@ -1379,7 +1563,7 @@ func TestListHandler_JoinList_Error_When_Get_List_By_Invitation_Code(t *testing.
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	listService.EXPECT().GetListByInvitationCode(gomock.Any()).Return(nil, errors.New("error when querying by invite code"))
 	gin.SetMode(gin.TestMode)
@@ -1406,7 +1590,7 @@ func TestListHandler_JoinList_Missing_User_ID(t *testing.T) {
 	userListService := NewMockIUserListService(gomock.NewController(t))
 
 	listItemService := NewMockIListItemService(gomock.NewController(t))
-	listHandler := NewListHandler(listService, userListService, listItemService)
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
 
 	gin.SetMode(gin.TestMode)
 
@@ -1427,9 +1611,10 @@ func TestListHandler_JoinList_Missing_User_ID(t *testing.T) {
 
 func TestNewListHandler(t *testing.T) {
 	type args struct {
-		service          IListService
-		userListService  IUserListService
-		listItemsService IListItemService
+		service             IListService
+		userListService     IUserListService
+		listItemsService    IListItemService
+		storeProfileService IStoreProfileService
 	}
 	tests := []struct {
 		name string
@@ -1439,25 +1624,354 @@ func TestNewListHandler(t *testing.T) {
 		{
 			name: "Test with nil services should pass",
 			args: args{
-				service:          nil,
-				userListService:  nil,
-				listItemsService: nil,
+				service:             nil,
+				userListService:     nil,
+				listItemsService:    nil,
+				storeProfileService: nil,
 			},
-			want: NewListHandler(nil, nil, nil),
+			want: NewListHandler(nil, nil, nil, nil),
 		},
 		{
 			name: "Test with no nil services should pass",
 			args: args{
-				service:          NewMockIListService(gomock.NewController(t)),
-				userListService:  NewMockIUserListService(gomock.NewController(t)),
-				listItemsService: NewMockIListItemService(gomock.NewController(t)),
+				service:             NewMockIListService(gomock.NewController(t)),
+				userListService:     NewMockIUserListService(gomock.NewController(t)),
+				listItemsService:    NewMockIListItemService(gomock.NewController(t)),
+				storeProfileService: NewMockIStoreProfileService(gomock.NewController(t)),
+			},
+			want: NewListHandler(NewMockIListService(gomock.NewController(t)), NewMockIUserListService(gomock.NewController(t)), NewMockIListItemService(gomock.NewController(t)), NewMockIStoreProfileService(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equalf(t, tt.want, NewListHandler(tt.args.service, tt.args.userListService, tt.args.listItemsService, tt.args.storeProfileService), "NewListHandler(%v, %v, %v, %v)", tt.args.service, tt.args.userListService, tt.args.listItemsService, tt.args.storeProfileService)
+		})
+	}
+}
+
+func TestListHandler_Create_Invalid_Recurrence(t *testing.T) {
+
+	validList := GetValidList()
+	validList.Recurrence = "FREQ=HOURLY"
+
+	listHandler := NewListHandler(NewMockIListService(gomock.NewController(t)), nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+	c.POST("/v1/lists/", listHandler.Create)
+
+	jsonDto, _ := json.Marshal(validList)
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodPost, "/v1/lists/", strings.NewReader(string(jsonDto)))
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestListHandler_Get_Nests_Subtasks(t *testing.T) {
+	validList := GetValidList()
+
+	party := GetValidListItem()
+	party.ID = 1
+	party.Title = "Preparar cumpleaños"
+
+	parentID := party.ID
+	cake := GetValidListItem()
+	cake.ID = 2
+	cake.Title = "Comprar torta"
+	cake.ParentID = &parentID
+	cake.IsDone = true
+
+	balloons := GetValidListItem()
+	balloons.ID = 3
+	balloons.Title = "Inflar globos"
+	balloons.ParentID = &parentID
+
+	listItemsReturned := []listItemModels.ListItem{party, cake, balloons}
+
+	listService := NewMockIListService(gomock.NewController(t))
+	validList.ListItems = listItemsReturned
+	listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Items: true}).Return(&validList, nil)
+	userListService := NewMockIUserListService(gomock.NewController(t))
+	listItemService := NewMockIListItemService(gomock.NewController(t))
+	listHandler := NewListHandler(listService, userListService, listItemService, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/lists")
+	{
+		v1.GET("/:id", listHandler.Get)
+	}
+
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest(http.MethodGet, "/v1/lists/1", nil)
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	var result models.List
+	_ = json.Unmarshal(w.Body.Bytes(), &result)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Len(t, result.ListItems, 1)
+	assert.Len(t, result.ListItems[0].Children, 2)
+	assert.Equal(t, listItemModels.ItemProgress{Done: 1, Total: 2}, *result.ListItems[0].Progress)
+}
+
+func TestListHandler_BulkDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(listService *MockIListService)
+		wantStatus int
+	}{
+		{
+			name: "Deleted",
+			body: `{"ids":[1,2,2]}`,
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().BulkDelete(gomock.Any(), []uint{1, 2}, uint(7)).
+					Return(&bulk.Result{Succeeded: []uint{1}, Forbidden: []uint{2}}, &versions.UndoToken{Token: "token"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Former body",
+			body: `[{"ID":1}]`,
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().BulkDelete(gomock.Any(), []uint{1}, uint(7)).Return(bulk.NewResult(), nil, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "No ids",
+			body:       `{"ids":[]}`,
+			setup:      func(listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			body: `{"ids":[1]}`,
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().BulkDelete(gomock.Any(), []uint{1}, uint(7)).Return(nil, nil, errors.New("error from list service"))
 			},
-			want: NewListHandler(NewMockIListService(gomock.NewController(t)), NewMockIUserListService(gomock.NewController(t)), NewMockIListItemService(gomock.NewController(t))),
+			wantStatus: http.StatusInternalServerError,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			assert.Equalf(t, tt.want, NewListHandler(tt.args.service, tt.args.userListService, tt.args.listItemsService), "NewListHandler(%v, %v, %v)", tt.args.service, tt.args.userListService, tt.args.listItemsService)
+			listService := NewMockIListService(gomock.NewController(t))
+			tt.setup(listService)
+
+			listHandler := NewListHandler(listService, nil, nil, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+			c.POST("/v1/lists/bulkDelete", listHandler.BulkDelete)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/lists/bulkDelete", strings.NewReader(tt.body))
+			req.Header.Set("user_id", "7")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListHandler_GetStats(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		userID     string
+		setup      func(listService *MockIListService)
+		wantStatus int
+	}{
+		{
+			name:   "Defaults",
+			query:  "",
+			userID: "7",
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().GetCompletionStats("7", gomock.Any(), 30, models.IntervalDay).
+					Return(&models.CompletionStats{Interval: models.IntervalDay}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Weeks on a time zone",
+			query:  "?days=90&interval=week&tz=Europe/Madrid",
+			userID: "7",
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().GetCompletionStats("7", gomock.Any(), 90, models.IntervalWeek).
+					DoAndReturn(func(userID string, now time.Time, days int, interval string) (*models.CompletionStats, error) {
+						assert.Equal(t, "Europe/Madrid", now.Location().String())
+						return &models.CompletionStats{Interval: interval}, nil
+					})
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Invalid user id",
+			query:      "",
+			userID:     "",
+			setup:      func(listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Too many days",
+			query:      "?days=366",
+			userID:     "7",
+			setup:      func(listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid interval",
+			query:      "?interval=month",
+			userID:     "7",
+			setup:      func(listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid time zone",
+			query:      "?tz=Mars/Olympus",
+			userID:     "7",
+			setup:      func(listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service error",
+			query:  "",
+			userID: "7",
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().GetCompletionStats(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("error from list service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listService := NewMockIListService(gomock.NewController(t))
+			tt.setup(listService)
+
+			listHandler := NewListHandler(listService, nil, nil, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+			c.GET("/v1/me/stats", listHandler.GetStats)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/me/stats"+tt.query, nil)
+			req.Header.Set("user_id", tt.userID)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestListHandler_GetLists_With_Includes(t *testing.T) {
+	lists := []models.List{GetValidList()}
+
+	listService := NewMockIListService(gomock.NewController(t))
+	listService.EXPECT().GetLists("1", models.ListIncludes{Items: true, Members: true}).Return(&lists, nil)
+	listHandler := NewListHandler(listService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+	c.GET("/v1/lists", listHandler.GetLists)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/lists?include=items,members", nil)
+	req.Header.Set("user_id", "1")
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestListHandler_Get_Members_Only(t *testing.T) {
+	validList := GetValidList()
+	validList.Members = []userListsModel.UserList{{ListID: 1, UserID: 5, User: &userListsModel.Member{ID: 5, Name: "Ana"}}}
+
+	listService := NewMockIListService(gomock.NewController(t))
+	listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Members: true}).Return(&validList, nil)
+	listHandler := NewListHandler(listService, nil, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+	c.GET("/v1/lists/:id", listHandler.Get)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/lists/1?include=members", nil)
+	req.Header.Set("user_id", "5")
+
+	c.ServeHTTP(w, req)
+
+	var result models.List
+	_ = json.Unmarshal(w.Body.Bytes(), &result)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, result.ListItems)
+	assert.Empty(t, result.Totals)
+	assert.Equal(t, "Ana", result.Members[0].User.Name)
+}
+
+func TestListHandler_Get_Members_Not_A_Member(t *testing.T) {
+	validList := GetValidList()
+	validList.Members = []userListsModel.UserList{{ListID: 1, UserID: 5, User: &userListsModel.Member{ID: 5, Name: "Ana"}}}
+
+	tests := []struct {
+		name       string
+		userID     string
+		setup      func(listService *MockIListService)
+		wantStatus int
+	}{
+		{
+			name:   "Caller is not a member",
+			userID: "9",
+			setup: func(listService *MockIListService) {
+				listService.EXPECT().GetWithIncludes("1", models.ListIncludes{Members: true}).Return(&validList, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "Missing user id",
+			setup:      func(listService *MockIListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listService := NewMockIListService(gomock.NewController(t))
+			tt.setup(listService)
+			listHandler := NewListHandler(listService, nil, nil, nil)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+			c.GET("/v1/lists/:id", listHandler.Get)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/lists/1?include=members", nil)
+			req.Header.Set("user_id", tt.userID)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.NotContains(t, w.Body.String(), "Ana")
 		})
 	}
 }
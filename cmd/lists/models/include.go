@@ -0,0 +1,39 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	IncludeItems   = "items"
+	IncludeMembers = "members"
+)
+
+// ListIncludes picks the associations read along with lists, as asked for with ?include=items,members. Each one
+// costs a fixed amount of queries whatever the amount of lists or items. ItemAssignee only keeps the items assigned
+// to that user.
+type ListIncludes struct {
+	Items        bool
+	Members      bool
+	ItemAssignee string
+}
+
+// ParseIncludes reads the comma separated associations of ?include=.
+func ParseIncludes(param string) (ListIncludes, error) {
+	includes := ListIncludes{}
+
+	for _, name := range strings.Split(param, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+		case IncludeItems:
+			includes.Items = true
+		case IncludeMembers:
+			includes.Members = true
+		default:
+			return ListIncludes{}, fmt.Errorf("include must be a comma separated list of %s and %s", IncludeItems, IncludeMembers)
+		}
+	}
+
+	return includes, nil
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseIncludes(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   string
+		want    ListIncludes
+		wantErr bool
+	}{
+		{name: "Nothing", param: "", want: ListIncludes{}},
+		{name: "Items", param: "items", want: ListIncludes{Items: true}},
+		{name: "Items and members", param: "items, members", want: ListIncludes{Items: true, Members: true}},
+		{name: "Trailing comma", param: "members,", want: ListIncludes{Members: true}},
+		{name: "Unknown association", param: "items,owner", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIncludes(tt.param)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
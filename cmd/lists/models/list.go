@@ -2,16 +2,29 @@ package models
 
 import (
 	"SuperListsAPI/cmd/listItems/models"
+	userListsModel "SuperListsAPI/cmd/userLists/models"
 	"gorm.io/gorm"
+	"time"
 )
 
+// List is a shared list of items. A list with a Recurrence resets all its items to pending on every occurrence
+// of the rule, counted from RecurrenceStart on TimeZone. NextResetAt is kept by the service.
+// ListItems and Members are only read when asked for with ListIncludes, writes leave them untouched.
 type List struct {
 	gorm.Model
-	Name          string            `json:"name" validate:"required"`
-	Description   string            `json:"description" validate:"required"`
-	InviteCode    string            `json:"invite_code"`
-	UserCreatorID uint              `json:"user_creator_id"`
-	ListItems     []models.ListItem `json:"list_items" gorm:"-"`
+	Name            string                    `json:"name" validate:"required"`
+	Description     string                    `json:"description" validate:"required"`
+	InviteCode      string                    `json:"invite_code"`
+	UserCreatorID   uint                      `json:"user_creator_id"`
+	ListItems       []models.ListItem         `json:"list_items" gorm:"foreignKey:ListID"`
+	Totals          []models.ListTotals       `json:"totals,omitempty" gorm:"-"`
+	ItemGroups      []models.ItemGroup        `json:"item_groups,omitempty" gorm:"-"`
+	Recurrence      string                    `json:"recurrence,omitempty" validate:"omitempty,max=200"`
+	RecurrenceStart *time.Time                `json:"recurrence_start,omitempty"`
+	TimeZone        string                    `json:"time_zone,omitempty" validate:"omitempty,timezone"`
+	NextResetAt     *time.Time                `json:"next_reset_at,omitempty"`
+	Stats           *ListStats                `json:"stats,omitempty" gorm:"embedded"`
+	Members         []userListsModel.UserList `json:"members,omitempty" gorm:"foreignKey:ListID"`
 }
 
 type ListJoinRequest struct {
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+const (
+	RoleOwner  = "owner"
+	RoleMember = "member"
+)
+
+const (
+	IntervalDay  = "day"
+	IntervalWeek = "week"
+)
+
+// ListStats sums up a list for the lists overview. LastActivityAt is the last change recorded on the list or on its
+// items, and Role is RoleOwner for the user who created the list and RoleMember for everybody else. The stats are
+// computed columns of the lists query, they are never written.
+type ListStats struct {
+	ItemCount      int        `json:"item_count" gorm:"->"`
+	DoneCount      int        `json:"done_count" gorm:"->"`
+	MemberCount    int        `json:"member_count" gorm:"->"`
+	LastActivityAt *time.Time `json:"last_activity_at,omitempty" gorm:"->"`
+	Role           string     `json:"role" gorm:"->"`
+}
+
+// CompletionStats counts the items created and completed on the lists of a user, per day or per week. Periods start
+// at midnight on TimeZone, weeks on Monday, and periods without items are listed as well.
+type CompletionStats struct {
+	From      time.Time         `json:"from"`
+	To        time.Time         `json:"to"`
+	Interval  string            `json:"interval"`
+	TimeZone  string            `json:"time_zone"`
+	Created   int               `json:"created"`
+	Completed int               `json:"completed"`
+	Periods   []CompletionCount `json:"periods"`
+}
+
+// CompletionCount is the amount of items created and completed from Start on, until the next period starts.
+type CompletionCount struct {
+	Start     string `json:"start"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+}
@@ -1,11 +1,17 @@
 package repository
 
 import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
 	"SuperListsAPI/cmd/lists/models"
 	userListsModel "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/versions"
+	"context"
 	"github.com/gofrs/uuid"
 	"gorm.io/gorm"
-	"log"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
 type ListRepository struct {
@@ -16,45 +22,140 @@ func NewListRepository(db *gorm.DB) ListRepository {
 	return ListRepository{db: db}
 }
 
-func (lr *ListRepository) Create(list models.List) (*models.List, error) {
+func (lr *ListRepository) Create(ctx context.Context, list models.List) (*models.List, error) {
 
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return CreateList(tx, &list)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &list, nil
+
+}
+
+// CreateList creates list with a new invite code and records it, on the transaction tx of a caller that creates
+// more along with it.
+func CreateList(tx *gorm.DB, list *models.List) error {
 	inviteCode, _ := uuid.NewV4()
 
 	list.InviteCode = inviteCode.String()
 
-	if result := lr.db.Create(&list); result.Error != nil {
-		return nil, result.Error
+	if result := tx.Omit(clause.Associations).Create(list); result.Error != nil {
+		return result.Error
 	}
 
-	return &list, nil
-
+	return activity.Record(tx, listEntry(list.ID, activity.ActionCreated, activity.Diff(nil, *list)))
 }
 
-func (lr *ListRepository) GetLists(userId string) (*[]models.List, error) {
+// GetLists returns the lists userId is a member of, each with its models.ListStats and the associations on includes.
+// The stats are computed by the database on the same query, with a subquery per aggregate that only reads the rows
+// of the list at hand.
+func (lr *ListRepository) GetLists(userId string, includes models.ListIncludes) (*[]models.List, error) {
 
 	var lists []models.List
-	var userLists []userListsModel.UserList
-	listsIDs := []int{}
 
-	if result := lr.db.Where("user_id = ?", userId).Find(&userLists); result.Error != nil {
+	result := lr.preload(includes).Model(&models.List{}).
+		Select(`lists.*,
+			(SELECT COUNT(*) FROM list_items WHERE list_items.list_id = lists.id AND list_items.deleted_at IS NULL) AS item_count,
+			(SELECT COUNT(*) FROM list_items WHERE list_items.list_id = lists.id AND list_items.deleted_at IS NULL AND list_items.is_done = true) AS done_count,
+			(SELECT COUNT(*) FROM user_lists AS members WHERE members.list_id = lists.id AND members.deleted_at IS NULL) AS member_count,
+			COALESCE((SELECT MAX(activities.created_at) FROM activities WHERE activities.list_id = lists.id), lists.updated_at, lists.created_at) AS last_activity_at,
+			CASE WHEN lists.user_creator_id = ? THEN ? ELSE ? END AS role`, userId, models.RoleOwner, models.RoleMember).
+		Where("lists.id IN (?)", lr.memberLists(userId)).
+		Order("lists.id").
+		Find(&lists)
+
+	if result.Error != nil {
 		return nil, result.Error
 	}
-	//TODO Add tests for this if statement
-	if len(userLists) < 1 {
-		log.Print("No user-lists for user with ID: ", userId)
-		return &[]models.List{}, nil
-	}
 
-	for _, userList := range userLists {
-		listsIDs = append(listsIDs, int(userList.ListID))
+	for _, list := range lists {
+		fillAssignees(list.ListItems)
 	}
 
-	if result := lr.db.Find(&lists, listsIDs); result.Error != nil {
+	return &lists, nil
+
+}
+
+// GetWithIncludes returns the list listID along with the associations on includes.
+func (lr *ListRepository) GetWithIncludes(listID string, includes models.ListIncludes) (*models.List, error) {
+	var list models.List
+
+	if result := lr.preload(includes).First(&list, listID); result.Error != nil {
 		return nil, result.Error
 	}
 
-	return &lists, nil
+	fillAssignees(list.ListItems)
 
+	return &list, nil
+}
+
+// preload reads the associations on includes with a single query each, whatever the amount of lists: the items
+// along with their comment count, the assignments of those items, and the members joined with their user.
+func (lr *ListRepository) preload(includes models.ListIncludes) *gorm.DB {
+	query := lr.db
+
+	if includes.Items {
+		query = query.Preload("ListItems", func(db *gorm.DB) *gorm.DB {
+			db = db.Select("list_items.*, (SELECT COUNT(*) FROM comments WHERE comments.list_item_id = list_items.id AND comments.deleted_at IS NULL) AS comment_count")
+			if includes.ItemAssignee != "" {
+				db = db.Where("list_items.id IN (?)", lr.db.Model(&listItemModels.ListItemAssignee{}).Select("list_item_id").Where("user_id = ?", includes.ItemAssignee))
+			}
+			return db.Order("list_items.position").Order("list_items.id")
+		}).Preload("ListItems.Assignments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		})
+	}
+
+	if includes.Members {
+		query = query.Preload("Members", func(db *gorm.DB) *gorm.DB {
+			// The join is spelled out so only the id and name of the members are read, their emails stay private.
+			// Its columns are quoted by the dialect, as Joins("User") would, to be read back into User
+			return db.Select("user_lists.*, ?, ?",
+				clause.Column{Table: "User", Name: "id", Alias: "User__id"},
+				clause.Column{Table: "User", Name: "name", Alias: "User__name"}).
+				Joins("LEFT JOIN ? ON ? = ?",
+					clause.Table{Name: "users", Alias: "User"},
+					clause.Column{Table: "user_lists", Name: "user_id"},
+					clause.Column{Table: "User", Name: "id"}).
+				Order("user_lists.id")
+		})
+	}
+
+	return query
+}
+
+// fillAssignees sets the Assignees of items from their preloaded Assignments.
+func fillAssignees(items []listItemModels.ListItem) {
+	for i := range items {
+		for _, assignment := range items[i].Assignments {
+			items[i].Assignees = append(items[i].Assignees, assignment.UserID)
+		}
+	}
+}
+
+// memberLists selects the ids of the lists userId is a member of, to be used as a subquery.
+func (lr *ListRepository) memberLists(userId string) *gorm.DB {
+	return lr.db.Model(&userListsModel.UserList{}).Select("list_id").Where("user_id = ?", userId)
+}
+
+// GetItemTimes returns when the items on the lists of userId were created and completed, from since on.
+func (lr *ListRepository) GetItemTimes(userId string, since time.Time) ([]time.Time, []time.Time, error) {
+
+	var created []time.Time
+	if result := lr.db.Model(&listItemModels.ListItem{}).Where("list_id IN (?) AND created_at >= ?", lr.memberLists(userId), since).Pluck("created_at", &created); result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	var completed []time.Time
+	if result := lr.db.Model(&listItemModels.ListItem{}).Where("list_id IN (?) AND completed_at >= ?", lr.memberLists(userId), since).Pluck("completed_at", &completed); result.Error != nil {
+		return nil, nil, result.Error
+	}
+
+	return created, completed, nil
 }
 
 func (lr *ListRepository) Get(listId string) (*models.List, error) {
@@ -66,22 +167,61 @@ func (lr *ListRepository) Get(listId string) (*models.List, error) {
 	return &list, nil
 }
 
-func (lr *ListRepository) Update(list models.List) (*models.List, error) {
+func (lr *ListRepository) Update(ctx context.Context, list models.List) (*models.List, error) {
 
-	if result := lr.db.Save(&list); result.Error != nil {
-		return nil, result.Error
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var before models.List
+		if result := tx.First(&before, list.ID); result.Error != nil {
+			return result.Error
+		}
+
+		if err := versions.Save(tx, activity.TargetList, listStates(before)...); err != nil {
+			return err
+		}
+
+		if result := tx.Omit(clause.Associations).Save(&list); result.Error != nil {
+			return result.Error
+		}
+
+		return activity.Record(tx, listEntry(list.ID, activity.ActionUpdated, activity.Diff(before, list)))
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
 	return &list, nil
 }
 
-func (lr *ListRepository) Delete(idToDelete string) (*string, error) {
-	//db.Delete(&users, []int{1,2,3})
-	if result := lr.db.Delete(&models.List{}, idToDelete); result.Error != nil || result.RowsAffected < 1 {
-		return nil, result.Error
+func (lr *ListRepository) Delete(ctx context.Context, idToDelete string) (*string, error) {
+	var deletedID *string
+
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var list models.List
+		if result := tx.Find(&list, idToDelete); result.Error != nil {
+			return result.Error
+		}
+
+		if list.ID != 0 {
+			if err := versions.Save(tx, activity.TargetList, listStates(list)...); err != nil {
+				return err
+			}
+		}
+
+		//db.Delete(&users, []int{1,2,3})
+		if result := tx.Delete(&models.List{}, idToDelete); result.Error != nil || result.RowsAffected < 1 {
+			return result.Error
+		}
+
+		deletedID = &idToDelete
+		return activity.Record(tx, listEntry(list.ID, activity.ActionDeleted, activity.Diff(list, nil)))
+	})
+
+	if err != nil {
+		return nil, err
 	}
 
-	return &idToDelete, nil
+	return deletedID, nil
 
 }
 
@@ -96,28 +236,129 @@ func (lr *ListRepository) GetListByInvitationCode(invitationCode string) (*model
 	return &list, nil
 }
 
-func (lr *ListRepository) BulkDelete(listsToDelete []models.List) (*int, error) {
+// BulkDelete deletes the lists on listIDs created by userID, the returned token undoes the whole deletion. Lists
+// created by somebody else are left untouched and reported as forbidden.
+func (lr *ListRepository) BulkDelete(ctx context.Context, listIDs []uint, userID uint) (*bulk.Result, *versions.UndoToken, error) {
+
+	result := bulk.NewResult()
+	var undoToken *versions.UndoToken
+
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var found []models.List
+		if query := tx.Find(&found, listIDs); query.Error != nil {
+			return query.Error
+		}
+
+		exists := make(map[uint]bool, len(found))
+		var lists []models.List
+		idsToDelete := []uint{}
+		for _, list := range found {
+			exists[list.ID] = true
+			if list.UserCreatorID != userID {
+				result.Forbidden = append(result.Forbidden, list.ID)
+				continue
+			}
+			lists = append(lists, list)
+			idsToDelete = append(idsToDelete, list.ID)
+		}
+		result.NotFound = bulk.Missing(listIDs, exists)
+
+		if len(idsToDelete) == 0 {
+			return nil
+		}
+
+		if err := versions.Save(tx, activity.TargetList, listStates(lists...)...); err != nil {
+			return err
+		}
+
+		if query := tx.Delete(&models.List{}, idsToDelete); query.Error != nil {
+			return query.Error
+		}
+
+		entries := make([]activity.Entry, 0, len(lists))
+		for _, list := range lists {
+			entries = append(entries, listEntry(list.ID, activity.ActionDeleted, activity.Diff(list, nil)))
+		}
+
+		if err := activity.Record(tx, entries...); err != nil {
+			return err
+		}
+
+		result.Succeeded = idsToDelete
+
+		var err error
+		undoToken, err = versions.NewUndoToken(tx, activity.TargetList, idsToDelete)
+		return err
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
 
-	idsToDelete := extractIdsFromListsToDelete(listsToDelete)
+	return result, undoToken, nil
+}
+
+// GetListsToReset returns recurring lists whose reset time already came.
+func (lr *ListRepository) GetListsToReset(now time.Time, limit int) (*[]models.List, error) {
+	var lists []models.List
 
-	result := lr.db.Delete(&models.List{}, idsToDelete)
+	result := lr.db.Where("next_reset_at <= ?", now).Order("next_reset_at").Limit(limit).Find(&lists)
 
 	if result.Error != nil {
 		return nil, result.Error
 	}
 
-	rowsDeleted := int(result.RowsAffected)
-
-	return &rowsDeleted, nil
+	return &lists, nil
 }
 
-func extractIdsFromListsToDelete(listsToDelete []models.List) *[]uint {
+// ResetList sets every item of the list back to pending and moves its reset time to nextResetAt, as long as the
+// list was still due to reset at resetAt. It returns false when another replica already reset it.
+func (lr *ListRepository) ResetList(ctx context.Context, listID uint, resetAt time.Time, nextResetAt *time.Time) (bool, error) {
+	reset := false
+
+	err := lr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.List{}).
+			Where("id = ? AND next_reset_at = ?", listID, resetAt).
+			Update("next_reset_at", nextResetAt)
+
+		if result.Error != nil || result.RowsAffected < 1 {
+			return result.Error
+		}
+
+		result = tx.Table("list_items").
+			Where("list_id = ? AND deleted_at IS NULL", listID).
+			Updates(map[string]interface{}{"is_done": false, "completed_at": nil})
 
-	var idsToDelete []uint
+		if result.Error != nil {
+			return result.Error
+		}
 
-	for _, list := range listsToDelete {
-		idsToDelete = append(idsToDelete, list.ID)
+		reset = true
+		return activity.Record(tx, listEntry(listID, activity.ActionReset, nil))
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return reset, nil
+}
+
+func listEntry(listID uint, action string, changes activity.Changes) activity.Entry {
+	return activity.Entry{
+		ListID:     listID,
+		Action:     action,
+		TargetType: activity.TargetList,
+		TargetID:   listID,
+		Changes:    changes,
 	}
+}
 
-	return &idsToDelete
-}
\ No newline at end of file
+// listStates describes lists before they change, to keep them as versions.
+func listStates(lists ...models.List) []versions.State {
+	states := make([]versions.State, 0, len(lists))
+	for _, list := range lists {
+		states = append(states, versions.State{ListID: list.ID, TargetID: list.ID, Value: list})
+	}
+	return states
+}
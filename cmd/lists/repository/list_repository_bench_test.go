@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/lists/models"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+)
+
+// readCases are the reads behind GET /v1/lists and GET /v1/lists/:id, each with the amount of queries it takes. The
+// amount must not grow with the amount of lists, items or members read.
+var readCases = []struct {
+	name     string
+	includes models.ListIncludes
+	queries  int
+}{
+	{name: "no includes", includes: models.ListIncludes{}, queries: 1},
+	{name: "items", includes: models.ListIncludes{Items: true}, queries: 3},
+	{name: "members", includes: models.ListIncludes{Members: true}, queries: 2},
+	{name: "items and members", includes: models.ListIncludes{Items: true, Members: true}, queries: 4},
+}
+
+var readSizes = []int{1, 10, 100}
+
+func TestListRepository_GetWithIncludes_Query_Count(t *testing.T) {
+	for _, tc := range readCases {
+		for _, size := range readSizes {
+			t.Run(fmt.Sprintf("%s, %d rows", tc.name, size), func(t *testing.T) {
+				listRepository, mock, queries := getCountingRepository(t)
+				expectReads(mock, tc.includes, 1, size)
+
+				result, err := listRepository.GetWithIncludes("1", tc.includes)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+				if tc.includes.Items && len(result.ListItems) != size {
+					t.Fatalf("got %d items, want %d", len(result.ListItems), size)
+				}
+				if tc.includes.Members && len(result.Members) != size {
+					t.Fatalf("got %d members, want %d", len(result.Members), size)
+				}
+				if *queries != tc.queries {
+					t.Fatalf("got %d queries, want %d", *queries, tc.queries)
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func TestListRepository_GetLists_Query_Count(t *testing.T) {
+	for _, tc := range readCases {
+		for _, size := range readSizes {
+			t.Run(fmt.Sprintf("%s, %d lists", tc.name, size), func(t *testing.T) {
+				listRepository, mock, queries := getCountingRepository(t)
+				expectReads(mock, tc.includes, size, size)
+
+				result, err := listRepository.GetLists("1", tc.includes)
+
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(*result) != size {
+					t.Fatalf("got %d lists, want %d", len(*result), size)
+				}
+				if *queries != tc.queries {
+					t.Fatalf("got %d queries, want %d", *queries, tc.queries)
+				}
+				if err := mock.ExpectationsWereMet(); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkListRepository_GetWithIncludes(b *testing.B) {
+	for _, tc := range readCases {
+		for _, size := range readSizes {
+			b.Run(fmt.Sprintf("%s/%d rows", tc.name, size), func(b *testing.B) {
+				listRepository, mock, queries := getCountingRepository(b)
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					expectReads(mock, tc.includes, 1, size)
+					b.StartTimer()
+
+					if _, err := listRepository.GetWithIncludes("1", tc.includes); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				reportQueries(b, *queries, tc.queries)
+			})
+		}
+	}
+}
+
+func BenchmarkListRepository_GetLists(b *testing.B) {
+	for _, tc := range readCases {
+		for _, size := range readSizes {
+			b.Run(fmt.Sprintf("%s/%d lists", tc.name, size), func(b *testing.B) {
+				listRepository, mock, queries := getCountingRepository(b)
+
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					expectReads(mock, tc.includes, size, size)
+					b.StartTimer()
+
+					if _, err := listRepository.GetLists("1", tc.includes); err != nil {
+						b.Fatal(err)
+					}
+				}
+
+				reportQueries(b, *queries, tc.queries)
+			})
+		}
+	}
+}
+
+// getCountingRepository returns a repository over a mocked database along with the amount of queries it has run.
+func getCountingRepository(tb testing.TB) (ListRepository, sqlmock.Sqlmock, *int) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		tb.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	tb.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+
+	if err != nil {
+		tb.Fatal(err.Error())
+	}
+
+	// Subqueries are built through a dry run of the query callbacks, only queries sent to the database are counted
+	queries := 0
+	err = gormDb.Callback().Query().After("gorm:query").Register("count_queries", func(db *gorm.DB) {
+		if !db.DryRun {
+			queries++
+		}
+	})
+	if err != nil {
+		tb.Fatal(err.Error())
+	}
+
+	return NewListRepository(gormDb), mock, &queries
+}
+
+// expectReads expects the queries of a read of lists lists, each one with size items and size members, the items
+// with a comment and an assignee each.
+func expectReads(mock sqlmock.Sqlmock, includes models.ListIncludes, lists int, size int) {
+	listRows := sqlmock.NewRows([]string{"id", "name", "user_creator_id", "item_count", "role"})
+	itemRows := sqlmock.NewRows([]string{"id", "list_id", "title", "comment_count"})
+	assigneeRows := sqlmock.NewRows([]string{"id", "list_item_id", "user_id"})
+	memberRows := sqlmock.NewRows([]string{"id", "list_id", "user_id", "User__id", "User__name"})
+
+	for list := 1; list <= lists; list++ {
+		listRows.AddRow(list, fmt.Sprint("List ", list), 1, size, models.RoleOwner)
+
+		for i := 1; i <= size; i++ {
+			itemID := list*size + i
+			itemRows.AddRow(itemID, list, fmt.Sprint("Item ", i), 1)
+			assigneeRows.AddRow(itemID, itemID, i)
+			memberRows.AddRow(itemID, list, i, i, fmt.Sprint("User ", i))
+		}
+	}
+
+	mock.ExpectQuery("FROM `lists`").WillReturnRows(listRows)
+
+	if includes.Items {
+		mock.ExpectQuery("FROM `list_items`").WillReturnRows(itemRows)
+		mock.ExpectQuery("FROM `list_item_assignees`").WillReturnRows(assigneeRows)
+	}
+
+	if includes.Members {
+		mock.ExpectQuery("FROM `user_lists` LEFT JOIN `users`").WillReturnRows(memberRows)
+	}
+}
+
+// reportQueries reports the queries per read, failing when a read took other than want queries.
+func reportQueries(b *testing.B, queries int, want int) {
+	b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+
+	if queries != want*b.N {
+		b.Fatalf("got %d queries on %d reads, want %d per read", queries, b.N, want)
+	}
+}
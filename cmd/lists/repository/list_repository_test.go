@@ -2,15 +2,21 @@ package repository
 
 import (
 	"SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/versions"
+	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func TestNewListRepository(t *testing.T) {
@@ -66,15 +72,19 @@ func TestListRepository_Create(t *testing.T) {
 	listRepository := NewListRepository(gormDb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists` (`created_at`,`updated_at`,`deleted_at`,`name`,`description`,`invite_code`,`user_creator_id`) VALUES (?,?,?,?,?,?,?)")).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists` (`created_at`,`updated_at`,`deleted_at`,`name`,`description`,`invite_code`,`user_creator_id`,`recurrence`,`recurrence_start`,`time_zone`,`next_reset_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	//WillReturnError(errors.New("error when insert into lists"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities` (`list_id`,`actor_id`,`action`,`target_type`,`target_id`,`changes`,`created_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(1, 1, activity.ActionCreated, activity.TargetList, 1, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	result, err := listRepository.Create(validList)
+	result, err := listRepository.Create(activity.WithActor(context.Background(), 1), validList)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListRepository_Create_Error(t *testing.T) {
@@ -101,11 +111,11 @@ func TestListRepository_Create_Error(t *testing.T) {
 	listRepository := NewListRepository(gormDb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists` (`created_at`,`updated_at`,`deleted_at`,`name`,`description`,`invite_code`,`user_creator_id`) VALUES (?,?,?,?,?,?,?)")).
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists` (`created_at`,`updated_at`,`deleted_at`,`name`,`description`,`invite_code`,`user_creator_id`,`recurrence`,`recurrence_start`,`time_zone`,`next_reset_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)")).
 		WillReturnError(errors.New("error when insert into lists"))
 	mock.ExpectCommit()
 
-	result, err := listRepository.Create(validList)
+	result, err := listRepository.Create(context.Background(), validList)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -133,20 +143,25 @@ func TestListRepository_GetLists(t *testing.T) {
 	}
 	gormDb.Debug()
 
-	row := sqlmock.NewRows([]string{"id"}).
-		AddRow(1)
+	lastActivityAt := time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "name", "user_creator_id", "item_count", "done_count", "member_count", "last_activity_at", "role"}).
+		AddRow(1, "Groceries", 1, 12, 7, 2, lastActivityAt, "owner").
+		AddRow(2, "Chores", 3, 0, 0, 3, lastActivityAt, "member")
 
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_lists` WHERE user_id = ? AND `user_lists`.`deleted_at` IS NULL")).
-		WillReturnRows(row)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL")).
-		WillReturnRows(row)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT lists.*,")).
+		WithArgs("1", "owner", "member", "1").
+		WillReturnRows(rows)
 
 	listRepository := NewListRepository(gormDb)
 
-	result, err := listRepository.GetLists("1")
+	result, err := listRepository.GetLists("1", models.ListIncludes{})
 
 	assert.NoError(t, err)
-	assert.NotNil(t, result)
+	assert.Len(t, *result, 2)
+	assert.Equal(t, "Groceries", (*result)[0].Name)
+	assert.Equal(t, &models.ListStats{ItemCount: 12, DoneCount: 7, MemberCount: 2, LastActivityAt: &lastActivityAt, Role: models.RoleOwner}, (*result)[0].Stats)
+	assert.Equal(t, models.RoleMember, (*result)[1].Stats.Role)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListRepository_GetLists_List_Repo_Error(t *testing.T) {
@@ -171,17 +186,12 @@ func TestListRepository_GetLists_List_Repo_Error(t *testing.T) {
 	}
 	gormDb.Debug()
 
-	row := sqlmock.NewRows([]string{"id"}).
-		AddRow(1)
-
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_lists` WHERE user_id = ? AND `user_lists`.`deleted_at` IS NULL")).
-		WillReturnRows(row)
-	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT lists.*,")).
 		WillReturnError(errors.New("error from list db"))
 
 	listRepository := NewListRepository(gormDb)
 
-	result, err := listRepository.GetLists("1")
+	result, err := listRepository.GetLists("1", models.ListIncludes{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -214,7 +224,7 @@ func TestListRepository_GetLists_Error(t *testing.T) {
 
 	listRepository := NewListRepository(gormDb)
 
-	result, err := listRepository.GetLists("1")
+	result, err := listRepository.GetLists("1", models.ListIncludes{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -256,6 +266,68 @@ func TestListRepository_Get(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestListRepository_GetWithIncludes_Members(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT user_lists.*, `User`.`id` AS `User__id`, `User`.`name` AS `User__name` FROM `user_lists` LEFT JOIN `users` `User`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "User__id", "User__name"}).AddRow(3, 1, 5, 5, "Ana"))
+
+	listRepository := NewListRepository(gormDb)
+
+	result, err := listRepository.GetWithIncludes("1", models.ListIncludes{Members: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", result.Members[0].User.Name)
+	assert.Empty(t, result.Members[0].User.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepository_GetWithIncludes_Members_Postgres(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "lists" WHERE "lists"."id" = $1`)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT user_lists.*, "User"."id" AS "User__id", "User"."name" AS "User__name" FROM "user_lists" LEFT JOIN "users" "User" ON "user_lists"."user_id" = "User"."id" WHERE "user_lists"."list_id" = $1 AND "user_lists"."deleted_at" IS NULL ORDER BY user_lists.id`)).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "User__id", "User__name"}).AddRow(3, 1, 5, 5, "Ana"))
+
+	listRepository := NewListRepository(gormDb)
+
+	result, err := listRepository.GetWithIncludes("1", models.ListIncludes{Members: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", result.Members[0].User.Name)
+	assert.Empty(t, result.Members[0].User.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestListRepository_Get_Error(t *testing.T) {
 	//TODO mejorar e ir a esta forma
 	//gormDb, mock := GetMockDB()
@@ -309,19 +381,35 @@ func TestListRepository_Update(t *testing.T) {
 	gormDb.Debug()
 
 	validList := GetValidList()
+	validList.ID = 1
+	validList.Name = "renamed"
 
 	listRepository := NewListRepository(gormDb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists` (`created_at`,`updated_at`,`deleted_at`,`name`,`description`,`invite_code`,`user_creator_id`) VALUES (?,?,?,?,?,?,?)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL ORDER BY `lists`.`id` LIMIT 1")).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "invite_code", "user_creator_id"}).
+			AddRow(1, "mocked name", "mocked description", "mockedCode", 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions` WHERE target_type = ? AND target_id IN (?) GROUP BY `target_id`")).
+		WithArgs(activity.TargetList, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}).AddRow(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions` (`target_type`,`target_id`,`list_id`,`number`,`actor_id`,`snapshot`,`created_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(activity.TargetList, 1, 1, 2, nil, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WithArgs(1, nil, activity.ActionUpdated, activity.TargetList, 1, `{"name":{"before":"mocked name","after":"renamed"}}`, sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	result, err := listRepository.Update(validList)
+	result, err := listRepository.Update(context.Background(), validList)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, result.ID, uint(1))
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListRepository_Update_Error(t *testing.T) {
@@ -344,15 +432,22 @@ func TestListRepository_Update_Error(t *testing.T) {
 	gormDb.Debug()
 
 	validList := GetValidList()
+	validList.ID = 1
 
 	listRepository := NewListRepository(gormDb)
 
 	mock.ExpectBegin()
-	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `lists` (`created_at`,`updated_at`,`deleted_at`,`name`,`description`,`invite_code`,`user_creator_id`) VALUES (?,?,?,?,?,?,?)")).
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ?")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET")).
 		WillReturnError(errors.New("error when updating into lists"))
-	mock.ExpectCommit()
+	mock.ExpectRollback()
 
-	result, err := listRepository.Update(validList)
+	result, err := listRepository.Update(context.Background(), validList)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -378,16 +473,26 @@ func TestListRepository_Delete(t *testing.T) {
 	gormDb.Debug()
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "mocked name"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET `deleted_at`=? WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL")).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WithArgs(1, nil, activity.ActionDeleted, activity.TargetList, 1, `{"name":{"before":"mocked name"}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	listRepository := NewListRepository(gormDb)
 
-	result, err := listRepository.Delete("1")
+	result, err := listRepository.Delete(context.Background(), "1")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestListRepository_Delete_Error(t *testing.T) {
@@ -410,18 +515,106 @@ func TestListRepository_Delete_Error(t *testing.T) {
 	gormDb.Debug()
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET `deleted_at`=? WHERE `lists`.`id` = ? AND `lists`.`deleted_at` IS NULL")).
 		WillReturnError(errors.New("error when updating list"))
-	mock.ExpectCommit()
+	mock.ExpectRollback()
 
 	listRepository := NewListRepository(gormDb)
 
-	result, err := listRepository.Delete("1")
+	result, err := listRepository.Delete(context.Background(), "1")
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
 
+func TestListRepository_BulkDelete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` IN (?,?,?,?) AND `lists`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_creator_id"}).AddRow(1, "first", 7).AddRow(2, "second", 7).AddRow(4, "shared", 8))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions`")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET `deleted_at`=? WHERE `lists`.`id` IN (?,?) AND `lists`.`deleted_at` IS NULL")).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `undo_tokens` WHERE user_id = ? AND expires_at < ?")).
+		WithArgs(7, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `undo_tokens` (`token`,`user_id`,`target_type`,`target_ids`,`expires_at`,`created_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), 7, activity.TargetList, "[1,2]", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	listRepository := NewListRepository(gormDb)
+
+	result, undoToken, err := listRepository.BulkDelete(activity.WithActor(context.Background(), 7), []uint{1, 2, 3, 4}, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &bulk.Result{Succeeded: []uint{1, 2}, NotFound: []uint{3}, Forbidden: []uint{4}, Unchanged: []uint{}}, result)
+	assert.Equal(t, versions.IDs{1, 2}, undoToken.TargetIDs)
+	assert.NotEmpty(t, undoToken.Token)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListRepository_BulkDelete_Nothing_Deleted(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` IN (?,?) AND `lists`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "user_creator_id"}).AddRow(4, "shared", 8))
+	mock.ExpectCommit()
+
+	listRepository := NewListRepository(gormDb)
+
+	result, undoToken, err := listRepository.BulkDelete(context.Background(), []uint{4, 5}, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{4}, result.Forbidden)
+	assert.Equal(t, []uint{5}, result.NotFound)
+	assert.Nil(t, undoToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestListRepository_GetListByInvitationCode(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	if err != nil {
@@ -514,3 +707,138 @@ func GetMockDB() (*gorm.DB, sqlmock.Sqlmock) {
 
 	return gormDb, mock
 }
+
+func TestListRepository_GetListsToReset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	now := time.Date(2022, 3, 6, 8, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE next_reset_at <= ? AND `lists`.`deleted_at` IS NULL ORDER BY next_reset_at LIMIT 100")).
+		WithArgs(now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recurrence", "next_reset_at"}).AddRow(1, "FREQ=WEEKLY", now))
+
+	listRepository := NewListRepository(gormDb)
+
+	result, err := listRepository.GetListsToReset(now, 100)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestListRepository_ResetList(t *testing.T) {
+	resetAt := time.Date(2022, 3, 6, 8, 0, 0, 0, time.UTC)
+	nextResetAt := resetAt.AddDate(0, 0, 7)
+
+	tests := []struct {
+		name        string
+		listUpdated int64
+		want        bool
+	}{
+		{
+			name:        "Due list is reset",
+			listUpdated: 1,
+			want:        true,
+		},
+		{
+			name:        "List already reset by another replica",
+			listUpdated: 0,
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer db.Close()
+
+			gormDb, err := gorm.Open(mysql.New(mysql.Config{
+				Conn:                      db,
+				SkipInitializeWithVersion: true,
+			}), &gorm.Config{
+				Logger: logger.Default.LogMode(logger.Info),
+			})
+
+			if err != nil {
+				t.Error(err.Error())
+			}
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET `next_reset_at`=?,`updated_at`=? WHERE (id = ? AND next_reset_at = ?) AND `lists`.`deleted_at` IS NULL")).
+				WithArgs(nextResetAt, sqlmock.AnyArg(), 1, resetAt).
+				WillReturnResult(sqlmock.NewResult(0, tt.listUpdated))
+			if tt.want {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `completed_at`=?,`is_done`=? WHERE list_id = ? AND deleted_at IS NULL")).
+					WithArgs(nil, false, 1).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+					WithArgs(1, nil, activity.ActionReset, activity.TargetList, 1, "{}", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			}
+			mock.ExpectCommit()
+
+			listRepository := NewListRepository(gormDb)
+
+			result, err := listRepository.ResetList(context.Background(), 1, resetAt, &nextResetAt)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListRepository_GetItemTimes(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	since := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	createdAt := time.Date(2022, 3, 2, 10, 0, 0, 0, time.UTC)
+	completedAt := time.Date(2022, 3, 3, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `created_at` FROM `list_items` WHERE (list_id IN (SELECT `list_id` FROM `user_lists` WHERE user_id = ? AND `user_lists`.`deleted_at` IS NULL) AND created_at >= ?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs("1", since).
+		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `completed_at` FROM `list_items` WHERE (list_id IN (SELECT `list_id` FROM `user_lists` WHERE user_id = ? AND `user_lists`.`deleted_at` IS NULL) AND completed_at >= ?) AND `list_items`.`deleted_at` IS NULL")).
+		WithArgs("1", since).
+		WillReturnRows(sqlmock.NewRows([]string{"completed_at"}).AddRow(completedAt))
+
+	listRepository := NewListRepository(gormDb)
+
+	created, completed, err := listRepository.GetItemTimes("1", since)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{createdAt}, created)
+	assert.Equal(t, []time.Time{completedAt}, completed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
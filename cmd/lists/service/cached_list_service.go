@@ -0,0 +1,54 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/cache"
+	"context"
+	"strconv"
+	"time"
+)
+
+// CachedListService keeps the lists of each user, and each list read with its associations, on a cache for up
+// to ttl. Entries are dropped by the cache invalidator when the activity of a list changes them.
+type CachedListService struct {
+	ListService
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+func NewCachedListService(listService ListService, readCache cache.Cache, ttl time.Duration) CachedListService {
+	return CachedListService{ListService: listService, cache: readCache, ttl: ttl}
+}
+
+// GetLists caches the reads without includes, the ones the list overview makes.
+func (cls *CachedListService) GetLists(userId string, includes models.ListIncludes) (*[]models.List, error) {
+	userID, err := strconv.ParseUint(userId, 10, 32)
+	if err != nil || includes != (models.ListIncludes{}) {
+		return cls.ListService.GetLists(userId, includes)
+	}
+
+	var lists *[]models.List
+	err = cache.Fetch(context.Background(), cls.cache, cache.UserListsKey(uint(userID)), cls.ttl, &lists, func() (err error) {
+		lists, err = cls.ListService.GetLists(userId, includes)
+		return err
+	})
+
+	return lists, err
+}
+
+// GetWithIncludes caches every read but the ones filtering items by assignee.
+func (cls *CachedListService) GetWithIncludes(listID string, includes models.ListIncludes) (*models.List, error) {
+	id, err := strconv.ParseUint(listID, 10, 32)
+	if err != nil || includes.ItemAssignee != "" {
+		return cls.ListService.GetWithIncludes(listID, includes)
+	}
+
+	var list *models.List
+	key := cache.ListKey(uint(id), includes.Items, includes.Members)
+	err = cache.Fetch(context.Background(), cls.cache, key, cls.ttl, &list, func() (err error) {
+		list, err = cls.ListService.GetWithIncludes(listID, includes)
+		return err
+	})
+
+	return list, err
+}
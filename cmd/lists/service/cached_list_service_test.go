@@ -0,0 +1,82 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/cache"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+func TestCachedListService_GetLists(t *testing.T) {
+	list := GetValidList()
+	lists := []models.List{list}
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetLists("1", models.ListIncludes{}).Return(&lists, nil).Times(2)
+	mockedRepo.EXPECT().GetLists("1", models.ListIncludes{Members: true}).Return(&lists, nil).Times(2)
+
+	readCache := cache.NewLRU(10)
+	listService := NewCachedListService(NewListService(mockedRepo), readCache, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		result, err := listService.GetLists("1", models.ListIncludes{})
+		assert.NoError(t, err)
+		assert.Equal(t, lists, *result)
+	}
+
+	// Reads with includes are not cached
+	for i := 0; i < 2; i++ {
+		_, err := listService.GetLists("1", models.ListIncludes{Members: true})
+		assert.NoError(t, err)
+	}
+
+	assert.NoError(t, readCache.Delete(context.Background(), cache.UserListsKey(1)))
+	_, err := listService.GetLists("1", models.ListIncludes{})
+	assert.NoError(t, err)
+}
+
+func TestCachedListService_GetWithIncludes(t *testing.T) {
+	list := GetValidList()
+	list.ID = 4
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetWithIncludes("4", models.ListIncludes{Items: true}).Return(&list, nil).Times(1)
+	mockedRepo.EXPECT().GetWithIncludes("4", models.ListIncludes{Items: true, Members: true}).Return(&list, nil).Times(1)
+	mockedRepo.EXPECT().GetWithIncludes("4", models.ListIncludes{Items: true, ItemAssignee: "me"}).Return(&list, nil).Times(2)
+
+	readCache := cache.NewLRU(10)
+	listService := NewCachedListService(NewListService(mockedRepo), readCache, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		result, err := listService.GetWithIncludes("4", models.ListIncludes{Items: true})
+		assert.NoError(t, err)
+		assert.Equal(t, list, *result)
+
+		_, err = listService.GetWithIncludes("4", models.ListIncludes{Items: true, Members: true})
+		assert.NoError(t, err)
+
+		// Reads filtered by assignee are not cached
+		_, err = listService.GetWithIncludes("4", models.ListIncludes{Items: true, ItemAssignee: "me"})
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, readCache.Len())
+}
+
+func TestCachedListService_GetWithIncludes_Not_Found(t *testing.T) {
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetWithIncludes("4", models.ListIncludes{}).Return(nil, gorm.ErrRecordNotFound)
+
+	readCache := cache.NewLRU(10)
+	listService := NewCachedListService(NewListService(mockedRepo), readCache, time.Minute)
+
+	result, err := listService.GetWithIncludes("4", models.ListIncludes{})
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+	assert.Equal(t, 0, readCache.Len())
+}
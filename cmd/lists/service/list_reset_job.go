@@ -0,0 +1,79 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/recurrence"
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const resetBatchSize = 100
+
+// ListResetJob sets the items of recurring lists back to pending when their period rolls over, it implements
+// scheduler.Job. ResetList only succeeds for the replica that moves NextResetAt forward, so every replica can run it.
+type ListResetJob struct {
+	repository IListRepository
+	now        func() time.Time
+}
+
+func NewListResetJob(repository IListRepository) ListResetJob {
+	return ListResetJob{repository: repository, now: time.Now}
+}
+
+func (lrj *ListResetJob) Name() string {
+	return "list-resets"
+}
+
+func (lrj *ListResetJob) Run(ctx context.Context) error {
+	now := lrj.now()
+
+	lists, err := lrj.repository.GetListsToReset(now, resetBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, list := range *lists {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		next, err := nextReset(list, now)
+		if err != nil {
+			// A list whose rule can no longer be read stops resetting instead of blocking the others.
+			log.Print(fmt.Sprintf("Invalid recurrence on list %d: %s", list.ID, err.Error()))
+		}
+
+		if _, err := lrj.repository.ResetList(ctx, list.ID, *list.NextResetAt, next); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// nextReset is the first occurrence of the list rule after now, nil when the rule ended.
+func nextReset(list models.List, now time.Time) (*time.Time, error) {
+	rule, err := recurrence.Parse(list.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := recurrence.Location(list.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	start := *list.NextResetAt
+	if list.RecurrenceStart != nil {
+		start = *list.RecurrenceStart
+	}
+
+	next, ok := rule.Next(start, now, loc)
+	if !ok {
+		return nil, nil
+	}
+
+	return &next, nil
+}
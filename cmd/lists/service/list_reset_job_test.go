@@ -0,0 +1,71 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/lists/models"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestListResetJob_Resets_Due_Lists(t *testing.T) {
+	loc, _ := time.LoadLocation("Europe/Madrid")
+
+	// Sundays at 08:00 in Madrid. Clocks moved forward on 2022-03-27, the next reset keeps the local time.
+	start := time.Date(2022, 3, 6, 8, 0, 0, 0, loc)
+	resetAt := time.Date(2022, 3, 27, 8, 0, 0, 0, loc)
+	now := resetAt.Add(30 * time.Second)
+	want := time.Date(2022, 4, 3, 8, 0, 0, 0, loc)
+
+	list := models.List{
+		Recurrence:      "FREQ=WEEKLY;BYDAY=SU",
+		RecurrenceStart: &start,
+		TimeZone:        "Europe/Madrid",
+		NextResetAt:     &resetAt,
+	}
+	list.ID = 3
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetListsToReset(now, resetBatchSize).Return(&[]models.List{list}, nil)
+	mockedRepo.EXPECT().ResetList(gomock.Any(), uint(3), resetAt, gomock.Any()).DoAndReturn(func(_ context.Context, listID uint, resetAt time.Time, next *time.Time) (bool, error) {
+		assert.True(t, want.Equal(*next), "want %s, got %s", want, next)
+		return true, nil
+	})
+
+	job := NewListResetJob(mockedRepo)
+	job.now = func() time.Time { return now }
+
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func TestListResetJob_Stops_Ended_Recurrence(t *testing.T) {
+	start := time.Date(2022, 3, 6, 8, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+
+	list := models.List{
+		Recurrence:      "FREQ=WEEKLY;UNTIL=20220310",
+		RecurrenceStart: &start,
+		NextResetAt:     &start,
+	}
+	list.ID = 3
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetListsToReset(now, resetBatchSize).Return(&[]models.List{list}, nil)
+	mockedRepo.EXPECT().ResetList(gomock.Any(), uint(3), start, nil).Return(true, nil)
+
+	job := NewListResetJob(mockedRepo)
+	job.now = func() time.Time { return now }
+
+	assert.NoError(t, job.Run(context.Background()))
+}
+
+func TestListResetJob_Error(t *testing.T) {
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetListsToReset(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from db"))
+
+	job := NewListResetJob(mockedRepo)
+
+	assert.Error(t, job.Run(context.Background()))
+}
@@ -2,18 +2,27 @@ package service
 
 import (
 	"SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/bulk"
+	"SuperListsAPI/internal/recurrence"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"time"
 )
 
 //go:generate mockgen -source=list_service.go -destination lists_service_mock.go -package service
 
 type IListRepository interface {
-	Create(list models.List) (*models.List, error)
-	GetLists(userId string) (*[]models.List, error)
+	Create(ctx context.Context, list models.List) (*models.List, error)
+	GetLists(userId string, includes models.ListIncludes) (*[]models.List, error)
 	Get(listId string) (*models.List, error)
-	Update(list models.List) (*models.List, error)
-	Delete(listID string) (*string, error)
+	GetWithIncludes(listID string, includes models.ListIncludes) (*models.List, error)
+	Update(ctx context.Context, list models.List) (*models.List, error)
+	Delete(ctx context.Context, listID string) (*string, error)
 	GetListByInvitationCode(invitationCode string) (*models.List, error)
-	BulkDelete(listsToDelete []models.List) (*int, error)
+	BulkDelete(ctx context.Context, listIDs []uint, userID uint) (*bulk.Result, *versions.UndoToken, error)
+	GetListsToReset(now time.Time, limit int) (*[]models.List, error)
+	ResetList(ctx context.Context, listID uint, resetAt time.Time, nextResetAt *time.Time) (bool, error)
+	GetItemTimes(userId string, since time.Time) ([]time.Time, []time.Time, error)
 }
 
 type ListService struct {
@@ -24,30 +33,133 @@ func NewListService(repository IListRepository) ListService {
 	return ListService{listRepository: repository}
 }
 
-func (ls *ListService) Create(list models.List) (*models.List, error) {
-	return ls.listRepository.Create(list)
+func (ls *ListService) Create(ctx context.Context, list models.List) (*models.List, error) {
+	if err := scheduleReset(&list, time.Now()); err != nil {
+		return nil, err
+	}
+	return ls.listRepository.Create(ctx, list)
 }
 
-func (ls *ListService) GetLists(userId string) (*[]models.List, error) {
-	return ls.listRepository.GetLists(userId)
+func (ls *ListService) GetLists(userId string, includes models.ListIncludes) (*[]models.List, error) {
+	return ls.listRepository.GetLists(userId, includes)
 }
 
 func (ls *ListService) Get(listId string) (*models.List, error) {
 	return ls.listRepository.Get(listId)
 }
 
-func (ls *ListService) Update(list models.List) (*models.List, error) {
-	return ls.listRepository.Update(list)
+// GetWithIncludes returns the list listID along with the associations on includes.
+func (ls *ListService) GetWithIncludes(listID string, includes models.ListIncludes) (*models.List, error) {
+	return ls.listRepository.GetWithIncludes(listID, includes)
 }
 
-func (ls *ListService) Delete(listID string) (*string, error) {
-	return ls.listRepository.Delete(listID)
+func (ls *ListService) Update(ctx context.Context, list models.List) (*models.List, error) {
+	if err := scheduleReset(&list, time.Now()); err != nil {
+		return nil, err
+	}
+	return ls.listRepository.Update(ctx, list)
+}
+
+func (ls *ListService) Delete(ctx context.Context, listID string) (*string, error) {
+	return ls.listRepository.Delete(ctx, listID)
 }
 
 func (ls *ListService) GetListByInvitationCode(invitationCode string) (*models.List, error) {
 	return ls.listRepository.GetListByInvitationCode(invitationCode)
 }
 
-func (ls *ListService) BulkDelete(listsToDelete []models.List) (*int, error) {
-	return ls.listRepository.BulkDelete(listsToDelete)
+// BulkDelete deletes the lists on listIDs that userID created.
+func (ls *ListService) BulkDelete(ctx context.Context, listIDs []uint, userID uint) (*bulk.Result, *versions.UndoToken, error) {
+	return ls.listRepository.BulkDelete(ctx, listIDs, userID)
+}
+
+// GetCompletionStats counts the items created and completed on the lists of userID over the last days up to
+// now, grouped by day or week. Periods are taken on the location of now and weeks start on Monday.
+func (ls *ListService) GetCompletionStats(userID string, now time.Time, days int, interval string) (*models.CompletionStats, error) {
+	from := periodStart(now.AddDate(0, 0, 1-days), interval)
+
+	created, completed, err := ls.listRepository.GetItemTimes(userID, from)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := models.CompletionStats{
+		From:     from,
+		To:       now,
+		Interval: interval,
+		TimeZone: now.Location().String(),
+		Periods:  []models.CompletionCount{},
+	}
+
+	periods := map[string]int{}
+	for start := from; !start.After(now); start = nextPeriod(start, interval) {
+		key := start.Format(statsDateLayout)
+		periods[key] = len(stats.Periods)
+		stats.Periods = append(stats.Periods, models.CompletionCount{Start: key})
+	}
+
+	for _, t := range created {
+		if i, ok := periods[periodStart(t.In(now.Location()), interval).Format(statsDateLayout)]; ok {
+			stats.Periods[i].Created++
+			stats.Created++
+		}
+	}
+	for _, t := range completed {
+		if i, ok := periods[periodStart(t.In(now.Location()), interval).Format(statsDateLayout)]; ok {
+			stats.Periods[i].Completed++
+			stats.Completed++
+		}
+	}
+
+	return &stats, nil
+}
+
+const statsDateLayout = "2006-01-02"
+
+// periodStart returns the midnight that starts the day or week holding t.
+func periodStart(t time.Time, interval string) time.Time {
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	if interval == models.IntervalWeek {
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	}
+	return start
+}
+
+func nextPeriod(start time.Time, interval string) time.Time {
+	if interval == models.IntervalWeek {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// scheduleReset normalizes the list recurrence and sets when the list resets next. The recurrence starts now
+// when no start was given.
+func scheduleReset(list *models.List, now time.Time) error {
+	if list.Recurrence == "" {
+		list.RecurrenceStart = nil
+		list.NextResetAt = nil
+		return nil
+	}
+
+	rule, err := recurrence.Parse(list.Recurrence)
+	if err != nil {
+		return err
+	}
+
+	loc, err := recurrence.Location(list.TimeZone)
+	if err != nil {
+		return err
+	}
+
+	if list.RecurrenceStart == nil {
+		list.RecurrenceStart = &now
+	}
+
+	list.Recurrence = rule.String()
+	list.NextResetAt = nil
+	if next, ok := rule.Next(*list.RecurrenceStart, now, loc); ok {
+		list.NextResetAt = &next
+	}
+
+	return nil
 }
@@ -2,6 +2,8 @@ package service
 
 import (
 	"SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/recurrence"
+	"context"
 	"errors"
 	"github.com/gofrs/uuid"
 	"github.com/golang/mock/gomock"
@@ -9,6 +11,7 @@ import (
 	"gorm.io/gorm"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestNewListService(t *testing.T) {
@@ -45,10 +48,10 @@ func TestListService_Create(t *testing.T) {
 	validList := GetValidList()
 
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Create(gomock.Any()).Return(&validList, nil)
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validList, nil)
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.Create(validList)
+	result, err := listService.Create(context.Background(), validList)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -60,10 +63,10 @@ func TestListService_Create_Error(t *testing.T) {
 	validList := GetValidList()
 
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from list repository"))
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list repository"))
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.Create(validList)
+	result, err := listService.Create(context.Background(), validList)
 
 	assert.Error(t, err)
 	assert.Empty(t, result)
@@ -74,10 +77,10 @@ func TestListService_GetLists(t *testing.T) {
 	lists := []models.List{GetValidList(), GetValidList()}
 
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().GetLists(gomock.Any()).Return(&lists, nil)
+	mockedRepo.EXPECT().GetLists(gomock.Any(), models.ListIncludes{}).Return(&lists, nil)
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.GetLists("1")
+	result, err := listService.GetLists("1", models.ListIncludes{})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -86,10 +89,10 @@ func TestListService_GetLists(t *testing.T) {
 func TestListService_GetLists_Error(t *testing.T) {
 
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().GetLists(gomock.Any()).Return(nil, errors.New("error from list repository"))
+	mockedRepo.EXPECT().GetLists(gomock.Any(), models.ListIncludes{}).Return(nil, errors.New("error from list repository"))
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.GetLists("1")
+	result, err := listService.GetLists("1", models.ListIncludes{})
 
 	assert.Error(t, err)
 	assert.Empty(t, result)
@@ -120,14 +123,28 @@ func TestListService_Get_Error(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestListService_GetWithIncludes(t *testing.T) {
+	list := GetValidList()
+	includes := models.ListIncludes{Items: true, Members: true}
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetWithIncludes("1", includes).Return(&list, nil)
+	listService := NewListService(mockedRepo)
+
+	result, err := listService.GetWithIncludes("1", includes)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &list, result)
+}
+
 func TestListService_Update(t *testing.T) {
 
 	list := GetValidList()
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Update(gomock.Any()).Return(&list, nil)
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(&list, nil)
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.Update(list)
+	result, err := listService.Update(context.Background(), list)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -137,10 +154,10 @@ func TestListService_Update_Error(t *testing.T) {
 
 	list := GetValidList()
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Update(gomock.Any()).Return(nil, errors.New("error from list repository"))
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list repository"))
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.Update(list)
+	result, err := listService.Update(context.Background(), list)
 
 	assert.Error(t, err)
 	assert.Empty(t, result)
@@ -151,10 +168,10 @@ func TestListService_Delete(t *testing.T) {
 	deletedId := "1"
 
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Delete(gomock.Any()).Return(&deletedId, nil)
+	mockedRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedId, nil)
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.Delete("1")
+	result, err := listService.Delete(context.Background(), "1")
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, result)
@@ -163,10 +180,10 @@ func TestListService_Delete(t *testing.T) {
 func TestListService_Delete_Error(t *testing.T) {
 
 	mockedRepo := NewMockIListRepository(gomock.NewController(t))
-	mockedRepo.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from list repository"))
+	mockedRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from list repository"))
 	listService := NewListService(mockedRepo)
 
-	result, err := listService.Delete("1")
+	result, err := listService.Delete(context.Background(), "1")
 
 	assert.Error(t, err)
 	assert.Empty(t, result)
@@ -197,6 +214,121 @@ func TestListService_GetListByInvitationCode_Error(t *testing.T) {
 	assert.Empty(t, result)
 }
 
+func TestListService_Create_Schedules_Reset(t *testing.T) {
+
+	start := time.Date(2022, 3, 6, 8, 0, 0, 0, time.UTC)
+
+	validList := GetValidList()
+	validList.Recurrence = "freq=weekly;byday=su"
+	validList.RecurrenceStart = &start
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, list models.List) (*models.List, error) {
+		return &list, nil
+	})
+	listService := NewListService(mockedRepo)
+
+	result, err := listService.Create(context.Background(), validList)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=WEEKLY;BYDAY=SU", result.Recurrence)
+	assert.NotNil(t, result.NextResetAt)
+	assert.Equal(t, time.Sunday, result.NextResetAt.Weekday())
+	assert.Equal(t, 8, result.NextResetAt.Hour())
+	assert.True(t, result.NextResetAt.After(time.Now()))
+}
+
+func TestListService_Update_Clears_Reset(t *testing.T) {
+
+	resetAt := time.Now()
+
+	validList := GetValidList()
+	validList.NextResetAt = &resetAt
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, list models.List) (*models.List, error) {
+		return &list, nil
+	})
+	listService := NewListService(mockedRepo)
+
+	result, err := listService.Update(context.Background(), validList)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.NextResetAt)
+}
+
+func TestListService_Create_Invalid_Recurrence(t *testing.T) {
+
+	validList := GetValidList()
+	validList.Recurrence = "FREQ=YEARLY"
+
+	listService := NewListService(NewMockIListRepository(gomock.NewController(t)))
+
+	_, err := listService.Create(context.Background(), validList)
+
+	assert.True(t, errors.Is(err, recurrence.ErrInvalidRule))
+}
+
+func TestListService_GetCompletionStats_Days(t *testing.T) {
+	loc, _ := time.LoadLocation("America/Argentina/Buenos_Aires")
+	now := time.Date(2022, 3, 10, 15, 0, 0, 0, loc)
+	from := time.Date(2022, 3, 8, 0, 0, 0, 0, loc)
+	created := []time.Time{
+		time.Date(2022, 3, 8, 10, 0, 0, 0, loc),
+		time.Date(2022, 3, 10, 2, 0, 0, 0, time.UTC), // still March 9th in Buenos Aires
+	}
+	completed := []time.Time{time.Date(2022, 3, 10, 9, 0, 0, 0, loc)}
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemTimes("1", from).Return(created, completed, nil)
+	listService := NewListService(mockedRepo)
+
+	result, err := listService.GetCompletionStats("1", now, 3, models.IntervalDay)
+
+	assert.NoError(t, err)
+	assert.Equal(t, from, result.From)
+	assert.Equal(t, "America/Argentina/Buenos_Aires", result.TimeZone)
+	assert.Equal(t, 2, result.Created)
+	assert.Equal(t, 1, result.Completed)
+	assert.Equal(t, []models.CompletionCount{
+		{Start: "2022-03-08", Created: 1},
+		{Start: "2022-03-09", Created: 1},
+		{Start: "2022-03-10", Completed: 1},
+	}, result.Periods)
+}
+
+func TestListService_GetCompletionStats_Weeks(t *testing.T) {
+	now := time.Date(2022, 3, 10, 15, 0, 0, 0, time.UTC) // Thursday
+	from := time.Date(2022, 2, 28, 0, 0, 0, 0, time.UTC)
+	completed := []time.Time{
+		time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2022, 3, 7, 10, 0, 0, 0, time.UTC),
+	}
+
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemTimes("1", from).Return(nil, completed, nil)
+	listService := NewListService(mockedRepo)
+
+	result, err := listService.GetCompletionStats("1", now, 10, models.IntervalWeek)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []models.CompletionCount{
+		{Start: "2022-02-28", Completed: 1},
+		{Start: "2022-03-07", Completed: 1},
+	}, result.Periods)
+}
+
+func TestListService_GetCompletionStats_Error(t *testing.T) {
+	mockedRepo := NewMockIListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetItemTimes(gomock.Any(), gomock.Any()).Return(nil, nil, errors.New("error from list repository"))
+	listService := NewListService(mockedRepo)
+
+	result, err := listService.GetCompletionStats("1", time.Now(), 30, models.IntervalDay)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
 func GetValidList() models.List {
 
 	inviteCode, _ := uuid.NewV4()
@@ -6,7 +6,11 @@ package service
 
 import (
 	models "SuperListsAPI/cmd/lists/models"
+	bulk "SuperListsAPI/internal/bulk"
+	versions "SuperListsAPI/internal/versions"
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -35,48 +39,49 @@ func (m *MockIListRepository) EXPECT() *MockIListRepositoryMockRecorder {
 }
 
 // BulkDelete mocks base method.
-func (m *MockIListRepository) BulkDelete(listsToDelete []models.List) (*int, error) {
+func (m *MockIListRepository) BulkDelete(ctx context.Context, listIDs []uint, userID uint) (*bulk.Result, *versions.UndoToken, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BulkDelete", listsToDelete)
-	ret0, _ := ret[0].(*int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "BulkDelete", ctx, listIDs, userID)
+	ret0, _ := ret[0].(*bulk.Result)
+	ret1, _ := ret[1].(*versions.UndoToken)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // BulkDelete indicates an expected call of BulkDelete.
-func (mr *MockIListRepositoryMockRecorder) BulkDelete(listsToDelete interface{}) *gomock.Call {
+func (mr *MockIListRepositoryMockRecorder) BulkDelete(ctx, listIDs, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListRepository)(nil).BulkDelete), listsToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkDelete", reflect.TypeOf((*MockIListRepository)(nil).BulkDelete), ctx, listIDs, userID)
 }
 
 // Create mocks base method.
-func (m *MockIListRepository) Create(list models.List) (*models.List, error) {
+func (m *MockIListRepository) Create(ctx context.Context, list models.List) (*models.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", list)
+	ret := m.ctrl.Call(m, "Create", ctx, list)
 	ret0, _ := ret[0].(*models.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIListRepositoryMockRecorder) Create(list interface{}) *gomock.Call {
+func (mr *MockIListRepositoryMockRecorder) Create(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListRepository)(nil).Create), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIListRepository)(nil).Create), ctx, list)
 }
 
 // Delete mocks base method.
-func (m *MockIListRepository) Delete(listID string) (*string, error) {
+func (m *MockIListRepository) Delete(ctx context.Context, listID string) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", listID)
+	ret := m.ctrl.Call(m, "Delete", ctx, listID)
 	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIListRepositoryMockRecorder) Delete(listID interface{}) *gomock.Call {
+func (mr *MockIListRepositoryMockRecorder) Delete(ctx, listID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListRepository)(nil).Delete), listID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIListRepository)(nil).Delete), ctx, listID)
 }
 
 // Get mocks base method.
@@ -94,6 +99,22 @@ func (mr *MockIListRepositoryMockRecorder) Get(listId interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListRepository)(nil).Get), listId)
 }
 
+// GetItemTimes mocks base method.
+func (m *MockIListRepository) GetItemTimes(userId string, since time.Time) ([]time.Time, []time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemTimes", userId, since)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].([]time.Time)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetItemTimes indicates an expected call of GetItemTimes.
+func (mr *MockIListRepositoryMockRecorder) GetItemTimes(userId, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemTimes", reflect.TypeOf((*MockIListRepository)(nil).GetItemTimes), userId, since)
+}
+
 // GetListByInvitationCode mocks base method.
 func (m *MockIListRepository) GetListByInvitationCode(invitationCode string) (*models.List, error) {
 	m.ctrl.T.Helper()
@@ -110,31 +131,76 @@ func (mr *MockIListRepositoryMockRecorder) GetListByInvitationCode(invitationCod
 }
 
 // GetLists mocks base method.
-func (m *MockIListRepository) GetLists(userId string) (*[]models.List, error) {
+func (m *MockIListRepository) GetLists(userId string, includes models.ListIncludes) (*[]models.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLists", userId)
+	ret := m.ctrl.Call(m, "GetLists", userId, includes)
 	ret0, _ := ret[0].(*[]models.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLists indicates an expected call of GetLists.
-func (mr *MockIListRepositoryMockRecorder) GetLists(userId interface{}) *gomock.Call {
+func (mr *MockIListRepositoryMockRecorder) GetLists(userId, includes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockIListRepository)(nil).GetLists), userId, includes)
+}
+
+// GetListsToReset mocks base method.
+func (m *MockIListRepository) GetListsToReset(now time.Time, limit int) (*[]models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListsToReset", now, limit)
+	ret0, _ := ret[0].(*[]models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListsToReset indicates an expected call of GetListsToReset.
+func (mr *MockIListRepositoryMockRecorder) GetListsToReset(now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListsToReset", reflect.TypeOf((*MockIListRepository)(nil).GetListsToReset), now, limit)
+}
+
+// GetWithIncludes mocks base method.
+func (m *MockIListRepository) GetWithIncludes(listID string, includes models.ListIncludes) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithIncludes", listID, includes)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWithIncludes indicates an expected call of GetWithIncludes.
+func (mr *MockIListRepositoryMockRecorder) GetWithIncludes(listID, includes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithIncludes", reflect.TypeOf((*MockIListRepository)(nil).GetWithIncludes), listID, includes)
+}
+
+// ResetList mocks base method.
+func (m *MockIListRepository) ResetList(ctx context.Context, listID uint, resetAt time.Time, nextResetAt *time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetList", ctx, listID, resetAt, nextResetAt)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetList indicates an expected call of ResetList.
+func (mr *MockIListRepositoryMockRecorder) ResetList(ctx, listID, resetAt, nextResetAt interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLists", reflect.TypeOf((*MockIListRepository)(nil).GetLists), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetList", reflect.TypeOf((*MockIListRepository)(nil).ResetList), ctx, listID, resetAt, nextResetAt)
 }
 
 // Update mocks base method.
-func (m *MockIListRepository) Update(list models.List) (*models.List, error) {
+func (m *MockIListRepository) Update(ctx context.Context, list models.List) (*models.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", list)
+	ret := m.ctrl.Call(m, "Update", ctx, list)
 	ret0, _ := ret[0].(*models.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockIListRepositoryMockRecorder) Update(list interface{}) *gomock.Call {
+func (mr *MockIListRepositoryMockRecorder) Update(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListRepository)(nil).Update), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIListRepository)(nil).Update), ctx, list)
 }
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/notifications/models"
+	"SuperListsAPI/internal/httputil"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=notifications.go -destination notifications_mock.go -package handler
+
+type INotificationService interface {
+	GetNotificationsByUserID(userID string, unreadOnly bool) (*[]models.Notification, error)
+	MarkAsRead(userID string, notificationID string) (*int, error)
+}
+
+type NotificationHandler struct {
+	notificationService INotificationService
+}
+
+func NewNotificationHandler(notificationService INotificationService) NotificationHandler {
+	return NotificationHandler{notificationService: notificationService}
+}
+
+func (nh *NotificationHandler) GetNotifications(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	unreadOnly := false
+	if unread := c.Query("unread"); unread != "" {
+		parsed, err := strconv.ParseBool(unread)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid unread filter",
+			})
+			c.Abort()
+			return
+		}
+		unreadOnly = parsed
+	}
+
+	notifications, err := nh.notificationService.GetNotificationsByUserID(fmt.Sprint(userID), unreadOnly)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if notifications == nil || len(*notifications) < 1 {
+		c.JSON(http.StatusNoContent, notifications)
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+	return
+}
+
+func (nh *NotificationHandler) MarkAsRead(c *gin.Context) {
+	notificationID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := strconv.Atoi(notificationID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid notification id",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := nh.notificationService.MarkAsRead(fmt.Sprint(userID), notificationID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result == nil || *result < 1 {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Notification with id %s not found", notificationID))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
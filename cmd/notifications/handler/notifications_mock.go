@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notifications.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/notifications/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockINotificationService is a mock of INotificationService interface.
+type MockINotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationServiceMockRecorder
+}
+
+// MockINotificationServiceMockRecorder is the mock recorder for MockINotificationService.
+type MockINotificationServiceMockRecorder struct {
+	mock *MockINotificationService
+}
+
+// NewMockINotificationService creates a new mock instance.
+func NewMockINotificationService(ctrl *gomock.Controller) *MockINotificationService {
+	mock := &MockINotificationService{ctrl: ctrl}
+	mock.recorder = &MockINotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationService) EXPECT() *MockINotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNotificationsByUserID mocks base method.
+func (m *MockINotificationService) GetNotificationsByUserID(userID string, unreadOnly bool) (*[]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsByUserID", userID, unreadOnly)
+	ret0, _ := ret[0].(*[]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationsByUserID indicates an expected call of GetNotificationsByUserID.
+func (mr *MockINotificationServiceMockRecorder) GetNotificationsByUserID(userID, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsByUserID", reflect.TypeOf((*MockINotificationService)(nil).GetNotificationsByUserID), userID, unreadOnly)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockINotificationService) MarkAsRead(userID, notificationID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", userID, notificationID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockINotificationServiceMockRecorder) MarkAsRead(userID, notificationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockINotificationService)(nil).MarkAsRead), userID, notificationID)
+}
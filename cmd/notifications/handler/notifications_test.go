@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/notifications/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNewNotificationHandler(t *testing.T) {
+	type args struct {
+		service INotificationService
+	}
+	tests := []struct {
+		name string
+		args args
+		want NotificationHandler
+	}{
+		{
+			name: "Test with nil service should pass",
+			args: args{nil},
+			want: NewNotificationHandler(nil),
+		},
+		{
+			name: "Test with no nil service should pass",
+			args: args{NewMockINotificationService(gomock.NewController(t))},
+			want: NewNotificationHandler(NewMockINotificationService(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewNotificationHandler(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewNotificationHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationHandler_GetNotifications(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		query      string
+		setup      func(service *MockINotificationService)
+		wantStatus int
+	}{
+		{
+			name:   "Unread notifications of the caller",
+			userID: "7",
+			query:  "?unread=true",
+			setup: func(service *MockINotificationService) {
+				service.EXPECT().GetNotificationsByUserID("7", true).Return(&[]models.Notification{{ID: 1}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "No notifications",
+			userID: "7",
+			setup: func(service *MockINotificationService) {
+				service.EXPECT().GetNotificationsByUserID("7", false).Return(&[]models.Notification{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:       "Invalid unread filter",
+			userID:     "7",
+			query:      "?unread=maybe",
+			setup:      func(service *MockINotificationService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Missing user id",
+			setup:      func(service *MockINotificationService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service error",
+			userID: "7",
+			setup: func(service *MockINotificationService) {
+				service.EXPECT().GetNotificationsByUserID("7", false).Return(nil, errors.New("error from db"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMockINotificationService(gomock.NewController(t))
+			tt.setup(service)
+
+			notificationHandler := NewNotificationHandler(service)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/notifications"+tt.query, nil)
+			c.Request.Header.Set("user_id", tt.userID)
+
+			notificationHandler.GetNotifications(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestNotificationHandler_MarkAsRead(t *testing.T) {
+	updated := 1
+	notUpdated := 0
+
+	tests := []struct {
+		name           string
+		notificationID string
+		setup          func(service *MockINotificationService)
+		wantStatus     int
+	}{
+		{
+			name:           "Notification is marked as read",
+			notificationID: "5",
+			setup: func(service *MockINotificationService) {
+				service.EXPECT().MarkAsRead("7", "5").Return(&updated, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:           "Notification of another user",
+			notificationID: "5",
+			setup: func(service *MockINotificationService) {
+				service.EXPECT().MarkAsRead("7", "5").Return(&notUpdated, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Invalid notification id",
+			notificationID: "abc",
+			setup:          func(service *MockINotificationService) {},
+			wantStatus:     http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMockINotificationService(gomock.NewController(t))
+			tt.setup(service)
+
+			notificationHandler := NewNotificationHandler(service)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/notifications/"+tt.notificationID+"/read", nil)
+			c.Request.Header.Set("user_id", "7")
+			c.Params = gin.Params{{Key: "id", Value: tt.notificationID}}
+
+			notificationHandler.MarkAsRead(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
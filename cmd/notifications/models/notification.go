@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Notification is a reminder or a mention delivered in-app. DeliveryID is unique so a retried delivery
+// never shows up twice on the user inbox.
+type Notification struct {
+	ID         uint       `gorm:"primarykey" json:"id"`
+	UserID     uint       `json:"user_id"`
+	DeliveryID string     `json:"delivery_id"`
+	Kind       string     `json:"kind"`
+	ReminderID uint       `json:"reminder_id,omitempty"`
+	CommentID  uint       `json:"comment_id,omitempty"`
+	ListID     uint       `json:"list_id"`
+	ListItemID uint       `json:"list_item_id"`
+	Title      string     `json:"title"`
+	DueAt      *time.Time `json:"due_at,omitempty"`
+	ReadAt     *time.Time `json:"read_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
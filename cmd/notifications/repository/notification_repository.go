@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/notifications/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type NotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationRepository(db *gorm.DB) NotificationRepository {
+	return NotificationRepository{db: db}
+}
+
+// Create ignores notifications whose delivery id was already stored.
+func (nr *NotificationRepository) Create(notification models.Notification) (*models.Notification, error) {
+
+	result := nr.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "delivery_id"}},
+		DoNothing: true,
+	}).Create(&notification)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &notification, nil
+}
+
+func (nr *NotificationRepository) GetNotificationsByUserID(userID string, unreadOnly bool) (*[]models.Notification, error) {
+
+	var notifications []models.Notification
+
+	query := nr.db.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	if result := query.Order("created_at desc").Find(&notifications); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &notifications, nil
+}
+
+// MarkAsRead only touches the notification when it belongs to userID and keeps the first read time.
+func (nr *NotificationRepository) MarkAsRead(userID string, notificationID string, readAt time.Time) (*int, error) {
+
+	result := nr.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", notificationID, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rowsUpdated := int(result.RowsAffected)
+
+	return &rowsUpdated, nil
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/notifications/models"
+	"SuperListsAPI/internal/notify"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewNotificationRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want NotificationRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewNotificationRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewNotificationRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewNotificationRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewNotificationRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationRepository_Create_Ignores_Repeated_Delivery(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	notificationRepository := NewNotificationRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `notifications` (`user_id`,`delivery_id`,`kind`,`reminder_id`,`comment_id`,`list_id`,`list_item_id`,`title`,`due_at`,`read_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `id`=`id`")).
+		WithArgs(1, "7", notify.KindReminder, 2, 0, 3, 4, "Buy milk", sqlmock.AnyArg(), nil, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := notificationRepository.Create(GetValidNotification())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestNotificationRepository_Create_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	notificationRepository := NewNotificationRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `notifications`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := notificationRepository.Create(GetValidNotification())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestNotificationRepository_GetNotificationsByUserID(t *testing.T) {
+	tests := []struct {
+		name       string
+		unreadOnly bool
+		query      string
+	}{
+		{
+			name:  "All notifications",
+			query: "SELECT * FROM `notifications` WHERE user_id = ? ORDER BY created_at desc",
+		},
+		{
+			name:       "Unread notifications",
+			unreadOnly: true,
+			query:      "SELECT * FROM `notifications` WHERE user_id = ? AND read_at IS NULL ORDER BY created_at desc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDb, mock := getMockedDatabase(t)
+
+			notificationRepository := NewNotificationRepository(gormDb)
+
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs("1").
+				WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "title"}).AddRow(5, 1, "Buy milk"))
+
+			result, err := notificationRepository.GetNotificationsByUserID("1", tt.unreadOnly)
+
+			assert.NoError(t, err)
+			assert.Len(t, *result, 1)
+			assert.Nil(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestNotificationRepository_MarkAsRead(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	notificationRepository := NewNotificationRepository(gormDb)
+
+	readAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `notifications` SET `read_at`=COALESCE(read_at, ?) WHERE id = ? AND user_id = ?")).
+		WithArgs(readAt, "5", "1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := notificationRepository.MarkAsRead("1", "5", readAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func GetValidNotification() models.Notification {
+	dueAt := time.Date(2022, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	return models.Notification{
+		UserID:     1,
+		DeliveryID: "7",
+		Kind:       notify.KindReminder,
+		ReminderID: 2,
+		ListID:     3,
+		ListItemID: 4,
+		Title:      "Buy milk",
+		DueAt:      &dueAt,
+	}
+}
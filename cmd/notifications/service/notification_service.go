@@ -0,0 +1,58 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/notifications/models"
+	"SuperListsAPI/internal/notify"
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=notification_service.go -destination notification_service_mock.go -package service
+
+type INotificationRepository interface {
+	Create(notification models.Notification) (*models.Notification, error)
+	GetNotificationsByUserID(userID string, unreadOnly bool) (*[]models.Notification, error)
+	MarkAsRead(userID string, notificationID string, readAt time.Time) (*int, error)
+}
+
+type NotificationService struct {
+	repository INotificationRepository
+}
+
+func NewNotificationService(repository INotificationRepository) NotificationService {
+	return NotificationService{repository: repository}
+}
+
+// Notify stores the event on the user inbox, it is the in-app notify.Notifier.
+func (ns *NotificationService) Notify(ctx context.Context, event notify.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	notification := models.Notification{
+		UserID:     event.UserID,
+		DeliveryID: event.DeliveryID,
+		Kind:       event.Kind,
+		ReminderID: event.ReminderID,
+		CommentID:  event.CommentID,
+		ListID:     event.ListID,
+		ListItemID: event.ListItemID,
+		Title:      event.Title,
+	}
+
+	if !event.DueAt.IsZero() {
+		notification.DueAt = &event.DueAt
+	}
+
+	_, err := ns.repository.Create(notification)
+
+	return err
+}
+
+func (ns *NotificationService) GetNotificationsByUserID(userID string, unreadOnly bool) (*[]models.Notification, error) {
+	return ns.repository.GetNotificationsByUserID(userID, unreadOnly)
+}
+
+func (ns *NotificationService) MarkAsRead(userID string, notificationID string) (*int, error) {
+	return ns.repository.MarkAsRead(userID, notificationID, time.Now())
+}
@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notification_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/notifications/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockINotificationRepository is a mock of INotificationRepository interface.
+type MockINotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotificationRepositoryMockRecorder
+}
+
+// MockINotificationRepositoryMockRecorder is the mock recorder for MockINotificationRepository.
+type MockINotificationRepositoryMockRecorder struct {
+	mock *MockINotificationRepository
+}
+
+// NewMockINotificationRepository creates a new mock instance.
+func NewMockINotificationRepository(ctrl *gomock.Controller) *MockINotificationRepository {
+	mock := &MockINotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockINotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotificationRepository) EXPECT() *MockINotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockINotificationRepository) Create(notification models.Notification) (*models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", notification)
+	ret0, _ := ret[0].(*models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockINotificationRepositoryMockRecorder) Create(notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockINotificationRepository)(nil).Create), notification)
+}
+
+// GetNotificationsByUserID mocks base method.
+func (m *MockINotificationRepository) GetNotificationsByUserID(userID string, unreadOnly bool) (*[]models.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationsByUserID", userID, unreadOnly)
+	ret0, _ := ret[0].(*[]models.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationsByUserID indicates an expected call of GetNotificationsByUserID.
+func (mr *MockINotificationRepositoryMockRecorder) GetNotificationsByUserID(userID, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationsByUserID", reflect.TypeOf((*MockINotificationRepository)(nil).GetNotificationsByUserID), userID, unreadOnly)
+}
+
+// MarkAsRead mocks base method.
+func (m *MockINotificationRepository) MarkAsRead(userID, notificationID string, readAt time.Time) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAsRead", userID, notificationID, readAt)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkAsRead indicates an expected call of MarkAsRead.
+func (mr *MockINotificationRepositoryMockRecorder) MarkAsRead(userID, notificationID, readAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAsRead", reflect.TypeOf((*MockINotificationRepository)(nil).MarkAsRead), userID, notificationID, readAt)
+}
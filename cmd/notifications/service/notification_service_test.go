@@ -0,0 +1,95 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/notifications/models"
+	"SuperListsAPI/internal/notify"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewNotificationService(t *testing.T) {
+	type args struct {
+		repository INotificationRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want NotificationService
+	}{
+		{
+			name: "Service with nil repo should pass",
+			args: args{nil},
+			want: NewNotificationService(nil),
+		},
+		{
+			name: "Service with no nil repo should pass",
+			args: args{NewMockINotificationRepository(gomock.NewController(t))},
+			want: NewNotificationService(NewMockINotificationRepository(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewNotificationService(tt.args.repository); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewNotificationService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationService_Notify(t *testing.T) {
+	event := notify.Event{
+		DeliveryID: "7",
+		Kind:       notify.KindReminder,
+		ReminderID: 2,
+		UserID:     1,
+		ListID:     3,
+		ListItemID: 4,
+		Title:      "Buy milk",
+		DueAt:      time.Date(2022, 3, 1, 18, 0, 0, 0, time.UTC),
+		Channel:    notify.ChannelInApp,
+	}
+
+	mockedRepo := NewMockINotificationRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(models.Notification{
+		UserID:     1,
+		DeliveryID: "7",
+		Kind:       notify.KindReminder,
+		ReminderID: 2,
+		ListID:     3,
+		ListItemID: 4,
+		Title:      "Buy milk",
+		DueAt:      &event.DueAt,
+	}).Return(&models.Notification{ID: 1}, nil)
+
+	notificationService := NewNotificationService(mockedRepo)
+
+	assert.NoError(t, notificationService.Notify(context.Background(), event))
+}
+
+func TestNotificationService_Notify_Error(t *testing.T) {
+	mockedRepo := NewMockINotificationRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from db"))
+
+	notificationService := NewNotificationService(mockedRepo)
+
+	assert.Error(t, notificationService.Notify(context.Background(), notify.Event{DeliveryID: "7"}))
+}
+
+func TestNotificationService_MarkAsRead(t *testing.T) {
+	updated := 1
+
+	mockedRepo := NewMockINotificationRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().MarkAsRead("1", "5", gomock.Any()).Return(&updated, nil)
+
+	notificationService := NewNotificationService(mockedRepo)
+
+	result, err := notificationService.MarkAsRead("1", "5")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
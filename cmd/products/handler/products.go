@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/products/models"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=products.go -destination products_mock.go -package handler
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	// maxImportSize is the biggest csv file accepted by the importer, in bytes.
+	maxImportSize = 10 << 20
+)
+
+type IProductService interface {
+	Create(product models.Product) (*models.Product, error)
+	Get(productID string) (*models.Product, error)
+	Update(product models.Product) (*models.Product, error)
+	Delete(productID string) (*int, error)
+	Search(term string, limit int) (*[]models.Product, error)
+	ImportCSV(file io.Reader) (*models.ImportResult, error)
+}
+
+type ProductHandler struct {
+	productService IProductService
+}
+
+func NewProductHandler(productService IProductService) ProductHandler {
+	return ProductHandler{productService: productService}
+}
+
+func (ph *ProductHandler) Create(c *gin.Context) {
+	var product models.Product
+
+	validate := validator.New()
+	err := c.ShouldBindJSON(&product)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+	err = validate.Struct(product)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := ph.productService.Create(product)
+
+	if errors.Is(err, models.ErrInvalidBarcode) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+func (ph *ProductHandler) Get(c *gin.Context) {
+	productID := c.Param("id")
+
+	if _, err := strconv.Atoi(productID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid product id",
+		})
+		c.Abort()
+		return
+	}
+
+	product, err := ph.productService.Get(productID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Product with id %s not found", productID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+	return
+}
+
+func (ph *ProductHandler) Update(c *gin.Context) {
+	var product models.Product
+
+	productID := c.Param("id")
+
+	err := c.ShouldBindJSON(&product)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	if _, err := strconv.Atoi(productID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid product id",
+		})
+		c.Abort()
+		return
+	}
+
+	if fmt.Sprint(product.ID) != productID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing product id on request path or product id mistmatch",
+		})
+		c.Abort()
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(product)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := ph.productService.Update(product)
+
+	if errors.Is(err, models.ErrInvalidBarcode) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (ph *ProductHandler) Delete(c *gin.Context) {
+	productID := c.Param("id")
+
+	if _, err := strconv.Atoi(productID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid product id",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := ph.productService.Delete(productID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result == nil || *result < 1 {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Product with id %s not found", productID))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (ph *ProductHandler) Search(c *gin.Context) {
+	term := c.Query("q")
+
+	limit := defaultSearchLimit
+	if rawLimit := c.Query("limit"); rawLimit != "" {
+		parsedLimit, err := strconv.Atoi(rawLimit)
+		if err != nil || parsedLimit < 1 || parsedLimit > maxSearchLimit {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": fmt.Sprintf("limit must be a number between 1 and %d", maxSearchLimit),
+			})
+			c.Abort()
+			return
+		}
+		limit = parsedLimit
+	}
+
+	if term == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing search term",
+		})
+		c.Abort()
+		return
+	}
+
+	products, err := ph.productService.Search(term, limit)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+	return
+}
+
+// Import accepts the csv either as a multipart form file named "file" or as the raw request body.
+func (ph *ProductHandler) Import(c *gin.Context) {
+	var file io.Reader
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+
+	if formFile, err := c.FormFile("file"); err == nil {
+		opened, err := formFile.Open()
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"msg": "invalid file",
+			})
+			c.Abort()
+			return
+		}
+		defer opened.Close()
+		file = opened
+	} else {
+		file = c.Request.Body
+	}
+
+	result, err := ph.productService.ImportCSV(file)
+
+	if errors.Is(err, models.ErrInvalidCSV) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: products.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/products/models"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIProductService is a mock of IProductService interface.
+type MockIProductService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIProductServiceMockRecorder
+}
+
+// MockIProductServiceMockRecorder is the mock recorder for MockIProductService.
+type MockIProductServiceMockRecorder struct {
+	mock *MockIProductService
+}
+
+// NewMockIProductService creates a new mock instance.
+func NewMockIProductService(ctrl *gomock.Controller) *MockIProductService {
+	mock := &MockIProductService{ctrl: ctrl}
+	mock.recorder = &MockIProductServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIProductService) EXPECT() *MockIProductServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIProductService) Create(product models.Product) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", product)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIProductServiceMockRecorder) Create(product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIProductService)(nil).Create), product)
+}
+
+// Delete mocks base method.
+func (m *MockIProductService) Delete(productID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", productID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIProductServiceMockRecorder) Delete(productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIProductService)(nil).Delete), productID)
+}
+
+// Get mocks base method.
+func (m *MockIProductService) Get(productID string) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", productID)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIProductServiceMockRecorder) Get(productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIProductService)(nil).Get), productID)
+}
+
+// ImportCSV mocks base method.
+func (m *MockIProductService) ImportCSV(file io.Reader) (*models.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportCSV", file)
+	ret0, _ := ret[0].(*models.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportCSV indicates an expected call of ImportCSV.
+func (mr *MockIProductServiceMockRecorder) ImportCSV(file interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportCSV", reflect.TypeOf((*MockIProductService)(nil).ImportCSV), file)
+}
+
+// Search mocks base method.
+func (m *MockIProductService) Search(term string, limit int) (*[]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", term, limit)
+	ret0, _ := ret[0].(*[]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockIProductServiceMockRecorder) Search(term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockIProductService)(nil).Search), term, limit)
+}
+
+// Update mocks base method.
+func (m *MockIProductService) Update(product models.Product) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", product)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIProductServiceMockRecorder) Update(product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIProductService)(nil).Update), product)
+}
@@ -0,0 +1,486 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/products/models"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewProductHandler(t *testing.T) {
+	type args struct {
+		service IProductService
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProductHandler
+	}{
+		{
+			name: "Test with nil service should pass",
+			args: args{nil},
+			want: NewProductHandler(nil),
+		},
+		{
+			name: "Test with no nil service should pass",
+			args: args{NewMockIProductService(gomock.NewController(t))},
+			want: NewProductHandler(NewMockIProductService(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProductHandler(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProductHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductHandler_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       interface{}
+		setup      func(service *MockIProductService)
+		wantStatus int
+	}{
+		{
+			name: "Valid product is created",
+			body: GetValidProduct(),
+			setup: func(service *MockIProductService) {
+				product := GetValidProduct()
+				service.EXPECT().Create(gomock.Any()).Return(&product, nil)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Invalid barcode is rejected",
+			body: GetValidProduct(),
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Create(gomock.Any()).Return(nil, models.ErrInvalidBarcode)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			body: GetValidProduct(),
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from product service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "Missing name",
+			body:       map[string]interface{}{"category": "almacen"},
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid json",
+			body:       map[string]interface{}{"name": 1},
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIProductService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			productHandler := NewProductHandler(mockedService)
+
+			jsonDto, _ := json.Marshal(tt.body)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/products")
+			{
+				v1.POST("/", productHandler.Create)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/products/", strings.NewReader(string(jsonDto)))
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestProductHandler_Get(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		setup      func(service *MockIProductService)
+		wantStatus int
+	}{
+		{
+			name: "Existing product",
+			id:   "1",
+			setup: func(service *MockIProductService) {
+				product := GetValidProduct()
+				service.EXPECT().Get("1").Return(&product, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Missing product",
+			id:   "1",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Get("1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Service error",
+			id:   "1",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Get("1").Return(nil, errors.New("error from product service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "Invalid id",
+			id:         "a",
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIProductService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			productHandler := NewProductHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/products")
+			{
+				v1.GET("/:id", productHandler.Get)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/products/"+tt.id, nil)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestProductHandler_Update(t *testing.T) {
+	validProduct := GetValidProduct()
+	validProduct.ID = 1
+
+	tests := []struct {
+		name       string
+		id         string
+		body       interface{}
+		setup      func(service *MockIProductService)
+		wantStatus int
+	}{
+		{
+			name: "Valid product is updated",
+			id:   "1",
+			body: validProduct,
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Update(validProduct).Return(&validProduct, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Invalid barcode is rejected",
+			id:   "1",
+			body: validProduct,
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Update(validProduct).Return(nil, models.ErrInvalidBarcode)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			id:   "1",
+			body: validProduct,
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Update(validProduct).Return(nil, errors.New("error from product service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "Id mismatch",
+			id:         "2",
+			body:       validProduct,
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid id",
+			id:         "a",
+			body:       validProduct,
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIProductService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			productHandler := NewProductHandler(mockedService)
+
+			jsonDto, _ := json.Marshal(tt.body)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/products")
+			{
+				v1.PUT("/:id", productHandler.Update)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/v1/products/"+tt.id, strings.NewReader(string(jsonDto)))
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestProductHandler_Delete(t *testing.T) {
+	deleted := 1
+	notDeleted := 0
+
+	tests := []struct {
+		name       string
+		id         string
+		setup      func(service *MockIProductService)
+		wantStatus int
+	}{
+		{
+			name: "Existing product is deleted",
+			id:   "1",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Delete("1").Return(&deleted, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Missing product",
+			id:   "1",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Delete("1").Return(&notDeleted, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Service error",
+			id:   "1",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Delete("1").Return(nil, errors.New("error from product service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "Invalid id",
+			id:         "a",
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIProductService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			productHandler := NewProductHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/products")
+			{
+				v1.DELETE("/:id", productHandler.Delete)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/products/"+tt.id, nil)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestProductHandler_Search(t *testing.T) {
+	products := []models.Product{GetValidProduct()}
+
+	tests := []struct {
+		name       string
+		query      string
+		setup      func(service *MockIProductService)
+		wantStatus int
+	}{
+		{
+			name:  "Search with default limit",
+			query: "?q=tom",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Search("tom", defaultSearchLimit).Return(&products, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "Search with limit",
+			query: "?q=tom&limit=5",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Search("tom", 5).Return(&products, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:  "Service error",
+			query: "?q=tom",
+			setup: func(service *MockIProductService) {
+				service.EXPECT().Search("tom", defaultSearchLimit).Return(nil, errors.New("error from product service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:       "Missing term",
+			query:      "",
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Limit too big",
+			query:      "?q=tom&limit=500",
+			setup:      func(service *MockIProductService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIProductService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			productHandler := NewProductHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/products")
+			{
+				v1.GET("/", productHandler.Search)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/products/"+tt.query, nil)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestProductHandler_Import_Multipart(t *testing.T) {
+	result := models.ImportResult{Imported: 1}
+
+	mockedService := NewMockIProductService(gomock.NewController(t))
+	mockedService.EXPECT().ImportCSV(gomock.Any()).Return(&result, nil)
+
+	productHandler := NewProductHandler(mockedService)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "products.csv")
+	_, _ = part.Write([]byte("name\nArroz\n"))
+	_ = writer.Close()
+
+	gin.SetMode(gin.TestMode)
+
+	c := gin.Default()
+
+	v1 := c.Group("/v1/products")
+	{
+		v1.POST("/import", productHandler.Import)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/products/import", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	c.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestProductHandler_Import_Raw_Body(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "Valid csv", err: nil, wantStatus: http.StatusOK},
+		{name: "Invalid csv", err: models.ErrInvalidCSV, wantStatus: http.StatusBadRequest},
+		{name: "Service error", err: errors.New("error from product service"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIProductService(gomock.NewController(t))
+			if tt.err != nil {
+				mockedService.EXPECT().ImportCSV(gomock.Any()).Return(nil, tt.err)
+			} else {
+				mockedService.EXPECT().ImportCSV(gomock.Any()).Return(&models.ImportResult{Imported: 1}, nil)
+			}
+
+			productHandler := NewProductHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/products")
+			{
+				v1.POST("/import", productHandler.Import)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/products/import", strings.NewReader("name\nArroz\n"))
+			req.Header.Set("Content-Type", "text/csv")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func GetValidProduct() models.Product {
+	return models.Product{
+		Name:     "Tomate perita",
+		Barcode:  "7790070318213",
+		Category: "verduleria",
+	}
+}
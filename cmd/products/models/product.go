@@ -0,0 +1,58 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrInvalidBarcode = errors.New("invalid barcode")
+var ErrInvalidCSV = errors.New("csv file must have a header row with at least a name column")
+
+type Product struct {
+	ID       uint   `json:"id" gorm:"column:product_id;primaryKey"`
+	Name     string `json:"name" validate:"required,max=250"`
+	Barcode  string `json:"barcode,omitempty" gorm:"default:null" validate:"omitempty,numeric,min=8,max=14"`
+	Category string `json:"category,omitempty"`
+}
+
+func (Product) TableName() string {
+	return "product"
+}
+
+type ImportError struct {
+	Line int    `json:"line"`
+	Msg  string `json:"msg"`
+}
+
+type ImportResult struct {
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
+
+// IsValidBarcode checks the length and check digit of EAN-8, UPC-A, EAN-13 and GTIN-14 codes.
+func IsValidBarcode(barcode string) bool {
+
+	switch len(barcode) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+
+	if _, err := strconv.ParseUint(barcode, 10, 64); err != nil {
+		return false
+	}
+
+	// Digits are weighted 3 and 1 alternately, starting with 3 on the digit right before the check digit.
+	sum := 0
+	for i := len(barcode) - 2; i >= 0; i-- {
+		digit := int(barcode[i] - '0')
+		if (len(barcode)-2-i)%2 == 0 {
+			digit *= 3
+		}
+		sum += digit
+	}
+
+	checkDigit := (10 - sum%10) % 10
+
+	return checkDigit == int(barcode[len(barcode)-1]-'0')
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsValidBarcode(t *testing.T) {
+	tests := []struct {
+		name    string
+		barcode string
+		want    bool
+	}{
+		{name: "Valid EAN-13", barcode: "7790070318213", want: true},
+		{name: "Valid EAN-13 with zero check digit", barcode: "4006381333931", want: true},
+		{name: "Valid EAN-8", barcode: "96385074", want: true},
+		{name: "Valid UPC-A", barcode: "036000291452", want: true},
+		{name: "Valid GTIN-14", barcode: "10012345678902", want: true},
+		{name: "Wrong check digit", barcode: "7790070318214", want: false},
+		{name: "Wrong length", barcode: "123456789", want: false},
+		{name: "Not numeric", barcode: "77900703182a3", want: false},
+		{name: "Empty", barcode: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidBarcode(tt.barcode))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/products/models"
+	"SuperListsAPI/internal/database"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// importBatchSize is the number of rows sent on each INSERT when importing a catalog.
+const importBatchSize = 100
+
+type ProductRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return ProductRepository{db: db}
+}
+
+func (pr *ProductRepository) Create(product models.Product) (*models.Product, error) {
+
+	if result := pr.db.Create(&product); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &product, nil
+}
+
+func (pr *ProductRepository) Get(productID string) (*models.Product, error) {
+
+	var product models.Product
+
+	if result := pr.db.First(&product, productID); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &product, nil
+}
+
+func (pr *ProductRepository) Update(product models.Product) (*models.Product, error) {
+
+	if result := pr.db.Save(&product); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &product, nil
+}
+
+func (pr *ProductRepository) Delete(productID string) (*int, error) {
+
+	result := pr.db.Delete(&models.Product{}, productID)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rowsDeleted := int(result.RowsAffected)
+
+	return &rowsDeleted, nil
+}
+
+// SearchCandidates returns products sharing at least the first letters of one of the words of term.
+// Matching is loose on purpose, ranking the candidates is up to the caller.
+func (pr *ProductRepository) SearchCandidates(term string, limit int) (*[]models.Product, error) {
+
+	var products []models.Product
+
+	query := pr.db.Model(&models.Product{})
+	conditions := pr.db
+
+	for i, word := range strings.Fields(strings.ToLower(term)) {
+		if letters := []rune(word); len(letters) > 3 {
+			word = string(letters[:3])
+		}
+		pattern := "%" + database.EscapeLike(word) + "%"
+		if i == 0 {
+			conditions = conditions.Where("LOWER(name) LIKE ? ESCAPE '!'", pattern)
+			continue
+		}
+		conditions = conditions.Or("LOWER(name) LIKE ? ESCAPE '!'", pattern)
+	}
+
+	if result := query.Where(conditions).Limit(limit).Find(&products); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &products, nil
+}
+
+// BulkCreate inserts products in batches. CreateInBatches runs every batch inside one transaction,
+// so either the whole catalog gets imported or nothing does.
+func (pr *ProductRepository) BulkCreate(products []models.Product) (*int, error) {
+
+	if result := pr.db.CreateInBatches(&products, importBatchSize); result.Error != nil {
+		return nil, result.Error
+	}
+
+	created := len(products)
+
+	return &created, nil
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/products/models"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestNewProductRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProductRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewProductRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewProductRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProductRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProductRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductRepository_Create(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `product` (`name`,`category`,`barcode`) VALUES (?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := productRepository.Create(GetValidProduct())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestProductRepository_Create_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `product`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := productRepository.Create(GetValidProduct())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestProductRepository_Get(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `product` WHERE `product`.`product_id` = ? ORDER BY `product`.`product_id` LIMIT 1")).
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "name"}).AddRow(1, "Tomate"))
+
+	result, err := productRepository.Get("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Tomate", result.Name)
+}
+
+func TestProductRepository_Get_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `product`")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := productRepository.Get("1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestProductRepository_Update(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	product := GetValidProduct()
+	product.ID = 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `product` SET `name`=?,`barcode`=?,`category`=? WHERE `product_id` = ?")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := productRepository.Update(product)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestProductRepository_Update_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	product := GetValidProduct()
+	product.ID = 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `product`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := productRepository.Update(product)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestProductRepository_Delete(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `product` WHERE `product`.`product_id` = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := productRepository.Delete("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestProductRepository_Delete_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `product`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := productRepository.Delete("1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestProductRepository_SearchCandidates(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `product` WHERE (LOWER(name) LIKE ? ESCAPE '!' OR LOWER(name) LIKE ? ESCAPE '!') LIMIT 200")).
+		WithArgs("%tom%", "%per%").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "name"}).AddRow(1, "Tomate perita"))
+
+	result, err := productRepository.SearchCandidates("Tomate perita", 200)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestProductRepository_SearchCandidates_Multibyte_And_Wildcards(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `product` WHERE (LOWER(name) LIKE ? ESCAPE '!' OR LOWER(name) LIKE ? ESCAPE '!' OR LOWER(name) LIKE ? ESCAPE '!') LIMIT 200")).
+		WithArgs("%ñoq%", "%piñ%", "%1!%!_%").
+		WillReturnRows(sqlmock.NewRows([]string{"product_id", "name"}).AddRow(1, "Ñoquis de papa"))
+
+	result, err := productRepository.SearchCandidates("Ñoquis piña 1%_", 200)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+}
+
+func TestProductRepository_SearchCandidates_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `product`")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := productRepository.SearchCandidates("tomate", 200)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestProductRepository_BulkCreate(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `product` (`name`,`category`,`barcode`) VALUES (?,?,?),(?,?,?)")).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	result, err := productRepository.BulkCreate([]models.Product{GetValidProduct(), GetValidProduct()})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *result)
+}
+
+func TestProductRepository_BulkCreate_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	productRepository := NewProductRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `product`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := productRepository.BulkCreate([]models.Product{GetValidProduct()})
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func GetValidProduct() models.Product {
+	return models.Product{
+		Name:     "Tomate perita",
+		Barcode:  "7790070318213",
+		Category: "verduleria",
+	}
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/products/models"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+//go:generate mockgen -source=product_service.go -destination product_service_mock.go -package service
+
+const (
+	// searchCandidatesLimit bounds the rows fetched from the catalog before ranking them.
+	searchCandidatesLimit = 200
+	// minSearchScore is the similarity a product needs to show up on autocomplete results.
+	minSearchScore = 0.6
+)
+
+type IProductRepository interface {
+	Create(product models.Product) (*models.Product, error)
+	Get(productID string) (*models.Product, error)
+	Update(product models.Product) (*models.Product, error)
+	Delete(productID string) (*int, error)
+	SearchCandidates(term string, limit int) (*[]models.Product, error)
+	BulkCreate(products []models.Product) (*int, error)
+}
+
+type ProductService struct {
+	repository IProductRepository
+}
+
+func NewProductService(repository IProductRepository) ProductService {
+	return ProductService{repository: repository}
+}
+
+func (ps *ProductService) Create(product models.Product) (*models.Product, error) {
+
+	if product.Barcode != "" && !models.IsValidBarcode(product.Barcode) {
+		return nil, models.ErrInvalidBarcode
+	}
+
+	return ps.repository.Create(product)
+}
+
+func (ps *ProductService) Get(productID string) (*models.Product, error) {
+	return ps.repository.Get(productID)
+}
+
+func (ps *ProductService) Update(product models.Product) (*models.Product, error) {
+
+	if product.Barcode != "" && !models.IsValidBarcode(product.Barcode) {
+		return nil, models.ErrInvalidBarcode
+	}
+
+	return ps.repository.Update(product)
+}
+
+func (ps *ProductService) Delete(productID string) (*int, error) {
+	return ps.repository.Delete(productID)
+}
+
+// Search returns up to limit products whose name resembles term, best matches first.
+// Small typos are tolerated so "tomatr" still finds "Tomate perita".
+func (ps *ProductService) Search(term string, limit int) (*[]models.Product, error) {
+
+	term = strings.ToLower(strings.TrimSpace(term))
+
+	if term == "" {
+		return &[]models.Product{}, nil
+	}
+
+	candidates, err := ps.repository.SearchCandidates(term, searchCandidatesLimit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	type scoredProduct struct {
+		product models.Product
+		score   float64
+	}
+
+	var scored []scoredProduct
+	for _, candidate := range *candidates {
+		if score := matchScore(strings.ToLower(candidate.Name), term); score >= minSearchScore {
+			scored = append(scored, scoredProduct{candidate, score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+		return scored[i].product.Name < scored[j].product.Name
+	})
+
+	products := []models.Product{}
+	for i := 0; i < len(scored) && i < limit; i++ {
+		products = append(products, scored[i].product)
+	}
+
+	return &products, nil
+}
+
+// ImportCSV loads products from a csv file with a header row. Recognized columns are name, barcode and category,
+// in any order. Invalid rows are reported back and skipped, valid ones are inserted in a single transaction.
+func (ps *ProductService) ImportCSV(file io.Reader) (*models.ImportResult, error) {
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, models.ErrInvalidCSV
+	}
+
+	columns := map[string]int{}
+	for i, column := range header {
+		columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	if _, ok := columns["name"]; !ok {
+		return nil, models.ErrInvalidCSV
+	}
+
+	result := models.ImportResult{Errors: []models.ImportError{}}
+	seenBarcodes := map[string]int{}
+	var products []models.Product
+
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, models.ImportError{Line: line, Msg: err.Error()})
+			continue
+		}
+
+		product := models.Product{
+			Name:     csvValue(record, columns, "name"),
+			Barcode:  csvValue(record, columns, "barcode"),
+			Category: csvValue(record, columns, "category"),
+		}
+
+		if product.Name == "" {
+			result.Errors = append(result.Errors, models.ImportError{Line: line, Msg: "missing name"})
+			continue
+		}
+
+		if product.Barcode != "" {
+			if !models.IsValidBarcode(product.Barcode) {
+				result.Errors = append(result.Errors, models.ImportError{Line: line, Msg: models.ErrInvalidBarcode.Error()})
+				continue
+			}
+			if previousLine, ok := seenBarcodes[product.Barcode]; ok {
+				result.Errors = append(result.Errors, models.ImportError{Line: line, Msg: fmt.Sprintf("barcode already present on line %d", previousLine)})
+				continue
+			}
+			seenBarcodes[product.Barcode] = line
+		}
+
+		products = append(products, product)
+	}
+
+	if len(products) == 0 {
+		return &result, nil
+	}
+
+	imported, err := ps.repository.BulkCreate(products)
+
+	if err != nil {
+		return nil, err
+	}
+
+	result.Imported = *imported
+
+	return &result, nil
+}
+
+func csvValue(record []string, columns map[string]int, column string) string {
+	index, ok := columns[column]
+	if !ok || index >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[index])
+}
+
+// matchScore rates from 0 to 1 how well name matches what the user typed so far.
+func matchScore(name string, term string) float64 {
+
+	switch {
+	case name == term:
+		return 1
+	case strings.HasPrefix(name, term):
+		return 0.95
+	}
+
+	best := 0.0
+	for i, word := range strings.Fields(name) {
+		if strings.HasPrefix(word, term) {
+			return 0.9
+		}
+
+		// Compare against the beginning of the word only, the user may not have finished typing it.
+		prefix := word
+		if len([]rune(prefix)) > len([]rune(term)) {
+			prefix = string([]rune(prefix)[:len([]rune(term))])
+		}
+
+		similarity := 1 - float64(levenshtein(prefix, term))/float64(len([]rune(term)))
+		if i > 0 {
+			// Products starting with what the user typed are more likely to be the one they're looking for
+			similarity *= 0.95
+		}
+		if similarity > best {
+			best = similarity
+		}
+	}
+
+	if strings.Contains(name, term) && best < 0.8 {
+		best = 0.8
+	}
+
+	return best * 0.85
+}
+
+func levenshtein(a string, b string) int {
+	first, second := []rune(a), []rune(b)
+
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
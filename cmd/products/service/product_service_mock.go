@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: product_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/products/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIProductRepository is a mock of IProductRepository interface.
+type MockIProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIProductRepositoryMockRecorder
+}
+
+// MockIProductRepositoryMockRecorder is the mock recorder for MockIProductRepository.
+type MockIProductRepositoryMockRecorder struct {
+	mock *MockIProductRepository
+}
+
+// NewMockIProductRepository creates a new mock instance.
+func NewMockIProductRepository(ctrl *gomock.Controller) *MockIProductRepository {
+	mock := &MockIProductRepository{ctrl: ctrl}
+	mock.recorder = &MockIProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIProductRepository) EXPECT() *MockIProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// BulkCreate mocks base method.
+func (m *MockIProductRepository) BulkCreate(products []models.Product) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", products)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockIProductRepositoryMockRecorder) BulkCreate(products interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockIProductRepository)(nil).BulkCreate), products)
+}
+
+// Create mocks base method.
+func (m *MockIProductRepository) Create(product models.Product) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", product)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIProductRepositoryMockRecorder) Create(product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIProductRepository)(nil).Create), product)
+}
+
+// Delete mocks base method.
+func (m *MockIProductRepository) Delete(productID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", productID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIProductRepositoryMockRecorder) Delete(productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIProductRepository)(nil).Delete), productID)
+}
+
+// Get mocks base method.
+func (m *MockIProductRepository) Get(productID string) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", productID)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIProductRepositoryMockRecorder) Get(productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIProductRepository)(nil).Get), productID)
+}
+
+// SearchCandidates mocks base method.
+func (m *MockIProductRepository) SearchCandidates(term string, limit int) (*[]models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchCandidates", term, limit)
+	ret0, _ := ret[0].(*[]models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchCandidates indicates an expected call of SearchCandidates.
+func (mr *MockIProductRepositoryMockRecorder) SearchCandidates(term, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCandidates", reflect.TypeOf((*MockIProductRepository)(nil).SearchCandidates), term, limit)
+}
+
+// Update mocks base method.
+func (m *MockIProductRepository) Update(product models.Product) (*models.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", product)
+	ret0, _ := ret[0].(*models.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIProductRepositoryMockRecorder) Update(product interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIProductRepository)(nil).Update), product)
+}
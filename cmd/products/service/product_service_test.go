@@ -0,0 +1,249 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/products/models"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewProductService(t *testing.T) {
+	type args struct {
+		repository IProductRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want ProductService
+	}{
+		{
+			name: "Service with nil repo should pass",
+			args: args{nil},
+			want: NewProductService(nil),
+		},
+		{
+			name: "Service with no nil repo should pass",
+			args: args{NewMockIProductRepository(gomock.NewController(t))},
+			want: NewProductService(NewMockIProductRepository(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewProductService(tt.args.repository); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProductService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductService_Create(t *testing.T) {
+	product := GetValidProduct()
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(product).Return(&product, nil)
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Create(product)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestProductService_Create_Invalid_Barcode(t *testing.T) {
+	product := GetValidProduct()
+	product.Barcode = "7790070318214"
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Create(product)
+
+	assert.ErrorIs(t, err, models.ErrInvalidBarcode)
+	assert.Nil(t, result)
+}
+
+func TestProductService_Update(t *testing.T) {
+	product := GetValidProduct()
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Update(product).Return(&product, nil)
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Update(product)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestProductService_Update_Invalid_Barcode(t *testing.T) {
+	product := GetValidProduct()
+	product.Barcode = "123"
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Update(product)
+
+	assert.ErrorIs(t, err, models.ErrInvalidBarcode)
+	assert.Nil(t, result)
+}
+
+func TestProductService_Get(t *testing.T) {
+	product := GetValidProduct()
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Get("1").Return(&product, nil)
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Get("1")
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+}
+
+func TestProductService_Delete(t *testing.T) {
+	deleted := 1
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Delete("1").Return(&deleted, nil)
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Delete("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestProductService_Search(t *testing.T) {
+	candidates := []models.Product{
+		{ID: 1, Name: "Salsa de tomate"},
+		{ID: 2, Name: "Tomate perita"},
+		{ID: 3, Name: "Tomillo"},
+		{ID: 4, Name: "Tomate cherry"},
+		{ID: 5, Name: "Atún al natural"},
+	}
+
+	tests := []struct {
+		name  string
+		term  string
+		limit int
+		want  []uint
+	}{
+		{name: "Prefix matches go first", term: "tomate", limit: 10, want: []uint{4, 2, 1}},
+		{name: "Typos are tolerated", term: "tomatw", limit: 10, want: []uint{4, 2, 1}},
+		{name: "Limit is respected", term: "tomate", limit: 1, want: []uint{4}},
+		{name: "Unrelated terms find nothing", term: "yerba", limit: 10, want: []uint{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+			mockedRepo.EXPECT().SearchCandidates(tt.term, searchCandidatesLimit).Return(&candidates, nil)
+
+			productService := NewProductService(mockedRepo)
+
+			result, err := productService.Search(tt.term, tt.limit)
+
+			assert.NoError(t, err)
+
+			ids := []uint{}
+			for _, product := range *result {
+				ids = append(ids, product.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestProductService_Search_Empty_Term(t *testing.T) {
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Search("  ", 10)
+
+	assert.NoError(t, err)
+	assert.Empty(t, *result)
+}
+
+func TestProductService_Search_Error(t *testing.T) {
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().SearchCandidates(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from product repository"))
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.Search("tomate", 10)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestProductService_ImportCSV(t *testing.T) {
+	file := strings.NewReader("Category,Name,Barcode\n" +
+		"verduleria,Tomate perita,7790070318213\n" +
+		"almacen,,\n" +
+		"almacen,Yerba,123\n" +
+		"almacen,Tomate repetido,7790070318213\n" +
+		"almacen,Arroz,\n")
+
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().BulkCreate(gomock.Any()).DoAndReturn(func(products []models.Product) (*int, error) {
+		assert.Equal(t, []models.Product{
+			{Name: "Tomate perita", Barcode: "7790070318213", Category: "verduleria"},
+			{Name: "Arroz", Category: "almacen"},
+		}, products)
+		imported := len(products)
+		return &imported, nil
+	})
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.ImportCSV(file)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.Imported)
+	assert.Equal(t, []models.ImportError{
+		{Line: 3, Msg: "missing name"},
+		{Line: 4, Msg: models.ErrInvalidBarcode.Error()},
+		{Line: 5, Msg: "barcode already present on line 2"},
+	}, result.Errors)
+}
+
+func TestProductService_ImportCSV_Missing_Name_Column(t *testing.T) {
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.ImportCSV(strings.NewReader("barcode,category\n"))
+
+	assert.ErrorIs(t, err, models.ErrInvalidCSV)
+	assert.Nil(t, result)
+}
+
+func TestProductService_ImportCSV_Error(t *testing.T) {
+	mockedRepo := NewMockIProductRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().BulkCreate(gomock.Any()).Return(nil, errors.New("error from product repository"))
+
+	productService := NewProductService(mockedRepo)
+
+	result, err := productService.ImportCSV(strings.NewReader("name\nArroz\n"))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func GetValidProduct() models.Product {
+	return models.Product{
+		Name:     "Tomate perita",
+		Barcode:  "7790070318213",
+		Category: "verduleria",
+	}
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"SuperListsAPI/cmd/reminders/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/httputil"
+	"SuperListsAPI/internal/notify"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=reminders.go -destination reminders_mock.go -package handler
+
+type IReminderService interface {
+	Create(reminder models.Reminder) (*models.Reminder, error)
+	GetRemindersByUserID(userID string) (*[]models.Reminder, error)
+	Delete(userID string, reminderID string) (*int, error)
+}
+
+type IListItemService interface {
+	Get(listItemID string) (*listItemModels.ListItem, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+type ReminderHandler struct {
+	reminderService IReminderService
+	listItemService IListItemService
+	userListService IUserListService
+}
+
+func NewReminderHandler(reminderService IReminderService, listItemService IListItemService, userListService IUserListService) ReminderHandler {
+	return ReminderHandler{reminderService: reminderService, listItemService: listItemService, userListService: userListService}
+}
+
+// Create sets a reminder on an item or on a whole list. The caller must be a member of the list.
+func (rh *ReminderHandler) Create(c *gin.Context) {
+	var reminder models.Reminder
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	validate := validator.New()
+	err := c.ShouldBindJSON(&reminder)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+	err = validate.Struct(reminder)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	if (reminder.ListID == nil) == (reminder.ListItemID == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": models.ErrInvalidReminderScope.Error(),
+		})
+		c.Abort()
+		return
+	}
+
+	if !validTarget(validate, reminder.Channel, reminder.Target) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": fmt.Sprintf("invalid target for %s reminders", reminder.Channel),
+		})
+		c.Abort()
+		return
+	}
+
+	if reminder.Channel == notify.ChannelInApp {
+		reminder.Target = ""
+	}
+
+	listID, ok := rh.reminderListID(c, reminder)
+	if !ok {
+		return
+	}
+
+	if !httputil.CheckListMember(c, rh.userListService, fmt.Sprint(listID), uint(userID)) {
+		return
+	}
+
+	reminder.ID = 0
+	reminder.UserID = uint(userID)
+
+	result, err := rh.reminderService.Create(reminder)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+func (rh *ReminderHandler) GetReminders(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	reminders, err := rh.reminderService.GetRemindersByUserID(fmt.Sprint(userID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if reminders == nil || len(*reminders) < 1 {
+		c.JSON(http.StatusNoContent, reminders)
+		return
+	}
+
+	c.JSON(http.StatusOK, reminders)
+	return
+}
+
+func (rh *ReminderHandler) Delete(c *gin.Context) {
+	reminderID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := strconv.Atoi(reminderID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid reminder id",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := rh.reminderService.Delete(fmt.Sprint(userID), reminderID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result == nil || *result < 1 {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Reminder with id %s not found", reminderID))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// reminderListID returns the list the reminder applies to, loading the item for item reminders.
+func (rh *ReminderHandler) reminderListID(c *gin.Context, reminder models.Reminder) (uint, bool) {
+	if reminder.ListID != nil {
+		return *reminder.ListID, true
+	}
+
+	listItemID := fmt.Sprint(*reminder.ListItemID)
+
+	listItem, err := rh.listItemService.Get(listItemID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("ListItem with id %s not found", listItemID))
+		return 0, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return 0, false
+	}
+
+	return uint(listItem.ListID), true
+}
+
+// validTarget checks the target is an email address or a public http(s) url, as needed by the channel.
+func validTarget(validate *validator.Validate, channel string, target string) bool {
+	switch channel {
+	case notify.ChannelEmail:
+		return validate.Var(target, "required,email") == nil
+	case notify.ChannelWebhook:
+		return validate.Var(target, "required,url") == nil && notify.ValidWebhookTarget(target)
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminders.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/reminders/models"
+	models1 "SuperListsAPI/cmd/userLists/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIReminderService is a mock of IReminderService interface.
+type MockIReminderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReminderServiceMockRecorder
+}
+
+// MockIReminderServiceMockRecorder is the mock recorder for MockIReminderService.
+type MockIReminderServiceMockRecorder struct {
+	mock *MockIReminderService
+}
+
+// NewMockIReminderService creates a new mock instance.
+func NewMockIReminderService(ctrl *gomock.Controller) *MockIReminderService {
+	mock := &MockIReminderService{ctrl: ctrl}
+	mock.recorder = &MockIReminderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReminderService) EXPECT() *MockIReminderServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIReminderService) Create(reminder models0.Reminder) (*models0.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", reminder)
+	ret0, _ := ret[0].(*models0.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIReminderServiceMockRecorder) Create(reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIReminderService)(nil).Create), reminder)
+}
+
+// Delete mocks base method.
+func (m *MockIReminderService) Delete(userID, reminderID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, reminderID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIReminderServiceMockRecorder) Delete(userID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIReminderService)(nil).Delete), userID, reminderID)
+}
+
+// GetRemindersByUserID mocks base method.
+func (m *MockIReminderService) GetRemindersByUserID(userID string) (*[]models0.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemindersByUserID", userID)
+	ret0, _ := ret[0].(*[]models0.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemindersByUserID indicates an expected call of GetRemindersByUserID.
+func (mr *MockIReminderServiceMockRecorder) GetRemindersByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindersByUserID", reflect.TypeOf((*MockIReminderService)(nil).GetRemindersByUserID), userID)
+}
+
+// MockIListItemService is a mock of IListItemService interface.
+type MockIListItemService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIListItemServiceMockRecorder
+}
+
+// MockIListItemServiceMockRecorder is the mock recorder for MockIListItemService.
+type MockIListItemServiceMockRecorder struct {
+	mock *MockIListItemService
+}
+
+// NewMockIListItemService creates a new mock instance.
+func NewMockIListItemService(ctrl *gomock.Controller) *MockIListItemService {
+	mock := &MockIListItemService{ctrl: ctrl}
+	mock.recorder = &MockIListItemServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIListItemService) EXPECT() *MockIListItemServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIListItemService) Get(listItemID string) (*models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", listItemID)
+	ret0, _ := ret[0].(*models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIListItemServiceMockRecorder) Get(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIListItemService)(nil).Get), listItemID)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models1.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
@@ -0,0 +1,234 @@
+package handler
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	"SuperListsAPI/cmd/reminders/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewReminderHandler(t *testing.T) {
+	type args struct {
+		service IReminderService
+	}
+	tests := []struct {
+		name string
+		args args
+		want ReminderHandler
+	}{
+		{
+			name: "Test with nil service should pass",
+			args: args{nil},
+			want: NewReminderHandler(nil, nil, nil),
+		},
+		{
+			name: "Test with no nil service should pass",
+			args: args{NewMockIReminderService(gomock.NewController(t))},
+			want: NewReminderHandler(NewMockIReminderService(gomock.NewController(t)), nil, nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewReminderHandler(tt.args.service, nil, nil); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewReminderHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderHandler_Create(t *testing.T) {
+	members := &[]userListModels.UserList{{ListID: 3, UserID: 7}}
+
+	tests := []struct {
+		name       string
+		body       interface{}
+		setup      func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name: "Item reminder for a list member",
+			body: map[string]interface{}{"list_item_id": 4, "offset_minutes": 30, "channel": "in_app", "target": "ignored"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(&listItemModels.ListItem{ListID: 3}, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				reminders.EXPECT().Create(gomock.Any()).DoAndReturn(func(reminder models.Reminder) (*models.Reminder, error) {
+					if reminder.UserID != 7 || reminder.Target != "" {
+						return nil, errors.New("unexpected reminder")
+					}
+					return &reminder, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "List reminder by webhook",
+			body: map[string]interface{}{"list_id": 3, "offset_minutes": 60, "channel": "webhook", "target": "https://example.com/hook"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				reminders.EXPECT().Create(gomock.Any()).DoAndReturn(func(reminder models.Reminder) (*models.Reminder, error) {
+					return &reminder, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "Reminder without list or item",
+			body: map[string]interface{}{"offset_minutes": 30, "channel": "in_app"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Unknown channel",
+			body: map[string]interface{}{"list_id": 3, "channel": "sms"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Offset too large",
+			body: map[string]interface{}{"list_id": 3, "offset_minutes": 50000, "channel": "in_app"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Email reminder without address",
+			body: map[string]interface{}{"list_id": 3, "channel": "email"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Webhook reminder with non http url",
+			body: map[string]interface{}{"list_id": 3, "channel": "webhook", "target": "ftp://example.com"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Webhook reminder to the metadata service",
+			body: map[string]interface{}{"list_id": 3, "channel": "webhook", "target": "http://169.254.169.254/latest/meta-data"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Item not found",
+			body: map[string]interface{}{"list_item_id": 4, "channel": "in_app"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				listItems.EXPECT().Get("4").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Caller is not a list member",
+			body: map[string]interface{}{"list_id": 5, "channel": "in_app"},
+			setup: func(reminders *MockIReminderService, listItems *MockIListItemService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("5").Return(&[]userListModels.UserList{{ListID: 5, UserID: 8}}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			reminders := NewMockIReminderService(ctrl)
+			listItems := NewMockIListItemService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(reminders, listItems, userLists)
+
+			reminderHandler := NewReminderHandler(reminders, listItems, userLists)
+
+			body, _ := json.Marshal(tt.body)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/reminders", strings.NewReader(string(body)))
+			c.Request.Header.Set("user_id", "7")
+
+			reminderHandler.Create(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestReminderHandler_GetReminders(t *testing.T) {
+	reminders := NewMockIReminderService(gomock.NewController(t))
+	reminders.EXPECT().GetRemindersByUserID("7").Return(&[]models.Reminder{{Channel: "in_app"}}, nil)
+
+	reminderHandler := NewReminderHandler(reminders, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest(http.MethodGet, "/v1/reminders", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	reminderHandler.GetReminders(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReminderHandler_Delete(t *testing.T) {
+	deleted := 1
+	notDeleted := 0
+
+	tests := []struct {
+		name       string
+		reminderID string
+		setup      func(service *MockIReminderService)
+		wantStatus int
+	}{
+		{
+			name:       "Own reminder is deleted",
+			reminderID: "3",
+			setup: func(service *MockIReminderService) {
+				service.EXPECT().Delete("7", "3").Return(&deleted, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Reminder of another user",
+			reminderID: "3",
+			setup: func(service *MockIReminderService) {
+				service.EXPECT().Delete("7", "3").Return(&notDeleted, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid reminder id",
+			reminderID: "abc",
+			setup:      func(service *MockIReminderService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewMockIReminderService(gomock.NewController(t))
+			tt.setup(service)
+
+			reminderHandler := NewReminderHandler(service, nil, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodDelete, "/v1/reminders/"+tt.reminderID, nil)
+			c.Request.Header.Set("user_id", "7")
+			c.Params = gin.Params{{Key: "id", Value: tt.reminderID}}
+
+			reminderHandler.Delete(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
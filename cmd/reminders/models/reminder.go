@@ -0,0 +1,61 @@
+package models
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+var ErrInvalidReminderScope = errors.New("a reminder belongs to either a list or a list item")
+
+// Reminder fires OffsetMinutes before the due date of an item, or of every item on a list when ListID is set.
+// Target is the email address or webhook url for those channels and is ignored for in-app reminders.
+type Reminder struct {
+	gorm.Model
+	UserID        uint   `json:"user_id"`
+	ListID        *uint  `json:"list_id"`
+	ListItemID    *uint  `json:"list_item_id"`
+	OffsetMinutes int    `json:"offset_minutes" validate:"min=0,max=40320"`
+	Channel       string `json:"channel" validate:"required,oneof=in_app email webhook"`
+	Target        string `json:"target" validate:"max=500"`
+}
+
+const (
+	DeliveryPending = "pending"
+	DeliveryClaimed = "claimed"
+	DeliverySent    = "sent"
+	DeliveryFailed  = "failed"
+	DeliverySkipped = "skipped"
+)
+
+// ReminderDelivery is one occurrence of a reminder for a given item due date. The (reminder_id, list_item_id, due_at)
+// key is unique, so planning the same occurrence twice, from any replica, keeps a single row.
+type ReminderDelivery struct {
+	ID            uint       `gorm:"primarykey" json:"id"`
+	ReminderID    uint       `json:"reminder_id"`
+	ListItemID    uint       `json:"list_item_id"`
+	DueAt         time.Time  `json:"due_at"`
+	FireAt        time.Time  `json:"fire_at"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	Status        string     `json:"status"`
+	ClaimedBy     string     `json:"claimed_by"`
+	ClaimedUntil  *time.Time `json:"claimed_until"`
+	Attempts      int        `json:"attempts"`
+	LastError     string     `json:"last_error"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ClaimedDelivery is a delivery claimed by a replica, along with the current state of its reminder and item.
+type ClaimedDelivery struct {
+	ReminderDelivery
+	UserID            uint
+	Channel           string
+	Target            string
+	ReminderDeletedAt *time.Time
+	ListID            uint
+	Title             string
+	IsDone            bool
+	CurrentDueAt      *time.Time
+	ItemDeletedAt     *time.Time
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/reminders/models"
+	"gorm.io/gorm"
+	"time"
+)
+
+type ReminderRepository struct {
+	db *gorm.DB
+}
+
+func NewReminderRepository(db *gorm.DB) ReminderRepository {
+	return ReminderRepository{db: db}
+}
+
+func (rr *ReminderRepository) Create(reminder models.Reminder) (*models.Reminder, error) {
+
+	if result := rr.db.Create(&reminder); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &reminder, nil
+}
+
+func (rr *ReminderRepository) GetRemindersByUserID(userID string) (*[]models.Reminder, error) {
+
+	var reminders []models.Reminder
+
+	if result := rr.db.Where("user_id = ?", userID).Order("id").Find(&reminders); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &reminders, nil
+}
+
+func (rr *ReminderRepository) Delete(userID string, reminderID string) (*int, error) {
+
+	result := rr.db.Where("user_id = ?", userID).Delete(&models.Reminder{}, reminderID)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rowsDeleted := int(result.RowsAffected)
+
+	return &rowsDeleted, nil
+}
+
+// PlanDeliveries creates the pending deliveries of every reminder whose fire time falls between from and to.
+// Items that are done, deleted or on a list the reminder owner no longer belongs to are left out. Rows that
+// already exist are kept as they are, so every replica can plan the same window.
+func (rr *ReminderRepository) PlanDeliveries(from time.Time, to time.Time) (*int, error) {
+
+	result := rr.db.Exec(`INSERT INTO reminder_deliveries (reminder_id, list_item_id, due_at, fire_at, next_attempt_at, status, attempts, created_at)
+SELECT r.id, li.id, li.due_at, li.due_at - r.offset_minutes * interval '1 minute', li.due_at - r.offset_minutes * interval '1 minute', ?, 0, ?
+FROM reminders r
+JOIN list_items li ON li.id = r.list_item_id OR (r.list_item_id IS NULL AND li.list_id = r.list_id)
+JOIN user_lists ul ON ul.list_id = li.list_id AND ul.user_id = r.user_id AND ul.deleted_at IS NULL
+WHERE r.deleted_at IS NULL AND li.deleted_at IS NULL AND li.is_done = false AND li.due_at IS NOT NULL
+AND li.due_at - r.offset_minutes * interval '1 minute' BETWEEN ? AND ?
+ON CONFLICT (reminder_id, list_item_id, due_at) DO NOTHING`, models.DeliveryPending, to, from, to)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rowsCreated := int(result.RowsAffected)
+
+	return &rowsCreated, nil
+}
+
+// ClaimDeliveries leases up to limit deliveries that are due at now to claimedBy until leaseUntil. Rows locked by
+// another replica are skipped, and claims whose lease ran out are taken over, so a replica that dies while
+// delivering does not lose its reminders.
+func (rr *ReminderRepository) ClaimDeliveries(claimedBy string, now time.Time, leaseUntil time.Time, limit int) (*[]models.ClaimedDelivery, error) {
+
+	var deliveries []models.ClaimedDelivery
+
+	result := rr.db.Raw(`WITH claimed AS (
+UPDATE reminder_deliveries SET status = ?, claimed_by = ?, claimed_until = ?, attempts = attempts + 1
+WHERE id IN (
+SELECT id FROM reminder_deliveries
+WHERE (status = ? AND next_attempt_at <= ?) OR (status = ? AND claimed_until <= ?)
+ORDER BY fire_at LIMIT ? FOR UPDATE SKIP LOCKED)
+RETURNING *)
+SELECT claimed.*, r.user_id, r.channel, r.target, r.deleted_at AS reminder_deleted_at,
+li.list_id, li.title, li.is_done, li.due_at AS current_due_at, li.deleted_at AS item_deleted_at
+FROM claimed
+JOIN reminders r ON r.id = claimed.reminder_id
+LEFT JOIN list_items li ON li.id = claimed.list_item_id
+ORDER BY claimed.fire_at`,
+		models.DeliveryClaimed, claimedBy, leaseUntil,
+		models.DeliveryPending, now, models.DeliveryClaimed, now, limit).Scan(&deliveries)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &deliveries, nil
+}
+
+func (rr *ReminderRepository) MarkSent(deliveryID uint, claimedBy string, sentAt time.Time) error {
+	return rr.release(deliveryID, claimedBy, map[string]interface{}{
+		"status":  models.DeliverySent,
+		"sent_at": sentAt,
+	})
+}
+
+func (rr *ReminderRepository) MarkSkipped(deliveryID uint, claimedBy string) error {
+	return rr.release(deliveryID, claimedBy, map[string]interface{}{
+		"status": models.DeliverySkipped,
+	})
+}
+
+func (rr *ReminderRepository) MarkFailed(deliveryID uint, claimedBy string, reason string) error {
+	return rr.release(deliveryID, claimedBy, map[string]interface{}{
+		"status":     models.DeliveryFailed,
+		"last_error": reason,
+	})
+}
+
+// Reschedule puts the delivery back as pending so any replica retries it from nextAttemptAt on.
+func (rr *ReminderRepository) Reschedule(deliveryID uint, claimedBy string, nextAttemptAt time.Time, reason string) error {
+	return rr.release(deliveryID, claimedBy, map[string]interface{}{
+		"status":          models.DeliveryPending,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      reason,
+	})
+}
+
+// release only updates deliveries still claimed by claimedBy, a replica whose lease ran out must not
+// overwrite the outcome of the one that took the delivery over.
+func (rr *ReminderRepository) release(deliveryID uint, claimedBy string, updates map[string]interface{}) error {
+	updates["claimed_by"] = ""
+	updates["claimed_until"] = nil
+
+	result := rr.db.Model(&models.ReminderDelivery{}).
+		Where("id = ? AND status = ? AND claimed_by = ?", deliveryID, models.DeliveryClaimed, claimedBy).
+		Updates(updates)
+
+	return result.Error
+}
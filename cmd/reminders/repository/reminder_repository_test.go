@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/reminders/models"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewReminderRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want ReminderRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewReminderRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewReminderRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewReminderRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewReminderRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderRepository_Create(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `reminders` (`created_at`,`updated_at`,`deleted_at`,`user_id`,`list_id`,`list_item_id`,`offset_minutes`,`channel`,`target`) VALUES (?,?,?,?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, nil, 4, 30, "in_app", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := reminderRepository.Create(GetValidReminder())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestReminderRepository_Create_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `reminders`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := reminderRepository.Create(GetValidReminder())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestReminderRepository_Delete(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `reminders` SET `deleted_at`=? WHERE user_id = ? AND `reminders`.`id` = ? AND `reminders`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "1", "3").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	result, err := reminderRepository.Delete("1", "3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestReminderRepository_PlanDeliveries(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	from := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO reminder_deliveries (reminder_id, list_item_id, due_at, fire_at, next_attempt_at, status, attempts, created_at)")).
+		WithArgs(models.DeliveryPending, to, from, to).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	result, err := reminderRepository.PlanDeliveries(from, to)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *result)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestReminderRepository_ClaimDeliveries(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	leaseUntil := now.Add(2 * time.Minute)
+	dueAt := now.Add(30 * time.Minute)
+
+	mock.ExpectQuery(regexp.QuoteMeta("WITH claimed AS (")).
+		WithArgs(models.DeliveryClaimed, "replica-1", leaseUntil, models.DeliveryPending, now, models.DeliveryClaimed, now, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "reminder_id", "list_item_id", "due_at", "status", "attempts", "user_id", "channel", "list_id", "title", "current_due_at"}).
+			AddRow(7, 2, 4, dueAt, models.DeliveryClaimed, 1, 1, "in_app", 3, "Buy milk", dueAt))
+
+	result, err := reminderRepository.ClaimDeliveries("replica-1", now, leaseUntil, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 1)
+	assert.Equal(t, uint(7), (*result)[0].ID)
+	assert.Equal(t, uint(3), (*result)[0].ListID)
+	assert.Equal(t, "Buy milk", (*result)[0].Title)
+	assert.True(t, dueAt.Equal(*(*result)[0].CurrentDueAt))
+}
+
+func TestReminderRepository_MarkSent_Only_Updates_Own_Claim(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	sentAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `reminder_deliveries` SET `claimed_by`=?,`claimed_until`=?,`sent_at`=?,`status`=? WHERE id = ? AND status = ? AND claimed_by = ?")).
+		WithArgs("", nil, sentAt, models.DeliverySent, 7, models.DeliveryClaimed, "replica-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, reminderRepository.MarkSent(7, "replica-1", sentAt))
+}
+
+func TestReminderRepository_Reschedule(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	reminderRepository := NewReminderRepository(gormDb)
+
+	next := time.Date(2022, 3, 1, 10, 1, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `reminder_deliveries` SET `claimed_by`=?,`claimed_until`=?,`last_error`=?,`next_attempt_at`=?,`status`=? WHERE id = ? AND status = ? AND claimed_by = ?")).
+		WithArgs("", nil, "timeout", next, models.DeliveryPending, 7, models.DeliveryClaimed, "replica-1").
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	assert.Error(t, reminderRepository.Reschedule(7, "replica-1", next, "timeout"))
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func GetValidReminder() models.Reminder {
+	listItemID := uint(4)
+
+	return models.Reminder{
+		UserID:        1,
+		ListItemID:    &listItemID,
+		OffsetMinutes: 30,
+		Channel:       "in_app",
+	}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/reminders/models"
+	"SuperListsAPI/internal/notify"
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	// plannerLookback lets reminders that should have fired while no replica was running still go out.
+	plannerLookback = 24 * time.Hour
+	// A claimed batch must be sent within its lease, or another replica claims and sends it again. Sends are
+	// bounded by deliveryTimeout, so a batch takes at most 100s of its 2m lease.
+	claimBatchSize  = 10
+	claimLease      = 2 * time.Minute
+	deliveryTimeout = 10 * time.Second
+	maxAttempts     = 5
+	retryBaseDelay  = time.Minute
+	retryMaxDelay   = time.Hour
+)
+
+// ReminderJob plans and sends due reminders, it implements scheduler.Job.
+//
+// Any number of replicas can run it at once: planning is idempotent and each delivery is leased to a single
+// replica with row locks. A replica that stops between notifying and recording the outcome leaves the lease to
+// expire and the delivery is sent again, so notifiers get the delivery id to drop repeated events.
+type ReminderJob struct {
+	repository IReminderRepository
+	notifier   notify.Notifier
+	replicaID  string
+	now        func() time.Time
+}
+
+func NewReminderJob(repository IReminderRepository, notifier notify.Notifier, replicaID string) ReminderJob {
+	return ReminderJob{
+		repository: repository,
+		notifier:   notifier,
+		replicaID:  replicaID,
+		now:        time.Now,
+	}
+}
+
+func (rj *ReminderJob) Name() string {
+	return "reminders"
+}
+
+func (rj *ReminderJob) Run(ctx context.Context) error {
+	now := rj.now()
+
+	if _, err := rj.repository.PlanDeliveries(now.Add(-plannerLookback), now); err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		claimedAt := rj.now()
+		leaseUntil := claimedAt.Add(claimLease)
+
+		deliveries, err := rj.repository.ClaimDeliveries(rj.replicaID, claimedAt, leaseUntil, claimBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range *deliveries {
+			// Deliveries left once the lease can't cover another send expire and are claimed again
+			if rj.now().Add(deliveryTimeout).After(leaseUntil) {
+				return nil
+			}
+
+			if err := rj.deliver(ctx, delivery, claimedAt); err != nil {
+				return err
+			}
+		}
+
+		if len(*deliveries) < claimBatchSize {
+			return nil
+		}
+	}
+
+	return ctx.Err()
+}
+
+func (rj *ReminderJob) deliver(ctx context.Context, delivery models.ClaimedDelivery, now time.Time) error {
+	if isStale(delivery) {
+		return rj.repository.MarkSkipped(delivery.ID, rj.replicaID)
+	}
+
+	if delivery.Attempts > maxAttempts {
+		return rj.repository.MarkFailed(delivery.ID, rj.replicaID, delivery.LastError)
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, deliveryTimeout)
+	defer cancel()
+
+	err := rj.notifier.Notify(sendCtx, notify.Event{
+		DeliveryID: strconv.FormatUint(uint64(delivery.ID), 10),
+		Kind:       notify.KindReminder,
+		ReminderID: delivery.ReminderID,
+		UserID:     delivery.UserID,
+		ListID:     delivery.ListID,
+		ListItemID: delivery.ListItemID,
+		Title:      delivery.Title,
+		DueAt:      delivery.DueAt,
+		FireAt:     delivery.FireAt,
+		Channel:    delivery.Channel,
+		Target:     delivery.Target,
+	})
+
+	if err == nil {
+		return rj.repository.MarkSent(delivery.ID, rj.replicaID, rj.now())
+	}
+
+	if errors.Is(err, notify.ErrChannelNotConfigured) || delivery.Attempts >= maxAttempts {
+		return rj.repository.MarkFailed(delivery.ID, rj.replicaID, err.Error())
+	}
+
+	return rj.repository.Reschedule(delivery.ID, rj.replicaID, now.Add(retryDelay(delivery.Attempts)), err.Error())
+}
+
+// isStale tells whether the occurrence no longer applies: the reminder or item was removed, the item was
+// completed or its due date changed after the delivery was planned.
+func isStale(delivery models.ClaimedDelivery) bool {
+	return delivery.ReminderDeletedAt != nil ||
+		delivery.ItemDeletedAt != nil ||
+		delivery.ListID == 0 ||
+		delivery.IsDone ||
+		delivery.CurrentDueAt == nil ||
+		!delivery.CurrentDueAt.Equal(delivery.DueAt)
+}
+
+// retryDelay doubles the wait after every failed attempt.
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/reminders/models"
+	"SuperListsAPI/internal/notify"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type notifierFunc func(ctx context.Context, event notify.Event) error
+
+func (f notifierFunc) Notify(ctx context.Context, event notify.Event) error {
+	return f(ctx, event)
+}
+
+var jobNow = time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func getClaimedDelivery() models.ClaimedDelivery {
+	dueAt := jobNow.Add(30 * time.Minute)
+
+	return models.ClaimedDelivery{
+		ReminderDelivery: models.ReminderDelivery{
+			ID:         7,
+			ReminderID: 2,
+			ListItemID: 4,
+			DueAt:      dueAt,
+			FireAt:     jobNow,
+			Status:     models.DeliveryClaimed,
+			ClaimedBy:  "replica-1",
+			Attempts:   1,
+		},
+		UserID:       1,
+		Channel:      notify.ChannelInApp,
+		ListID:       3,
+		Title:        "Buy milk",
+		CurrentDueAt: &dueAt,
+	}
+}
+
+func runJob(t *testing.T, delivery models.ClaimedDelivery, notifier notify.Notifier, expect func(repo *MockIReminderRepository)) error {
+	repo := NewMockIReminderRepository(gomock.NewController(t))
+	repo.EXPECT().PlanDeliveries(jobNow.Add(-plannerLookback), jobNow).Return(new(int), nil)
+	repo.EXPECT().ClaimDeliveries("replica-1", jobNow, jobNow.Add(claimLease), claimBatchSize).
+		Return(&[]models.ClaimedDelivery{delivery}, nil)
+	expect(repo)
+
+	job := NewReminderJob(repo, notifier, "replica-1")
+	job.now = func() time.Time { return jobNow }
+
+	return job.Run(context.Background())
+}
+
+func TestReminderJob_Sends_Due_Reminder(t *testing.T) {
+	var sent []notify.Event
+
+	notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+		sent = append(sent, event)
+		return nil
+	})
+
+	err := runJob(t, getClaimedDelivery(), notifier, func(repo *MockIReminderRepository) {
+		repo.EXPECT().MarkSent(uint(7), "replica-1", jobNow).Return(nil)
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, sent, 1)
+	assert.Equal(t, "7", sent[0].DeliveryID)
+	assert.Equal(t, uint(1), sent[0].UserID)
+	assert.Equal(t, "Buy milk", sent[0].Title)
+}
+
+func TestReminderJob_Skips_Stale_Deliveries(t *testing.T) {
+	movedDueAt := jobNow.Add(2 * time.Hour)
+	deletedAt := jobNow
+
+	tests := []struct {
+		name   string
+		modify func(delivery *models.ClaimedDelivery)
+	}{
+		{
+			name:   "Item is done",
+			modify: func(delivery *models.ClaimedDelivery) { delivery.IsDone = true },
+		},
+		{
+			name:   "Item due date moved",
+			modify: func(delivery *models.ClaimedDelivery) { delivery.CurrentDueAt = &movedDueAt },
+		},
+		{
+			name:   "Item due date removed",
+			modify: func(delivery *models.ClaimedDelivery) { delivery.CurrentDueAt = nil },
+		},
+		{
+			name:   "Item deleted",
+			modify: func(delivery *models.ClaimedDelivery) { delivery.ItemDeletedAt = &deletedAt },
+		},
+		{
+			name:   "Reminder deleted",
+			modify: func(delivery *models.ClaimedDelivery) { delivery.ReminderDeletedAt = &deletedAt },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delivery := getClaimedDelivery()
+			tt.modify(&delivery)
+
+			notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+				t.Error("stale delivery should not be sent")
+				return nil
+			})
+
+			err := runJob(t, delivery, notifier, func(repo *MockIReminderRepository) {
+				repo.EXPECT().MarkSkipped(uint(7), "replica-1").Return(nil)
+			})
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestReminderJob_Retries_Failed_Delivery(t *testing.T) {
+	delivery := getClaimedDelivery()
+	delivery.Attempts = 2
+
+	notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+		return errors.New("timeout")
+	})
+
+	err := runJob(t, delivery, notifier, func(repo *MockIReminderRepository) {
+		repo.EXPECT().Reschedule(uint(7), "replica-1", jobNow.Add(2*time.Minute), "timeout").Return(nil)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestReminderJob_Gives_Up_After_Max_Attempts(t *testing.T) {
+	delivery := getClaimedDelivery()
+	delivery.Attempts = maxAttempts
+
+	notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+		return errors.New("timeout")
+	})
+
+	err := runJob(t, delivery, notifier, func(repo *MockIReminderRepository) {
+		repo.EXPECT().MarkFailed(uint(7), "replica-1", "timeout").Return(nil)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestReminderJob_Fails_Unconfigured_Channel(t *testing.T) {
+	delivery := getClaimedDelivery()
+	delivery.Channel = notify.ChannelEmail
+
+	err := runJob(t, delivery, notify.NewDispatcher(), func(repo *MockIReminderRepository) {
+		repo.EXPECT().MarkFailed(uint(7), "replica-1", notify.ErrChannelNotConfigured.Error()).Return(nil)
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestReminderJob_Batch_Fits_Lease(t *testing.T) {
+	assert.Less(t, int64(claimBatchSize*deliveryTimeout), int64(claimLease))
+}
+
+func TestReminderJob_Leaves_Deliveries_Past_Lease(t *testing.T) {
+	first := getClaimedDelivery()
+	second := getClaimedDelivery()
+	second.ID = 8
+	clock := jobNow
+
+	repo := NewMockIReminderRepository(gomock.NewController(t))
+	repo.EXPECT().PlanDeliveries(jobNow.Add(-plannerLookback), jobNow).Return(new(int), nil)
+	repo.EXPECT().ClaimDeliveries("replica-1", jobNow, jobNow.Add(claimLease), claimBatchSize).
+		Return(&[]models.ClaimedDelivery{first, second}, nil)
+
+	// The first send takes so long that the lease can't cover another one
+	afterSend := jobNow.Add(claimLease - deliveryTimeout + time.Second)
+	repo.EXPECT().MarkSent(uint(7), "replica-1", afterSend).Return(nil)
+
+	var sent []string
+	notifier := notifierFunc(func(ctx context.Context, event notify.Event) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(deliveryTimeout), deadline, time.Second)
+
+		sent = append(sent, event.DeliveryID)
+		clock = afterSend
+		return nil
+	})
+
+	job := NewReminderJob(repo, notifier, "replica-1")
+	job.now = func() time.Time { return clock }
+
+	assert.NoError(t, job.Run(context.Background()))
+	assert.Equal(t, []string{"7"}, sent)
+}
+
+func TestReminderJob_Stops_On_Claim_Error(t *testing.T) {
+	repo := NewMockIReminderRepository(gomock.NewController(t))
+	repo.EXPECT().PlanDeliveries(gomock.Any(), gomock.Any()).Return(new(int), nil)
+	repo.EXPECT().ClaimDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("error from db"))
+
+	job := NewReminderJob(repo, notify.NewDispatcher(), "replica-1")
+
+	assert.Error(t, job.Run(context.Background()))
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 2*time.Minute, retryDelay(2))
+	assert.Equal(t, 8*time.Minute, retryDelay(4))
+	assert.Equal(t, retryMaxDelay, retryDelay(20))
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/reminders/models"
+	"time"
+)
+
+//go:generate mockgen -source=reminder_service.go -destination reminder_service_mock.go -package service
+
+type IReminderRepository interface {
+	Create(reminder models.Reminder) (*models.Reminder, error)
+	GetRemindersByUserID(userID string) (*[]models.Reminder, error)
+	Delete(userID string, reminderID string) (*int, error)
+	PlanDeliveries(from time.Time, to time.Time) (*int, error)
+	ClaimDeliveries(claimedBy string, now time.Time, leaseUntil time.Time, limit int) (*[]models.ClaimedDelivery, error)
+	MarkSent(deliveryID uint, claimedBy string, sentAt time.Time) error
+	MarkSkipped(deliveryID uint, claimedBy string) error
+	MarkFailed(deliveryID uint, claimedBy string, reason string) error
+	Reschedule(deliveryID uint, claimedBy string, nextAttemptAt time.Time, reason string) error
+}
+
+type ReminderService struct {
+	repository IReminderRepository
+}
+
+func NewReminderService(repository IReminderRepository) ReminderService {
+	return ReminderService{repository: repository}
+}
+
+func (rs *ReminderService) Create(reminder models.Reminder) (*models.Reminder, error) {
+	if (reminder.ListID == nil) == (reminder.ListItemID == nil) {
+		return nil, models.ErrInvalidReminderScope
+	}
+
+	return rs.repository.Create(reminder)
+}
+
+func (rs *ReminderService) GetRemindersByUserID(userID string) (*[]models.Reminder, error) {
+	return rs.repository.GetRemindersByUserID(userID)
+}
+
+func (rs *ReminderService) Delete(userID string, reminderID string) (*int, error) {
+	return rs.repository.Delete(userID, reminderID)
+}
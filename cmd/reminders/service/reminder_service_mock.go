@@ -0,0 +1,167 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminder_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/reminders/models"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIReminderRepository is a mock of IReminderRepository interface.
+type MockIReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIReminderRepositoryMockRecorder
+}
+
+// MockIReminderRepositoryMockRecorder is the mock recorder for MockIReminderRepository.
+type MockIReminderRepositoryMockRecorder struct {
+	mock *MockIReminderRepository
+}
+
+// NewMockIReminderRepository creates a new mock instance.
+func NewMockIReminderRepository(ctrl *gomock.Controller) *MockIReminderRepository {
+	mock := &MockIReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockIReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIReminderRepository) EXPECT() *MockIReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockIReminderRepository) ClaimDeliveries(claimedBy string, now, leaseUntil time.Time, limit int) (*[]models.ClaimedDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", claimedBy, now, leaseUntil, limit)
+	ret0, _ := ret[0].(*[]models.ClaimedDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockIReminderRepositoryMockRecorder) ClaimDeliveries(claimedBy, now, leaseUntil, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockIReminderRepository)(nil).ClaimDeliveries), claimedBy, now, leaseUntil, limit)
+}
+
+// Create mocks base method.
+func (m *MockIReminderRepository) Create(reminder models.Reminder) (*models.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", reminder)
+	ret0, _ := ret[0].(*models.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIReminderRepositoryMockRecorder) Create(reminder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIReminderRepository)(nil).Create), reminder)
+}
+
+// Delete mocks base method.
+func (m *MockIReminderRepository) Delete(userID, reminderID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, reminderID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIReminderRepositoryMockRecorder) Delete(userID, reminderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIReminderRepository)(nil).Delete), userID, reminderID)
+}
+
+// GetRemindersByUserID mocks base method.
+func (m *MockIReminderRepository) GetRemindersByUserID(userID string) (*[]models.Reminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemindersByUserID", userID)
+	ret0, _ := ret[0].(*[]models.Reminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRemindersByUserID indicates an expected call of GetRemindersByUserID.
+func (mr *MockIReminderRepositoryMockRecorder) GetRemindersByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindersByUserID", reflect.TypeOf((*MockIReminderRepository)(nil).GetRemindersByUserID), userID)
+}
+
+// MarkFailed mocks base method.
+func (m *MockIReminderRepository) MarkFailed(deliveryID uint, claimedBy, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", deliveryID, claimedBy, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockIReminderRepositoryMockRecorder) MarkFailed(deliveryID, claimedBy, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockIReminderRepository)(nil).MarkFailed), deliveryID, claimedBy, reason)
+}
+
+// MarkSent mocks base method.
+func (m *MockIReminderRepository) MarkSent(deliveryID uint, claimedBy string, sentAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSent", deliveryID, claimedBy, sentAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSent indicates an expected call of MarkSent.
+func (mr *MockIReminderRepositoryMockRecorder) MarkSent(deliveryID, claimedBy, sentAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSent", reflect.TypeOf((*MockIReminderRepository)(nil).MarkSent), deliveryID, claimedBy, sentAt)
+}
+
+// MarkSkipped mocks base method.
+func (m *MockIReminderRepository) MarkSkipped(deliveryID uint, claimedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkSkipped", deliveryID, claimedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkSkipped indicates an expected call of MarkSkipped.
+func (mr *MockIReminderRepositoryMockRecorder) MarkSkipped(deliveryID, claimedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkSkipped", reflect.TypeOf((*MockIReminderRepository)(nil).MarkSkipped), deliveryID, claimedBy)
+}
+
+// PlanDeliveries mocks base method.
+func (m *MockIReminderRepository) PlanDeliveries(from, to time.Time) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanDeliveries", from, to)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanDeliveries indicates an expected call of PlanDeliveries.
+func (mr *MockIReminderRepositoryMockRecorder) PlanDeliveries(from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanDeliveries", reflect.TypeOf((*MockIReminderRepository)(nil).PlanDeliveries), from, to)
+}
+
+// Reschedule mocks base method.
+func (m *MockIReminderRepository) Reschedule(deliveryID uint, claimedBy string, nextAttemptAt time.Time, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", deliveryID, claimedBy, nextAttemptAt, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockIReminderRepositoryMockRecorder) Reschedule(deliveryID, claimedBy, nextAttemptAt, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockIReminderRepository)(nil).Reschedule), deliveryID, claimedBy, nextAttemptAt, reason)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/reminders/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func TestNewReminderService(t *testing.T) {
+	type args struct {
+		repository IReminderRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want ReminderService
+	}{
+		{
+			name: "Service with nil repo should pass",
+			args: args{nil},
+			want: NewReminderService(nil),
+		},
+		{
+			name: "Service with no nil repo should pass",
+			args: args{NewMockIReminderRepository(gomock.NewController(t))},
+			want: NewReminderService(NewMockIReminderRepository(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewReminderService(tt.args.repository); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewReminderService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReminderService_Create(t *testing.T) {
+	listID := uint(3)
+	listItemID := uint(4)
+
+	tests := []struct {
+		name       string
+		listID     *uint
+		listItemID *uint
+		wantErr    error
+	}{
+		{
+			name:       "Item reminder",
+			listItemID: &listItemID,
+		},
+		{
+			name:   "List reminder",
+			listID: &listID,
+		},
+		{
+			name:    "Reminder without list or item",
+			wantErr: models.ErrInvalidReminderScope,
+		},
+		{
+			name:       "Reminder with both list and item",
+			listID:     &listID,
+			listItemID: &listItemID,
+			wantErr:    models.ErrInvalidReminderScope,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminder := models.Reminder{UserID: 1, ListID: tt.listID, ListItemID: tt.listItemID, Channel: "in_app"}
+
+			mockedRepo := NewMockIReminderRepository(gomock.NewController(t))
+			if tt.wantErr == nil {
+				mockedRepo.EXPECT().Create(reminder).Return(&reminder, nil)
+			}
+
+			reminderService := NewReminderService(mockedRepo)
+
+			_, err := reminderService.Create(reminder)
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
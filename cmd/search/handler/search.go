@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/search/models"
+	"SuperListsAPI/internal/httputil"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:generate mockgen -source=search.go -destination search_mock.go -package handler
+
+type ISearchService interface {
+	Search(query models.Query) (*models.SearchPage, error)
+}
+
+type SearchHandler struct {
+	searchService ISearchService
+}
+
+func NewSearchHandler(searchService ISearchService) SearchHandler {
+	return SearchHandler{searchService: searchService}
+}
+
+// Search answers a page of the lists and items matching ?q= on the lists the caller is a member of. ?lang= restricts
+// the search to es or en words, both are searched by default.
+func (sh *SearchHandler) Search(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	text := strings.TrimSpace(c.Query("q"))
+	if text == "" || utf8.RuneCountInString(text) > models.MaxQueryLength {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": fmt.Sprintf("q must have between 1 and %d characters", models.MaxQueryLength),
+		})
+		c.Abort()
+		return
+	}
+
+	language := c.Query("lang")
+	if language != models.LanguageAny && language != models.LanguageSpanish && language != models.LanguageEnglish {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "lang must be es or en",
+		})
+		c.Abort()
+		return
+	}
+
+	page, ok := httputil.QueryNumber(c, "page", 1, 1, 0)
+	if !ok {
+		return
+	}
+
+	pageSize, ok := httputil.QueryNumber(c, "page_size", models.DefaultPageSize, 1, models.MaxPageSize)
+	if !ok {
+		return
+	}
+
+	result, err := sh.searchService.Search(models.Query{
+		Text:     text,
+		Language: language,
+		UserID:   uint(userID),
+		Page:     page,
+		PageSize: pageSize,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/search/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISearchService is a mock of ISearchService interface.
+type MockISearchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISearchServiceMockRecorder
+}
+
+// MockISearchServiceMockRecorder is the mock recorder for MockISearchService.
+type MockISearchServiceMockRecorder struct {
+	mock *MockISearchService
+}
+
+// NewMockISearchService creates a new mock instance.
+func NewMockISearchService(ctrl *gomock.Controller) *MockISearchService {
+	mock := &MockISearchService{ctrl: ctrl}
+	mock.recorder = &MockISearchServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISearchService) EXPECT() *MockISearchServiceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockISearchService) Search(query models.Query) (*models.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].(*models.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockISearchServiceMockRecorder) Search(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockISearchService)(nil).Search), query)
+}
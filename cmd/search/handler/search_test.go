@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/search/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSearchHandler_Search(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		query      string
+		setup      func(searchService *MockISearchService)
+		wantStatus int
+	}{
+		{
+			name:   "Default page on every language",
+			userID: "7",
+			query:  "q=" + url.QueryEscape("plumber's number"),
+			setup: func(searchService *MockISearchService) {
+				searchService.EXPECT().Search(models.Query{Text: "plumber's number", UserID: 7, Page: 1, PageSize: models.DefaultPageSize}).
+					Return(&models.SearchPage{Results: []models.Result{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Spanish words only",
+			userID: "7",
+			query:  "q=plomero&lang=es&page=2&page_size=5",
+			setup: func(searchService *MockISearchService) {
+				searchService.EXPECT().Search(models.Query{Text: "plomero", Language: models.LanguageSpanish, UserID: 7, Page: 2, PageSize: 5}).
+					Return(&models.SearchPage{Results: []models.Result{}}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Missing text",
+			userID:     "7",
+			query:      "q=%20%20",
+			setup:      func(searchService *MockISearchService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Text too long",
+			userID:     "7",
+			query:      "q=" + strings.Repeat("a", models.MaxQueryLength+1),
+			setup:      func(searchService *MockISearchService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Unsupported language",
+			userID:     "7",
+			query:      "q=plomero&lang=fr",
+			setup:      func(searchService *MockISearchService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid page size",
+			userID:     "7",
+			query:      "q=plomero&page_size=500",
+			setup:      func(searchService *MockISearchService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Missing user id",
+			query:      "q=plomero",
+			setup:      func(searchService *MockISearchService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service error",
+			userID: "7",
+			query:  "q=plomero",
+			setup: func(searchService *MockISearchService) {
+				searchService.EXPECT().Search(gomock.Any()).Return(nil, errors.New("error from search service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			searchService := NewMockISearchService(gomock.NewController(t))
+			tt.setup(searchService)
+
+			searchHandler := NewSearchHandler(searchService)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/search?"+tt.query, nil)
+			if tt.userID != "" {
+				c.Request.Header.Set("user_id", tt.userID)
+			}
+
+			searchHandler.Search(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
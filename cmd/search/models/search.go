@@ -0,0 +1,131 @@
+package models
+
+import (
+	"html"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+	MaxQueryLength  = 200
+)
+
+// Languages a search can be restricted to. LanguageAny matches words on every language the search index is built
+// with, Spanish and English.
+const (
+	LanguageAny     = ""
+	LanguageSpanish = "es"
+	LanguageEnglish = "en"
+)
+
+const (
+	TypeList     = "list"
+	TypeListItem = "list_item"
+)
+
+// HighlightStart and HighlightEnd surround the matched words on highlighted text, everything else on it is html
+// escaped.
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
+// Query is a search on the lists UserID is a member of.
+type Query struct {
+	Text     string
+	Language string
+	UserID   uint
+	Page     int
+	PageSize int
+}
+
+// Result is a list or an item matching a search. Title is the list name or the item title and Snippet is the part of
+// the description around the match, both highlighted. ListName is the name of the list the result belongs to.
+type Result struct {
+	Type     string  `json:"type" gorm:"column:type"`
+	ID       uint    `json:"id"`
+	ListID   uint    `json:"list_id"`
+	ListName string  `json:"list_name"`
+	Title    string  `json:"title"`
+	Snippet  string  `json:"snippet"`
+	Rank     float64 `json:"rank" gorm:"column:score"`
+}
+
+// SearchPage is a page of results, best matches first.
+type SearchPage struct {
+	Results  []Result `json:"results"`
+	Page     int      `json:"page"`
+	PageSize int      `json:"page_size"`
+	Total    int64    `json:"total"`
+}
+
+// Highlight html escapes text and surrounds every case insensitive occurrence of term with the highlight marks.
+func Highlight(text string, term string) string {
+	if term == "" {
+		return html.EscapeString(text)
+	}
+
+	lowerText := strings.ToLower(text)
+	lowerTerm := strings.ToLower(term)
+
+	// Lower casing can change the byte length of some characters, positions are only reliable when it doesn't
+	if len(lowerText) != len(text) || len(lowerTerm) != len(term) {
+		return html.EscapeString(text)
+	}
+
+	var highlighted strings.Builder
+	for {
+		index := strings.Index(lowerText, lowerTerm)
+		if index < 0 {
+			break
+		}
+
+		highlighted.WriteString(html.EscapeString(text[:index]))
+		highlighted.WriteString(HighlightStart)
+		highlighted.WriteString(html.EscapeString(text[index : index+len(term)]))
+		highlighted.WriteString(HighlightEnd)
+
+		text = text[index+len(term):]
+		lowerText = lowerText[index+len(term):]
+	}
+	highlighted.WriteString(html.EscapeString(text))
+
+	return highlighted.String()
+}
+
+// Excerpt cuts text down to the first occurrence of term with radius characters on each side, marking the cuts with
+// an ellipsis. The excerpt keeps its length when the occurrence is close to the start, and text without term keeps its
+// beginning.
+func Excerpt(text string, term string, radius int) string {
+	if utf8.RuneCountInString(text) <= 2*radius+len(term) {
+		return text
+	}
+
+	start := 0
+	lowerText := strings.ToLower(text)
+	index := strings.Index(lowerText, strings.ToLower(term))
+	if index > 0 && len(lowerText) == len(text) {
+		start = utf8.RuneCountInString(text[:index]) - radius
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	runes := []rune(text)
+	end := start + 2*radius + utf8.RuneCountInString(term)
+	if end > len(runes) {
+		end = len(runes)
+	}
+
+	excerpt := string(runes[start:end])
+	if start > 0 {
+		excerpt = "…" + excerpt
+	}
+	if end < len(runes) {
+		excerpt += "…"
+	}
+
+	return excerpt
+}
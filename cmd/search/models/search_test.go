@@ -0,0 +1,89 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		term string
+		want string
+	}{
+		{
+			name: "Every occurrence is marked keeping its case",
+			text: "Plumber number, call the plumber",
+			term: "plumber",
+			want: "<mark>Plumber</mark> number, call the <mark>plumber</mark>",
+		},
+		{
+			name: "Text is escaped",
+			text: "<b>Fontanero</b> & gas",
+			term: "fontanero",
+			want: "&lt;b&gt;<mark>Fontanero</mark>&lt;/b&gt; &amp; gas",
+		},
+		{
+			name: "No match",
+			text: "Groceries",
+			term: "paint",
+			want: "Groceries",
+		},
+		{
+			name: "Empty term",
+			text: "Groceries",
+			term: "",
+			want: "Groceries",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Highlight(tt.text, tt.term))
+		})
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		term   string
+		radius int
+		want   string
+	}{
+		{
+			name:   "Short text is kept",
+			text:   "call the plumber",
+			term:   "plumber",
+			radius: 10,
+			want:   "call the plumber",
+		},
+		{
+			name:   "Cut around the match",
+			text:   "remember to call the plumber before friday about the kitchen sink",
+			term:   "plumber",
+			radius: 9,
+			want:   "…call the plumber before f…",
+		},
+		{
+			name:   "Match at the start",
+			text:   "plumber number is on the fridge door, next to the calendar",
+			term:   "plumber",
+			radius: 5,
+			want:   "plumber number is…",
+		},
+		{
+			name:   "No match",
+			text:   "remember to call the plumber before friday",
+			term:   "paint",
+			radius: 5,
+			want:   "remember to cal…",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Excerpt(tt.text, tt.term, tt.radius))
+		})
+	}
+}
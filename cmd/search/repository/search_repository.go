@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/search/models"
+	"SuperListsAPI/internal/database"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// searchConfigs are the Postgres text search configurations used for each language. The search vectors of lists and
+// items hold the words of both, see sql/create_tables.sql.
+var searchConfigs = map[string][]string{
+	models.LanguageAny:     {"spanish", "english"},
+	models.LanguageSpanish: {"spanish"},
+	models.LanguageEnglish: {"english"},
+}
+
+const (
+	// titleHeadline marks every match on titles, snippetHeadline keeps the fragments of the description around them.
+	titleHeadline   = "StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightEnd + ", HighlightAll=true"
+	snippetHeadline = "StartSel=" + models.HighlightStart + ", StopSel=" + models.HighlightEnd + ", MaxFragments=2, MaxWords=20, MinWords=5, FragmentDelimiter=\" … \""
+
+	// snippetRadius is the amount of characters kept on each side of a match on the descriptions of LIKE results.
+	snippetRadius = 60
+)
+
+// memberLists selects the lists the searching user can access.
+const memberLists = "SELECT list_id FROM user_lists WHERE user_id = @userID AND deleted_at IS NULL"
+
+// fullTextMatches selects the lists and items matching {query}. Their text is html escaped before highlighting, so
+// the highlight marks are the only markup on the results.
+const fullTextMatches = `SELECT 'list' AS type, l.id, l.id AS list_id, l.name AS list_name,
+	{escape:l.name} AS title, {escape:l.description} AS snippet, ts_rank(l.search_vector, {query}) AS score
+FROM lists l
+WHERE l.search_vector @@ {query} AND l.deleted_at IS NULL AND l.id IN (` + memberLists + `)
+UNION ALL
+SELECT 'list_item' AS type, i.id, i.list_id, l.name AS list_name,
+	{escape:i.title} AS title, {escape:i.description} AS snippet, ts_rank(i.search_vector, {query}) AS score
+FROM list_items i JOIN lists l ON l.id = i.list_id
+WHERE i.search_vector @@ {query} AND i.deleted_at IS NULL AND l.deleted_at IS NULL AND i.list_id IN (` + memberLists + `)`
+
+// fullTextPage highlights a page of matches, ts_headline is expensive so it only runs on the rows answered.
+const fullTextPage = `SELECT type, id, list_id, list_name,
+	ts_headline('{config}', title, {query}, @titleHeadline) AS title,
+	ts_headline('{config}', snippet, {query}, @snippetHeadline) AS snippet,
+	score
+FROM (` + fullTextMatches + ` ORDER BY score DESC, type, id LIMIT @limit OFFSET @offset) matches
+ORDER BY score DESC, type, id`
+
+// likeMatches is the fallback for databases without full text search. Matches on the title rank above matches on the
+// description only.
+const likeMatches = `SELECT 'list' AS type, l.id, l.id AS list_id, l.name AS list_name,
+	l.name AS title, COALESCE(l.description, '') AS snippet,
+	CASE WHEN LOWER(l.name) LIKE @pattern ESCAPE '!' THEN 1 ELSE 0.5 END AS score
+FROM lists l
+WHERE (LOWER(l.name) LIKE @pattern ESCAPE '!' OR LOWER(l.description) LIKE @pattern ESCAPE '!')
+	AND l.deleted_at IS NULL AND l.id IN (` + memberLists + `)
+UNION ALL
+SELECT 'list_item' AS type, i.id, i.list_id, l.name AS list_name,
+	i.title AS title, COALESCE(i.description, '') AS snippet,
+	CASE WHEN LOWER(i.title) LIKE @pattern ESCAPE '!' THEN 1 ELSE 0.5 END AS score
+FROM list_items i JOIN lists l ON l.id = i.list_id
+WHERE (LOWER(i.title) LIKE @pattern ESCAPE '!' OR LOWER(i.description) LIKE @pattern ESCAPE '!')
+	AND i.deleted_at IS NULL AND l.deleted_at IS NULL AND i.list_id IN (` + memberLists + `)`
+
+const likePage = `SELECT * FROM (` + likeMatches + `) matches ORDER BY score DESC, type, id LIMIT @limit OFFSET @offset`
+
+type SearchRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return SearchRepository{db: db}
+}
+
+// Search returns a page of the lists and items matching the query on the lists the user is a member of, best matches
+// first. Postgres databases use full text search, any other database falls back to a case insensitive LIKE.
+func (sr *SearchRepository) Search(query models.Query) (*models.SearchPage, error) {
+
+	searchPage := models.SearchPage{Results: []models.Result{}, Page: query.Page, PageSize: query.PageSize}
+
+	fullText := sr.db.Dialector.Name() == "postgres"
+
+	matches, page := likeMatches, likePage
+	args := map[string]interface{}{
+		"userID":  query.UserID,
+		"pattern": "%" + database.EscapeLike(strings.ToLower(query.Text)) + "%",
+	}
+
+	if fullText {
+		replacer := fullTextReplacer(query.Language)
+		matches, page = replacer.Replace(fullTextMatches), replacer.Replace(fullTextPage)
+		args = map[string]interface{}{
+			"userID":          query.UserID,
+			"text":            query.Text,
+			"titleHeadline":   titleHeadline,
+			"snippetHeadline": snippetHeadline,
+		}
+	}
+
+	if result := sr.db.Raw("SELECT COUNT(*) FROM ("+matches+") matches", args).Scan(&searchPage.Total); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if searchPage.Total == 0 {
+		return &searchPage, nil
+	}
+
+	args["limit"] = query.PageSize
+	args["offset"] = (query.Page - 1) * query.PageSize
+
+	if result := sr.db.Raw(page, args).Scan(&searchPage.Results); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if !fullText {
+		for i, result := range searchPage.Results {
+			searchPage.Results[i].Title = models.Highlight(result.Title, query.Text)
+			searchPage.Results[i].Snippet = models.Highlight(models.Excerpt(result.Snippet, query.Text, snippetRadius), query.Text)
+		}
+	}
+
+	return &searchPage, nil
+}
+
+// fullTextReplacer fills the full text statements with the tsquery and the headline configuration of language.
+// Matches on every configuration are highlighted with the first one, since the query holds the words as parsed by it.
+func fullTextReplacer(language string) *strings.Replacer {
+	configs, ok := searchConfigs[language]
+	if !ok {
+		configs = searchConfigs[models.LanguageAny]
+	}
+
+	tsQueries := make([]string, 0, len(configs))
+	for _, config := range configs {
+		tsQueries = append(tsQueries, "websearch_to_tsquery('"+config+"', @text)")
+	}
+
+	return strings.NewReplacer(
+		"{query}", "("+strings.Join(tsQueries, " || ")+")",
+		"{config}", configs[0],
+		"{escape:l.name}", escapeHTML("l.name"),
+		"{escape:l.description}", escapeHTML("l.description"),
+		"{escape:i.title}", escapeHTML("i.title"),
+		"{escape:i.description}", escapeHTML("i.description"),
+	)
+}
+
+// escapeHTML is the sql expression html escaping column, empty when the column is null.
+func escapeHTML(column string) string {
+	return "replace(replace(replace(COALESCE(" + column + ", ''), '&', '&amp;'), '<', '&lt;'), '>', '&gt;')"
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/search/models"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestNewSearchRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want SearchRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewSearchRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewSearchRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewSearchRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewSearchRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var resultColumns = []string{"type", "id", "list_id", "list_name", "title", "snippet", "score"}
+
+func TestSearchRepository_Search_Full_Text(t *testing.T) {
+	gormDb, mock := getMockedPostgres(t)
+
+	searchRepository := NewSearchRepository(gormDb)
+
+	tsQuery := "(websearch_to_tsquery('spanish', $1) || websearch_to_tsquery('english', $2))"
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (SELECT 'list' AS type")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery(regexp.QuoteMeta("ts_headline('spanish', title, "+tsQuery)).
+		WithArgs("plomero", "plomero", titleHeadline, "plomero", "plomero", snippetHeadline,
+			"plomero", "plomero", "plomero", "plomero", uint(7),
+			"plomero", "plomero", "plomero", "plomero", uint(7), 2, 2).
+		WillReturnRows(sqlmock.NewRows(resultColumns).
+			AddRow(models.TypeListItem, 12, 3, "Casa", "Llamar al <mark>plomero</mark>", "", 0.6))
+
+	result, err := searchRepository.Search(models.Query{Text: "plomero", UserID: 7, Page: 2, PageSize: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.Total)
+	assert.Equal(t, []models.Result{{Type: models.TypeListItem, ID: 12, ListID: 3, ListName: "Casa", Title: "Llamar al <mark>plomero</mark>", Rank: 0.6}}, result.Results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_Search_Full_Text_Language(t *testing.T) {
+	gormDb, mock := getMockedPostgres(t)
+
+	searchRepository := NewSearchRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("l.search_vector @@ (websearch_to_tsquery('english', $2)) AND")).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	result, err := searchRepository.Search(models.Query{Text: "plumber", Language: models.LanguageEnglish, UserID: 7, Page: 1, PageSize: 20})
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Results)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_Search_Like(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	searchRepository := NewSearchRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM (SELECT 'list' AS type")).
+		WithArgs("%50!% off%", "%50!% off%", "%50!% off%", uint(7), "%50!% off%", "%50!% off%", "%50!% off%", uint(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE (LOWER(l.name) LIKE ? ESCAPE '!' OR LOWER(l.description) LIKE ? ESCAPE '!')")).
+		WithArgs("%50!% off%", "%50!% off%", "%50!% off%", uint(7), "%50!% off%", "%50!% off%", "%50!% off%", uint(7), 20, 0).
+		WillReturnRows(sqlmock.NewRows(resultColumns).
+			AddRow(models.TypeList, 3, 3, "Sales", "Sales", "Shoes <b>50% OFF</b>", 0.5))
+
+	result, err := searchRepository.Search(models.Query{Text: "50% off", UserID: 7, Page: 1, PageSize: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Shoes &lt;b&gt;<mark>50% OFF</mark>&lt;/b&gt;", result.Results[0].Snippet)
+	assert.Equal(t, "Sales", result.Results[0].Title)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSearchRepository_Search_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	searchRepository := NewSearchRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*)")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := searchRepository.Search(models.Query{Text: "paint", UserID: 7, Page: 1, PageSize: 20})
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+// getMockedPostgres opens the stub database with the postgres dialect, the one taking the full text search path.
+func getMockedPostgres(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/search/models"
+	"strings"
+)
+
+//go:generate mockgen -source=search_service.go -destination search_service_mock.go -package service
+
+type ISearchRepository interface {
+	Search(query models.Query) (*models.SearchPage, error)
+}
+
+type SearchService struct {
+	repository ISearchRepository
+}
+
+func NewSearchService(repository ISearchRepository) SearchService {
+	return SearchService{repository: repository}
+}
+
+// Search collapses the whitespace of the searched text, so pasted text matches the same as typed text.
+func (ss *SearchService) Search(query models.Query) (*models.SearchPage, error) {
+	query.Text = strings.Join(strings.Fields(query.Text), " ")
+
+	return ss.repository.Search(query)
+}
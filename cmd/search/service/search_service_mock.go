@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: search_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/search/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockISearchRepository is a mock of ISearchRepository interface.
+type MockISearchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockISearchRepositoryMockRecorder
+}
+
+// MockISearchRepositoryMockRecorder is the mock recorder for MockISearchRepository.
+type MockISearchRepositoryMockRecorder struct {
+	mock *MockISearchRepository
+}
+
+// NewMockISearchRepository creates a new mock instance.
+func NewMockISearchRepository(ctrl *gomock.Controller) *MockISearchRepository {
+	mock := &MockISearchRepository{ctrl: ctrl}
+	mock.recorder = &MockISearchRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISearchRepository) EXPECT() *MockISearchRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockISearchRepository) Search(query models.Query) (*models.SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].(*models.SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockISearchRepositoryMockRecorder) Search(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockISearchRepository)(nil).Search), query)
+}
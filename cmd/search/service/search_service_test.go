@@ -0,0 +1,34 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/search/models"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSearchService_Search(t *testing.T) {
+	mockedRepo := NewMockISearchRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Search(models.Query{Text: "plumber number", UserID: 7, Page: 1, PageSize: 20}).
+		Return(&models.SearchPage{Total: 1}, nil)
+
+	searchService := NewSearchService(mockedRepo)
+
+	result, err := searchService.Search(models.Query{Text: "  plumber \n\t number ", UserID: 7, Page: 1, PageSize: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), result.Total)
+}
+
+func TestSearchService_Search_Error(t *testing.T) {
+	mockedRepo := NewMockISearchRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Search(gomock.Any()).Return(nil, errors.New("error from repository"))
+
+	searchService := NewSearchService(mockedRepo)
+
+	result, err := searchService.Search(models.Query{Text: "paint", UserID: 7, Page: 1, PageSize: 20})
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
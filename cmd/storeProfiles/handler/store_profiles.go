@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/storeProfiles/models"
+	"SuperListsAPI/internal/httputil"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+)
+
+//go:generate mockgen -source=store_profiles.go -destination store_profiles_mock.go -package handler
+
+type IStoreProfileService interface {
+	Create(profile models.StoreProfile) (*models.StoreProfile, error)
+	Get(userID string, profileID string) (*models.StoreProfile, error)
+	GetProfilesByUserID(userID string) (*[]models.StoreProfile, error)
+	Update(profile models.StoreProfile) (*models.StoreProfile, error)
+	Delete(userID string, profileID string) (*int, error)
+}
+
+type StoreProfileHandler struct {
+	storeProfileService IStoreProfileService
+}
+
+func NewStoreProfileHandler(storeProfileService IStoreProfileService) StoreProfileHandler {
+	return StoreProfileHandler{storeProfileService: storeProfileService}
+}
+
+func (sph *StoreProfileHandler) Create(c *gin.Context) {
+	var profile models.StoreProfile
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	validate := validator.New()
+	err := c.ShouldBindJSON(&profile)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+	err = validate.Struct(profile)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	profile.ID = 0
+	profile.UserID = uint(userID)
+
+	result, err := sph.storeProfileService.Create(profile)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+	return
+}
+
+func (sph *StoreProfileHandler) GetProfiles(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	profiles, err := sph.storeProfileService.GetProfilesByUserID(fmt.Sprint(userID))
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if profiles == nil || len(*profiles) < 1 {
+		c.JSON(http.StatusNoContent, profiles)
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+	return
+}
+
+func (sph *StoreProfileHandler) Get(c *gin.Context) {
+	profileID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := strconv.Atoi(profileID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid store profile id",
+		})
+		c.Abort()
+		return
+	}
+
+	profile, err := sph.storeProfileService.Get(fmt.Sprint(userID), profileID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Store profile with id %s not found", profileID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+	return
+}
+
+func (sph *StoreProfileHandler) Update(c *gin.Context) {
+	var profile models.StoreProfile
+
+	profileID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	err := c.ShouldBindJSON(&profile)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	if _, err := strconv.Atoi(profileID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid store profile id",
+		})
+		c.Abort()
+		return
+	}
+
+	if fmt.Sprint(profile.ID) != profileID {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing store profile id on request path or store profile id mistmatch",
+		})
+		c.Abort()
+		return
+	}
+
+	validate := validator.New()
+
+	err = validate.Struct(profile)
+
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid json",
+		})
+		c.Abort()
+		return
+	}
+
+	current, err := sph.storeProfileService.Get(fmt.Sprint(userID), profileID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Store profile with id %s not found", profileID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	profile.UserID = current.UserID
+	profile.CreatedAt = current.CreatedAt
+
+	result, err := sph.storeProfileService.Update(profile)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (sph *StoreProfileHandler) Delete(c *gin.Context) {
+	profileID := c.Param("id")
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	if _, err := strconv.Atoi(profileID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid store profile id",
+		})
+		c.Abort()
+		return
+	}
+
+	result, err := sph.storeProfileService.Delete(fmt.Sprint(userID), profileID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	if result == nil || *result < 1 {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Store profile with id %s not found", profileID))
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
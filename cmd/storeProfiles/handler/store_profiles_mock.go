@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store_profiles.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/storeProfiles/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIStoreProfileService is a mock of IStoreProfileService interface.
+type MockIStoreProfileService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStoreProfileServiceMockRecorder
+}
+
+// MockIStoreProfileServiceMockRecorder is the mock recorder for MockIStoreProfileService.
+type MockIStoreProfileServiceMockRecorder struct {
+	mock *MockIStoreProfileService
+}
+
+// NewMockIStoreProfileService creates a new mock instance.
+func NewMockIStoreProfileService(ctrl *gomock.Controller) *MockIStoreProfileService {
+	mock := &MockIStoreProfileService{ctrl: ctrl}
+	mock.recorder = &MockIStoreProfileServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStoreProfileService) EXPECT() *MockIStoreProfileServiceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIStoreProfileService) Create(profile models.StoreProfile) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", profile)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIStoreProfileServiceMockRecorder) Create(profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIStoreProfileService)(nil).Create), profile)
+}
+
+// Delete mocks base method.
+func (m *MockIStoreProfileService) Delete(userID, profileID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, profileID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIStoreProfileServiceMockRecorder) Delete(userID, profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIStoreProfileService)(nil).Delete), userID, profileID)
+}
+
+// Get mocks base method.
+func (m *MockIStoreProfileService) Get(userID, profileID string) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, profileID)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIStoreProfileServiceMockRecorder) Get(userID, profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIStoreProfileService)(nil).Get), userID, profileID)
+}
+
+// GetProfilesByUserID mocks base method.
+func (m *MockIStoreProfileService) GetProfilesByUserID(userID string) (*[]models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfilesByUserID", userID)
+	ret0, _ := ret[0].(*[]models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfilesByUserID indicates an expected call of GetProfilesByUserID.
+func (mr *MockIStoreProfileServiceMockRecorder) GetProfilesByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfilesByUserID", reflect.TypeOf((*MockIStoreProfileService)(nil).GetProfilesByUserID), userID)
+}
+
+// Update mocks base method.
+func (m *MockIStoreProfileService) Update(profile models.StoreProfile) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", profile)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIStoreProfileServiceMockRecorder) Update(profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIStoreProfileService)(nil).Update), profile)
+}
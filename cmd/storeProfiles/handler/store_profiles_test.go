@@ -0,0 +1,366 @@
+package handler
+
+import (
+	"SuperListsAPI/cmd/storeProfiles/models"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewStoreProfileHandler(t *testing.T) {
+	type args struct {
+		service IStoreProfileService
+	}
+	tests := []struct {
+		name string
+		args args
+		want StoreProfileHandler
+	}{
+		{
+			name: "Test with nil service should pass",
+			args: args{nil},
+			want: NewStoreProfileHandler(nil),
+		},
+		{
+			name: "Test with no nil service should pass",
+			args: args{NewMockIStoreProfileService(gomock.NewController(t))},
+			want: NewStoreProfileHandler(NewMockIStoreProfileService(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewStoreProfileHandler(tt.args.service); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewStoreProfileHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreProfileHandler_Create(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		body       interface{}
+		setup      func(service *MockIStoreProfileService)
+		wantStatus int
+	}{
+		{
+			name:   "Valid profile is created for the caller",
+			userID: "7",
+			body:   GetValidStoreProfile(),
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Create(gomock.Any()).DoAndReturn(func(profile models.StoreProfile) (*models.StoreProfile, error) {
+					if profile.UserID != 7 {
+						return nil, errors.New("profile not owned by the caller")
+					}
+					return &profile, nil
+				})
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "Missing user id",
+			body:       GetValidStoreProfile(),
+			setup:      func(service *MockIStoreProfileService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Empty category order",
+			userID:     "7",
+			body:       map[string]interface{}{"name": "Coto", "category_order": []string{}},
+			setup:      func(service *MockIStoreProfileService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Service error",
+			userID: "7",
+			body:   GetValidStoreProfile(),
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIStoreProfileService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			storeProfileHandler := NewStoreProfileHandler(mockedService)
+
+			jsonDto, _ := json.Marshal(tt.body)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/storeProfiles")
+			{
+				v1.POST("/", storeProfileHandler.Create)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/v1/storeProfiles/", strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", tt.userID)
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStoreProfileHandler_GetProfiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		setup      func(service *MockIStoreProfileService)
+		wantStatus int
+	}{
+		{
+			name: "User with profiles",
+			setup: func(service *MockIStoreProfileService) {
+				profiles := []models.StoreProfile{GetValidStoreProfile()}
+				service.EXPECT().GetProfilesByUserID("1").Return(&profiles, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "User without profiles",
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().GetProfilesByUserID("1").Return(&[]models.StoreProfile{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "Service error",
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().GetProfilesByUserID("1").Return(nil, errors.New("error from service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIStoreProfileService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			storeProfileHandler := NewStoreProfileHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/storeProfiles")
+			{
+				v1.GET("/", storeProfileHandler.GetProfiles)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/storeProfiles/", nil)
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStoreProfileHandler_Get(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		setup      func(service *MockIStoreProfileService)
+		wantStatus int
+	}{
+		{
+			name: "Own profile",
+			id:   "1",
+			setup: func(service *MockIStoreProfileService) {
+				profile := GetValidStoreProfile()
+				service.EXPECT().Get("1", "1").Return(&profile, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Profile missing or owned by someone else",
+			id:   "2",
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Get("1", "2").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Invalid id",
+			id:         "abc",
+			setup:      func(service *MockIStoreProfileService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIStoreProfileService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			storeProfileHandler := NewStoreProfileHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/storeProfiles")
+			{
+				v1.GET("/:id", storeProfileHandler.Get)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/v1/storeProfiles/"+tt.id, nil)
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStoreProfileHandler_Update(t *testing.T) {
+	validProfile := GetValidStoreProfile()
+	validProfile.ID = 1
+
+	tests := []struct {
+		name       string
+		id         string
+		body       interface{}
+		setup      func(service *MockIStoreProfileService)
+		wantStatus int
+	}{
+		{
+			name: "Own profile is updated",
+			id:   "1",
+			body: validProfile,
+			setup: func(service *MockIStoreProfileService) {
+				current := validProfile
+				service.EXPECT().Get("1", "1").Return(&current, nil)
+				service.EXPECT().Update(gomock.Any()).Return(&current, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Profile owned by someone else",
+			id:   "1",
+			body: validProfile,
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Get("1", "1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Id mismatch",
+			id:         "2",
+			body:       validProfile,
+			setup:      func(service *MockIStoreProfileService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIStoreProfileService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			storeProfileHandler := NewStoreProfileHandler(mockedService)
+
+			jsonDto, _ := json.Marshal(tt.body)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/storeProfiles")
+			{
+				v1.PUT("/:id", storeProfileHandler.Update)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/v1/storeProfiles/"+tt.id, strings.NewReader(string(jsonDto)))
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestStoreProfileHandler_Delete(t *testing.T) {
+	deleted := 1
+	notDeleted := 0
+
+	tests := []struct {
+		name       string
+		setup      func(service *MockIStoreProfileService)
+		wantStatus int
+	}{
+		{
+			name: "Own profile is deleted",
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Delete("1", "1").Return(&deleted, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Nothing deleted",
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Delete("1", "1").Return(&notDeleted, nil)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Service error",
+			setup: func(service *MockIStoreProfileService) {
+				service.EXPECT().Delete("1", "1").Return(nil, errors.New("error from service"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedService := NewMockIStoreProfileService(gomock.NewController(t))
+			tt.setup(mockedService)
+
+			storeProfileHandler := NewStoreProfileHandler(mockedService)
+
+			gin.SetMode(gin.TestMode)
+
+			c := gin.Default()
+
+			v1 := c.Group("/v1/storeProfiles")
+			{
+				v1.DELETE("/:id", storeProfileHandler.Delete)
+			}
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, "/v1/storeProfiles/1", nil)
+			req.Header.Set("user_id", "1")
+
+			c.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func GetValidStoreProfile() models.StoreProfile {
+	return models.StoreProfile{
+		Name:          "Coto",
+		CategoryOrder: []string{"produce", "dairy"},
+	}
+}
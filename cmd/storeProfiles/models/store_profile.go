@@ -0,0 +1,15 @@
+package models
+
+import (
+	"SuperListsAPI/internal/database"
+	"gorm.io/gorm"
+)
+
+// StoreProfile sorts list item categories the way they are laid out on a given store,
+// so a list can be walked aisle by aisle.
+type StoreProfile struct {
+	gorm.Model
+	UserID        uint                `json:"user_id"`
+	Name          string              `json:"name" validate:"required,max=100"`
+	CategoryOrder database.StringList `json:"category_order" gorm:"type:text" validate:"required,min=1,dive,required"`
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/storeProfiles/models"
+	"gorm.io/gorm"
+)
+
+type StoreProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreProfileRepository(db *gorm.DB) StoreProfileRepository {
+	return StoreProfileRepository{db: db}
+}
+
+func (spr *StoreProfileRepository) Create(profile models.StoreProfile) (*models.StoreProfile, error) {
+
+	if result := spr.db.Create(&profile); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &profile, nil
+}
+
+// Get returns the profile only when it belongs to userID.
+func (spr *StoreProfileRepository) Get(userID string, profileID string) (*models.StoreProfile, error) {
+
+	var profile models.StoreProfile
+
+	if result := spr.db.Where("user_id = ?", userID).First(&profile, profileID); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &profile, nil
+}
+
+func (spr *StoreProfileRepository) GetByName(userID string, name string) (*models.StoreProfile, error) {
+
+	var profile models.StoreProfile
+
+	if result := spr.db.Where("user_id = ? AND LOWER(name) = LOWER(?)", userID, name).First(&profile); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &profile, nil
+}
+
+func (spr *StoreProfileRepository) GetProfilesByUserID(userID string) (*[]models.StoreProfile, error) {
+
+	var profiles []models.StoreProfile
+
+	if result := spr.db.Where("user_id = ?", userID).Order("name").Find(&profiles); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &profiles, nil
+}
+
+func (spr *StoreProfileRepository) Update(profile models.StoreProfile) (*models.StoreProfile, error) {
+
+	if result := spr.db.Save(&profile); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &profile, nil
+}
+
+func (spr *StoreProfileRepository) Delete(userID string, profileID string) (*int, error) {
+
+	result := spr.db.Where("user_id = ?", userID).Delete(&models.StoreProfile{}, profileID)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	rowsDeleted := int(result.RowsAffected)
+
+	return &rowsDeleted, nil
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"SuperListsAPI/cmd/storeProfiles/models"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestNewStoreProfileRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want StoreProfileRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewStoreProfileRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewStoreProfileRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewStoreProfileRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewStoreProfileRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreProfileRepository_Create(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `store_profiles` (`created_at`,`updated_at`,`deleted_at`,`user_id`,`name`,`category_order`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, "Coto", `["produce","dairy"]`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := storeProfileRepository.Create(GetValidStoreProfile())
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestStoreProfileRepository_Create_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `store_profiles`")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := storeProfileRepository.Create(GetValidStoreProfile())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestStoreProfileRepository_Get(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "category_order"}).
+		AddRow(1, 1, "Coto", `["produce","dairy"]`)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `store_profiles` WHERE user_id = ? AND `store_profiles`.`id` = ? AND `store_profiles`.`deleted_at` IS NULL ORDER BY `store_profiles`.`id` LIMIT 1")).
+		WithArgs("1", "1").
+		WillReturnRows(rows)
+
+	result, err := storeProfileRepository.Get("1", "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Coto", result.Name)
+	assert.Equal(t, []string{"produce", "dairy"}, []string(result.CategoryOrder))
+}
+
+func TestStoreProfileRepository_Get_Not_Found(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `store_profiles`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result, err := storeProfileRepository.Get("2", "1")
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func TestStoreProfileRepository_GetByName(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "category_order"}).
+		AddRow(1, 1, "Coto", `["produce"]`)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `store_profiles` WHERE (user_id = ? AND LOWER(name) = LOWER(?)) AND `store_profiles`.`deleted_at` IS NULL ORDER BY `store_profiles`.`id` LIMIT 1")).
+		WithArgs("1", "coto").
+		WillReturnRows(rows)
+
+	result, err := storeProfileRepository.GetByName("1", "coto")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestStoreProfileRepository_GetProfilesByUserID(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "category_order"}).
+		AddRow(1, 1, "Coto", `["produce"]`).
+		AddRow(2, 1, "Dia", `["dairy"]`)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `store_profiles` WHERE user_id = ? AND `store_profiles`.`deleted_at` IS NULL ORDER BY name")).
+		WithArgs("1").
+		WillReturnRows(rows)
+
+	result, err := storeProfileRepository.GetProfilesByUserID("1")
+
+	assert.NoError(t, err)
+	assert.Len(t, *result, 2)
+}
+
+func TestStoreProfileRepository_GetProfilesByUserID_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `store_profiles`")).
+		WillReturnError(errors.New("error from db"))
+
+	result, err := storeProfileRepository.GetProfilesByUserID("1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestStoreProfileRepository_Update(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	profile := GetValidStoreProfile()
+	profile.ID = 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `store_profiles` SET")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := storeProfileRepository.Update(profile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), result.ID)
+}
+
+func TestStoreProfileRepository_Delete(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `store_profiles` SET `deleted_at`=? WHERE user_id = ? AND `store_profiles`.`id` = ? AND `store_profiles`.`deleted_at` IS NULL")).
+		WithArgs(sqlmock.AnyArg(), "1", "1").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := storeProfileRepository.Delete("1", "1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *result)
+}
+
+func TestStoreProfileRepository_Delete_Error(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	storeProfileRepository := NewStoreProfileRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `store_profiles` SET `deleted_at`=?")).
+		WillReturnError(errors.New("error from db"))
+	mock.ExpectRollback()
+
+	result, err := storeProfileRepository.Delete("1", "1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func GetValidStoreProfile() models.StoreProfile {
+	return models.StoreProfile{
+		UserID:        1,
+		Name:          "Coto",
+		CategoryOrder: []string{"produce", "dairy"},
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/storeProfiles/models"
+	"strconv"
+	"strings"
+)
+
+//go:generate mockgen -source=store_profile_service.go -destination store_profile_service_mock.go -package service
+
+type IStoreProfileRepository interface {
+	Create(profile models.StoreProfile) (*models.StoreProfile, error)
+	Get(userID string, profileID string) (*models.StoreProfile, error)
+	GetByName(userID string, name string) (*models.StoreProfile, error)
+	GetProfilesByUserID(userID string) (*[]models.StoreProfile, error)
+	Update(profile models.StoreProfile) (*models.StoreProfile, error)
+	Delete(userID string, profileID string) (*int, error)
+}
+
+type StoreProfileService struct {
+	repository IStoreProfileRepository
+}
+
+func NewStoreProfileService(repository IStoreProfileRepository) StoreProfileService {
+	return StoreProfileService{repository: repository}
+}
+
+func (sps *StoreProfileService) Create(profile models.StoreProfile) (*models.StoreProfile, error) {
+	profile.CategoryOrder = normalizeCategoryOrder(profile.CategoryOrder)
+	return sps.repository.Create(profile)
+}
+
+func (sps *StoreProfileService) Get(userID string, profileID string) (*models.StoreProfile, error) {
+	return sps.repository.Get(userID, profileID)
+}
+
+// Find resolves a profile reference as given on a query string, which can be either its id or its name.
+func (sps *StoreProfileService) Find(userID string, reference string) (*models.StoreProfile, error) {
+	if _, err := strconv.Atoi(reference); err == nil {
+		return sps.repository.Get(userID, reference)
+	}
+
+	return sps.repository.GetByName(userID, strings.TrimSpace(reference))
+}
+
+func (sps *StoreProfileService) GetProfilesByUserID(userID string) (*[]models.StoreProfile, error) {
+	return sps.repository.GetProfilesByUserID(userID)
+}
+
+func (sps *StoreProfileService) Update(profile models.StoreProfile) (*models.StoreProfile, error) {
+	profile.CategoryOrder = normalizeCategoryOrder(profile.CategoryOrder)
+	return sps.repository.Update(profile)
+}
+
+func (sps *StoreProfileService) Delete(userID string, profileID string) (*int, error) {
+	return sps.repository.Delete(userID, profileID)
+}
+
+// normalizeCategoryOrder lowercases categories so they match the ones stored on items and drops repeated ones,
+// keeping the first position where each category shows up.
+func normalizeCategoryOrder(categories []string) []string {
+	seen := make(map[string]bool, len(categories))
+	normalized := make([]string, 0, len(categories))
+
+	for _, category := range categories {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if category == "" || seen[category] {
+			continue
+		}
+		seen[category] = true
+		normalized = append(normalized, category)
+	}
+
+	return normalized
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: store_profile_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/storeProfiles/models"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIStoreProfileRepository is a mock of IStoreProfileRepository interface.
+type MockIStoreProfileRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIStoreProfileRepositoryMockRecorder
+}
+
+// MockIStoreProfileRepositoryMockRecorder is the mock recorder for MockIStoreProfileRepository.
+type MockIStoreProfileRepositoryMockRecorder struct {
+	mock *MockIStoreProfileRepository
+}
+
+// NewMockIStoreProfileRepository creates a new mock instance.
+func NewMockIStoreProfileRepository(ctrl *gomock.Controller) *MockIStoreProfileRepository {
+	mock := &MockIStoreProfileRepository{ctrl: ctrl}
+	mock.recorder = &MockIStoreProfileRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIStoreProfileRepository) EXPECT() *MockIStoreProfileRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockIStoreProfileRepository) Create(profile models.StoreProfile) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", profile)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockIStoreProfileRepositoryMockRecorder) Create(profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIStoreProfileRepository)(nil).Create), profile)
+}
+
+// Delete mocks base method.
+func (m *MockIStoreProfileRepository) Delete(userID, profileID string) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userID, profileID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIStoreProfileRepositoryMockRecorder) Delete(userID, profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIStoreProfileRepository)(nil).Delete), userID, profileID)
+}
+
+// Get mocks base method.
+func (m *MockIStoreProfileRepository) Get(userID, profileID string) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userID, profileID)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIStoreProfileRepositoryMockRecorder) Get(userID, profileID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIStoreProfileRepository)(nil).Get), userID, profileID)
+}
+
+// GetByName mocks base method.
+func (m *MockIStoreProfileRepository) GetByName(userID, name string) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", userID, name)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockIStoreProfileRepositoryMockRecorder) GetByName(userID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockIStoreProfileRepository)(nil).GetByName), userID, name)
+}
+
+// GetProfilesByUserID mocks base method.
+func (m *MockIStoreProfileRepository) GetProfilesByUserID(userID string) (*[]models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfilesByUserID", userID)
+	ret0, _ := ret[0].(*[]models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfilesByUserID indicates an expected call of GetProfilesByUserID.
+func (mr *MockIStoreProfileRepositoryMockRecorder) GetProfilesByUserID(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfilesByUserID", reflect.TypeOf((*MockIStoreProfileRepository)(nil).GetProfilesByUserID), userID)
+}
+
+// Update mocks base method.
+func (m *MockIStoreProfileRepository) Update(profile models.StoreProfile) (*models.StoreProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", profile)
+	ret0, _ := ret[0].(*models.StoreProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockIStoreProfileRepositoryMockRecorder) Update(profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIStoreProfileRepository)(nil).Update), profile)
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"SuperListsAPI/cmd/storeProfiles/models"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"reflect"
+	"testing"
+)
+
+func TestNewStoreProfileService(t *testing.T) {
+	type args struct {
+		repository IStoreProfileRepository
+	}
+	tests := []struct {
+		name string
+		args args
+		want StoreProfileService
+	}{
+		{
+			name: "Service with nil repo should pass",
+			args: args{nil},
+			want: NewStoreProfileService(nil),
+		},
+		{
+			name: "Service with no nil repo should pass",
+			args: args{NewMockIStoreProfileRepository(gomock.NewController(t))},
+			want: NewStoreProfileService(NewMockIStoreProfileRepository(gomock.NewController(t))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewStoreProfileService(tt.args.repository); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewStoreProfileService() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStoreProfileService_Create_Normalizes_Categories(t *testing.T) {
+	profile := GetValidStoreProfile()
+	profile.CategoryOrder = []string{" Produce", "dairy", "PRODUCE", "", "bakery"}
+
+	mockedRepo := NewMockIStoreProfileRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(profile models.StoreProfile) (*models.StoreProfile, error) {
+		return &profile, nil
+	})
+
+	storeProfileService := NewStoreProfileService(mockedRepo)
+
+	result, err := storeProfileService.Create(profile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"produce", "dairy", "bakery"}, []string(result.CategoryOrder))
+}
+
+func TestStoreProfileService_Update(t *testing.T) {
+	profile := GetValidStoreProfile()
+	profile.ID = 1
+
+	mockedRepo := NewMockIStoreProfileRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Update(profile).Return(&profile, nil)
+
+	storeProfileService := NewStoreProfileService(mockedRepo)
+
+	result, err := storeProfileService.Update(profile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, profile.ID, result.ID)
+}
+
+func TestStoreProfileService_Find(t *testing.T) {
+	profile := GetValidStoreProfile()
+
+	tests := []struct {
+		name      string
+		reference string
+		setup     func(repo *MockIStoreProfileRepository)
+		wantErr   bool
+	}{
+		{
+			name:      "Numeric reference looks up by id",
+			reference: "3",
+			setup: func(repo *MockIStoreProfileRepository) {
+				repo.EXPECT().Get("1", "3").Return(&profile, nil)
+			},
+		},
+		{
+			name:      "Any other reference looks up by name",
+			reference: " Coto ",
+			setup: func(repo *MockIStoreProfileRepository) {
+				repo.EXPECT().GetByName("1", "Coto").Return(&profile, nil)
+			},
+		},
+		{
+			name:      "Missing profile",
+			reference: "Dia",
+			setup: func(repo *MockIStoreProfileRepository) {
+				repo.EXPECT().GetByName("1", "Dia").Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockedRepo := NewMockIStoreProfileRepository(gomock.NewController(t))
+			tt.setup(mockedRepo)
+
+			storeProfileService := NewStoreProfileService(mockedRepo)
+
+			result, err := storeProfileService.Find("1", tt.reference)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Nil(t, result)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, result)
+		})
+	}
+}
+
+func TestStoreProfileService_Delete_Error(t *testing.T) {
+	mockedRepo := NewMockIStoreProfileRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Delete("1", "1").Return(nil, errors.New("error from repo"))
+
+	storeProfileService := NewStoreProfileService(mockedRepo)
+
+	result, err := storeProfileService.Delete("1", "1")
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func GetValidStoreProfile() models.StoreProfile {
+	return models.StoreProfile{
+		UserID:        1,
+		Name:          "Coto",
+		CategoryOrder: []string{"produce", "dairy"},
+	}
+}
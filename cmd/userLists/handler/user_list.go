@@ -2,6 +2,7 @@ package handler
 
 import (
 	"SuperListsAPI/cmd/userLists/models"
+	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -12,9 +13,9 @@ import (
 //go:generate mockgen -source=user_list.go -destination user_lists_mock.go -package handler
 
 type IUserListService interface {
-	Create(list models.UserList) (*models.UserList, error)
+	Create(ctx context.Context, list models.UserList) (*models.UserList, error)
 	Get(userListID string) (*models.UserList, error)
-	Delete(userListIDToDelete *[]uint) (*int, error)
+	Delete(ctx context.Context, userListIDToDelete *[]uint) (*int, error)
 	GetUserListsByUserID(userId string) (*[]models.UserList, error)
 	GetUserListsByListID(listID string) (*[]models.UserList, error)
 }
@@ -49,7 +50,7 @@ func (ulh *UserListHandler) Create(c *gin.Context) {
 		return
 	}
 
-	result, err := ulh.userListService.Create(userList)
+	result, err := ulh.userListService.Create(c.Request.Context(), userList)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -101,7 +102,7 @@ func (ulh *UserListHandler) Delete(c *gin.Context) {
 
 	userListToDelete := []uint{uint(parsedUserListID)}
 
-	deletedUserListID, err := ulh.userListService.Delete(&userListToDelete)
+	deletedUserListID, err := ulh.userListService.Delete(c.Request.Context(), &userListToDelete)
 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, err)
@@ -51,7 +51,7 @@ func TestUserListHandler_Create(t *testing.T) {
 	jsonDto, _ := json.Marshal(validUserList)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Create(gomock.Any()).Return(&validUserList, nil)
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validUserList, nil)
 
 	userListHandler := NewUserListHandler(userListService)
 
@@ -79,7 +79,7 @@ func TestUserListHandler_Create_Service_Error(t *testing.T) {
 	jsonDto, _ := json.Marshal(validUserList)
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from user list service"))
+	userListService.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from user list service"))
 
 	userListHandler := NewUserListHandler(userListService)
 
@@ -255,7 +255,7 @@ func TestUserListHandler_Delete(t *testing.T) {
 	deletedUserListID := 1
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Delete(gomock.Any()).Return(&deletedUserListID, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&deletedUserListID, nil)
 
 	userListHandler := NewUserListHandler(userListService)
 
@@ -278,7 +278,7 @@ func TestUserListHandler_Delete(t *testing.T) {
 func TestUserListHandler_Delete_Not_Found(t *testing.T) {
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Delete(gomock.Any()).Return(nil, nil)
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, nil)
 
 	userListHandler := NewUserListHandler(userListService)
 
@@ -301,7 +301,7 @@ func TestUserListHandler_Delete_Not_Found(t *testing.T) {
 func TestUserListHandler_Delete_Service_Error(t *testing.T) {
 
 	userListService := NewMockIUserListService(gomock.NewController(t))
-	userListService.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from user lists service trying to delete"))
+	userListService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from user lists service trying to delete"))
 
 	userListHandler := NewUserListHandler(userListService)
 
@@ -6,6 +6,7 @@ package handler
 
 import (
 	models "SuperListsAPI/cmd/userLists/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,33 +36,33 @@ func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockIUserListService) Create(list models.UserList) (*models.UserList, error) {
+func (m *MockIUserListService) Create(ctx context.Context, list models.UserList) (*models.UserList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", list)
+	ret := m.ctrl.Call(m, "Create", ctx, list)
 	ret0, _ := ret[0].(*models.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIUserListServiceMockRecorder) Create(list interface{}) *gomock.Call {
+func (mr *MockIUserListServiceMockRecorder) Create(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserListService)(nil).Create), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserListService)(nil).Create), ctx, list)
 }
 
 // Delete mocks base method.
-func (m *MockIUserListService) Delete(userListIDToDelete *[]uint) (*int, error) {
+func (m *MockIUserListService) Delete(ctx context.Context, userListIDToDelete *[]uint) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userListIDToDelete)
+	ret := m.ctrl.Call(m, "Delete", ctx, userListIDToDelete)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIUserListServiceMockRecorder) Delete(userListIDToDelete interface{}) *gomock.Call {
+func (mr *MockIUserListServiceMockRecorder) Delete(ctx, userListIDToDelete interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserListService)(nil).Delete), userListIDToDelete)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserListService)(nil).Delete), ctx, userListIDToDelete)
 }
 
 // Get mocks base method.
@@ -1,9 +1,66 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"gorm.io/gorm"
+	"strings"
+)
 
+// UserList makes User a member of the list ListID. User is only read when asked for, writes leave it untouched.
 type UserList struct {
 	gorm.Model
-	ListID uint `json:"list_id" validate:"required"`
-	UserID uint `json:"user_id" validate:"required"`
+	ListID uint    `json:"list_id" validate:"required"`
+	UserID uint    `json:"user_id" validate:"required"`
+	User   *Member `json:"user,omitempty" gorm:"foreignKey:UserID"`
+}
+
+// Member is the public profile of a list member, read from the users table without the credentials.
+type Member struct {
+	ID    uint   `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+}
+
+func (Member) TableName() string {
+	return "users"
+}
+
+// IsMember reports whether userID is one of members.
+func IsMember(members []UserList, userID uint) bool {
+	for _, member := range members {
+		if member.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// FindMember returns the member named by handle: their email, their name without spaces or only its first word,
+// ignoring case. Members are read with their User, handles shared by more than one member name nobody.
+func FindMember(members []UserList, handle string) (*UserList, bool) {
+	handle = strings.ToLower(handle)
+
+	var found *UserList
+	for i, member := range members {
+		if member.User == nil || !member.User.named(handle) {
+			continue
+		}
+		if found != nil && found.UserID != member.UserID {
+			return nil, false
+		}
+		found = &members[i]
+	}
+
+	return found, found != nil
+}
+
+func (m Member) named(handle string) bool {
+	if handle == "" {
+		return false
+	}
+	if strings.ToLower(m.Email) == handle {
+		return true
+	}
+
+	words := strings.Fields(strings.ToLower(m.Name))
+	return len(words) > 0 && (strings.Join(words, "") == handle || words[0] == handle)
 }
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIsMember(t *testing.T) {
+	members := []UserList{{ListID: 1, UserID: 1}, {ListID: 1, UserID: 2}}
+
+	assert.True(t, IsMember(members, 2))
+	assert.False(t, IsMember(members, 3))
+	assert.False(t, IsMember(nil, 1))
+}
+
+func TestFindMember(t *testing.T) {
+	members := []UserList{
+		{ListID: 1, UserID: 1, User: &Member{ID: 1, Name: "Ana García", Email: "ana@mail.com"}},
+		{ListID: 1, UserID: 2, User: &Member{ID: 2, Name: "Juan Pérez", Email: "juan@mail.com"}},
+		{ListID: 1, UserID: 3, User: &Member{ID: 3, Name: "Juan Gómez", Email: "jgomez@mail.com"}},
+		{ListID: 1, UserID: 4},
+	}
+
+	tests := []struct {
+		handle string
+		want   uint
+	}{
+		{handle: "ana", want: 1},
+		{handle: "AnaGarcía", want: 1},
+		{handle: "ana@mail.com", want: 1},
+		{handle: "juanpérez", want: 2},
+		{handle: "jgomez@mail.com", want: 3},
+		{handle: "juan"},
+		{handle: "pedro"},
+		{handle: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.handle, func(t *testing.T) {
+			member, ok := FindMember(members, tt.handle)
+
+			if tt.want == 0 {
+				assert.False(t, ok)
+				assert.Nil(t, member)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, member.UserID)
+		})
+	}
+}
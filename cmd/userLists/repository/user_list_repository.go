@@ -2,8 +2,11 @@ package repository
 
 import (
 	"SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/activity"
+	"context"
 	"errors"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type UserListRepository struct {
@@ -14,7 +17,7 @@ func NewUserListRepository(gormDB *gorm.DB) UserListRepository {
 	return UserListRepository{db: gormDB}
 }
 
-func (ulr *UserListRepository) Create(list models.UserList) (*models.UserList, error) {
+func (ulr *UserListRepository) Create(ctx context.Context, list models.UserList) (*models.UserList, error) {
 
 	var userLists []*models.UserList
 
@@ -30,12 +33,26 @@ func (ulr *UserListRepository) Create(list models.UserList) (*models.UserList, e
 		return nil, errors.New("You are already on this list!!")
 	}
 
-	if result := ulr.db.Create(&list); result.Error != nil {
-		return nil, result.Error
+	err := ulr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return CreateMember(tx, &list)
+	})
+
+	if err != nil {
+		return nil, err
 	}
 	return &list, nil
 }
 
+// CreateMember adds the member userList and records it, on the transaction tx of a caller that creates more along
+// with it. Unlike Create it doesn't look for the user on the list first.
+func CreateMember(tx *gorm.DB, userList *models.UserList) error {
+	if result := tx.Omit(clause.Associations).Create(userList); result.Error != nil {
+		return result.Error
+	}
+
+	return activity.Record(tx, memberEntry(*userList, activity.ActionJoined))
+}
+
 func (ulr *UserListRepository) Get(userListID string) (*models.UserList, error) {
 
 	userList := models.UserList{}
@@ -46,15 +63,33 @@ func (ulr *UserListRepository) Get(userListID string) (*models.UserList, error)
 	return &userList, nil
 }
 
-func (ulr *UserListRepository) Delete(userListIDs *[]uint) (*int, error) {
+func (ulr *UserListRepository) Delete(ctx context.Context, userListIDs *[]uint) (*int, error) {
 
-	result := ulr.db.Delete(&models.UserList{}, userListIDs)
+	var rowsQtyDeleted int
 
-	if result.Error != nil {
-		return nil, result.Error
-	}
+	err := ulr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var userLists []models.UserList
+		if result := tx.Find(&userLists, userListIDs); result.Error != nil {
+			return result.Error
+		}
 
-	rowsQtyDeleted := int(result.RowsAffected)
+		result := tx.Delete(&models.UserList{}, userListIDs)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsQtyDeleted = int(result.RowsAffected)
+
+		entries := make([]activity.Entry, 0, len(userLists))
+		for _, userList := range userLists {
+			entries = append(entries, memberEntry(userList, activity.ActionRemoved))
+		}
+
+		return activity.Record(tx, entries...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
 
 	return &rowsQtyDeleted, nil
 
@@ -78,3 +113,29 @@ func (ulr *UserListRepository) GetUserListsByListID(listID string) (*[]models.Us
 
 	return &userLists, nil
 }
+
+// GetMembers returns the members of the list listID along with their user profile.
+func (ulr *UserListRepository) GetMembers(listID string) (*[]models.UserList, error) {
+	var members []models.UserList
+	if result := ulr.db.Joins("User").Where("user_lists.list_id = ?", listID).Order("user_lists.id").Find(&members); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &members, nil
+}
+
+// memberEntry describes userList joining or leaving its list, the target is the member.
+func memberEntry(userList models.UserList, action string) activity.Entry {
+	changes := activity.Diff(nil, userList)
+	if action == activity.ActionRemoved {
+		changes = activity.Diff(userList, nil)
+	}
+
+	return activity.Entry{
+		ListID:     userList.ListID,
+		Action:     action,
+		TargetType: activity.TargetMember,
+		TargetID:   userList.UserID,
+		Changes:    changes,
+	}
+}
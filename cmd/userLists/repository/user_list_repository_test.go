@@ -2,6 +2,8 @@ package repository
 
 import (
 	"SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/activity"
+	"context"
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
@@ -67,14 +69,18 @@ func TestUserListRepository_Create(t *testing.T) {
 	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_lists`" +
 		" (`created_at`,`updated_at`,`deleted_at`,`list_id`,`user_id`) VALUES (?,?,?,?,?)")).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities` (`list_id`,`actor_id`,`action`,`target_type`,`target_id`,`changes`,`created_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(1, 9, activity.ActionJoined, activity.TargetMember, 1, `{"list_id":{"after":1},"user_id":{"after":1}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	userListRepo := NewUserListRepository(gormDb)
 
-	result, err := userListRepo.Create(GetValidUserList())
+	result, err := userListRepo.Create(activity.WithActor(context.Background(), 9), GetValidUserList())
 
 	assert.NotNil(t, result)
 	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestUserListRepository_Create_Already_In_List(t *testing.T) {
@@ -106,7 +112,7 @@ func TestUserListRepository_Create_Already_In_List(t *testing.T) {
 
 	userListRepo := NewUserListRepository(gormDb)
 
-	result, err := userListRepo.Create(GetValidUserList())
+	result, err := userListRepo.Create(context.Background(), GetValidUserList())
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -140,7 +146,7 @@ func TestUserListRepository_Create_Error(t *testing.T) {
 
 	userListRepo := NewUserListRepository(gormDb)
 
-	result, err := userListRepo.Create(GetValidUserList())
+	result, err := userListRepo.Create(context.Background(), GetValidUserList())
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -169,7 +175,7 @@ func TestUserListRepository_Create_Error_On_User_List_Query(t *testing.T) {
 
 	userListRepo := NewUserListRepository(gormDb)
 
-	result, err := userListRepo.Create(GetValidUserList())
+	result, err := userListRepo.Create(context.Background(), GetValidUserList())
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -257,19 +263,25 @@ func TestUserListRepository_Delete(t *testing.T) {
 	gormDb.Debug()
 
 	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_lists` WHERE `user_lists`.`id` = ? AND `user_lists`.`deleted_at` IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id"}).AddRow(1, 3, 7))
 	mock.ExpectExec(regexp.QuoteMeta("UPDATE `user_lists` SET `deleted_at`=? " +
 		"WHERE `user_lists`.`id` = ? AND `user_lists`.`deleted_at` IS NULL")).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WithArgs(3, 9, activity.ActionRemoved, activity.TargetMember, 7, `{"list_id":{"before":3},"user_id":{"before":7}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
 	userListRepository := NewUserListRepository(gormDb)
 	idsToDelete := []uint{uint(1)}
 
-	result, err := userListRepository.Delete(&idsToDelete)
+	result, err := userListRepository.Delete(activity.WithActor(context.Background(), 9), &idsToDelete)
 
 	assert.NotNil(t, result)
 	assert.NoError(t, err)
 	assert.Equal(t, *result, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
 
 }
 
@@ -302,7 +314,7 @@ func TestUserListRepository_Delete_Error(t *testing.T) {
 
 	idsToDelete := []uint{uint(1)}
 
-	result, err := userListRepository.Delete(&idsToDelete)
+	result, err := userListRepository.Delete(context.Background(), &idsToDelete)
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -436,3 +448,33 @@ func GetValidUserList() models.UserList {
 		UserID: 1,
 	}
 }
+
+func TestUserListRepository_GetMembers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer db.Close()
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM `user_lists` LEFT JOIN `users` `User` ON `user_lists`.`user_id` = `User`.`id` WHERE user_lists.list_id = ? AND `user_lists`.`deleted_at` IS NULL ORDER BY user_lists.id")).
+		WithArgs("1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "user_id", "User__id", "User__name", "User__email"}).
+			AddRow(1, 1, 2, 2, "Ana García", "ana@mail.com"))
+
+	userListRepo := NewUserListRepository(gormDb)
+
+	result, err := userListRepo.GetMembers("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.Member{ID: 2, Name: "Ana García", Email: "ana@mail.com"}, (*result)[0].User)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
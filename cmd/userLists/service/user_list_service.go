@@ -1,15 +1,19 @@
 package service
 
-import "SuperListsAPI/cmd/userLists/models"
+import (
+	"SuperListsAPI/cmd/userLists/models"
+	"context"
+)
 
 //go:generate mockgen -source=user_list_service.go -destination user_list_service_mock.go -package service
 
 type IUserListRepository interface {
-	Create(list models.UserList) (*models.UserList, error)
+	Create(ctx context.Context, list models.UserList) (*models.UserList, error)
 	Get(userListID string) (*models.UserList, error)
-	Delete(userListID *[]uint) (*int, error)
+	Delete(ctx context.Context, userListID *[]uint) (*int, error)
 	GetUserListsByUserID(userId string) (*[]models.UserList, error)
 	GetUserListsByListID(listID string) (*[]models.UserList, error)
+	GetMembers(listID string) (*[]models.UserList, error)
 }
 
 type UserListService struct {
@@ -20,16 +24,16 @@ func NewUserListService(repository IUserListRepository) UserListService {
 	return UserListService{userListRepository: repository}
 }
 
-func (uls *UserListService) Create(list models.UserList) (*models.UserList, error) {
-	return uls.userListRepository.Create(list)
+func (uls *UserListService) Create(ctx context.Context, list models.UserList) (*models.UserList, error) {
+	return uls.userListRepository.Create(ctx, list)
 }
 
 func (uls *UserListService) Get(userListID string) (*models.UserList, error) {
 	return uls.userListRepository.Get(userListID)
 }
 
-func (uls *UserListService) Delete(userListIDs *[]uint) (*int, error) {
-	return uls.userListRepository.Delete(userListIDs)
+func (uls *UserListService) Delete(ctx context.Context, userListIDs *[]uint) (*int, error) {
+	return uls.userListRepository.Delete(ctx, userListIDs)
 }
 
 func (uls *UserListService) GetUserListsByUserID(userId string) (*[]models.UserList, error) {
@@ -39,3 +43,8 @@ func (uls *UserListService) GetUserListsByUserID(userId string) (*[]models.UserL
 func (uls *UserListService) GetUserListsByListID(listID string) (*[]models.UserList, error) {
 	return uls.userListRepository.GetUserListsByListID(listID)
 }
+
+// GetMembers returns the members of the list listID along with their user profile.
+func (uls *UserListService) GetMembers(listID string) (*[]models.UserList, error) {
+	return uls.userListRepository.GetMembers(listID)
+}
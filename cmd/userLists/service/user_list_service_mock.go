@@ -6,6 +6,7 @@ package service
 
 import (
 	models "SuperListsAPI/cmd/userLists/models"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,33 +36,33 @@ func (m *MockIUserListRepository) EXPECT() *MockIUserListRepositoryMockRecorder
 }
 
 // Create mocks base method.
-func (m *MockIUserListRepository) Create(list models.UserList) (*models.UserList, error) {
+func (m *MockIUserListRepository) Create(ctx context.Context, list models.UserList) (*models.UserList, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", list)
+	ret := m.ctrl.Call(m, "Create", ctx, list)
 	ret0, _ := ret[0].(*models.UserList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIUserListRepositoryMockRecorder) Create(list interface{}) *gomock.Call {
+func (mr *MockIUserListRepositoryMockRecorder) Create(ctx, list interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserListRepository)(nil).Create), list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIUserListRepository)(nil).Create), ctx, list)
 }
 
 // Delete mocks base method.
-func (m *MockIUserListRepository) Delete(userListID *[]uint) (*int, error) {
+func (m *MockIUserListRepository) Delete(ctx context.Context, userListID *[]uint) (*int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userListID)
+	ret := m.ctrl.Call(m, "Delete", ctx, userListID)
 	ret0, _ := ret[0].(*int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockIUserListRepositoryMockRecorder) Delete(userListID interface{}) *gomock.Call {
+func (mr *MockIUserListRepositoryMockRecorder) Delete(ctx, userListID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserListRepository)(nil).Delete), userListID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIUserListRepository)(nil).Delete), ctx, userListID)
 }
 
 // Get mocks base method.
@@ -79,6 +80,21 @@ func (mr *MockIUserListRepositoryMockRecorder) Get(userListID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIUserListRepository)(nil).Get), userListID)
 }
 
+// GetMembers mocks base method.
+func (m *MockIUserListRepository) GetMembers(listID string) (*[]models.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembers", listID)
+	ret0, _ := ret[0].(*[]models.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembers indicates an expected call of GetMembers.
+func (mr *MockIUserListRepositoryMockRecorder) GetMembers(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembers", reflect.TypeOf((*MockIUserListRepository)(nil).GetMembers), listID)
+}
+
 // GetUserListsByListID mocks base method.
 func (m *MockIUserListRepository) GetUserListsByListID(listID string) (*[]models.UserList, error) {
 	m.ctrl.T.Helper()
@@ -2,6 +2,7 @@ package service
 
 import (
 	"SuperListsAPI/cmd/userLists/models"
+	"context"
 	"errors"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -44,11 +45,11 @@ func TestUserListService_Create(t *testing.T) {
 	validUserList := GetValidUserList()
 
 	mockedRepository := NewMockIUserListRepository(gomock.NewController(t))
-	mockedRepository.EXPECT().Create(gomock.Any()).Return(&validUserList, nil)
+	mockedRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(&validUserList, nil)
 
 	userListService := NewUserListService(mockedRepository)
 
-	result, err := userListService.Create(validUserList)
+	result, err := userListService.Create(context.Background(), validUserList)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -61,11 +62,11 @@ func TestUserListService_Create_Error(t *testing.T) {
 	validUserList := GetValidUserList()
 
 	mockedRepository := NewMockIUserListRepository(gomock.NewController(t))
-	mockedRepository.EXPECT().Create(gomock.Any()).Return(nil, errors.New("error from userList repository"))
+	mockedRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from userList repository"))
 
 	userListService := NewUserListService(mockedRepository)
 
-	result, err := userListService.Create(validUserList)
+	result, err := userListService.Create(context.Background(), validUserList)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -110,11 +111,11 @@ func TestUserListService_Delete(t *testing.T) {
 	qtyReturned := 1
 
 	mockedRepository := NewMockIUserListRepository(gomock.NewController(t))
-	mockedRepository.EXPECT().Delete(gomock.Any()).Return(&qtyReturned, nil)
+	mockedRepository.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(&qtyReturned, nil)
 
 	userListService := NewUserListService(mockedRepository)
 
-	result, err := userListService.Delete(&idsToDelete)
+	result, err := userListService.Delete(context.Background(), &idsToDelete)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -127,11 +128,11 @@ func TestUserListService_Delete_Error(t *testing.T) {
 	idsToDelete := []uint{uint(1)}
 
 	mockedRepository := NewMockIUserListRepository(gomock.NewController(t))
-	mockedRepository.EXPECT().Delete(gomock.Any()).Return(nil, errors.New("error from userList repository"))
+	mockedRepository.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.New("error from userList repository"))
 
 	userListService := NewUserListService(mockedRepository)
 
-	result, err := userListService.Delete(&idsToDelete)
+	result, err := userListService.Delete(context.Background(), &idsToDelete)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -190,6 +191,19 @@ func TestUserListService_GetUserListsByListID_Error(t *testing.T) {
 
 }
 
+func TestUserListService_GetMembers(t *testing.T) {
+	members := []models.UserList{{ListID: 1, UserID: 2, User: &models.Member{ID: 2, Name: "Ana"}}}
+	mockedRepo := NewMockIUserListRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetMembers("1").Return(&members, nil)
+
+	userListService := NewUserListService(mockedRepo)
+
+	result, err := userListService.GetMembers("1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &members, result)
+}
+
 func GetValidUserList() models.UserList {
 	return models.UserList{
 		Model:  gorm.Model{},
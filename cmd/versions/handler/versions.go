@@ -0,0 +1,228 @@
+package handler
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/httputil"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"net/http"
+)
+
+//go:generate mockgen -source=versions.go -destination versions_mock.go -package handler
+
+type IVersionService interface {
+	GetVersions(targetType string, targetID uint, page int, pageSize int) (*versions.VersionPage, error)
+	GetItemListID(listItemID uint) (*uint, error)
+	RestoreList(ctx context.Context, listID uint, number int) (*listModels.List, error)
+	RestoreListItem(ctx context.Context, listItemID uint, number int) (*listItemModels.ListItem, error)
+	Undo(ctx context.Context, token string, userID uint) (*int, error)
+}
+
+type IUserListService interface {
+	GetUserListsByListID(listID string) (*[]userListModels.UserList, error)
+}
+
+type VersionHandler struct {
+	versionService  IVersionService
+	userListService IUserListService
+}
+
+func NewVersionHandler(versionService IVersionService, userListService IUserListService) VersionHandler {
+	return VersionHandler{versionService: versionService, userListService: userListService}
+}
+
+// GetListVersions answers a page of the versions of the list on the request path, asked with ?page= and ?page_size=.
+func (vh *VersionHandler) GetListVersions(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listID, ok := httputil.PathID(c, "id", "invalid list id")
+	if !ok {
+		return
+	}
+
+	if !httputil.CheckListMember(c, vh.userListService, fmt.Sprint(listID), uint(userID)) {
+		return
+	}
+
+	vh.getVersions(c, activity.TargetList, listID)
+}
+
+// GetItemVersions answers a page of the versions of the item on the request path, deleted items included.
+func (vh *VersionHandler) GetItemVersions(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listItemID, ok := vh.memberItem(c, uint(userID))
+	if !ok {
+		return
+	}
+
+	vh.getVersions(c, activity.TargetListItem, listItemID)
+}
+
+func (vh *VersionHandler) getVersions(c *gin.Context, targetType string, targetID uint) {
+
+	page, ok := httputil.QueryNumber(c, "page", 1, 1, 0)
+	if !ok {
+		return
+	}
+
+	pageSize, ok := httputil.QueryNumber(c, "page_size", versions.DefaultPageSize, 1, versions.MaxPageSize)
+	if !ok {
+		return
+	}
+
+	result, err := vh.versionService.GetVersions(targetType, targetID, page, pageSize)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (vh *VersionHandler) RestoreList(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	listID, ok := httputil.PathID(c, "id", "invalid list id")
+	if !ok {
+		return
+	}
+
+	number, ok := httputil.PathID(c, "version", "invalid version")
+	if !ok {
+		return
+	}
+
+	if !httputil.CheckListMember(c, vh.userListService, fmt.Sprint(listID), uint(userID)) {
+		return
+	}
+
+	result, err := vh.versionService.RestoreList(c.Request.Context(), listID, int(number))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Version %d of list %d not found", number, listID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+func (vh *VersionHandler) RestoreListItem(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	number, ok := httputil.PathID(c, "version", "invalid version")
+	if !ok {
+		return
+	}
+
+	listItemID, ok := vh.memberItem(c, uint(userID))
+	if !ok {
+		return
+	}
+
+	result, err := vh.versionService.RestoreListItem(c.Request.Context(), listItemID, int(number))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("Version %d of list item %d not found", number, listItemID))
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// Undo reverts the bulk deletion behind the token on the request path. Tokens can only be used once, by the user
+// who got them.
+func (vh *VersionHandler) Undo(c *gin.Context) {
+
+	userID, ok := httputil.RequestUserID(c)
+	if !ok {
+		return
+	}
+
+	result, err := vh.versionService.Undo(c.Request.Context(), c.Param("token"), uint(userID))
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, "Undo token not found")
+		return
+	}
+
+	if errors.Is(err, versions.ErrUndoExpired) {
+		c.JSON(http.StatusGone, gin.H{
+			"msg": err.Error(),
+		})
+		return
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+	return
+}
+
+// memberItem reads the item on the request path, answering the request when it never existed or userID is not a
+// member of its list.
+func (vh *VersionHandler) memberItem(c *gin.Context, userID uint) (uint, bool) {
+
+	listItemID, ok := httputil.PathID(c, "id", "invalid list item id")
+	if !ok {
+		return 0, false
+	}
+
+	listID, err := vh.versionService.GetItemListID(listItemID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, fmt.Sprintf("ListItem with id %d not found", listItemID))
+		return 0, false
+	}
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return 0, false
+	}
+
+	if !httputil.CheckListMember(c, vh.userListService, fmt.Sprint(*listID), userID) {
+		return 0, false
+	}
+
+	return listItemID, true
+}
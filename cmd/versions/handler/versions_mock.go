@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: versions.go
+
+// Package handler is a generated GoMock package.
+package handler
+
+import (
+	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/lists/models"
+	models1 "SuperListsAPI/cmd/userLists/models"
+	versions "SuperListsAPI/internal/versions"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIVersionService is a mock of IVersionService interface.
+type MockIVersionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIVersionServiceMockRecorder
+}
+
+// MockIVersionServiceMockRecorder is the mock recorder for MockIVersionService.
+type MockIVersionServiceMockRecorder struct {
+	mock *MockIVersionService
+}
+
+// NewMockIVersionService creates a new mock instance.
+func NewMockIVersionService(ctrl *gomock.Controller) *MockIVersionService {
+	mock := &MockIVersionService{ctrl: ctrl}
+	mock.recorder = &MockIVersionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIVersionService) EXPECT() *MockIVersionServiceMockRecorder {
+	return m.recorder
+}
+
+// GetItemListID mocks base method.
+func (m *MockIVersionService) GetItemListID(listItemID uint) (*uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemListID", listItemID)
+	ret0, _ := ret[0].(*uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemListID indicates an expected call of GetItemListID.
+func (mr *MockIVersionServiceMockRecorder) GetItemListID(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemListID", reflect.TypeOf((*MockIVersionService)(nil).GetItemListID), listItemID)
+}
+
+// GetVersions mocks base method.
+func (m *MockIVersionService) GetVersions(targetType string, targetID uint, page, pageSize int) (*versions.VersionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", targetType, targetID, page, pageSize)
+	ret0, _ := ret[0].(*versions.VersionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockIVersionServiceMockRecorder) GetVersions(targetType, targetID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockIVersionService)(nil).GetVersions), targetType, targetID, page, pageSize)
+}
+
+// RestoreList mocks base method.
+func (m *MockIVersionService) RestoreList(ctx context.Context, listID uint, number int) (*models0.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreList", ctx, listID, number)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreList indicates an expected call of RestoreList.
+func (mr *MockIVersionServiceMockRecorder) RestoreList(ctx, listID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreList", reflect.TypeOf((*MockIVersionService)(nil).RestoreList), ctx, listID, number)
+}
+
+// RestoreListItem mocks base method.
+func (m *MockIVersionService) RestoreListItem(ctx context.Context, listItemID uint, number int) (*models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreListItem", ctx, listItemID, number)
+	ret0, _ := ret[0].(*models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreListItem indicates an expected call of RestoreListItem.
+func (mr *MockIVersionServiceMockRecorder) RestoreListItem(ctx, listItemID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreListItem", reflect.TypeOf((*MockIVersionService)(nil).RestoreListItem), ctx, listItemID, number)
+}
+
+// Undo mocks base method.
+func (m *MockIVersionService) Undo(ctx context.Context, token string, userID uint) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", ctx, token, userID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockIVersionServiceMockRecorder) Undo(ctx, token, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockIVersionService)(nil).Undo), ctx, token, userID)
+}
+
+// MockIUserListService is a mock of IUserListService interface.
+type MockIUserListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIUserListServiceMockRecorder
+}
+
+// MockIUserListServiceMockRecorder is the mock recorder for MockIUserListService.
+type MockIUserListServiceMockRecorder struct {
+	mock *MockIUserListService
+}
+
+// NewMockIUserListService creates a new mock instance.
+func NewMockIUserListService(ctrl *gomock.Controller) *MockIUserListService {
+	mock := &MockIUserListService{ctrl: ctrl}
+	mock.recorder = &MockIUserListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIUserListService) EXPECT() *MockIUserListServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserListsByListID mocks base method.
+func (m *MockIUserListService) GetUserListsByListID(listID string) (*[]models1.UserList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserListsByListID", listID)
+	ret0, _ := ret[0].(*[]models1.UserList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserListsByListID indicates an expected call of GetUserListsByListID.
+func (mr *MockIUserListServiceMockRecorder) GetUserListsByListID(listID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserListsByListID", reflect.TypeOf((*MockIUserListService)(nil).GetUserListsByListID), listID)
+}
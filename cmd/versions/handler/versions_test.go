@@ -0,0 +1,256 @@
+package handler
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/versions"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+var members = &[]userListModels.UserList{{ListID: 3, UserID: 7}}
+
+func TestVersionHandler_GetListVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		listID     string
+		query      string
+		setup      func(versionService *MockIVersionService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name:   "Default page",
+			listID: "3",
+			setup: func(versionService *MockIVersionService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				versionService.EXPECT().GetVersions(activity.TargetList, uint(3), 1, versions.DefaultPageSize).Return(&versions.VersionPage{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Invalid list id",
+			listID:     "0",
+			setup:      func(versionService *MockIVersionService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Invalid page size",
+			listID: "3",
+			query:  "?page_size=1000",
+			setup: func(versionService *MockIVersionService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Caller is not a list member",
+			listID: "3",
+			setup: func(versionService *MockIVersionService, userLists *MockIUserListService) {
+				userLists.EXPECT().GetUserListsByListID("3").Return(&[]userListModels.UserList{}, nil)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			versionService := NewMockIVersionService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(versionService, userLists)
+
+			versionHandler := NewVersionHandler(versionService, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.listID}}
+			c.Request, _ = http.NewRequest(http.MethodGet, "/v1/lists/"+tt.listID+"/versions"+tt.query, nil)
+			c.Request.Header.Set("user_id", "7")
+
+			versionHandler.GetListVersions(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestVersionHandler_GetItemVersions_Deleted_Item(t *testing.T) {
+	listID := uint(3)
+
+	ctrl := gomock.NewController(t)
+	versionService := NewMockIVersionService(ctrl)
+	userLists := NewMockIUserListService(ctrl)
+
+	versionService.EXPECT().GetItemListID(uint(4)).Return(&listID, nil)
+	userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+	versionService.EXPECT().GetVersions(activity.TargetListItem, uint(4), 1, versions.DefaultPageSize).Return(&versions.VersionPage{}, nil)
+
+	versionHandler := NewVersionHandler(versionService, userLists)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "4"}}
+	c.Request, _ = http.NewRequest(http.MethodGet, "/v1/listItems/4/versions", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	versionHandler.GetItemVersions(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestVersionHandler_RestoreListItem(t *testing.T) {
+	listID := uint(3)
+
+	tests := []struct {
+		name       string
+		listItemID string
+		version    string
+		setup      func(versionService *MockIVersionService, userLists *MockIUserListService)
+		wantStatus int
+	}{
+		{
+			name:       "Restored",
+			listItemID: "4",
+			version:    "2",
+			setup: func(versionService *MockIVersionService, userLists *MockIUserListService) {
+				versionService.EXPECT().GetItemListID(uint(4)).Return(&listID, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				versionService.EXPECT().RestoreListItem(gomock.Any(), uint(4), 2).Return(&listItemModels.ListItem{Title: "Milk"}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Invalid version",
+			listItemID: "4",
+			version:    "latest",
+			setup:      func(versionService *MockIVersionService, userLists *MockIUserListService) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "Item never existed",
+			listItemID: "4",
+			version:    "2",
+			setup: func(versionService *MockIVersionService, userLists *MockIUserListService) {
+				versionService.EXPECT().GetItemListID(uint(4)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "Version not found",
+			listItemID: "4",
+			version:    "9",
+			setup: func(versionService *MockIVersionService, userLists *MockIUserListService) {
+				versionService.EXPECT().GetItemListID(uint(4)).Return(&listID, nil)
+				userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+				versionService.EXPECT().RestoreListItem(gomock.Any(), uint(4), 9).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			versionService := NewMockIVersionService(ctrl)
+			userLists := NewMockIUserListService(ctrl)
+			tt.setup(versionService, userLists)
+
+			versionHandler := NewVersionHandler(versionService, userLists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.listItemID}, {Key: "version", Value: tt.version}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/listItems/"+tt.listItemID+"/versions/"+tt.version+"/restore", nil)
+			c.Request.Header.Set("user_id", "7")
+
+			versionHandler.RestoreListItem(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestVersionHandler_RestoreList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	versionService := NewMockIVersionService(ctrl)
+	userLists := NewMockIUserListService(ctrl)
+
+	userLists.EXPECT().GetUserListsByListID("3").Return(members, nil)
+	versionService.EXPECT().RestoreList(gomock.Any(), uint(3), 1).Return(&listModels.List{Name: "Groceries"}, nil)
+
+	versionHandler := NewVersionHandler(versionService, userLists)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "3"}, {Key: "version", Value: "1"}}
+	c.Request, _ = http.NewRequest(http.MethodPost, "/v1/lists/3/versions/1/restore", nil)
+	c.Request.Header.Set("user_id", "7")
+
+	versionHandler.RestoreList(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Groceries")
+}
+
+func TestVersionHandler_Undo(t *testing.T) {
+	restored := 2
+
+	tests := []struct {
+		name       string
+		setup      func(versionService *MockIVersionService)
+		wantStatus int
+	}{
+		{
+			name: "Undone",
+			setup: func(versionService *MockIVersionService) {
+				versionService.EXPECT().Undo(gomock.Any(), "token", uint(7)).Return(&restored, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "Unknown token",
+			setup: func(versionService *MockIVersionService) {
+				versionService.EXPECT().Undo(gomock.Any(), "token", uint(7)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "Expired token",
+			setup: func(versionService *MockIVersionService) {
+				versionService.EXPECT().Undo(gomock.Any(), "token", uint(7)).Return(nil, versions.ErrUndoExpired)
+			},
+			wantStatus: http.StatusGone,
+		},
+		{
+			name: "Error from service",
+			setup: func(versionService *MockIVersionService) {
+				versionService.EXPECT().Undo(gomock.Any(), "token", uint(7)).Return(nil, errors.New("error from db"))
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versionService := NewMockIVersionService(gomock.NewController(t))
+			tt.setup(versionService)
+
+			versionHandler := NewVersionHandler(versionService, nil)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "token", Value: "token"}}
+			c.Request, _ = http.NewRequest(http.MethodPost, "/v1/undo/token", nil)
+			c.Request.Header.Set("user_id", "7")
+
+			versionHandler.Undo(c)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"encoding/json"
+	"fmt"
+	"gorm.io/gorm"
+)
+
+type VersionRepository struct {
+	db *gorm.DB
+}
+
+func NewVersionRepository(db *gorm.DB) VersionRepository {
+	return VersionRepository{db: db}
+}
+
+func (vr *VersionRepository) GetVersions(targetType string, targetID uint, page int, pageSize int) (*versions.VersionPage, error) {
+	return versions.Find(vr.db, targetType, targetID, page, pageSize)
+}
+
+// GetItemListID returns the list an item is on, even when the item was deleted.
+func (vr *VersionRepository) GetItemListID(listItemID uint) (*uint, error) {
+
+	var listItem listItemModels.ListItem
+
+	if result := vr.db.Unscoped().Select("id", "list_id").First(&listItem, listItemID); result.Error != nil {
+		return nil, result.Error
+	}
+
+	listID := uint(listItem.ListID)
+
+	return &listID, nil
+}
+
+// RestoreList brings back the name and description a list had on version number, undeleting it if needed. Its
+// members, invite code and reset schedule stay as they are. The state it had before is kept as a new version.
+func (vr *VersionRepository) RestoreList(ctx context.Context, listID uint, number int) (*listModels.List, error) {
+
+	var restored listModels.List
+
+	err := vr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := versions.Get(tx, activity.TargetList, listID, number)
+		if err != nil {
+			return err
+		}
+
+		var snapshot listModels.List
+		if err := json.Unmarshal(version.Snapshot, &snapshot); err != nil {
+			return err
+		}
+
+		var current listModels.List
+		if result := tx.Unscoped().First(&current, listID); result.Error != nil {
+			return result.Error
+		}
+
+		restored = current
+		restored.DeletedAt = gorm.DeletedAt{}
+		restored.Name = snapshot.Name
+		restored.Description = snapshot.Description
+
+		if err := versions.Save(tx, activity.TargetList, versions.State{ListID: current.ID, TargetID: current.ID, Value: current}); err != nil {
+			return err
+		}
+
+		if result := tx.Unscoped().Save(&restored); result.Error != nil {
+			return result.Error
+		}
+
+		return activity.Record(tx, activity.Entry{
+			ListID:     restored.ID,
+			Action:     activity.ActionRestored,
+			TargetType: activity.TargetList,
+			TargetID:   restored.ID,
+			Changes:    activity.Diff(current, restored),
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &restored, nil
+}
+
+// RestoreListItem brings back everything an item had on version number, undeleting it if needed. The item stays
+// where it is now: on the same list, under the same parent and at the same position. The state it had before is
+// kept as a new version.
+func (vr *VersionRepository) RestoreListItem(ctx context.Context, listItemID uint, number int) (*listItemModels.ListItem, error) {
+
+	var restored listItemModels.ListItem
+
+	err := vr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		version, err := versions.Get(tx, activity.TargetListItem, listItemID, number)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(version.Snapshot, &restored); err != nil {
+			return err
+		}
+
+		var current listItemModels.ListItem
+		if result := tx.Unscoped().First(&current, listItemID); result.Error != nil {
+			return result.Error
+		}
+
+		restored.Model = gorm.Model{ID: current.ID, CreatedAt: current.CreatedAt}
+		restored.ListID = current.ListID
+		restored.ParentID = current.ParentID
+		restored.Position = current.Position
+
+		state := versions.State{ListID: uint(current.ListID), TargetID: current.ID, Value: current}
+		if err := versions.Save(tx, activity.TargetListItem, state); err != nil {
+			return err
+		}
+
+		if result := tx.Unscoped().Save(&restored); result.Error != nil {
+			return result.Error
+		}
+
+		return activity.Record(tx, activity.Entry{
+			ListID:     uint(restored.ListID),
+			Action:     activity.ActionRestored,
+			TargetType: activity.TargetListItem,
+			TargetID:   restored.ID,
+			Changes:    activity.Diff(current, restored),
+		})
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &restored, nil
+}
+
+// undoTargets are the tables bulk deletions can be undone on, along with the column holding the list of each row.
+var undoTargets = map[string]struct {
+	table      string
+	listColumn string
+}{
+	activity.TargetList:     {table: "lists", listColumn: "id"},
+	activity.TargetListItem: {table: "list_items", listColumn: "list_id"},
+}
+
+// Undo undeletes what the bulk deletion behind token deleted, and returns how many lists or items came back.
+// Anything restored some other way since then is left alone.
+func (vr *VersionRepository) Undo(ctx context.Context, token string, userID uint) (*int, error) {
+
+	var rowsRestored int
+
+	err := vr.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		undoToken, err := versions.ConsumeUndoToken(tx, token, userID)
+		if err != nil {
+			return err
+		}
+
+		target, ok := undoTargets[undoToken.TargetType]
+		if !ok {
+			return fmt.Errorf("can't undo deletions of %s", undoToken.TargetType)
+		}
+
+		var deleted []struct {
+			ID     uint
+			ListID uint
+		}
+
+		result := tx.Table(target.table).
+			Select("id, "+target.listColumn+" AS list_id").
+			Where("id IN ? AND deleted_at IS NOT NULL", []uint(undoToken.TargetIDs)).
+			Scan(&deleted)
+
+		if result.Error != nil || len(deleted) == 0 {
+			return result.Error
+		}
+
+		restoredIDs := make([]uint, 0, len(deleted))
+		entries := make([]activity.Entry, 0, len(deleted))
+		for _, row := range deleted {
+			restoredIDs = append(restoredIDs, row.ID)
+			entries = append(entries, activity.Entry{
+				ListID:     row.ListID,
+				Action:     activity.ActionRestored,
+				TargetType: undoToken.TargetType,
+				TargetID:   row.ID,
+			})
+		}
+
+		result = tx.Table(target.table).Where("id IN ?", restoredIDs).Update("deleted_at", nil)
+		if result.Error != nil {
+			return result.Error
+		}
+		rowsRestored = int(result.RowsAffected)
+
+		return activity.Record(tx, entries...)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &rowsRestored, nil
+}
@@ -0,0 +1,216 @@
+package repository
+
+import (
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewVersionRepository(t *testing.T) {
+	type args struct {
+		db *gorm.DB
+	}
+	tests := []struct {
+		name string
+		args args
+		want VersionRepository
+	}{
+		{
+			name: "Test with nil gormDB should pass",
+			args: args{nil},
+			want: NewVersionRepository(nil),
+		},
+		{
+			name: "Test with no nil gormDB should pass",
+			args: args{db: &gorm.DB{}},
+			want: NewVersionRepository(&gorm.DB{}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewVersionRepository(tt.args.db); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewVersionRepository() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionRepository_GetItemListID_Deleted_Item(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	versionRepository := NewVersionRepository(gormDb)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT `id`,`list_id` FROM `list_items` WHERE `list_items`.`id` = ? ORDER BY `list_items`.`id` LIMIT 1")).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(4, 3))
+
+	result, err := versionRepository.GetItemListID(4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), *result)
+}
+
+func TestVersionRepository_RestoreListItem(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	versionRepository := NewVersionRepository(gormDb)
+
+	deletedAt := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `versions` WHERE target_type = ? AND target_id = ? AND number = ?")).
+		WithArgs(activity.TargetListItem, 4, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "target_type", "target_id", "list_id", "number", "snapshot"}).
+			AddRow(8, activity.TargetListItem, 4, 1, 2, `{"ID":4,"list_id":1,"title":"Milk","description":"the oat one","position":1024}`))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `list_items` WHERE `list_items`.`id` = ? ORDER BY `list_items`.`id` LIMIT 1")).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id", "title", "description", "position", "deleted_at"}).
+			AddRow(4, 3, "Milk", "", 2048, deletedAt))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}).AddRow(4, 3))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions`")).
+		WithArgs(activity.TargetListItem, 4, 3, 4, 7, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(9, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WithArgs(3, 7, activity.ActionRestored, activity.TargetListItem, 4, `{"description":{"after":"the oat one"}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := versionRepository.RestoreListItem(activity.WithActor(context.Background(), 7), 4, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "the oat one", result.Description)
+	assert.Equal(t, 3, result.ListID)
+	assert.Equal(t, float64(2048), result.Position)
+	assert.False(t, result.DeletedAt.Valid)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVersionRepository_RestoreList(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	versionRepository := NewVersionRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `versions` WHERE target_type = ? AND target_id = ? AND number = ?")).
+		WithArgs(activity.TargetList, 3, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "snapshot"}).
+			AddRow(5, `{"ID":3,"name":"Groceries","description":"Weekly","invite_code":"old-code"}`))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `lists` WHERE `lists`.`id` = ?")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "invite_code"}).AddRow(3, "Stuff", "Weekly", "new-code"))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}).AddRow(3, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions`")).
+		WillReturnResult(sqlmock.NewResult(6, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `lists` SET")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).
+		WithArgs(3, nil, activity.ActionRestored, activity.TargetList, 3, `{"name":{"before":"Stuff","after":"Groceries"}}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	result, err := versionRepository.RestoreList(context.Background(), 3, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Groceries", result.Name)
+	assert.Equal(t, "new-code", result.InviteCode)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVersionRepository_RestoreList_Version_Not_Found(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	versionRepository := NewVersionRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `versions`")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	result, err := versionRepository.RestoreList(context.Background(), 3, 9)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func TestVersionRepository_Undo(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	versionRepository := NewVersionRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `undo_tokens` WHERE token = ? AND user_id = ?")).
+		WithArgs("token", 7).
+		WillReturnRows(sqlmock.NewRows([]string{"token", "user_id", "target_type", "target_ids", "expires_at"}).
+			AddRow("token", 7, activity.TargetListItem, "[1,2,3]", time.Now().Add(time.Minute)))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `undo_tokens` WHERE `undo_tokens`.`token` = ?")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id, list_id AS list_id FROM `list_items` WHERE id IN (?,?,?) AND deleted_at IS NOT NULL")).
+		WithArgs(1, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "list_id"}).AddRow(1, 5).AddRow(3, 5))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE `list_items` SET `deleted_at`=? WHERE id IN (?,?)")).
+		WithArgs(nil, 1, 3).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities` (`list_id`,`actor_id`,`action`,`target_type`,`target_id`,`changes`,`created_at`) VALUES (?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs(5, 7, activity.ActionRestored, activity.TargetListItem, 1, "{}", sqlmock.AnyArg(),
+			5, 7, activity.ActionRestored, activity.TargetListItem, 3, "{}", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	result, err := versionRepository.Undo(activity.WithActor(context.Background(), 7), "token", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVersionRepository_Undo_Expired(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	versionRepository := NewVersionRepository(gormDb)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `undo_tokens`")).
+		WillReturnRows(sqlmock.NewRows([]string{"token", "user_id", "target_type", "target_ids", "expires_at"}).
+			AddRow("token", 7, activity.TargetList, "[1]", time.Now().Add(-time.Minute)))
+	mock.ExpectRollback()
+
+	result, err := versionRepository.Undo(context.Background(), "token", 7)
+
+	assert.ErrorIs(t, err, versions.ErrUndoExpired)
+	assert.Nil(t, result)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      db,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Info),
+	})
+
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	return gormDb, mock
+}
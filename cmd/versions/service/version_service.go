@@ -0,0 +1,46 @@
+package service
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/versions"
+	"context"
+)
+
+//go:generate mockgen -source=version_service.go -destination version_service_mock.go -package service
+
+type IVersionRepository interface {
+	GetVersions(targetType string, targetID uint, page int, pageSize int) (*versions.VersionPage, error)
+	GetItemListID(listItemID uint) (*uint, error)
+	RestoreList(ctx context.Context, listID uint, number int) (*listModels.List, error)
+	RestoreListItem(ctx context.Context, listItemID uint, number int) (*listItemModels.ListItem, error)
+	Undo(ctx context.Context, token string, userID uint) (*int, error)
+}
+
+type VersionService struct {
+	repository IVersionRepository
+}
+
+func NewVersionService(repository IVersionRepository) VersionService {
+	return VersionService{repository: repository}
+}
+
+func (vs *VersionService) GetVersions(targetType string, targetID uint, page int, pageSize int) (*versions.VersionPage, error) {
+	return vs.repository.GetVersions(targetType, targetID, page, pageSize)
+}
+
+func (vs *VersionService) GetItemListID(listItemID uint) (*uint, error) {
+	return vs.repository.GetItemListID(listItemID)
+}
+
+func (vs *VersionService) RestoreList(ctx context.Context, listID uint, number int) (*listModels.List, error) {
+	return vs.repository.RestoreList(ctx, listID, number)
+}
+
+func (vs *VersionService) RestoreListItem(ctx context.Context, listItemID uint, number int) (*listItemModels.ListItem, error) {
+	return vs.repository.RestoreListItem(ctx, listItemID, number)
+}
+
+func (vs *VersionService) Undo(ctx context.Context, token string, userID uint) (*int, error) {
+	return vs.repository.Undo(ctx, token, userID)
+}
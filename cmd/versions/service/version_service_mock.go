@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: version_service.go
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	models "SuperListsAPI/cmd/listItems/models"
+	models0 "SuperListsAPI/cmd/lists/models"
+	versions "SuperListsAPI/internal/versions"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIVersionRepository is a mock of IVersionRepository interface.
+type MockIVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIVersionRepositoryMockRecorder
+}
+
+// MockIVersionRepositoryMockRecorder is the mock recorder for MockIVersionRepository.
+type MockIVersionRepositoryMockRecorder struct {
+	mock *MockIVersionRepository
+}
+
+// NewMockIVersionRepository creates a new mock instance.
+func NewMockIVersionRepository(ctrl *gomock.Controller) *MockIVersionRepository {
+	mock := &MockIVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockIVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIVersionRepository) EXPECT() *MockIVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetItemListID mocks base method.
+func (m *MockIVersionRepository) GetItemListID(listItemID uint) (*uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetItemListID", listItemID)
+	ret0, _ := ret[0].(*uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetItemListID indicates an expected call of GetItemListID.
+func (mr *MockIVersionRepositoryMockRecorder) GetItemListID(listItemID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemListID", reflect.TypeOf((*MockIVersionRepository)(nil).GetItemListID), listItemID)
+}
+
+// GetVersions mocks base method.
+func (m *MockIVersionRepository) GetVersions(targetType string, targetID uint, page, pageSize int) (*versions.VersionPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", targetType, targetID, page, pageSize)
+	ret0, _ := ret[0].(*versions.VersionPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVersions indicates an expected call of GetVersions.
+func (mr *MockIVersionRepositoryMockRecorder) GetVersions(targetType, targetID, page, pageSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockIVersionRepository)(nil).GetVersions), targetType, targetID, page, pageSize)
+}
+
+// RestoreList mocks base method.
+func (m *MockIVersionRepository) RestoreList(ctx context.Context, listID uint, number int) (*models0.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreList", ctx, listID, number)
+	ret0, _ := ret[0].(*models0.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreList indicates an expected call of RestoreList.
+func (mr *MockIVersionRepositoryMockRecorder) RestoreList(ctx, listID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreList", reflect.TypeOf((*MockIVersionRepository)(nil).RestoreList), ctx, listID, number)
+}
+
+// RestoreListItem mocks base method.
+func (m *MockIVersionRepository) RestoreListItem(ctx context.Context, listItemID uint, number int) (*models.ListItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreListItem", ctx, listItemID, number)
+	ret0, _ := ret[0].(*models.ListItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreListItem indicates an expected call of RestoreListItem.
+func (mr *MockIVersionRepositoryMockRecorder) RestoreListItem(ctx, listItemID, number interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreListItem", reflect.TypeOf((*MockIVersionRepository)(nil).RestoreListItem), ctx, listItemID, number)
+}
+
+// Undo mocks base method.
+func (m *MockIVersionRepository) Undo(ctx context.Context, token string, userID uint) (*int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Undo", ctx, token, userID)
+	ret0, _ := ret[0].(*int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Undo indicates an expected call of Undo.
+func (mr *MockIVersionRepositoryMockRecorder) Undo(ctx, token, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Undo", reflect.TypeOf((*MockIVersionRepository)(nil).Undo), ctx, token, userID)
+}
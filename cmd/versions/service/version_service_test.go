@@ -0,0 +1,49 @@
+package service
+
+import (
+	listModels "SuperListsAPI/cmd/lists/models"
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/versions"
+	"context"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestVersionService_GetVersions(t *testing.T) {
+	mockedRepo := NewMockIVersionRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().GetVersions(activity.TargetList, uint(3), 1, 20).Return(&versions.VersionPage{Total: 4}, nil)
+
+	versionService := NewVersionService(mockedRepo)
+
+	result, err := versionService.GetVersions(activity.TargetList, 3, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), result.Total)
+}
+
+func TestVersionService_RestoreList(t *testing.T) {
+	mockedRepo := NewMockIVersionRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().RestoreList(gomock.Any(), uint(3), 2).Return(&listModels.List{Name: "Groceries"}, nil)
+
+	versionService := NewVersionService(mockedRepo)
+
+	result, err := versionService.RestoreList(context.Background(), 3, 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Groceries", result.Name)
+}
+
+func TestVersionService_Undo(t *testing.T) {
+	restored := 2
+
+	mockedRepo := NewMockIVersionRepository(gomock.NewController(t))
+	mockedRepo.EXPECT().Undo(gomock.Any(), "token", uint(7)).Return(&restored, nil)
+
+	versionService := NewVersionService(mockedRepo)
+
+	result, err := versionService.Undo(context.Background(), "token", 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *result)
+}
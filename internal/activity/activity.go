@@ -0,0 +1,195 @@
+// Package activity records who changed what on a list. Entries are written by the repositories on the same
+// transaction as the change they describe, so a change is never saved without its entry or the other way round.
+package activity
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	ActionCreated    = "created"
+	ActionUpdated    = "updated"
+	ActionDeleted    = "deleted"
+	ActionCompleted  = "completed"
+	ActionReopened   = "reopened"
+	ActionMoved      = "moved"
+	ActionCopied     = "copied"
+	ActionMerged     = "merged"
+	ActionAssigned   = "assigned"
+	ActionUnassigned = "unassigned"
+	ActionReset      = "reset"
+	ActionJoined     = "joined"
+	ActionRemoved    = "removed"
+	ActionRestored   = "restored"
+)
+
+const (
+	TargetList     = "list"
+	TargetListItem = "list_item"
+	TargetMember   = "member"
+	TargetComment  = "comment"
+)
+
+// Entry is a single change made to a list or to something on it. ActorID is nil for changes made by background
+// jobs. Changes holds the fields that changed, keyed by their json name.
+type Entry struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	ListID     uint      `json:"list_id"`
+	ActorID    *uint     `json:"actor_id"`
+	Action     string    `json:"action"`
+	TargetType string    `json:"target_type"`
+	TargetID   uint      `json:"target_id"`
+	Changes    Changes   `json:"changes" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (Entry) TableName() string {
+	return "activities"
+}
+
+// Change holds the json encoded value of a field before and after a change, a missing side means the field was
+// empty.
+type Change struct {
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+// Changes is stored on a single text column, encoded as a json object.
+type Changes map[string]Change
+
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+
+	encoded, err := json.Marshal(map[string]Change(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(encoded), nil
+}
+
+func (c *Changes) Scan(value interface{}) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case nil:
+		*c = Changes{}
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return errors.New("unsupported type for Changes")
+	}
+
+	if len(raw) == 0 {
+		*c = Changes{}
+		return nil
+	}
+
+	return json.Unmarshal(raw, (*map[string]Change)(c))
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the user making the changes.
+func WithActor(ctx context.Context, actorID uint) context.Context {
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// ActorFrom returns the user set by WithActor, or nil when ctx doesn't carry one.
+func ActorFrom(ctx context.Context) *uint {
+	if ctx == nil {
+		return nil
+	}
+
+	actorID, ok := ctx.Value(actorKey{}).(uint)
+	if !ok {
+		return nil
+	}
+
+	return &actorID
+}
+
+type changeSetKey struct{}
+
+// ChangeSet collects the lists, and the members of lists, that Record changed on a context. Entries are collected
+// when recorded, before their transaction commits, so a set may hold changes that were rolled back.
+type ChangeSet struct {
+	mu      sync.Mutex
+	lists   map[uint]bool
+	members map[uint]bool
+}
+
+// TrackChanges returns a copy of ctx on which Record collects its changes on the returned set.
+func TrackChanges(ctx context.Context) (context.Context, *ChangeSet) {
+	changes := &ChangeSet{lists: map[uint]bool{}, members: map[uint]bool{}}
+	return context.WithValue(ctx, changeSetKey{}, changes), changes
+}
+
+// Lists returns the ids of the lists that changed, sorted.
+func (cs *ChangeSet) Lists() []uint {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return sortedIDs(cs.lists)
+}
+
+// Members returns the ids of the users who joined or left a list, sorted.
+func (cs *ChangeSet) Members() []uint {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return sortedIDs(cs.members)
+}
+
+func (cs *ChangeSet) add(entries []Entry) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	for _, entry := range entries {
+		cs.lists[entry.ListID] = true
+		if entry.TargetType == TargetMember {
+			cs.members[entry.TargetID] = true
+		}
+	}
+}
+
+func sortedIDs(set map[uint]bool) []uint {
+	ids := make([]uint, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Record saves entries on tx. Entries without an ActorID get the actor of the tx context, and the changes are
+// collected on the ChangeSet of the tx context when there is one.
+func Record(tx *gorm.DB, entries ...Entry) error {
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	actorID := ActorFrom(tx.Statement.Context)
+	for i := range entries {
+		if entries[i].ActorID == nil {
+			entries[i].ActorID = actorID
+		}
+	}
+
+	if changes, ok := tx.Statement.Context.Value(changeSetKey{}).(*ChangeSet); ok {
+		changes.add(entries)
+	}
+
+	return tx.Create(&entries).Error
+}
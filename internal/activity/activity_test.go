@@ -0,0 +1,122 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+)
+
+func TestChanges_Value(t *testing.T) {
+	value, err := Changes{"title": {Before: json.RawMessage(`"Milk"`), After: json.RawMessage(`"Oat milk"`)}}.Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"title":{"before":"Milk","after":"Oat milk"}}`, value)
+}
+
+func TestChanges_Value_Nil(t *testing.T) {
+	value, err := Changes(nil).Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", value)
+}
+
+func TestChanges_Scan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  Changes
+	}{
+		{name: "String", value: `{"title":{"after":"Milk"}}`, want: Changes{"title": {After: json.RawMessage(`"Milk"`)}}},
+		{name: "Bytes", value: []byte(`{"title":{"before":"Milk"}}`), want: Changes{"title": {Before: json.RawMessage(`"Milk"`)}}},
+		{name: "Empty", value: "", want: Changes{}},
+		{name: "Nil", value: nil, want: Changes{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var changes Changes
+
+			assert.NoError(t, changes.Scan(tt.value))
+			assert.Equal(t, tt.want, changes)
+		})
+	}
+}
+
+func TestChanges_Scan_Error(t *testing.T) {
+	var changes Changes
+
+	assert.Error(t, changes.Scan(42))
+	assert.Error(t, changes.Scan("not json"))
+}
+
+func TestActorFrom(t *testing.T) {
+	assert.Nil(t, ActorFrom(context.Background()))
+	assert.Equal(t, uint(7), *ActorFrom(WithActor(context.Background(), 7)))
+}
+
+func TestRecord(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	jobActor := uint(1)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities` (`list_id`,`actor_id`,`action`,`target_type`,`target_id`,`changes`,`created_at`) VALUES (?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs(3, 7, ActionCreated, TargetListItem, 4, "{}", sqlmock.AnyArg(), 3, 1, ActionReset, TargetList, 3, "{}", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	err = gormDb.WithContext(WithActor(context.Background(), 7)).Transaction(func(tx *gorm.DB) error {
+		return Record(tx,
+			Entry{ListID: 3, Action: ActionCreated, TargetType: TargetListItem, TargetID: 4},
+			Entry{ListID: 3, ActorID: &jobActor, Action: ActionReset, TargetType: TargetList, TargetID: 3},
+		)
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, Record(gormDb))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecord_TrackChanges(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `activities`")).WillReturnResult(sqlmock.NewResult(1, 3))
+	mock.ExpectCommit()
+
+	ctx, changes := TrackChanges(context.Background())
+	err = gormDb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return Record(tx,
+			Entry{ListID: 5, Action: ActionCreated, TargetType: TargetListItem, TargetID: 4},
+			Entry{ListID: 3, Action: ActionCreated, TargetType: TargetMember, TargetID: 9},
+			Entry{ListID: 5, Action: ActionDeleted, TargetType: TargetListItem, TargetID: 4},
+		)
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []uint{3, 5}, changes.Lists())
+	assert.Equal(t, []uint{9}, changes.Members())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
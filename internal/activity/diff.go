@@ -0,0 +1,88 @@
+package activity
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// emptyValues are the encodings of zero values, a field going from nothing to one of them is not a change.
+var emptyValues = [][]byte{
+	[]byte("null"), []byte(`""`), []byte("false"), []byte("0"), []byte("[]"), []byte("{}"), []byte(`"0001-01-01T00:00:00Z"`),
+}
+
+// Diff compares the saved fields of two values of the same struct type, either of them may be nil for creations
+// and deletions. Embedded structs like gorm.Model, fields without a json name and fields not stored by gorm are
+// left out.
+func Diff(before interface{}, after interface{}) Changes {
+
+	beforeFields := fields(before)
+	afterFields := fields(after)
+
+	changes := Changes{}
+
+	for name, beforeValue := range beforeFields {
+		afterValue := afterFields[name]
+		if !bytes.Equal(beforeValue, afterValue) {
+			changes[name] = Change{Before: beforeValue, After: afterValue}
+		}
+	}
+
+	for name, afterValue := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			changes[name] = Change{After: afterValue}
+		}
+	}
+
+	return changes
+}
+
+// fields encodes every saved field of value that isn't empty, keyed by its json name.
+func fields(value interface{}) map[string]json.RawMessage {
+
+	encoded := map[string]json.RawMessage{}
+
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return encoded
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return encoded
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+
+		if field.Anonymous || field.PkgPath != "" || field.Tag.Get("gorm") == "-" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		raw, err := json.Marshal(v.Field(i).Interface())
+		if err != nil || isEmpty(raw) {
+			continue
+		}
+
+		encoded[name] = raw
+	}
+
+	return encoded
+}
+
+func isEmpty(raw []byte) bool {
+	for _, empty := range emptyValues {
+		if bytes.Equal(raw, empty) {
+			return true
+		}
+	}
+	return false
+}
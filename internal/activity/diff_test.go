@@ -0,0 +1,77 @@
+package activity
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+)
+
+type item struct {
+	gorm.Model
+	Title    string     `json:"title"`
+	IsDone   bool       `json:"is_done"`
+	Tags     []string   `json:"tags"`
+	DueAt    *time.Time `json:"due_at"`
+	Note     string     `json:"note" gorm:"-"`
+	Internal string     `json:"-"`
+	secret   string
+}
+
+func TestDiff(t *testing.T) {
+	dueAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		before interface{}
+		after  interface{}
+		want   Changes
+	}{
+		{
+			name:   "Created",
+			before: nil,
+			after:  &item{Title: "Milk", Tags: []string{"dairy"}},
+			want: Changes{
+				"title": {After: json.RawMessage(`"Milk"`)},
+				"tags":  {After: json.RawMessage(`["dairy"]`)},
+			},
+		},
+		{
+			name:   "Deleted",
+			before: item{Title: "Milk", IsDone: true},
+			after:  nil,
+			want: Changes{
+				"title":   {Before: json.RawMessage(`"Milk"`)},
+				"is_done": {Before: json.RawMessage(`true`)},
+			},
+		},
+		{
+			name:   "Updated",
+			before: item{Title: "Milk", DueAt: &dueAt},
+			after:  item{Title: "Oat milk", IsDone: true},
+			want: Changes{
+				"title":   {Before: json.RawMessage(`"Milk"`), After: json.RawMessage(`"Oat milk"`)},
+				"is_done": {After: json.RawMessage(`true`)},
+				"due_at":  {Before: json.RawMessage(`"2022-03-01T10:00:00Z"`)},
+			},
+		},
+		{
+			name:   "Embedded, unsaved and unexported fields are left out",
+			before: item{Model: gorm.Model{ID: 1}, Note: "a", Internal: "a", secret: "a"},
+			after:  item{Model: gorm.Model{ID: 2}, Note: "b", Internal: "b", secret: "b"},
+			want:   Changes{},
+		},
+		{
+			name:   "Not a struct",
+			before: "Milk",
+			after:  3,
+			want:   Changes{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Diff(tt.before, tt.after))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package bulk holds the request and the result shared by the endpoints that act on many lists or items at once.
+package bulk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+)
+
+// MaxIDs is the most ids a single bulk request can hold.
+const MaxIDs = 500
+
+// Request names the targets of a bulk operation as {"ids": [1, 2]}. The array of objects sent by earlier clients,
+// like [{"ID": 1}, {"ID": 2}], is read as well. Repeated ids are kept once, in the order they first appear.
+type Request struct {
+	IDs []uint `json:"ids" validate:"required,min=1,dive,required"`
+}
+
+// Validate checks that r holds between 1 and MaxIDs ids, none of them 0.
+func (r Request) Validate() error {
+	if len(r.IDs) > MaxIDs {
+		return fmt.Errorf("ids can hold at most %d ids", MaxIDs)
+	}
+
+	return validator.New().Struct(r)
+}
+
+func (r *Request) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '[' {
+		var targets []struct{ ID uint }
+		if err := json.Unmarshal(data, &targets); err != nil {
+			return err
+		}
+
+		ids := make([]uint, 0, len(targets))
+		for _, target := range targets {
+			ids = append(ids, target.ID)
+		}
+		r.IDs = unique(ids)
+		return nil
+	}
+
+	type plainRequest Request
+	var request plainRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		return err
+	}
+
+	r.IDs = unique(request.IDs)
+	return nil
+}
+
+// Result tells what a bulk operation did with each id. Succeeded ids were changed, Unchanged ones already were in
+// the requested state, NotFound ones don't exist and Forbidden ones can't be changed by the caller.
+type Result struct {
+	Succeeded []uint `json:"succeeded"`
+	NotFound  []uint `json:"not_found"`
+	Forbidden []uint `json:"forbidden"`
+	Unchanged []uint `json:"unchanged"`
+}
+
+// NewResult returns a result without ids, whose lists encode as empty arrays.
+func NewResult() *Result {
+	return &Result{Succeeded: []uint{}, NotFound: []uint{}, Forbidden: []uint{}, Unchanged: []uint{}}
+}
+
+// Add appends the ids of other to r.
+func (r *Result) Add(other *Result) {
+	r.Succeeded = append(r.Succeeded, other.Succeeded...)
+	r.NotFound = append(r.NotFound, other.NotFound...)
+	r.Forbidden = append(r.Forbidden, other.Forbidden...)
+	r.Unchanged = append(r.Unchanged, other.Unchanged...)
+}
+
+// Missing returns the ids that are not in found, keeping their order.
+func Missing(ids []uint, found map[uint]bool) []uint {
+	missing := []uint{}
+	for _, id := range ids {
+		if !found[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+func unique(ids []uint) []uint {
+	seen := make(map[uint]bool, len(ids))
+	result := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
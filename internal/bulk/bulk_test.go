@@ -0,0 +1,79 @@
+package bulk
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRequest_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []uint
+		wantErr bool
+	}{
+		{name: "Ids", body: `{"ids": [3, 1, 3]}`, want: []uint{3, 1}},
+		{name: "Objects", body: ` [{"ID": 3, "title": "buy paint"}, {"id": 1}]`, want: []uint{3, 1}},
+		{name: "Empty", body: `{"ids": []}`, want: []uint{}},
+		{name: "Invalid", body: `{"ids": ["a"]}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var request Request
+
+			err := json.Unmarshal([]byte(tt.body), &request)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, request.IDs)
+		})
+	}
+}
+
+func TestRequest_Validate(t *testing.T) {
+	tooMany := make([]uint, MaxIDs+1)
+	for i := range tooMany {
+		tooMany[i] = uint(i + 1)
+	}
+
+	tests := []struct {
+		name    string
+		ids     []uint
+		wantErr bool
+	}{
+		{name: "Valid ids", ids: []uint{1, 2}},
+		{name: "As many ids as allowed", ids: tooMany[:MaxIDs]},
+		{name: "No ids", ids: []uint{}, wantErr: true},
+		{name: "Missing ids", wantErr: true},
+		{name: "Zero id", ids: []uint{1, 0}, wantErr: true},
+		{name: "Too many ids", ids: tooMany, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Request{IDs: tt.ids}.Validate()
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestResult_Add(t *testing.T) {
+	result := NewResult()
+	result.Forbidden = append(result.Forbidden, 4)
+
+	result.Add(&Result{Succeeded: []uint{1}, NotFound: []uint{2}, Unchanged: []uint{3}})
+
+	assert.Equal(t, &Result{Succeeded: []uint{1}, NotFound: []uint{2}, Forbidden: []uint{4}, Unchanged: []uint{3}}, result)
+}
+
+func TestMissing(t *testing.T) {
+	assert.Equal(t, []uint{2, 5}, Missing([]uint{1, 2, 3, 5}, map[uint]bool{1: true, 3: true}))
+}
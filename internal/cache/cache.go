@@ -0,0 +1,81 @@
+// Package cache keeps serialized API reads, like the lists of a user, in memory or on a Redis server, and drops
+// them when the activity recorded by a request or a job changes what they were read from.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+const defaultLRUSize = 10000
+
+// Cache keeps values under keys for up to a ttl, a zero ttl keeps them until evicted. Delete of a missing key is
+// not an error.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// NewFromEnv picks the cache with CACHE_DRIVER, "memory" by default or "redis". The memory cache keeps up to
+// CACHE_SIZE entries, the redis one reads REDIS_ADDR, REDIS_PASSWORD and REDIS_DB.
+func NewFromEnv() (Cache, error) {
+	switch driver := os.Getenv("CACHE_DRIVER"); driver {
+	case "", "memory":
+		size, err := strconv.Atoi(os.Getenv("CACHE_SIZE"))
+		if err != nil || size <= 0 {
+			size = defaultLRUSize
+		}
+		return NewLRU(size), nil
+	case "redis":
+		db := 0
+		if value := os.Getenv("REDIS_DB"); value != "" {
+			var err error
+			if db, err = strconv.Atoi(value); err != nil {
+				return nil, fmt.Errorf("invalid REDIS_DB %q", value)
+			}
+		}
+		return NewRedisCache(RedisConfig{
+			Addr:     os.Getenv("REDIS_ADDR"),
+			Password: os.Getenv("REDIS_PASSWORD"),
+			DB:       db,
+		})
+	default:
+		return nil, fmt.Errorf("unknown cache driver %q", driver)
+	}
+}
+
+// Fetch reads key into dest as JSON, or calls load to fill dest and keeps it under key for ttl. A failing cache
+// is logged and skipped so reads keep working while it is down, only the errors of load are returned.
+func Fetch(ctx context.Context, cache Cache, key string, ttl time.Duration, dest interface{}, load func() error) error {
+	value, found, err := cache.Get(ctx, key)
+	if err != nil {
+		log.Print(fmt.Sprintf("Error reading %s from the cache: %s", key, err.Error()))
+	}
+
+	if found {
+		if err := json.Unmarshal(value, dest); err == nil {
+			return nil
+		}
+		log.Print(fmt.Sprintf("Unreadable value on %s, reading it again", key))
+	}
+
+	if err := load(); err != nil {
+		return err
+	}
+
+	value, err = json.Marshal(dest)
+	if err == nil {
+		err = cache.Set(ctx, key, value, ttl)
+	}
+	if err != nil {
+		log.Print(fmt.Sprintf("Error writing %s to the cache: %s", key, err.Error()))
+	}
+
+	return nil
+}
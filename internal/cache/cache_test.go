@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// failingCache fails every operation, like a cache server that is down.
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("connection refused")
+}
+
+type cachedList struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("CACHE_DRIVER", "")
+	memory, err := NewFromEnv()
+	assert.NoError(t, err)
+	assert.IsType(t, &LRU{}, memory)
+	assert.Equal(t, defaultLRUSize, memory.(*LRU).capacity)
+
+	t.Setenv("CACHE_SIZE", "50")
+	memory, _ = NewFromEnv()
+	assert.Equal(t, 50, memory.(*LRU).capacity)
+
+	t.Setenv("CACHE_DRIVER", "redis")
+	t.Setenv("REDIS_ADDR", "localhost:6379")
+	t.Setenv("REDIS_DB", "3")
+	redis, err := NewFromEnv()
+	assert.NoError(t, err)
+	assert.Equal(t, RedisConfig{Addr: "localhost:6379", DB: 3}, redis.(*RedisCache).config)
+
+	t.Setenv("REDIS_DB", "first")
+	_, err = NewFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv("CACHE_DRIVER", "memcached")
+	_, err = NewFromEnv()
+	assert.EqualError(t, err, `unknown cache driver "memcached"`)
+}
+
+func TestFetch(t *testing.T) {
+	cache := WithMetrics(NewLRU(10))
+	ctx := context.Background()
+	loads := 0
+	load := func(dest *[]cachedList) func() error {
+		return func() error {
+			loads++
+			*dest = []cachedList{{ID: 1, Name: "Super"}}
+			return nil
+		}
+	}
+
+	var first []cachedList
+	assert.NoError(t, Fetch(ctx, cache, "lists", time.Minute, &first, load(&first)))
+	var second []cachedList
+	assert.NoError(t, Fetch(ctx, cache, "lists", time.Minute, &second, load(&second)))
+
+	assert.Equal(t, 1, loads)
+	assert.Equal(t, first, second)
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, HitRatio: 0.5}, cache.Stats())
+
+	assert.NoError(t, cache.Delete(ctx, "lists", "others"))
+	var third []cachedList
+	assert.NoError(t, Fetch(ctx, cache, "lists", time.Minute, &third, load(&third)))
+
+	assert.Equal(t, 2, loads)
+	assert.Equal(t, Stats{Hits: 1, Misses: 2, Invalidations: 2, HitRatio: 1.0 / 3}, cache.Stats())
+}
+
+func TestFetch_Load_Error(t *testing.T) {
+	cache := NewLRU(10)
+
+	var lists []cachedList
+	err := Fetch(context.Background(), cache, "lists", time.Minute, &lists, func() error {
+		return errors.New("database is down")
+	})
+
+	assert.EqualError(t, err, "database is down")
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestFetch_Unreadable_Value(t *testing.T) {
+	cache := NewLRU(10)
+	ctx := context.Background()
+	assert.NoError(t, cache.Set(ctx, "lists", []byte("not json"), 0))
+
+	var lists []cachedList
+	err := Fetch(ctx, cache, "lists", time.Minute, &lists, func() error {
+		lists = []cachedList{{ID: 1}}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cachedList{{ID: 1}}, lists)
+	stored, _, _ := cache.Get(ctx, "lists")
+	assert.JSONEq(t, `[{"id":1,"name":""}]`, string(stored))
+}
+
+func TestFetch_Cache_Down(t *testing.T) {
+	cache := WithMetrics(failingCache{})
+
+	var lists []cachedList
+	err := Fetch(context.Background(), cache, "lists", time.Minute, &lists, func() error {
+		lists = []cachedList{{ID: 1}}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []cachedList{{ID: 1}}, lists)
+	assert.Equal(t, Stats{Errors: 2}, cache.Stats())
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"SuperListsAPI/internal/activity"
+	"SuperListsAPI/internal/scheduler"
+	"context"
+	"fmt"
+	"log"
+)
+
+// MemberLookup returns the ids of the users who belong to the list listID.
+type MemberLookup func(listID uint) ([]uint, error)
+
+// Invalidator drops the reads that a set of changes made stale: every read of a changed list, and the lists of
+// its members and of the users who joined or left it.
+type Invalidator struct {
+	cache   Cache
+	members MemberLookup
+}
+
+func NewInvalidator(cache Cache, members MemberLookup) Invalidator {
+	return Invalidator{cache: cache, members: members}
+}
+
+// Invalidate deletes the keys made stale by changes. Keys of lists whose members could not be read are still
+// deleted, along with the lists of the members that were read, before the lookup error is returned.
+func (i *Invalidator) Invalidate(ctx context.Context, changes *activity.ChangeSet) error {
+	var keys []string
+	var lookupErr error
+	users := map[uint]bool{}
+
+	for _, userID := range changes.Members() {
+		users[userID] = true
+	}
+
+	for _, listID := range changes.Lists() {
+		keys = append(keys, listKeys(listID)...)
+
+		members, err := i.members(listID)
+		if err != nil {
+			lookupErr = fmt.Errorf("reading the members of list %d: %w", listID, err)
+			continue
+		}
+		for _, userID := range members {
+			users[userID] = true
+		}
+	}
+
+	for userID := range users {
+		keys = append(keys, UserListsKey(userID))
+	}
+
+	if len(keys) == 0 {
+		return lookupErr
+	}
+
+	if err := i.cache.Delete(ctx, keys...); err != nil {
+		return err
+	}
+
+	return lookupErr
+}
+
+// Job wraps job so the changes of each of its runs are invalidated once it returns.
+func (i *Invalidator) Job(job scheduler.Job) scheduler.Job {
+	return &invalidatingJob{Job: job, invalidator: i}
+}
+
+type invalidatingJob struct {
+	scheduler.Job
+	invalidator *Invalidator
+}
+
+func (ij *invalidatingJob) Run(ctx context.Context) error {
+	ctx, changes := activity.TrackChanges(ctx)
+	err := ij.Job.Run(ctx)
+
+	// The job context may be cancelled already, the changes it committed are invalidated anyway
+	if invalidateErr := ij.invalidator.Invalidate(context.Background(), changes); invalidateErr != nil {
+		log.Print(fmt.Sprintf("Error invalidating the cache after job %s: %s", ij.Name(), invalidateErr.Error()))
+	}
+
+	return err
+}
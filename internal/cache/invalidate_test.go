@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"SuperListsAPI/internal/activity"
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"sort"
+	"testing"
+)
+
+// recordingCache keeps the keys deleted from it.
+type recordingCache struct {
+	*LRU
+	deleted []string
+}
+
+func (rc *recordingCache) Delete(ctx context.Context, keys ...string) error {
+	rc.deleted = append(rc.deleted, keys...)
+	return rc.LRU.Delete(ctx, keys...)
+}
+
+// recordingJob records entries on each run, like the jobs that change lists.
+type recordingJob struct {
+	db      *gorm.DB
+	entries []activity.Entry
+}
+
+func (rj *recordingJob) Name() string {
+	return "recording"
+}
+
+func (rj *recordingJob) Run(ctx context.Context) error {
+	return rj.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return activity.Record(tx, rj.entries...)
+	})
+}
+
+func getDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return gormDb, mock
+}
+
+func members(lists map[uint][]uint) MemberLookup {
+	return func(listID uint) ([]uint, error) {
+		if userIDs, ok := lists[listID]; ok {
+			return userIDs, nil
+		}
+		return nil, errors.New("list not found")
+	}
+}
+
+func TestInvalidator_Job(t *testing.T) {
+	gormDb, mock := getDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `activities`").WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	cache := &recordingCache{LRU: NewLRU(100)}
+	ctx := context.Background()
+	assert.NoError(t, cache.Set(ctx, UserListsKey(1), []byte("[]"), 0))
+	assert.NoError(t, cache.Set(ctx, UserListsKey(3), []byte("[]"), 0))
+	assert.NoError(t, cache.Set(ctx, ListKey(4, true, false), []byte("{}"), 0))
+
+	invalidator := NewInvalidator(cache, members(map[uint][]uint{4: {1, 2}}))
+	job := invalidator.Job(&recordingJob{db: gormDb, entries: []activity.Entry{
+		{ListID: 4, Action: activity.ActionUpdated, TargetType: activity.TargetListItem, TargetID: 10},
+		{ListID: 4, Action: activity.ActionRemoved, TargetType: activity.TargetMember, TargetID: 5},
+	}})
+
+	assert.NoError(t, job.Run(ctx))
+	assert.Equal(t, "recording", job.Name())
+
+	sort.Strings(cache.deleted)
+	assert.Equal(t, []string{
+		"superlists:list:4:items",
+		"superlists:list:4:items=false:members=false",
+		"superlists:list:4:items=false:members=true",
+		"superlists:list:4:items=true:members=false",
+		"superlists:list:4:items=true:members=true",
+		"superlists:user:1:lists",
+		"superlists:user:2:lists",
+		"superlists:user:5:lists",
+	}, cache.deleted)
+	assert.Equal(t, 1, cache.Len())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInvalidator_Invalidate_Lookup_Error(t *testing.T) {
+	cache := &recordingCache{LRU: NewLRU(100)}
+	invalidator := NewInvalidator(cache, members(map[uint][]uint{1: {7}}))
+
+	gormDb, mock := getDatabase(t)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO `activities`").WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	ctx, changes := activity.TrackChanges(context.Background())
+	err := gormDb.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return activity.Record(tx,
+			activity.Entry{ListID: 1, Action: activity.ActionUpdated, TargetType: activity.TargetList, TargetID: 1},
+			activity.Entry{ListID: 2, Action: activity.ActionUpdated, TargetType: activity.TargetList, TargetID: 2},
+		)
+	})
+	assert.NoError(t, err)
+
+	err = invalidator.Invalidate(context.Background(), changes)
+
+	assert.EqualError(t, err, "reading the members of list 2: list not found")
+	assert.Contains(t, cache.deleted, ListItemsKey(2))
+	assert.Contains(t, cache.deleted, UserListsKey(7))
+}
+
+func TestInvalidator_Invalidate_No_Changes(t *testing.T) {
+	cache := &recordingCache{LRU: NewLRU(100)}
+	invalidator := NewInvalidator(cache, members(nil))
+
+	_, changes := activity.TrackChanges(context.Background())
+
+	assert.NoError(t, invalidator.Invalidate(context.Background(), changes))
+	assert.Empty(t, cache.deleted)
+}
@@ -0,0 +1,31 @@
+package cache
+
+import "fmt"
+
+const keyPrefix = "superlists:"
+
+// ListKey names a read of the list listID, told apart by the associations it includes.
+func ListKey(listID uint, items bool, members bool) string {
+	return fmt.Sprintf("%slist:%d:items=%t:members=%t", keyPrefix, listID, items, members)
+}
+
+// ListItemsKey names the read of the items of the list listID.
+func ListItemsKey(listID uint) string {
+	return fmt.Sprintf("%slist:%d:items", keyPrefix, listID)
+}
+
+// UserListsKey names the read of the lists of userID.
+func UserListsKey(userID uint) string {
+	return fmt.Sprintf("%suser:%d:lists", keyPrefix, userID)
+}
+
+// listKeys are every key holding a read of the list listID.
+func listKeys(listID uint) []string {
+	return []string{
+		ListKey(listID, false, false),
+		ListKey(listID, true, false),
+		ListKey(listID, false, true),
+		ListKey(listID, true, true),
+		ListItemsKey(listID),
+	}
+}
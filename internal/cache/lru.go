@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// LRU keeps up to capacity entries in the process memory, evicting the least recently used one when full.
+// Expired entries are dropped when read.
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+		now:      time.Now,
+	}
+}
+
+func (l *LRU) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+
+	entry := element.Value.(*lruEntry)
+	if !entry.expiresAt.IsZero() && !l.now().Before(entry.expiresAt) {
+		l.remove(element)
+		return nil, false, nil
+	}
+
+	l.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (l *LRU) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = l.now().Add(ttl)
+	}
+
+	if element, ok := l.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		l.order.MoveToFront(element)
+		return nil
+	}
+
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expiresAt: expiresAt})
+	for l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+
+	return nil
+}
+
+func (l *LRU) Delete(ctx context.Context, keys ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := l.entries[key]; ok {
+			l.remove(element)
+		}
+	}
+
+	return nil
+}
+
+// Len returns the amount of entries kept, expired ones included until they are read.
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	lru := NewLRU(2)
+	ctx := context.Background()
+
+	assert.NoError(t, lru.Set(ctx, "a", []byte("1"), 0))
+	assert.NoError(t, lru.Set(ctx, "b", []byte("2"), 0))
+
+	value, found, err := lru.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("1"), value)
+
+	// b is now the least recently used one
+	assert.NoError(t, lru.Set(ctx, "c", []byte("3"), 0))
+	assert.Equal(t, 2, lru.Len())
+
+	_, found, _ = lru.Get(ctx, "b")
+	assert.False(t, found)
+	_, found, _ = lru.Get(ctx, "c")
+	assert.True(t, found)
+
+	assert.NoError(t, lru.Set(ctx, "a", []byte("4"), 0))
+	value, _, _ = lru.Get(ctx, "a")
+	assert.Equal(t, []byte("4"), value)
+
+	assert.NoError(t, lru.Delete(ctx, "a", "missing"))
+	_, found, _ = lru.Get(ctx, "a")
+	assert.False(t, found)
+	assert.Equal(t, 1, lru.Len())
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	lru := NewLRU(10)
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	lru.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	assert.NoError(t, lru.Set(ctx, "short", []byte("1"), time.Minute))
+	assert.NoError(t, lru.Set(ctx, "forever", []byte("2"), 0))
+
+	now = now.Add(59 * time.Second)
+	_, found, _ := lru.Get(ctx, "short")
+	assert.True(t, found)
+
+	now = now.Add(time.Second)
+	_, found, _ = lru.Get(ctx, "short")
+	assert.False(t, found)
+	_, found, _ = lru.Get(ctx, "forever")
+	assert.True(t, found)
+	assert.Equal(t, 1, lru.Len())
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Metered counts the reads that hit and missed a cache, the errors it returned and the keys deleted from it.
+type Metered struct {
+	Cache
+	hits          uint64
+	misses        uint64
+	errors        uint64
+	invalidations uint64
+}
+
+// Stats is a snapshot of the counters of a Metered cache. HitRatio is the share of hits among the reads that
+// did not fail.
+type Stats struct {
+	Hits          uint64  `json:"hits"`
+	Misses        uint64  `json:"misses"`
+	Errors        uint64  `json:"errors"`
+	Invalidations uint64  `json:"invalidations"`
+	HitRatio      float64 `json:"hit_ratio"`
+}
+
+func WithMetrics(cache Cache) *Metered {
+	return &Metered{Cache: cache}
+}
+
+func (m *Metered) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, found, err := m.Cache.Get(ctx, key)
+	switch {
+	case err != nil:
+		atomic.AddUint64(&m.errors, 1)
+	case found:
+		atomic.AddUint64(&m.hits, 1)
+	default:
+		atomic.AddUint64(&m.misses, 1)
+	}
+	return value, found, err
+}
+
+func (m *Metered) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := m.Cache.Set(ctx, key, value, ttl)
+	if err != nil {
+		atomic.AddUint64(&m.errors, 1)
+	}
+	return err
+}
+
+func (m *Metered) Delete(ctx context.Context, keys ...string) error {
+	err := m.Cache.Delete(ctx, keys...)
+	if err != nil {
+		atomic.AddUint64(&m.errors, 1)
+		return err
+	}
+	atomic.AddUint64(&m.invalidations, uint64(len(keys)))
+	return nil
+}
+
+func (m *Metered) Stats() Stats {
+	stats := Stats{
+		Hits:          atomic.LoadUint64(&m.hits),
+		Misses:        atomic.LoadUint64(&m.misses),
+		Errors:        atomic.LoadUint64(&m.errors),
+		Invalidations: atomic.LoadUint64(&m.invalidations),
+	}
+
+	if reads := stats.Hits + stats.Misses; reads > 0 {
+		stats.HitRatio = float64(stats.Hits) / float64(reads)
+	}
+
+	return stats
+}
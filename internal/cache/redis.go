@@ -0,0 +1,257 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	redisTimeout  = 2 * time.Second
+	redisMaxIdle  = 8
+	redisMaxReply = 64 << 20
+)
+
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+}
+
+// RedisCache talks RESP to a Redis compatible server, so replicas of the API share their cache. Connections are
+// kept open for reuse, up to redisMaxIdle of them.
+type RedisCache struct {
+	config  RedisConfig
+	dialer  net.Dialer
+	idle    chan *redisConn
+	timeout time.Duration
+}
+
+// redisError is an error reply of the server, the connection that got it can still be used.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func NewRedisCache(config RedisConfig) (*RedisCache, error) {
+	if config.Addr == "" {
+		return nil, errors.New("redis cache needs an address")
+	}
+
+	return &RedisCache{
+		config:  config,
+		dialer:  net.Dialer{Timeout: redisTimeout},
+		idle:    make(chan *redisConn, redisMaxIdle),
+		timeout: redisTimeout,
+	}, nil
+}
+
+func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := r.do(ctx, "GET", key)
+	if err != nil || reply == nil {
+		return nil, false, err
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("redis: unexpected reply %v to GET", reply)
+	}
+
+	return value, true, nil
+}
+
+func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []string{"SET", key, string(value)}
+	if ttl > 0 {
+		milliseconds := ttl.Milliseconds()
+		if milliseconds < 1 {
+			milliseconds = 1
+		}
+		args = append(args, "PX", strconv.FormatInt(milliseconds, 10))
+	}
+
+	_, err := r.do(ctx, args...)
+	return err
+}
+
+func (r *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	_, err := r.do(ctx, append([]string{"DEL"}, keys...)...)
+	return err
+}
+
+// Close closes the idle connections. Connections in use are closed when given back.
+func (r *RedisCache) Close() error {
+	for {
+		select {
+		case conn := <-r.idle:
+			conn.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// do sends a command and reads its reply, on a connection of the pool or a new one.
+func (r *RedisCache) do(ctx context.Context, args ...string) (interface{}, error) {
+	conn, err := r.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(r.deadline(ctx), args...)
+
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		conn.conn.Close()
+		return nil, err
+	}
+
+	r.put(conn)
+	return reply, err
+}
+
+func (r *RedisCache) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-r.idle:
+		return conn, nil
+	default:
+	}
+
+	netConn, err := r.dialer.DialContext(ctx, "tcp", r.config.Addr)
+	if err != nil {
+		return nil, err
+	}
+	conn := &redisConn{conn: netConn, reader: bufio.NewReader(netConn)}
+
+	if r.config.Password != "" {
+		if _, err := conn.do(r.deadline(ctx), "AUTH", r.config.Password); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+	if r.config.DB != 0 {
+		if _, err := conn.do(r.deadline(ctx), "SELECT", strconv.Itoa(r.config.DB)); err != nil {
+			netConn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+func (r *RedisCache) put(conn *redisConn) {
+	select {
+	case r.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+// deadline is the one of ctx, or the default timeout from now when ctx has none or a later one.
+func (r *RedisCache) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(r.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+func (c *redisConn) do(deadline time.Time, args ...string) (interface{}, error) {
+	if err := c.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	if _, err := c.conn.Write(encodeCommand(args)); err != nil {
+		return nil, err
+	}
+
+	return readReply(c.reader)
+}
+
+// encodeCommand writes args as a RESP array of bulk strings.
+func encodeCommand(args []string) []byte {
+	command := []byte("*" + strconv.Itoa(len(args)) + "\r\n")
+	for _, arg := range args {
+		command = append(command, "$"+strconv.Itoa(len(arg))+"\r\n"...)
+		command = append(command, arg...)
+		command = append(command, "\r\n"...)
+	}
+	return command
+}
+
+// readReply reads a RESP reply: simple strings as string, errors as redisError, integers as int64, bulk strings
+// as []byte and arrays as []interface{}. Null bulk strings and arrays are nil.
+func readReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(line) == 0 {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size > redisMaxReply {
+			return nil, fmt.Errorf("redis: invalid bulk length %q", line[1:])
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	case '*':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length %q", line[1:])
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		values := make([]interface{}, size)
+		for i := range values {
+			if values[i], err = readReply(reader); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("redis: unexpected reply %q", line)
+	}
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(line) < 2 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("redis: malformed line %q", line)
+	}
+	return line[:len(line)-2], nil
+}
@@ -0,0 +1,183 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a local stand-in for a Redis server, it answers AUTH, SELECT, GET, SET and DEL from memory and
+// keeps the commands it got.
+type fakeRedis struct {
+	mu       sync.Mutex
+	password string
+	values   map[string]string
+	ttls     map[string]string
+	commands []string
+	conns    int
+}
+
+func newFakeRedis(t *testing.T, password string) (*fakeRedis, string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	fake := &fakeRedis{password: password, values: map[string]string{}, ttls: map[string]string{}}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go fake.serve(conn)
+		}
+	}()
+
+	return fake, listener.Addr().String()
+}
+
+func (f *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	f.mu.Lock()
+	f.conns++
+	f.mu.Unlock()
+
+	reader := bufio.NewReader(conn)
+	authenticated := f.password == ""
+	for {
+		request, err := readReply(reader)
+		if err != nil {
+			return
+		}
+
+		var args []string
+		for _, arg := range request.([]interface{}) {
+			args = append(args, string(arg.([]byte)))
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, strings.Join(args, " "))
+		var reply string
+		switch {
+		case args[0] == "AUTH":
+			authenticated = args[1] == f.password
+			reply = "+OK\r\n"
+			if !authenticated {
+				reply = "-WRONGPASS invalid password\r\n"
+			}
+		case !authenticated:
+			reply = "-NOAUTH Authentication required.\r\n"
+		case args[0] == "SELECT":
+			reply = "+OK\r\n"
+		case args[0] == "GET":
+			value, ok := f.values[args[1]]
+			reply = "$-1\r\n"
+			if ok {
+				reply = fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+			}
+		case args[0] == "SET":
+			f.values[args[1]] = args[2]
+			if len(args) == 5 && args[3] == "PX" {
+				f.ttls[args[1]] = args[4]
+			}
+			reply = "+OK\r\n"
+		case args[0] == "DEL":
+			deleted := 0
+			for _, key := range args[1:] {
+				if _, ok := f.values[key]; ok {
+					delete(f.values, key)
+					deleted++
+				}
+			}
+			reply = ":" + strconv.Itoa(deleted) + "\r\n"
+		default:
+			reply = "-ERR unknown command '" + args[0] + "'\r\n"
+		}
+		f.mu.Unlock()
+
+		if _, err := conn.Write([]byte(reply)); err != nil {
+			return
+		}
+	}
+}
+
+func TestRedisCache(t *testing.T) {
+	fake, addr := newFakeRedis(t, "secret")
+
+	redis, err := NewRedisCache(RedisConfig{Addr: addr, Password: "secret", DB: 2})
+	assert.NoError(t, err)
+	t.Cleanup(func() { redis.Close() })
+
+	ctx := context.Background()
+	value := []byte("{\"name\":\"Super\"}\r\n")
+
+	_, found, err := redis.Get(ctx, "superlists:list:1")
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	assert.NoError(t, redis.Set(ctx, "superlists:list:1", value, 5*time.Minute))
+	assert.NoError(t, redis.Set(ctx, "superlists:list:2", []byte("[]"), 0))
+	assert.Equal(t, "300000", fake.ttls["superlists:list:1"])
+	assert.NotContains(t, fake.ttls, "superlists:list:2")
+
+	stored, found, err := redis.Get(ctx, "superlists:list:1")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, value, stored)
+
+	assert.NoError(t, redis.Delete(ctx, "superlists:list:1", "superlists:list:2", "superlists:list:3"))
+	assert.NoError(t, redis.Delete(ctx))
+	assert.Empty(t, fake.values)
+
+	// Every command went through a single connection, authenticated and on the configured database once
+	assert.Equal(t, 1, fake.conns)
+	assert.Equal(t, []string{"AUTH secret", "SELECT 2"}, fake.commands[:2])
+}
+
+func TestRedisCache_Error_Reply(t *testing.T) {
+	_, addr := newFakeRedis(t, "secret")
+
+	redis, err := NewRedisCache(RedisConfig{Addr: addr, Password: "wrong"})
+	assert.NoError(t, err)
+
+	_, _, err = redis.Get(context.Background(), "superlists:list:1")
+	assert.EqualError(t, err, "redis: WRONGPASS invalid password")
+
+	redis, err = NewRedisCache(RedisConfig{Addr: addr, Password: "secret"})
+	assert.NoError(t, err)
+
+	_, err = redis.do(context.Background(), "FLUSHALL")
+	assert.EqualError(t, err, "redis: ERR unknown command 'FLUSHALL'")
+
+	// The connection is still usable after an error reply
+	assert.NoError(t, redis.Set(context.Background(), "superlists:list:1", []byte("{}"), 0))
+	assert.Len(t, redis.idle, 1)
+}
+
+func TestRedisCache_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	redis, err := NewRedisCache(RedisConfig{Addr: addr})
+	assert.NoError(t, err)
+
+	_, _, err = redis.Get(context.Background(), "superlists:list:1")
+	assert.Error(t, err)
+
+	_, err = NewRedisCache(RedisConfig{})
+	assert.Error(t, err)
+}
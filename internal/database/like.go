@@ -0,0 +1,9 @@
+package database
+
+import "strings"
+
+// EscapeLike escapes the LIKE wildcards of text with the ! escape character, to be matched with ESCAPE '!'.
+// Backslashes need no escaping, an explicit escape character makes them plain characters.
+func EscapeLike(text string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(text)
+}
@@ -0,0 +1,11 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEscapeLike(t *testing.T) {
+	assert.Equal(t, "tomate", EscapeLike("tomate"))
+	assert.Equal(t, "100!% jugo!_de!!naranja\\", EscapeLike("100% jugo_de!naranja\\"))
+}
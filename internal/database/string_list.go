@@ -0,0 +1,46 @@
+package database
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+)
+
+// StringList stores a list of strings on a single text column, encoded as a json array.
+type StringList []string
+
+func (sl StringList) Value() (driver.Value, error) {
+	if sl == nil {
+		return "[]", nil
+	}
+
+	encoded, err := json.Marshal([]string(sl))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(encoded), nil
+}
+
+func (sl *StringList) Scan(value interface{}) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case nil:
+		*sl = StringList{}
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return errors.New("unsupported type for StringList")
+	}
+
+	if len(raw) == 0 {
+		*sl = StringList{}
+		return nil
+	}
+
+	return json.Unmarshal(raw, (*[]string)(sl))
+}
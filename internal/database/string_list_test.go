@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStringList_Value(t *testing.T) {
+	value, err := StringList{"verduleria", "almacen"}.Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `["verduleria","almacen"]`, value)
+}
+
+func TestStringList_Value_Nil(t *testing.T) {
+	value, err := StringList(nil).Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", value)
+}
+
+func TestStringList_Scan(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  StringList
+	}{
+		{name: "From string", value: `["a","b"]`, want: StringList{"a", "b"}},
+		{name: "From bytes", value: []byte(`["a"]`), want: StringList{"a"}},
+		{name: "From nil", value: nil, want: StringList{}},
+		{name: "From empty string", value: "", want: StringList{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got StringList
+
+			assert.NoError(t, got.Scan(tt.value))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestStringList_Scan_Error(t *testing.T) {
+	var got StringList
+
+	assert.Error(t, got.Scan(42))
+	assert.Error(t, got.Scan("not json"))
+}
@@ -0,0 +1,118 @@
+// Package httputil holds the request helpers shared by the handlers. Helpers that check a request answer it,
+// and abort it, when the check fails, so handlers only have to return.
+package httputil
+
+import (
+	listItemModels "SuperListsAPI/cmd/listItems/models"
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"SuperListsAPI/internal/recurrence"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// RequestUserID reads the user id set by the jwt middleware, answering the request with 400 when it is not usable.
+func RequestUserID(c *gin.Context) (int, bool) {
+	userID := c.Request.Header.Get("user_id")
+
+	if userID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "missing user id on request header",
+		})
+		c.Abort()
+		return 0, false
+	}
+
+	parsedUserID, err := strconv.Atoi(userID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": "invalid user id",
+		})
+		c.Abort()
+		return 0, false
+	}
+
+	return parsedUserID, true
+}
+
+// QueryNumber reads an optional positive number from the query string. A max of 0 means no upper limit.
+func QueryNumber(c *gin.Context, name string, defaultValue int, min int, max int) (int, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return defaultValue, true
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < min || (max > 0 && value > max) {
+		msg := fmt.Sprintf("%s must be a number greater than or equal to %d", name, min)
+		if max > 0 {
+			msg = fmt.Sprintf("%s must be a number between %d and %d", name, min, max)
+		}
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": msg,
+		})
+		c.Abort()
+		return 0, false
+	}
+
+	return value, true
+}
+
+// PathID reads a positive number from the request path, answering the request with 400 and msg when it isn't one.
+func PathID(c *gin.Context, name string, msg string) (uint, bool) {
+	value, err := strconv.Atoi(c.Param(name))
+
+	if err != nil || value < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": msg,
+		})
+		c.Abort()
+		return 0, false
+	}
+
+	return uint(value), true
+}
+
+// ListMembers reads the members of a list, as the user list service does.
+type ListMembers interface {
+	GetUserListsByListID(listId string) (*[]userListModels.UserList, error)
+}
+
+// CheckListMember answers the request with 403 when userID is not a member of the list listID, or with 500 when its
+// members can't be read.
+func CheckListMember(c *gin.Context, userLists ListMembers, listID string, userID uint) bool {
+	members, err := userLists.GetUserListsByListID(listID)
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, err)
+		return false
+	}
+
+	if !userListModels.IsMember(*members, userID) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"msg": listItemModels.ErrNotListMember.Error(),
+		})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
+
+// ValidRecurrence answers the request with 400 when rule is set but is not a supported recurrence rule.
+func ValidRecurrence(c *gin.Context, rule string) bool {
+	if rule == "" {
+		return true
+	}
+
+	if _, err := recurrence.Parse(rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"msg": err.Error(),
+		})
+		c.Abort()
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+package httputil
+
+import (
+	userListModels "SuperListsAPI/cmd/userLists/models"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRequestUserID(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		want       int
+		wantOk     bool
+		wantStatus int
+	}{
+		{name: "Valid user id", userID: "7", want: 7, wantOk: true, wantStatus: http.StatusOK},
+		{name: "Missing user id", userID: "", wantStatus: http.StatusBadRequest},
+		{name: "Invalid user id", userID: "seven", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+			c.Request.Header.Set("user_id", tt.userID)
+
+			got, ok := RequestUserID(c)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, !tt.wantOk, c.IsAborted())
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestQueryNumber(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		max     int
+		want    int
+		wantOk  bool
+		wantMsg string
+	}{
+		{name: "Missing value takes the default", query: "", max: 50, want: 20, wantOk: true},
+		{name: "Value within limits", query: "?page_size=30", max: 50, want: 30, wantOk: true},
+		{name: "No upper limit", query: "?page_size=500", want: 500, wantOk: true},
+		{name: "Not a number", query: "?page_size=ten", max: 50, wantMsg: "page_size must be a number between 1 and 50"},
+		{name: "Below min", query: "?page_size=0", wantMsg: "page_size must be a number greater than or equal to 1"},
+		{name: "Above max", query: "?page_size=51", max: 50, wantMsg: "page_size must be a number between 1 and 50"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			got, ok := QueryNumber(c, "page_size", 20, 1, tt.max)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestPathID(t *testing.T) {
+	tests := []struct {
+		name   string
+		id     string
+		want   uint
+		wantOk bool
+	}{
+		{name: "Valid id", id: "9", want: 9, wantOk: true},
+		{name: "Zero id", id: "0"},
+		{name: "Not a number", id: "nine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+
+			got, ok := PathID(c, "id", "invalid list id")
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), "invalid list id")
+			}
+		})
+	}
+}
+
+type listMembers struct {
+	members []userListModels.UserList
+	err     error
+}
+
+func (lm listMembers) GetUserListsByListID(listId string) (*[]userListModels.UserList, error) {
+	return &lm.members, lm.err
+}
+
+func TestCheckListMember(t *testing.T) {
+	members := listMembers{members: []userListModels.UserList{{ListID: 3, UserID: 7}}}
+
+	tests := []struct {
+		name       string
+		userLists  ListMembers
+		userID     uint
+		want       bool
+		wantStatus int
+	}{
+		{name: "Member", userLists: members, userID: 7, want: true, wantStatus: http.StatusOK},
+		{name: "Not a member", userLists: members, userID: 8, wantStatus: http.StatusForbidden},
+		{name: "Members can't be read", userLists: listMembers{err: errors.New("error from db")}, userID: 7, wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			assert.Equal(t, tt.want, CheckListMember(c, tt.userLists, "3", tt.userID))
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestValidRecurrence(t *testing.T) {
+	tests := []struct {
+		name string
+		rule string
+		want bool
+	}{
+		{name: "No rule", rule: "", want: true},
+		{name: "Supported rule", rule: "FREQ=WEEKLY;BYDAY=MO", want: true},
+		{name: "Unsupported rule", rule: "FREQ=SOMETIMES", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request, _ = http.NewRequest(http.MethodPost, "/", nil)
+
+			assert.Equal(t, tt.want, ValidRecurrence(c, tt.rule))
+			assert.Equal(t, !tt.want, c.IsAborted())
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+type sendMailFunc func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+
+// EmailNotifier sends a plain text email to the address stored on the reminder target.
+type EmailNotifier struct {
+	addr     string
+	from     string
+	auth     smtp.Auth
+	sendMail sendMailFunc
+}
+
+func NewEmailNotifier(host, port, username, password, from string) EmailNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return EmailNotifier{
+		addr:     host + ":" + port,
+		from:     from,
+		auth:     auth,
+		sendMail: smtp.SendMail,
+	}
+}
+
+// NewEmailNotifierFromEnv reads SMTP_HOST, SMTP_PORT, SMTP_USER, SMTP_PASSWORD and SMTP_FROM.
+// The second value is false when SMTP_HOST is not set.
+func NewEmailNotifierFromEnv() (EmailNotifier, bool) {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		return EmailNotifier{}, false
+	}
+
+	port := os.Getenv("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+
+	return NewEmailNotifier(host, port, os.Getenv("SMTP_USER"), os.Getenv("SMTP_PASSWORD"), os.Getenv("SMTP_FROM")), true
+}
+
+func (e EmailNotifier) Notify(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// The target is user provided, a line break would let it inject headers.
+	if strings.ContainsAny(event.Target, "\r\n") {
+		return fmt.Errorf("invalid email address")
+	}
+
+	subject := fmt.Sprintf("Reminder: %s", event.Title)
+	body := fmt.Sprintf("%s is due at %s.", event.Title, event.DueAt.Format("2006-01-02 15:04 MST"))
+
+	msg := "From: " + e.from + "\r\n" +
+		"To: " + event.Target + "\r\n" +
+		"Subject: " + strings.NewReplacer("\r", "", "\n", "").Replace(subject) + "\r\n" +
+		"Message-ID: <" + event.DeliveryID + "@superlists>\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" + body + "\r\n"
+
+	return e.sendMail(e.addr, e.auth, e.from, []string{event.Target}, []byte(msg))
+}
@@ -0,0 +1,64 @@
+// Package notify delivers reminder and mention events to users through pluggable channels.
+package notify
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	ChannelInApp   = "in_app"
+	ChannelEmail   = "email"
+	ChannelWebhook = "webhook"
+)
+
+const (
+	KindReminder = "reminder"
+	KindMention  = "mention"
+)
+
+var ErrChannelNotConfigured = errors.New("notification channel not configured")
+
+// Event is a single reminder occurrence or a mention on a comment. DeliveryID is stable across retries so
+// receivers can deduplicate. Mentions have no DueAt.
+type Event struct {
+	DeliveryID string    `json:"delivery_id"`
+	Kind       string    `json:"kind"`
+	ReminderID uint      `json:"reminder_id,omitempty"`
+	CommentID  uint      `json:"comment_id,omitempty"`
+	UserID     uint      `json:"user_id"`
+	ListID     uint      `json:"list_id"`
+	ListItemID uint      `json:"list_item_id"`
+	Title      string    `json:"title"`
+	DueAt      time.Time `json:"due_at"`
+	FireAt     time.Time `json:"fire_at"`
+	Channel    string    `json:"channel"`
+	Target     string    `json:"-"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// Dispatcher routes each event to the notifier registered for its channel.
+type Dispatcher struct {
+	notifiers map[string]Notifier
+}
+
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{notifiers: map[string]Notifier{}}
+}
+
+func (d *Dispatcher) Register(channel string, notifier Notifier) {
+	d.notifiers[channel] = notifier
+}
+
+func (d *Dispatcher) Notify(ctx context.Context, event Event) error {
+	notifier, ok := d.notifiers[event.Channel]
+	if !ok {
+		return ErrChannelNotConfigured
+	}
+
+	return notifier.Notify(ctx, event)
+}
@@ -0,0 +1,151 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+var ErrForbiddenTarget = errors.New("webhook target is not a public address")
+
+// nonPublicNetworks are the blocks not covered by the net.IP checks of publicIP that webhooks can't reach.
+var nonPublicNetworks = parseCIDRs("0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4", "64:ff9b::/96")
+
+// WebhookNotifier posts the event as json to the url stored on the reminder target.
+// When a secret is set the body is signed with HMAC-SHA256 on the X-Signature header.
+// Targets are user supplied, so connections are only made to public addresses and redirects are not followed.
+type WebhookNotifier struct {
+	client *http.Client
+	secret string
+}
+
+func NewWebhookNotifier(secret string) WebhookNotifier {
+	return newWebhookNotifier(secret, publicIP)
+}
+
+// newWebhookNotifier builds a notifier that only connects to the addresses accepted by allowed.
+func newWebhookNotifier(secret string, allowed func(ip net.IP) bool) WebhookNotifier {
+	// The address is checked once resolved, so names pointing to internal addresses are refused as well
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network string, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return fmt.Errorf("%w: %s", ErrForbiddenTarget, host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return WebhookNotifier{
+		client: &http.Client{
+			Timeout:   webhookTimeout,
+			Transport: transport,
+			CheckRedirect: func(request *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		secret: secret,
+	}
+}
+
+// ValidWebhookTarget checks target is an http(s) url that does not name a local or private host. Names are
+// resolved when delivering, where the address they point to is checked again.
+func ValidWebhookTarget(target string) bool {
+	parsed, err := url.Parse(target)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.User != nil {
+		return false
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return publicIP(ip)
+	}
+	return true
+}
+
+// publicIP tells whether ip is reachable on the internet, and not a loopback, private, link local (like cloud
+// metadata services) or otherwise reserved address.
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+func (w WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, event.Target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Idempotency-Key", event.DeliveryID)
+
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		request.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	// Redirects are answered as they come, and are failures as well
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,464 @@
+// Package quickadd turns the free text typed on the quick add box into the fields of a list item.
+//
+// A line such as "2 kg tomatoes tomorrow !high @ana #veggies" yields a quantity, a unit, a title,
+// a due date, a priority, an assignee and tags. Keywords are understood both in Spanish and English.
+package quickadd
+
+import (
+	"github.com/shopspring/decimal"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	PriorityLow    = "low"
+	PriorityMedium = "medium"
+	PriorityHigh   = "high"
+	PriorityUrgent = "urgent"
+)
+
+// Result holds everything understood from a quick add line. Units use the same codes as list items.
+type Result struct {
+	Title    string           `json:"title"`
+	Quantity *decimal.Decimal `json:"quantity,omitempty"`
+	Unit     string           `json:"unit,omitempty"`
+	DueAt    *time.Time       `json:"due_at,omitempty"`
+	AllDay   bool             `json:"all_day,omitempty"`
+	Priority string           `json:"priority,omitempty"`
+	Assignee string           `json:"assignee,omitempty"`
+	Tags     []string         `json:"tags,omitempty"`
+}
+
+var units = map[string]string{
+	"mg": "mg", "miligramo": "mg", "miligramos": "mg", "milligram": "mg", "milligrams": "mg",
+	"g": "g", "gr": "g", "grs": "g", "gramo": "g", "gramos": "g", "gram": "g", "grams": "g",
+	"kg": "kg", "kgs": "kg", "kilo": "kg", "kilos": "kg", "kilogramo": "kg", "kilogramos": "kg", "kilogram": "kg", "kilograms": "kg",
+	"ml": "ml", "mililitro": "ml", "mililitros": "ml", "millilitre": "ml", "millilitres": "ml", "milliliter": "ml", "milliliters": "ml",
+	"cl": "cl", "centilitro": "cl", "centilitros": "cl",
+	"l": "l", "lt": "l", "lts": "l", "litro": "l", "litros": "l", "litre": "l", "litres": "l", "liter": "l", "liters": "l",
+	"u": "pcs", "un": "pcs", "unidad": "pcs", "unidades": "pcs", "pcs": "pcs", "pc": "pcs", "piece": "pcs", "pieces": "pcs",
+	"docena": "dozen", "docenas": "dozen", "dozen": "dozen", "dozens": "dozen",
+}
+
+var priorities = map[string]string{
+	"low": PriorityLow, "baja": PriorityLow, "bajo": PriorityLow,
+	"medium": PriorityMedium, "media": PriorityMedium, "medio": PriorityMedium, "normal": PriorityMedium,
+	"high": PriorityHigh, "alta": PriorityHigh, "alto": PriorityHigh, "!": PriorityHigh,
+	"urgent": PriorityUrgent, "urgente": PriorityUrgent, "!!": PriorityUrgent,
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "domingo": time.Sunday,
+	"monday": time.Monday, "lunes": time.Monday,
+	"tuesday": time.Tuesday, "martes": time.Tuesday,
+	"wednesday": time.Wednesday, "miercoles": time.Wednesday,
+	"thursday": time.Thursday, "jueves": time.Thursday,
+	"friday": time.Friday, "viernes": time.Friday,
+	"saturday": time.Saturday, "sabado": time.Saturday,
+}
+
+// relativeDays are the words that point to a day counted from today.
+var relativeDays = map[string]int{
+	"today": 0, "hoy": 0,
+	"tomorrow": 1, "manana": 1,
+	"pasado manana": 2, "day after tomorrow": 2,
+}
+
+// connectors are dropped when they introduce a date or a time, "el lunes" or "at 18:00" for example.
+var connectors = map[string]bool{
+	"on": true, "next": true, "this": true, "at": true, "by": true,
+	"el": true, "este": true, "proximo": true, "a": true, "las": true, "para": true,
+}
+
+// fillers are dropped between a quantity and the item name, as in "2 kg de tomates".
+var fillers = map[string]bool{"de": true, "of": true, "x": true}
+
+type parser struct {
+	now    time.Time
+	tokens []string
+	result Result
+
+	date    time.Time
+	hasDate bool
+	hour    int
+	minute  int
+	hasTime bool
+}
+
+// Parse reads a quick add line. Relative dates such as "tomorrow" or "el viernes" are resolved against now,
+// on now's location.
+func Parse(text string, now time.Time) Result {
+	p := parser{now: now, tokens: strings.Fields(text)}
+	p.parse()
+	return p.result
+}
+
+func (p *parser) parse() {
+	var title []string
+
+	p.parseQuantity()
+
+	for i := 0; i < len(p.tokens); {
+		token := p.tokens[i]
+
+		if consumed := p.parseMarker(token); consumed {
+			i++
+			continue
+		}
+
+		if consumed := p.parseWhen(i); consumed > 0 {
+			i += consumed
+			continue
+		}
+
+		title = append(title, token)
+		i++
+	}
+
+	for len(title) > 0 && fillers[fold(title[0])] && p.result.Quantity != nil {
+		title = title[1:]
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	p.resolveDueAt()
+}
+
+// parseQuantity takes a leading amount, optionally glued to or followed by its unit: "2 kg", "2kg", "1,5 l" or "x3".
+func (p *parser) parseQuantity() {
+	if len(p.tokens) == 0 {
+		return
+	}
+
+	first := strings.ToLower(p.tokens[0])
+	first = strings.TrimPrefix(first, "x")
+
+	digits := strings.IndexFunc(first, func(r rune) bool {
+		return !unicode.IsDigit(r) && r != '.' && r != ',' && r != '/'
+	})
+	number, unit := first, ""
+	if digits >= 0 {
+		number, unit = first[:digits], first[digits:]
+	}
+
+	quantity, ok := parseNumber(number)
+	if !ok {
+		return
+	}
+
+	if unit != "" {
+		code, known := units[fold(unit)]
+		if !known {
+			return
+		}
+		p.result.Unit = code
+		p.tokens = p.tokens[1:]
+	} else {
+		p.tokens = p.tokens[1:]
+		if len(p.tokens) > 0 {
+			if code, known := units[fold(trimPunctuation(p.tokens[0]))]; known {
+				p.result.Unit = code
+				p.tokens = p.tokens[1:]
+			}
+		}
+	}
+
+	p.result.Quantity = &quantity
+}
+
+// parseMarker handles the single token markers: #tag, @assignee and !priority.
+func (p *parser) parseMarker(token string) bool {
+	if len(token) < 2 {
+		return false
+	}
+
+	switch token[0] {
+	case '#':
+		tag := strings.ToLower(trimPunctuation(token[1:]))
+		if tag == "" {
+			return false
+		}
+		for _, existing := range p.result.Tags {
+			if existing == tag {
+				return true
+			}
+		}
+		p.result.Tags = append(p.result.Tags, tag)
+		return true
+	case '@':
+		assignee := trimPunctuation(token[1:])
+		if assignee == "" {
+			return false
+		}
+		if p.result.Assignee == "" {
+			p.result.Assignee = assignee
+		}
+		return true
+	case '!':
+		priority, ok := priorities[fold(token[1:])]
+		if !ok {
+			return false
+		}
+		p.result.Priority = priority
+		return true
+	}
+
+	return false
+}
+
+// parseWhen recognises a date or a time starting at tokens[i] and returns how many tokens it used.
+func (p *parser) parseWhen(i int) int {
+	start := i
+	for i < len(p.tokens) && connectors[fold(p.tokens[i])] {
+		i++
+	}
+	if i == len(p.tokens) {
+		return 0
+	}
+
+	consumed := 0
+	if !p.hasDate {
+		consumed = p.parseDate(i)
+	}
+	if consumed == 0 && !p.hasTime {
+		consumed = p.parseTime(i)
+	}
+	if consumed == 0 {
+		return 0
+	}
+
+	return i - start + consumed
+}
+
+func (p *parser) parseDate(i int) int {
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+
+	for size := 3; size >= 1; size-- {
+		if i+size > len(p.tokens) {
+			continue
+		}
+		phrase := fold(trimPunctuation(strings.Join(p.tokens[i:i+size], " ")))
+		if days, ok := relativeDays[phrase]; ok {
+			p.setDate(today.AddDate(0, 0, days))
+			return size
+		}
+	}
+
+	word := fold(trimPunctuation(p.tokens[i]))
+
+	if weekday, ok := weekdays[word]; ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		p.setDate(today.AddDate(0, 0, days))
+		return 1
+	}
+
+	// "in 3 days", "en 2 semanas"
+	if (word == "in" || word == "en") && i+2 < len(p.tokens) {
+		amount, err := strconv.Atoi(p.tokens[i+1])
+		if err == nil && amount >= 0 {
+			switch fold(p.tokens[i+2]) {
+			case "day", "days", "dia", "dias":
+				p.setDate(today.AddDate(0, 0, amount))
+				return 3
+			case "week", "weeks", "semana", "semanas":
+				p.setDate(today.AddDate(0, 0, 7*amount))
+				return 3
+			}
+		}
+	}
+
+	if date, ok := parseCalendarDate(trimPunctuation(p.tokens[i]), today); ok {
+		p.setDate(date)
+		return 1
+	}
+
+	return 0
+}
+
+// parseCalendarDate reads ISO dates (2024-03-15) and day first dates (15/03, 15/03/2024). A day first date
+// without year points to its next occurrence.
+func parseCalendarDate(token string, today time.Time) (time.Time, bool) {
+	if date, err := time.ParseInLocation("2006-01-02", token, today.Location()); err == nil {
+		return date, true
+	}
+
+	parts := strings.Split(token, "/")
+	if len(parts) < 2 || len(parts) > 3 {
+		return time.Time{}, false
+	}
+
+	day, errDay := strconv.Atoi(parts[0])
+	month, errMonth := strconv.Atoi(parts[1])
+	if errDay != nil || errMonth != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+
+	year := today.Year()
+	if len(parts) == 3 {
+		parsedYear, err := strconv.Atoi(parts[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		if parsedYear < 100 {
+			parsedYear += 2000
+		}
+		year = parsedYear
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, today.Location())
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	if len(parts) == 2 && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+
+	return date, true
+}
+
+// parseTime reads 18:30, 18hs, 18h, 6pm and 6:30pm. A bare number is only taken as an hour
+// when it follows "at" or "a las".
+func (p *parser) parseTime(i int) int {
+	token := strings.ToLower(trimPunctuation(p.tokens[i]))
+
+	consumed := 1
+	if i+1 < len(p.tokens) {
+		next := strings.ToLower(trimPunctuation(p.tokens[i+1]))
+		if next == "am" || next == "pm" || next == "hs" || next == "h" {
+			token += next
+			consumed = 2
+		}
+	}
+
+	suffix := ""
+	for _, candidate := range []string{"am", "pm", "hs", "h"} {
+		if strings.HasSuffix(token, candidate) {
+			suffix = candidate
+			token = strings.TrimSuffix(token, candidate)
+			break
+		}
+	}
+
+	hourPart, minutePart, hasMinutes := token, "", false
+	if separator := strings.Index(token, ":"); separator >= 0 {
+		hourPart, minutePart, hasMinutes = token[:separator], token[separator+1:], true
+	}
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil {
+		return 0
+	}
+
+	minute := 0
+	if hasMinutes {
+		minute, err = strconv.Atoi(minutePart)
+		if err != nil || len(minutePart) != 2 {
+			return 0
+		}
+	}
+
+	if suffix == "" && !hasMinutes && !p.afterTimeConnector(i) {
+		return 0
+	}
+
+	switch suffix {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return 0
+	}
+
+	p.hour, p.minute, p.hasTime = hour, minute, true
+	return consumed
+}
+
+func (p *parser) afterTimeConnector(i int) bool {
+	if i == 0 {
+		return false
+	}
+	previous := fold(p.tokens[i-1])
+	return previous == "at" || previous == "las"
+}
+
+func (p *parser) setDate(date time.Time) {
+	p.date = date
+	p.hasDate = true
+}
+
+// resolveDueAt joins the date and time parts. A time without a date means its next occurrence.
+func (p *parser) resolveDueAt() {
+	if !p.hasDate && !p.hasTime {
+		return
+	}
+
+	if !p.hasTime {
+		dueAt := p.date
+		p.result.DueAt = &dueAt
+		p.result.AllDay = true
+		return
+	}
+
+	date := p.date
+	if !p.hasDate {
+		date = time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	}
+
+	dueAt := time.Date(date.Year(), date.Month(), date.Day(), p.hour, p.minute, 0, 0, date.Location())
+	if !p.hasDate && dueAt.Before(p.now) {
+		dueAt = dueAt.AddDate(0, 0, 1)
+	}
+
+	p.result.DueAt = &dueAt
+}
+
+func parseNumber(token string) (decimal.Decimal, bool) {
+	if token == "" {
+		return decimal.Zero, false
+	}
+
+	// Only proper fractions such as 1/2 are amounts, 15/03 is a date.
+	if separator := strings.Index(token, "/"); separator >= 0 {
+		numerator, errN := decimal.NewFromString(token[:separator])
+		denominator, errD := decimal.NewFromString(token[separator+1:])
+		if errN != nil || errD != nil || !numerator.IsPositive() || numerator.GreaterThanOrEqual(denominator) {
+			return decimal.Zero, false
+		}
+		return numerator.DivRound(denominator, 3), true
+	}
+
+	number, err := decimal.NewFromString(strings.Replace(token, ",", ".", 1))
+	if err != nil || !number.IsPositive() {
+		return decimal.Zero, false
+	}
+
+	return number, true
+}
+
+// fold lowercases and strips accents so "Mañana" and "manana" or "Sábado" and "sabado" read the same.
+func fold(text string) string {
+	withoutAccents, _, err := transform.String(transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC), text)
+	if err != nil {
+		withoutAccents = text
+	}
+	return strings.ToLower(withoutAccents)
+}
+
+func trimPunctuation(text string) string {
+	return strings.TrimRightFunc(text, func(r rune) bool {
+		return unicode.IsPunct(r) && r != '/' && r != ':'
+	})
+}
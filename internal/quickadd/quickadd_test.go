@@ -0,0 +1,196 @@
+package quickadd
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// now is a Wednesday afternoon.
+var now = time.Date(2024, time.March, 13, 15, 30, 0, 0, time.UTC)
+
+func date(year int, month time.Month, day, hour, minute int) *time.Time {
+	d := time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	return &d
+}
+
+func quantity(value string) *decimal.Decimal {
+	q := decimal.RequireFromString(value)
+	return &q
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Result
+	}{
+		{
+			name:  "Everything at once",
+			input: "2 kg tomatoes tomorrow !high @ana #veggies",
+			want: Result{
+				Title:    "tomatoes",
+				Quantity: quantity("2"),
+				Unit:     "kg",
+				DueAt:    date(2024, time.March, 14, 0, 0),
+				AllDay:   true,
+				Priority: PriorityHigh,
+				Assignee: "ana",
+				Tags:     []string{"veggies"},
+			},
+		},
+		{
+			name:  "Spanish keywords",
+			input: "1,5 litros de leche mañana a las 18 !alta @Juan #lacteos",
+			want: Result{
+				Title:    "leche",
+				Quantity: quantity("1.5"),
+				Unit:     "l",
+				DueAt:    date(2024, time.March, 14, 18, 0),
+				Priority: PriorityHigh,
+				Assignee: "Juan",
+				Tags:     []string{"lacteos"},
+			},
+		},
+		{
+			name:  "Plain title",
+			input: "Pan lactal",
+			want:  Result{Title: "Pan lactal"},
+		},
+		{
+			name:  "Unit glued to the amount",
+			input: "500g queso rallado",
+			want:  Result{Title: "queso rallado", Quantity: quantity("500"), Unit: "g"},
+		},
+		{
+			name:  "Amount without unit",
+			input: "6 huevos",
+			want:  Result{Title: "huevos", Quantity: quantity("6")},
+		},
+		{
+			name:  "Multiplier amount",
+			input: "x3 yogures",
+			want:  Result{Title: "yogures", Quantity: quantity("3")},
+		},
+		{
+			name:  "Fraction amount",
+			input: "1/2 docena facturas",
+			want:  Result{Title: "facturas", Quantity: quantity("0.5"), Unit: "dozen"},
+		},
+		{
+			name:  "Weekday in Spanish with accent",
+			input: "Pagar la luz el sábado",
+			want:  Result{Title: "Pagar la luz", DueAt: date(2024, time.March, 16, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Same weekday means next week",
+			input: "call mom next wednesday",
+			want:  Result{Title: "call mom", DueAt: date(2024, time.March, 20, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Day after tomorrow",
+			input: "comprar regalo pasado mañana",
+			want:  Result{Title: "comprar regalo", DueAt: date(2024, time.March, 15, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Relative amount of days",
+			input: "renew passport in 3 days",
+			want:  Result{Title: "renew passport", DueAt: date(2024, time.March, 16, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Relative amount of weeks in Spanish",
+			input: "turno dentista en 2 semanas",
+			want:  Result{Title: "turno dentista", DueAt: date(2024, time.March, 27, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Day first date",
+			input: "vencimiento tarjeta 20/03",
+			want:  Result{Title: "vencimiento tarjeta", DueAt: date(2024, time.March, 20, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Day first date already gone rolls to next year",
+			input: "cumple abuela 01/02",
+			want:  Result{Title: "cumple abuela", DueAt: date(2025, time.February, 1, 0, 0), AllDay: true},
+		},
+		{
+			name:  "Iso date and pm time",
+			input: "dinner 2024-04-01 at 8:30pm",
+			want:  Result{Title: "dinner", DueAt: date(2024, time.April, 1, 20, 30)},
+		},
+		{
+			name:  "Time already gone today moves to tomorrow",
+			input: "sacar la basura 9:00",
+			want:  Result{Title: "sacar la basura", DueAt: date(2024, time.March, 14, 9, 0)},
+		},
+		{
+			name:  "Time later today",
+			input: "buy bread 18hs",
+			want:  Result{Title: "buy bread", DueAt: date(2024, time.March, 13, 18, 0)},
+		},
+		{
+			name:  "Urgent with repeated bangs and several tags",
+			input: "medicamentos !!! #farmacia #Salud #farmacia",
+			want:  Result{Title: "medicamentos", Priority: PriorityUrgent, Tags: []string{"farmacia", "salud"}},
+		},
+		{
+			name:  "Unknown markers stay on the title",
+			input: "Hola! @ # !wow",
+			want:  Result{Title: "Hola! @ # !wow"},
+		},
+		{
+			name:  "Numbers in the middle are part of the title",
+			input: "pilas AA 4 unidades",
+			want:  Result{Title: "pilas AA 4 unidades"},
+		},
+		{
+			name:  "Connectors without a date stay on the title",
+			input: "pan a la francesa",
+			want:  Result{Title: "pan a la francesa"},
+		},
+		{
+			name:  "Only the first assignee is kept",
+			input: "limpiar @ana @juan",
+			want:  Result{Title: "limpiar", Assignee: "ana"},
+		},
+		{
+			name:  "Empty input",
+			input: "   ",
+			want:  Result{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.input, now)
+
+			assert.Equal(t, tt.want.Title, got.Title)
+			assert.Equal(t, tt.want.Unit, got.Unit)
+			assert.Equal(t, tt.want.Priority, got.Priority)
+			assert.Equal(t, tt.want.Assignee, got.Assignee)
+			assert.Equal(t, tt.want.Tags, got.Tags)
+			assert.Equal(t, tt.want.AllDay, got.AllDay)
+
+			if tt.want.Quantity == nil {
+				assert.Nil(t, got.Quantity)
+			} else if assert.NotNil(t, got.Quantity) {
+				assert.True(t, tt.want.Quantity.Equal(*got.Quantity), "quantity %s, want %s", got.Quantity, tt.want.Quantity)
+			}
+
+			if tt.want.DueAt == nil {
+				assert.Nil(t, got.DueAt)
+			} else if assert.NotNil(t, got.DueAt) {
+				assert.True(t, tt.want.DueAt.Equal(*got.DueAt), "due at %s, want %s", got.DueAt, tt.want.DueAt)
+			}
+		})
+	}
+}
+
+func TestParse_Uses_Location_Of_Now(t *testing.T) {
+	buenosAires := time.FixedZone("ART", -3*60*60)
+	// Still Wednesday in Buenos Aires while it is already Thursday in UTC.
+	localNow := time.Date(2024, time.March, 13, 23, 0, 0, 0, buenosAires)
+
+	got := Parse("llamar hoy", localNow)
+
+	assert.Equal(t, time.Date(2024, time.March, 13, 0, 0, 0, 0, buenosAires), *got.DueAt)
+}
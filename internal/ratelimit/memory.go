@@ -0,0 +1,61 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in the process memory, so each replica limits on its own. Buckets that filled up
+// again are dropped every sweepInterval, as a full bucket is the same as a missing one.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*memoryBucket
+	lastSweep time.Time
+}
+
+type memoryBucket struct {
+	bucket
+	limit Limit
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]*memoryBucket{}}
+}
+
+func (ms *MemoryStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if now.Sub(ms.lastSweep) >= sweepInterval {
+		ms.sweep(now)
+	}
+
+	b, ok := ms.buckets[key]
+	if !ok {
+		b = &memoryBucket{bucket: newBucket(limit, now), limit: limit}
+		ms.buckets[key] = b
+	}
+	b.limit = limit
+
+	return b.take(limit, now), nil
+}
+
+// Len returns the amount of buckets kept.
+func (ms *MemoryStore) Len() int {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return len(ms.buckets)
+}
+
+func (ms *MemoryStore) sweep(now time.Time) {
+	for key, b := range ms.buckets {
+		b.refill(b.limit, now)
+		if b.tokens >= float64(b.limit.Requests) {
+			delete(ms.buckets, key)
+		}
+	}
+	ms.lastSweep = now
+}
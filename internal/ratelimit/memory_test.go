@@ -0,0 +1,44 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore_Take(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 1, Per: time.Minute}
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	result, err := store.Take(ctx, "login:ip:10.0.0.1", limit, now)
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	result, _ = store.Take(ctx, "login:ip:10.0.0.1", limit, now)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, time.Minute, result.RetryAfter)
+
+	// Every key has its own bucket
+	result, _ = store.Take(ctx, "login:ip:10.0.0.2", limit, now)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, 2, store.Len())
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	_, _ = store.Take(ctx, "api:user:1", Limit{Requests: 10, Per: time.Minute}, now)
+	_, _ = store.Take(ctx, "bulk:user:1", Limit{Requests: 1, Per: time.Hour}, now)
+
+	// After a minute the api bucket is full again and dropped, the bulk one is still empty
+	_, _ = store.Take(ctx, "api:user:2", Limit{Requests: 10, Per: time.Minute}, now.Add(time.Minute))
+
+	assert.Equal(t, 2, store.Len())
+	result, _ := store.Take(ctx, "bulk:user:1", Limit{Requests: 1, Per: time.Hour}, now.Add(time.Minute))
+	assert.False(t, result.Allowed)
+}
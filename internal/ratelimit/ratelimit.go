@@ -0,0 +1,97 @@
+// Package ratelimit keeps token buckets that limit how often a client can make a request. Buckets live on a Store,
+// in the process memory for now, so replicas could later share them on a common server.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit allows bursts of up to Requests requests, refilled at Requests per Per.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// ParseLimit reads a limit written as requests/duration, like "10/1m".
+func ParseLimit(value string) (Limit, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, want requests/duration", value)
+	}
+
+	requests, err := strconv.Atoi(parts[0])
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, requests must be a positive number", value)
+	}
+
+	per, err := time.ParseDuration(parts[1])
+	if err != nil || per <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, duration must be positive", value)
+	}
+
+	return Limit{Requests: requests, Per: per}, nil
+}
+
+// Result is the state of a bucket after taking a token from it. Reset is the time until the bucket is full again
+// and RetryAfter, only set when the request was not allowed, the time until a token is available.
+type Result struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Store keeps a bucket per key. Take must refill and take from the bucket atomically, as concurrent requests of
+// a client share its bucket.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// bucket is a token bucket as of updated, it starts full.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newBucket(limit Limit, now time.Time) bucket {
+	return bucket{tokens: float64(limit.Requests), updated: now}
+}
+
+// refill adds the tokens earned since the last update, up to the limit.
+func (b *bucket) refill(limit Limit, now time.Time) {
+	if now.After(b.updated) {
+		b.tokens = math.Min(float64(limit.Requests), b.tokens+now.Sub(b.updated).Seconds()*rate(limit))
+		b.updated = now
+	}
+}
+
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	b.refill(limit, now)
+
+	result := Result{Allowed: b.tokens >= 1}
+	if result.Allowed {
+		b.tokens--
+	} else {
+		result.RetryAfter = timeToEarn(limit, 1-b.tokens)
+	}
+
+	result.Remaining = int(b.tokens)
+	result.Reset = timeToEarn(limit, float64(limit.Requests)-b.tokens)
+
+	return result
+}
+
+// rate is the amount of tokens earned per second.
+func rate(limit Limit) float64 {
+	return float64(limit.Requests) / limit.Per.Seconds()
+}
+
+// timeToEarn is the time it takes to earn tokens.
+func timeToEarn(limit Limit, tokens float64) time.Duration {
+	return time.Duration(tokens / rate(limit) * float64(time.Second))
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Limit
+		wantErr bool
+	}{
+		{value: "10/1m", want: Limit{Requests: 10, Per: time.Minute}},
+		{value: "300/30s", want: Limit{Requests: 300, Per: 30 * time.Second}},
+		{value: "10", wantErr: true},
+		{value: "0/1m", wantErr: true},
+		{value: "ten/1m", wantErr: true},
+		{value: "10/minute", wantErr: true},
+		{value: "10/-1m", wantErr: true},
+		{value: "10/1m/1h", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseLimit(tt.value)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBucket_Take(t *testing.T) {
+	limit := Limit{Requests: 2, Per: time.Minute}
+	now := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	b := newBucket(limit, now)
+
+	assert.Equal(t, Result{Allowed: true, Remaining: 1, Reset: 30 * time.Second}, b.take(limit, now))
+	assert.Equal(t, Result{Allowed: true, Remaining: 0, Reset: time.Minute}, b.take(limit, now))
+	assert.Equal(t, Result{Allowed: false, Remaining: 0, Reset: time.Minute, RetryAfter: 30 * time.Second}, b.take(limit, now))
+
+	// A token is earned every 30 seconds
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, Result{Allowed: false, Remaining: 0, Reset: 40 * time.Second, RetryAfter: 10 * time.Second}, b.take(limit, now))
+
+	now = now.Add(10 * time.Second)
+	assert.Equal(t, Result{Allowed: true, Remaining: 0, Reset: time.Minute}, b.take(limit, now))
+
+	// The bucket does not fill past the limit
+	now = now.Add(time.Hour)
+	assert.Equal(t, Result{Allowed: true, Remaining: 1, Reset: 30 * time.Second}, b.take(limit, now))
+}
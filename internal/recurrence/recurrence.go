@@ -0,0 +1,353 @@
+// Package recurrence implements the subset of RFC 5545 recurrence rules used by recurring items and lists:
+// DAILY, WEEKLY with BYDAY and MONTHLY with BYMONTHDAY, along with INTERVAL and UNTIL.
+//
+// Occurrences keep the wall clock time of the first one on its time zone, so a 09:00 weekly chore stays at
+// 09:00 across daylight saving changes. As RFC 5545 asks, a local time skipped by a DST gap is moved forward
+// by the length of the gap, an ambiguous one resolves to its first instance, and monthly days that do not
+// exist on a month, such as the 31st, are skipped for that month.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// maxPeriods bounds the search for the next occurrence, a rule like the 31st every 2 months can go
+// several periods without occurrences but never this many.
+const maxPeriods = 1000
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+type Rule struct {
+	Freq       string
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Until      *time.Time
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH". The "RRULE:" prefix is optional.
+func Parse(value string) (Rule, error) {
+	rule := Rule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	if value == "" {
+		return Rule{}, ErrInvalidRule
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		separator := strings.Index(part, "=")
+		if separator < 1 {
+			return Rule{}, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+		key, val := part[:separator], part[separator+1:]
+
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = val
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(val)
+			if err == nil && (rule.Interval < 1 || rule.Interval > 1000) {
+				err = ErrInvalidRule
+			}
+		case "BYDAY":
+			rule.ByDay, err = parseByDay(val)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseByMonthDay(val)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseUntil(val)
+			rule.Until = &until
+		case "WKST":
+			if val != "MO" {
+				err = ErrInvalidRule
+			}
+		default:
+			err = ErrInvalidRule
+		}
+
+		if err != nil {
+			return Rule{}, fmt.Errorf("%w: %q", ErrInvalidRule, part)
+		}
+	}
+
+	switch rule.Freq {
+	case Daily:
+		if len(rule.ByDay) > 0 || len(rule.ByMonthDay) > 0 {
+			return Rule{}, fmt.Errorf("%w: DAILY does not take BYDAY or BYMONTHDAY", ErrInvalidRule)
+		}
+	case Weekly:
+		if len(rule.ByMonthDay) > 0 {
+			return Rule{}, fmt.Errorf("%w: WEEKLY does not take BYMONTHDAY", ErrInvalidRule)
+		}
+	case Monthly:
+		if len(rule.ByDay) > 0 {
+			return Rule{}, fmt.Errorf("%w: MONTHLY only takes BYMONTHDAY", ErrInvalidRule)
+		}
+	default:
+		return Rule{}, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, rule.Freq)
+	}
+
+	return rule, nil
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+	seen := map[time.Weekday]bool{}
+
+	for _, code := range strings.Split(value, ",") {
+		day, ok := weekdays[code]
+		if !ok {
+			return nil, ErrInvalidRule
+		}
+		if !seen[day] {
+			seen[day] = true
+			days = append(days, day)
+		}
+	}
+
+	// Weeks start on monday, so sunday sorts last.
+	sort.Slice(days, func(i, j int) bool {
+		return (days[i]+6)%7 < (days[j]+6)%7
+	})
+
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var days []int
+
+	for _, raw := range strings.Split(value, ",") {
+		day, err := strconv.Atoi(raw)
+		if err != nil || day == 0 || day < -31 || day > 31 {
+			return nil, ErrInvalidRule
+		}
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				// A date UNTIL includes the whole day.
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, ErrInvalidRule
+}
+
+// Location loads an IANA time zone, an empty name is UTC.
+func Location(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// String renders the rule back on its RRULE form.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			codes = append(codes, weekdayCodes[day])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after after, for the series whose first occurrence is start,
+// on the time zone loc. The second value is false when the series ended.
+func (r Rule) Next(start time.Time, after time.Time, loc *time.Location) (time.Time, bool) {
+	start = start.In(loc)
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	// Jump close to after instead of walking every period since start.
+	first := 0
+	if elapsed := r.periodsBetween(start, after.In(loc)); elapsed > 1 {
+		first = (elapsed - 1) / interval * interval
+	}
+
+	for period := first; period < first+maxPeriods*interval; period += interval {
+		for _, occurrence := range r.occurrences(start, period, loc) {
+			if occurrence.Before(start) || !occurrence.After(after) {
+				continue
+			}
+			if r.Until != nil && occurrence.After(*r.Until) {
+				return time.Time{}, false
+			}
+			return occurrence, true
+		}
+		if r.Until != nil && r.periodStart(start, period, loc).After(*r.Until) {
+			return time.Time{}, false
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// occurrences lists, in order, the occurrences of the period-th day, week or month counted from start.
+func (r Rule) occurrences(start time.Time, period int, loc *time.Location) []time.Time {
+	year, month, day := start.Date()
+	hour, min, sec := start.Clock()
+
+	at := func(y int, m time.Month, d int) time.Time {
+		return wallClock(y, m, d, hour, min, sec, loc)
+	}
+
+	switch r.Freq {
+	case Daily:
+		return []time.Time{at(year, month, day+period)}
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{start.Weekday()}
+		}
+		monday := day - mondayOffset(start.Weekday()) + period*7
+		occurrences := make([]time.Time, 0, len(days))
+		for _, weekday := range days {
+			occurrences = append(occurrences, at(year, month, monday+mondayOffset(weekday)))
+		}
+		return occurrences
+	case Monthly:
+		monthDays := r.ByMonthDay
+		if len(monthDays) == 0 {
+			monthDays = []int{day}
+		}
+		targetYear, targetMonth := addMonths(year, month, period)
+		length := daysIn(targetYear, targetMonth)
+
+		var resolved []int
+		for _, monthDay := range monthDays {
+			if monthDay < 0 {
+				monthDay = length + monthDay + 1
+			}
+			if monthDay >= 1 && monthDay <= length {
+				resolved = append(resolved, monthDay)
+			}
+		}
+		sort.Ints(resolved)
+
+		occurrences := make([]time.Time, 0, len(resolved))
+		for i, monthDay := range resolved {
+			if i > 0 && resolved[i-1] == monthDay {
+				continue
+			}
+			occurrences = append(occurrences, at(targetYear, targetMonth, monthDay))
+		}
+		return occurrences
+	}
+
+	return nil
+}
+
+// wallClock is time.Date, except that a local time skipped by a DST gap is read with the offset in use before
+// the gap, which moves it forward by the gap length. time.Date leaves that case unspecified.
+func wallClock(year int, month time.Month, day, hour, min, sec int, loc *time.Location) time.Time {
+	t := time.Date(year, month, day, hour, min, sec, 0, loc)
+	if t.Hour() == hour && t.Minute() == min {
+		return t
+	}
+
+	_, offsetBefore := t.Add(-12 * time.Hour).Zone()
+	naive := time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+
+	return naive.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+}
+
+func (r Rule) periodStart(start time.Time, period int, loc *time.Location) time.Time {
+	year, month, day := start.Date()
+
+	switch r.Freq {
+	case Weekly:
+		return time.Date(year, month, day-mondayOffset(start.Weekday())+period*7, 0, 0, 0, 0, loc)
+	case Monthly:
+		targetYear, targetMonth := addMonths(year, month, period)
+		return time.Date(targetYear, targetMonth, 1, 0, 0, 0, 0, loc)
+	}
+
+	return time.Date(year, month, day+period, 0, 0, 0, 0, loc)
+}
+
+// periodsBetween counts the whole days, weeks or months between the calendar dates of start and end.
+func (r Rule) periodsBetween(start time.Time, end time.Time) int {
+	days := civilDays(end) - civilDays(start)
+
+	switch r.Freq {
+	case Weekly:
+		return (days + mondayOffset(start.Weekday())) / 7
+	case Monthly:
+		return (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+	}
+
+	return days
+}
+
+// civilDays numbers calendar dates, ignoring the time zone offset so DST changes do not shift the count.
+func civilDays(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func addMonths(year int, month time.Month, months int) (int, time.Month) {
+	total := int(month) - 1 + months
+	return year + total/12, time.Month(total%12 + 1)
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
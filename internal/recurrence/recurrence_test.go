@@ -0,0 +1,382 @@
+package recurrence
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("loading %s: %s", name, err)
+	}
+	return loc
+}
+
+func TestParse(t *testing.T) {
+	until := time.Date(2022, 12, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  Rule
+	}{
+		{
+			name:  "Daily",
+			value: "FREQ=DAILY",
+			want:  Rule{Freq: Daily, Interval: 1},
+		},
+		{
+			name:  "Prefix and lower case",
+			value: "rrule:freq=daily;interval=3",
+			want:  Rule{Freq: Daily, Interval: 3},
+		},
+		{
+			name:  "Weekly days are sorted from monday",
+			value: "FREQ=WEEKLY;BYDAY=SU,WE,MO,WE",
+			want:  Rule{Freq: Weekly, Interval: 1, ByDay: []time.Weekday{time.Monday, time.Wednesday, time.Sunday}},
+		},
+		{
+			name:  "Monthly with last day",
+			value: "FREQ=MONTHLY;BYMONTHDAY=1,-1",
+			want:  Rule{Freq: Monthly, Interval: 1, ByMonthDay: []int{1, -1}},
+		},
+		{
+			name:  "Until date includes the whole day",
+			value: "FREQ=DAILY;UNTIL=20221231",
+			want:  Rule{Freq: Daily, Interval: 1, Until: &until},
+		},
+		{
+			name:  "Until date time",
+			value: "FREQ=DAILY;UNTIL=20221231T235959Z;WKST=MO",
+			want:  Rule{Freq: Daily, Interval: 1, Until: &until},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"FREQ=YEARLY",
+		"FREQ=HOURLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;INTERVAL=x",
+		"FREQ=DAILY;BYDAY=MO",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYDAY=MO",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=DAILY;UNTIL=tomorrow",
+		"FREQ=DAILY;WKST=SU",
+		"FREQ",
+	} {
+		t.Run(value, func(t *testing.T) {
+			_, err := Parse(value)
+
+			assert.True(t, errors.Is(err, ErrInvalidRule), "got %v", err)
+		})
+	}
+}
+
+func TestRule_String_Round_Trips(t *testing.T) {
+	for _, value := range []string{
+		"FREQ=DAILY",
+		"FREQ=DAILY;INTERVAL=2",
+		"FREQ=WEEKLY;BYDAY=MO,WE,SU",
+		"FREQ=MONTHLY;INTERVAL=3;BYMONTHDAY=15,-1",
+		"FREQ=WEEKLY;UNTIL=20221231T120000Z",
+	} {
+		t.Run(value, func(t *testing.T) {
+			rule, err := Parse(value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, value, rule.String())
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	utc := time.UTC
+	// 2022-03-01 is a tuesday.
+	start := time.Date(2022, 3, 1, 9, 0, 0, 0, utc)
+
+	tests := []struct {
+		name  string
+		rule  string
+		after time.Time
+		want  time.Time
+	}{
+		{
+			name:  "Daily next day",
+			rule:  "FREQ=DAILY",
+			after: start,
+			want:  time.Date(2022, 3, 2, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Before the series starts returns the first occurrence",
+			rule:  "FREQ=DAILY",
+			after: start.Add(-48 * time.Hour),
+			want:  start,
+		},
+		{
+			name:  "Daily same day later hour",
+			rule:  "FREQ=DAILY",
+			after: time.Date(2022, 3, 10, 8, 0, 0, 0, utc),
+			want:  time.Date(2022, 3, 10, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Every 3 days keeps the start alignment",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			after: time.Date(2022, 3, 5, 12, 0, 0, 0, utc),
+			want:  time.Date(2022, 3, 7, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Weekly defaults to the start weekday",
+			rule:  "FREQ=WEEKLY",
+			after: start,
+			want:  time.Date(2022, 3, 8, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Weekly on several days",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR",
+			after: start,
+			want:  time.Date(2022, 3, 4, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Weekly days before start on the first week are skipped",
+			rule:  "FREQ=WEEKLY;BYDAY=MO",
+			after: start.Add(-time.Hour),
+			want:  time.Date(2022, 3, 7, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Sunday closes the week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU",
+			after: start,
+			want:  time.Date(2022, 3, 6, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Every other week skips the odd weeks",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			after: time.Date(2022, 3, 3, 10, 0, 0, 0, utc),
+			want:  time.Date(2022, 3, 15, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Far in the future",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU",
+			after: time.Date(2030, 1, 1, 0, 0, 0, 0, utc),
+			want:  time.Date(2030, 1, 8, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Monthly defaults to the start day",
+			rule:  "FREQ=MONTHLY",
+			after: start,
+			want:  time.Date(2022, 4, 1, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Monthly on several days",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=15,1",
+			after: start,
+			want:  time.Date(2022, 3, 15, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Monthly last day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			after: time.Date(2022, 4, 1, 0, 0, 0, 0, utc),
+			want:  time.Date(2022, 4, 30, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Monthly last day on a leap year february",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			after: time.Date(2024, 2, 1, 0, 0, 0, 0, utc),
+			want:  time.Date(2024, 2, 29, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Monthly on the 31st skips short months",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=31",
+			after: time.Date(2022, 3, 31, 10, 0, 0, 0, utc),
+			want:  time.Date(2022, 5, 31, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Monthly on the 30th skips february",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=30",
+			after: time.Date(2023, 1, 30, 10, 0, 0, 0, utc),
+			want:  time.Date(2023, 3, 30, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Quarterly crosses the year",
+			rule:  "FREQ=MONTHLY;INTERVAL=3",
+			after: time.Date(2022, 12, 15, 0, 0, 0, 0, utc),
+			want:  time.Date(2023, 3, 1, 9, 0, 0, 0, utc),
+		},
+		{
+			name:  "Until is inclusive",
+			rule:  "FREQ=DAILY;UNTIL=20220303T090000Z",
+			after: time.Date(2022, 3, 2, 9, 0, 0, 0, utc),
+			want:  time.Date(2022, 3, 3, 9, 0, 0, 0, utc),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			assert.NoError(t, err)
+
+			got, ok := rule.Next(start, tt.after, utc)
+
+			assert.True(t, ok)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestRule_Next_Ends_After_Until(t *testing.T) {
+	start := time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{
+		"FREQ=DAILY;UNTIL=20220303T090000Z",
+		"FREQ=WEEKLY;BYDAY=MO;UNTIL=20220303",
+		"FREQ=MONTHLY;BYMONTHDAY=31;UNTIL=20220415",
+	} {
+		t.Run(value, func(t *testing.T) {
+			rule, err := Parse(value)
+			assert.NoError(t, err)
+
+			_, ok := rule.Next(start, time.Date(2022, 3, 31, 10, 0, 0, 0, time.UTC), time.UTC)
+
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestRule_Next_Keeps_Wall_Clock_Across_DST(t *testing.T) {
+	tests := []struct {
+		name  string
+		zone  string
+		rule  string
+		start time.Time
+		after time.Time
+		want  string
+	}{
+		{
+			// Clocks move forward on 2022-03-13 in New York.
+			name:  "Daily across spring forward",
+			zone:  "America/New_York",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2022, 3, 12, 9, 0, 0, 0, time.UTC),
+			want:  "2022-03-13T09:00:00-04:00",
+		},
+		{
+			// Clocks move back on 2022-11-06 in New York.
+			name: "Weekly across fall back",
+			zone: "America/New_York",
+			rule: "FREQ=WEEKLY",
+			want: "2022-11-07T09:00:00-05:00",
+		},
+		{
+			// Clocks move back on 2022-10-30 in Berlin.
+			name: "Monthly across fall back",
+			zone: "Europe/Berlin",
+			rule: "FREQ=MONTHLY",
+			want: "2022-11-15T09:00:00+01:00",
+		},
+		{
+			// Southern hemisphere, clocks move forward on 2022-10-02 in Sydney.
+			name: "Daily across southern spring forward",
+			zone: "Australia/Sydney",
+			rule: "FREQ=DAILY",
+			want: "2022-10-02T09:00:00+11:00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loc := mustLoad(t, tt.zone)
+			want, _ := time.Parse(time.RFC3339, tt.want)
+
+			rule, err := Parse(tt.rule)
+			assert.NoError(t, err)
+
+			start := time.Date(2022, 1, 1, 9, 0, 0, 0, loc)
+			switch rule.Freq {
+			case Daily:
+				start = time.Date(want.Year(), want.Month(), want.Day()-5, 9, 0, 0, 0, loc)
+			case Weekly:
+				start = time.Date(want.Year(), want.Month(), want.Day()-14, 9, 0, 0, 0, loc)
+			case Monthly:
+				start = time.Date(want.Year(), want.Month()-3, want.Day(), 9, 0, 0, 0, loc)
+			}
+
+			// The occurrence right before want.
+			after := want.Add(-time.Hour * 20)
+
+			got, ok := rule.Next(start, after, loc)
+
+			assert.True(t, ok)
+			assert.True(t, want.Equal(got), "want %s, got %s", want, got.In(loc))
+			assert.Equal(t, 9, got.In(loc).Hour())
+		})
+	}
+}
+
+func TestRule_Next_DST_Gap_Moves_Forward(t *testing.T) {
+	loc := mustLoad(t, "America/New_York")
+
+	// 02:30 does not exist on 2022-03-13 in New York.
+	start := time.Date(2022, 3, 10, 2, 30, 0, 0, loc)
+	rule, _ := Parse("FREQ=DAILY")
+
+	got, ok := rule.Next(start, time.Date(2022, 3, 12, 3, 0, 0, 0, loc), loc)
+
+	assert.True(t, ok)
+	assert.Equal(t, "2022-03-13T03:30:00-04:00", got.Format(time.RFC3339))
+
+	// The day after is back to 02:30.
+	got, ok = rule.Next(start, got, loc)
+
+	assert.True(t, ok)
+	assert.Equal(t, "2022-03-14T02:30:00-04:00", got.Format(time.RFC3339))
+}
+
+func TestRule_Next_DST_Ambiguous_Time_Fires_Once(t *testing.T) {
+	loc := mustLoad(t, "America/New_York")
+
+	// 01:30 happens twice on 2022-11-06 in New York.
+	start := time.Date(2022, 11, 1, 1, 30, 0, 0, loc)
+	rule, _ := Parse("FREQ=DAILY")
+
+	got, ok := rule.Next(start, time.Date(2022, 11, 5, 12, 0, 0, 0, loc), loc)
+
+	assert.True(t, ok)
+	assert.Equal(t, "2022-11-06T01:30:00-04:00", got.Format(time.RFC3339))
+
+	got, ok = rule.Next(start, got, loc)
+
+	assert.True(t, ok)
+	assert.Equal(t, "2022-11-07T01:30:00-05:00", got.Format(time.RFC3339))
+}
+
+func TestRule_Next_Uses_Local_Calendar(t *testing.T) {
+	// 23:00 on a monday in Buenos Aires is already tuesday in UTC.
+	loc := mustLoad(t, "America/Argentina/Buenos_Aires")
+	start := time.Date(2022, 3, 7, 23, 0, 0, 0, loc)
+
+	rule, _ := Parse("FREQ=WEEKLY;BYDAY=MO")
+
+	got, ok := rule.Next(start, start, loc)
+
+	assert.True(t, ok)
+	assert.Equal(t, "2022-03-14T23:00:00-03:00", got.In(loc).Format(time.RFC3339))
+	assert.Equal(t, time.Tuesday, got.UTC().Weekday())
+}
@@ -0,0 +1,81 @@
+// Package scheduler runs background jobs on fixed intervals inside the API process.
+//
+// Jobs must be safe to run concurrently on several replicas, the scheduler does not coordinate
+// between processes. Each job runs at most once at a time within a process.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+type entry struct {
+	job      Job
+	interval time.Duration
+}
+
+type Scheduler struct {
+	entries []entry
+	wg      sync.WaitGroup
+}
+
+func New() *Scheduler {
+	return &Scheduler{}
+}
+
+// Every registers job to run right after Start and then every interval.
+func (s *Scheduler) Every(interval time.Duration, job Job) {
+	s.entries = append(s.entries, entry{job: job, interval: interval})
+}
+
+// Start runs every registered job on its own goroutine until ctx is cancelled.
+func (s *Scheduler) Start(ctx context.Context) {
+	for _, e := range s.entries {
+		s.wg.Add(1)
+		go func(e entry) {
+			defer s.wg.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+}
+
+// Wait blocks until every job returned after the context given to Start was cancelled.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, e entry) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		runJob(ctx, e.job)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runJob keeps a failing or panicking job from taking the process down, it will be retried on the next tick.
+func runJob(ctx context.Context, job Job) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Print(fmt.Sprintf("Job %s panicked: %v", job.Name(), r))
+		}
+	}()
+
+	if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+		log.Print(fmt.Sprintf("Job %s failed: %s", job.Name(), err.Error()))
+	}
+}
@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type countingJob struct {
+	runs  int32
+	err   error
+	panic bool
+}
+
+func (j *countingJob) Name() string {
+	return "counting"
+}
+
+func (j *countingJob) Run(ctx context.Context) error {
+	atomic.AddInt32(&j.runs, 1)
+	if j.panic {
+		panic("boom")
+	}
+	return j.err
+}
+
+func TestScheduler_Runs_Jobs_Until_Cancelled(t *testing.T) {
+	job := &countingJob{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := New()
+	s.Every(5*time.Millisecond, job)
+	s.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&job.runs) >= 3
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	s.Wait()
+
+	runs := atomic.LoadInt32(&job.runs)
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, runs, atomic.LoadInt32(&job.runs))
+}
+
+func TestScheduler_Runs_Right_After_Start(t *testing.T) {
+	job := &countingJob{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := New()
+	s.Every(time.Hour, job)
+	s.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&job.runs) == 1
+	}, time.Second, time.Millisecond)
+}
+
+func TestScheduler_Survives_Failing_Jobs(t *testing.T) {
+	failing := &countingJob{err: errors.New("failed")}
+	panicking := &countingJob{panic: true}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := New()
+	s.Every(5*time.Millisecond, failing)
+	s.Every(5*time.Millisecond, panicking)
+	s.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&failing.runs) >= 2 && atomic.LoadInt32(&panicking.runs) >= 2
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	s.Wait()
+}
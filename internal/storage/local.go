@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// LocalStorage keeps every object as a file under root.
+type LocalStorage struct {
+	root string
+}
+
+func NewLocalStorage(root string) (*LocalStorage, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalStorage{root: root}, nil
+}
+
+// Put writes to a temporary file first so readers never see a partial object.
+func (ls *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	path, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	written, err := io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if size >= 0 && written != size {
+		return io.ErrUnexpectedEOF
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+func (ls *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := ls.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return file, err
+}
+
+func (ls *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := ls.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (ls *LocalStorage) path(key string) (string, error) {
+	if err := validKey(key); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(ls.root, filepath.FromSlash(key)), nil
+}
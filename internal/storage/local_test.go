@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	root := t.TempDir()
+
+	store, err := NewLocalStorage(root)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	assert.NoError(t, store.Put(ctx, "items/1/photo", strings.NewReader("jpeg"), 4, "image/jpeg"))
+
+	object, err := store.Get(ctx, "items/1/photo")
+	assert.NoError(t, err)
+	content, _ := ioutil.ReadAll(object)
+	object.Close()
+	assert.Equal(t, "jpeg", string(content))
+
+	assert.NoError(t, store.Delete(ctx, "items/1/photo"))
+	assert.NoError(t, store.Delete(ctx, "items/1/photo"))
+
+	_, err = store.Get(ctx, "items/1/photo")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalStorage_Short_Body_Is_Not_Stored(t *testing.T) {
+	root := t.TempDir()
+
+	store, err := NewLocalStorage(root)
+	assert.NoError(t, err)
+
+	err = store.Put(context.Background(), "items/1/photo", strings.NewReader("jp"), 4, "image/jpeg")
+
+	assert.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	entries, _ := os.ReadDir(filepath.Join(root, "items", "1"))
+	assert.Empty(t, entries)
+}
+
+func TestLocalStorage_Invalid_Keys(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "items/../../secret", "items//photo", `items\photo`} {
+		_, err := store.Get(context.Background(), key)
+		assert.ErrorIs(t, err, ErrInvalidKey, key)
+	}
+}
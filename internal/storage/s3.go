@@ -0,0 +1,235 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3Timeout        = 60 * time.Second
+	s3DefaultRegion  = "us-east-1"
+	s3UnsignedBody   = "UNSIGNED-PAYLOAD"
+	s3SigningService = "s3"
+)
+
+type S3Config struct {
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// S3Storage talks to any S3 compatible service (AWS, MinIO, Ceph...) using path style urls, so the bucket does not
+// need its own DNS name. Requests are signed with AWS Signature Version 4.
+type S3Storage struct {
+	client   *http.Client
+	endpoint *url.URL
+	config   S3Config
+	now      func() time.Time
+}
+
+func NewS3Storage(config S3Config) (*S3Storage, error) {
+	if config.Endpoint == "" || config.Bucket == "" || config.AccessKey == "" || config.SecretKey == "" {
+		return nil, errors.New("s3 storage needs an endpoint, a bucket and credentials")
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(config.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", config.Endpoint)
+	}
+
+	if config.Region == "" {
+		config.Region = s3DefaultRegion
+	}
+
+	return &S3Storage{
+		client:   &http.Client{Timeout: s3Timeout},
+		endpoint: endpoint,
+		config:   config,
+		now:      time.Now,
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	request, err := s.request(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return err
+	}
+
+	request.ContentLength = size
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	request, err := s.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Body, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	request, err := s.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := s.do(request)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return response.Body.Close()
+}
+
+func (s *S3Storage) request(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+
+	objectURL := *s.endpoint
+	objectURL.Path = s.endpoint.Path + "/" + s.config.Bucket + "/" + key
+	objectURL.RawPath = s.endpoint.Path + "/" + uriEncode(s.config.Bucket, false) + "/" + uriEncode(key, false)
+
+	return http.NewRequestWithContext(ctx, method, objectURL.String(), body)
+}
+
+// do signs and sends the request, turning error responses into errors. The caller closes the body.
+func (s *S3Storage) do(request *http.Request) (*http.Response, error) {
+	request.Header.Set("X-Amz-Content-Sha256", s3UnsignedBody)
+	signV4(request, s.config.AccessKey, s.config.SecretKey, s.config.Region, s3SigningService, s.now())
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode >= 200 && response.StatusCode <= 299 {
+		return response, nil
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+
+	detail, _ := ioutil.ReadAll(io.LimitReader(response.Body, 1024))
+	return nil, fmt.Errorf("s3 %s responded with status %d: %s", request.Method, response.StatusCode, strings.TrimSpace(string(detail)))
+}
+
+// signV4 adds the Authorization header of AWS Signature Version 4, signing the host and every header already
+// set on the request. The payload hash must already be on X-Amz-Content-Sha256.
+func signV4(request *http.Request, accessKey string, secretKey string, region string, service string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	request.Header.Set("X-Amz-Date", amzDate)
+
+	host := request.Host
+	if host == "" {
+		host = request.URL.Host
+	}
+
+	headers := map[string]string{"host": host}
+	for name, values := range request.Header {
+		if name = strings.ToLower(name); name != "authorization" {
+			headers[name] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		request.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := day + "/" + region + "/" + service + "/aws4_request"
+	hashedRequest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(hashedRequest[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), day)
+	for _, part := range []string{region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+func canonicalQuery(query url.Values) string {
+	pairs := make([]string, 0, len(query))
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes everything but the unreserved characters, keeping the slashes of object paths unless asked.
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~', b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,64 @@
+// Package storage keeps binary objects, like item attachments, on the local disk or on an S3 compatible service.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const defaultLocalPath = "data/attachments"
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Storage saves objects under slash separated keys. Put replaces the object when the key already exists and
+// Delete of a missing object is not an error, so both can be retried.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFromEnv picks the storage with STORAGE_DRIVER, "local" by default or "s3". The local storage writes under
+// STORAGE_PATH, the s3 one reads S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY.
+func NewFromEnv() (Storage, error) {
+	switch driver := os.Getenv("STORAGE_DRIVER"); driver {
+	case "", "local":
+		path := os.Getenv("STORAGE_PATH")
+		if path == "" {
+			path = defaultLocalPath
+		}
+		return NewLocalStorage(path)
+	case "s3":
+		return NewS3Storage(S3Config{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    os.Getenv("S3_BUCKET"),
+			AccessKey: os.Getenv("S3_ACCESS_KEY"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+		})
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", driver)
+	}
+}
+
+// validKey rejects keys that could point outside of the storage root.
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+
+	return nil
+}
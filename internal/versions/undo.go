@@ -0,0 +1,130 @@
+package versions
+
+import (
+	"SuperListsAPI/internal/activity"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"github.com/gofrs/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// UndoTTL is how long a bulk deletion can be undone.
+const UndoTTL = 10 * time.Minute
+
+// Bulk deletions answer with the undo token and its expiration on these headers, leaving their body as it was.
+const (
+	UndoTokenHeader     = "X-Undo-Token"
+	UndoExpiresAtHeader = "X-Undo-Expires-At"
+)
+
+var ErrUndoExpired = errors.New("undo token expired")
+
+// UndoToken undoes a bulk deletion of the TargetIDs made by UserID. It can only be used once, by the same user,
+// until ExpiresAt.
+type UndoToken struct {
+	Token      string    `json:"token" gorm:"primarykey"`
+	UserID     *uint     `json:"-"`
+	TargetType string    `json:"target_type"`
+	TargetIDs  IDs       `json:"target_ids" gorm:"type:text"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"-"`
+}
+
+// IDs stores a list of ids on a single text column, encoded as a json array.
+type IDs []uint
+
+func (ids IDs) Value() (driver.Value, error) {
+	if ids == nil {
+		return "[]", nil
+	}
+
+	encoded, err := json.Marshal([]uint(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	return string(encoded), nil
+}
+
+func (ids *IDs) Scan(value interface{}) error {
+	var raw []byte
+
+	switch v := value.(type) {
+	case nil:
+		*ids = IDs{}
+		return nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return errors.New("unsupported type for IDs")
+	}
+
+	if len(raw) == 0 {
+		*ids = IDs{}
+		return nil
+	}
+
+	return json.Unmarshal(raw, (*[]uint)(ids))
+}
+
+// NewUndoToken hands out a token to undo the deletion of targetIDs, for the actor of the tx context. Expired tokens
+// of the same actor are cleared on the way. Nothing is handed out when nothing was deleted.
+func NewUndoToken(tx *gorm.DB, targetType string, targetIDs []uint) (*UndoToken, error) {
+
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	token, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	undoToken := UndoToken{
+		Token:      token.String(),
+		UserID:     activity.ActorFrom(tx.Statement.Context),
+		TargetType: targetType,
+		TargetIDs:  targetIDs,
+		ExpiresAt:  now.Add(UndoTTL),
+	}
+
+	if undoToken.UserID != nil {
+		if result := tx.Where("user_id = ? AND expires_at < ?", *undoToken.UserID, now).Delete(&UndoToken{}); result.Error != nil {
+			return nil, result.Error
+		}
+	}
+
+	if result := tx.Create(&undoToken); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &undoToken, nil
+}
+
+// ConsumeUndoToken takes token out for userID, so it can't be used twice. It returns gorm.ErrRecordNotFound when
+// the token doesn't exist or belongs to somebody else, and ErrUndoExpired once it can no longer be used.
+func ConsumeUndoToken(tx *gorm.DB, token string, userID uint) (*UndoToken, error) {
+
+	var undoToken UndoToken
+
+	result := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("token = ? AND user_id = ?", token, userID).First(&undoToken)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if !time.Now().Before(undoToken.ExpiresAt) {
+		return nil, ErrUndoExpired
+	}
+
+	if result := tx.Delete(&undoToken); result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &undoToken, nil
+}
@@ -0,0 +1,115 @@
+package versions
+
+import (
+	"SuperListsAPI/internal/activity"
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestIDs_Value(t *testing.T) {
+	value, err := IDs{1, 2}.Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[1,2]", value)
+
+	value, err = IDs(nil).Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[]", value)
+}
+
+func TestIDs_Scan(t *testing.T) {
+	var ids IDs
+
+	assert.NoError(t, ids.Scan("[3,4]"))
+	assert.Equal(t, IDs{3, 4}, ids)
+
+	assert.NoError(t, ids.Scan(nil))
+	assert.Equal(t, IDs{}, ids)
+
+	assert.Error(t, ids.Scan(1.5))
+}
+
+func TestNewUndoToken(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `undo_tokens` WHERE user_id = ? AND expires_at < ?")).
+		WithArgs(7, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `undo_tokens` (`token`,`user_id`,`target_type`,`target_ids`,`expires_at`,`created_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(sqlmock.AnyArg(), 7, activity.TargetListItem, "[1,2]", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	var undoToken *UndoToken
+	err := gormDb.WithContext(activity.WithActor(context.Background(), 7)).Transaction(func(tx *gorm.DB) (err error) {
+		undoToken, err = NewUndoToken(tx, activity.TargetListItem, []uint{1, 2})
+		return err
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, undoToken.Token, 36)
+	assert.WithinDuration(t, time.Now().Add(UndoTTL), undoToken.ExpiresAt, time.Minute)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNewUndoToken_Nothing_Deleted(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	undoToken, err := NewUndoToken(gormDb, activity.TargetList, nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, undoToken)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestConsumeUndoToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		expiresAt time.Time
+		found     bool
+		wantErr   error
+	}{
+		{name: "Valid token", expiresAt: time.Now().Add(time.Minute), found: true},
+		{name: "Expired token", expiresAt: time.Now().Add(-time.Minute), found: true, wantErr: ErrUndoExpired},
+		{name: "Unknown token", found: false, wantErr: gorm.ErrRecordNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gormDb, mock := getMockedDatabase(t)
+
+			rows := sqlmock.NewRows([]string{"token", "user_id", "target_type", "target_ids", "expires_at"})
+			if tt.found {
+				rows.AddRow("token", 7, activity.TargetList, "[1]", tt.expiresAt)
+			}
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `undo_tokens` WHERE token = ? AND user_id = ? ORDER BY `undo_tokens`.`token` LIMIT 1 FOR UPDATE")).
+				WithArgs("token", 7).
+				WillReturnRows(rows)
+			if tt.wantErr == nil {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta("DELETE FROM `undo_tokens` WHERE `undo_tokens`.`token` = ?")).
+					WithArgs("token").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			}
+
+			result, err := ConsumeUndoToken(gormDb, "token", 7)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, result)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, IDs{1}, result.TargetIDs)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,177 @@
+// Package versions keeps what lists and items looked like before every change made to them, so they can be
+// browsed and rolled back, and the undo tokens handed out by bulk deletions. Like activity entries, versions and
+// tokens are written on the same transaction as the change.
+package versions
+
+import (
+	"SuperListsAPI/internal/activity"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Version is the state a list or item had before change number Number was made to it. ActorID is who made that
+// change, nil for background jobs.
+type Version struct {
+	ID         uint      `json:"id" gorm:"primarykey"`
+	TargetType string    `json:"target_type"`
+	TargetID   uint      `json:"target_id"`
+	ListID     uint      `json:"list_id"`
+	Number     int       `json:"number"`
+	ActorID    *uint     `json:"actor_id"`
+	Snapshot   Snapshot  `json:"snapshot" gorm:"type:text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// VersionPage is a page of the versions of a list or item, newest versions first.
+type VersionPage struct {
+	Versions []Version `json:"versions"`
+	Page     int       `json:"page"`
+	PageSize int       `json:"page_size"`
+	Total    int64     `json:"total"`
+}
+
+// Snapshot is the json encoding of a list or item, stored on a text column.
+type Snapshot []byte
+
+func (s Snapshot) Value() (driver.Value, error) {
+	if len(s) == 0 {
+		return "null", nil
+	}
+	return string(s), nil
+}
+
+func (s *Snapshot) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s = nil
+	case string:
+		*s = Snapshot(v)
+	case []byte:
+		*s = append(Snapshot(nil), v...)
+	default:
+		return errors.New("unsupported type for Snapshot")
+	}
+	return nil
+}
+
+func (s Snapshot) MarshalJSON() ([]byte, error) {
+	if len(s) == 0 {
+		return []byte("null"), nil
+	}
+	return s, nil
+}
+
+func (s *Snapshot) UnmarshalJSON(data []byte) error {
+	*s = append(Snapshot(nil), data...)
+	return nil
+}
+
+// State is what a list or item looked like right before a change.
+type State struct {
+	ListID   uint
+	TargetID uint
+	Value    interface{}
+}
+
+// Save stores states as the next version of their targets. Versions get the actor of the tx context.
+func Save(tx *gorm.DB, targetType string, states ...State) error {
+
+	if len(states) == 0 {
+		return nil
+	}
+
+	targetIDs := make([]uint, 0, len(states))
+	for _, state := range states {
+		targetIDs = append(targetIDs, state.TargetID)
+	}
+
+	var latest []struct {
+		TargetID uint
+		Number   int
+	}
+
+	result := tx.Model(&Version{}).
+		Select("target_id, MAX(number) AS number").
+		Where("target_type = ? AND target_id IN ?", targetType, targetIDs).
+		Group("target_id").
+		Scan(&latest)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	numbers := make(map[uint]int, len(latest))
+	for _, version := range latest {
+		numbers[version.TargetID] = version.Number
+	}
+
+	actorID := activity.ActorFrom(tx.Statement.Context)
+
+	versions := make([]Version, 0, len(states))
+	for _, state := range states {
+		snapshot, err := json.Marshal(state.Value)
+		if err != nil {
+			return err
+		}
+
+		numbers[state.TargetID]++
+		versions = append(versions, Version{
+			TargetType: targetType,
+			TargetID:   state.TargetID,
+			ListID:     state.ListID,
+			Number:     numbers[state.TargetID],
+			ActorID:    actorID,
+			Snapshot:   snapshot,
+		})
+	}
+
+	return tx.Create(&versions).Error
+}
+
+// Find returns a page of the versions of a target, newest versions first, along with how many there are.
+func Find(db *gorm.DB, targetType string, targetID uint, page int, pageSize int) (*VersionPage, error) {
+
+	versionPage := VersionPage{Versions: []Version{}, Page: page, PageSize: pageSize}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Where("target_type = ? AND target_id = ?", targetType, targetID)
+	}
+
+	if result := db.Model(&Version{}).Scopes(query).Count(&versionPage.Total); result.Error != nil {
+		return nil, result.Error
+	}
+
+	if versionPage.Total == 0 {
+		return &versionPage, nil
+	}
+
+	result := db.Scopes(query).Order("number DESC").Offset((page - 1) * pageSize).Limit(pageSize).Find(&versionPage.Versions)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &versionPage, nil
+}
+
+// Get returns version number of a target, gorm.ErrRecordNotFound when it doesn't exist.
+func Get(db *gorm.DB, targetType string, targetID uint, number int) (*Version, error) {
+
+	var version Version
+
+	result := db.Where("target_type = ? AND target_id = ? AND number = ?", targetType, targetID, number).First(&version)
+
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &version, nil
+}
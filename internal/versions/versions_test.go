@@ -0,0 +1,128 @@
+package versions
+
+import (
+	"SuperListsAPI/internal/activity"
+	"context"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type item struct {
+	ID    uint   `json:"id"`
+	Title string `json:"title"`
+}
+
+func TestSnapshot_Value(t *testing.T) {
+	value, err := Snapshot(`{"title":"Milk"}`).Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"title":"Milk"}`, value)
+
+	value, err = Snapshot(nil).Value()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "null", value)
+}
+
+func TestSnapshot_Scan(t *testing.T) {
+	var snapshot Snapshot
+
+	assert.NoError(t, snapshot.Scan([]byte(`{"title":"Milk"}`)))
+	assert.Equal(t, Snapshot(`{"title":"Milk"}`), snapshot)
+
+	assert.NoError(t, snapshot.Scan(`{"title":"Bread"}`))
+	assert.Equal(t, Snapshot(`{"title":"Bread"}`), snapshot)
+
+	assert.Error(t, snapshot.Scan(42))
+}
+
+func TestSnapshot_MarshalJSON(t *testing.T) {
+	encoded, err := json.Marshal(Version{Number: 2, Snapshot: Snapshot(`{"title":"Milk"}`)})
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(encoded), `"snapshot":{"title":"Milk"}`)
+
+	var version Version
+
+	assert.NoError(t, json.Unmarshal(encoded, &version))
+	assert.Equal(t, Snapshot(`{"title":"Milk"}`), version.Snapshot)
+}
+
+func TestSave(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT target_id, MAX(number) AS number FROM `versions` WHERE target_type = ? AND target_id IN (?,?) GROUP BY `target_id`")).
+		WithArgs(activity.TargetListItem, 1, 2).
+		WillReturnRows(sqlmock.NewRows([]string{"target_id", "number"}).AddRow(1, 4))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `versions` (`target_type`,`target_id`,`list_id`,`number`,`actor_id`,`snapshot`,`created_at`) VALUES (?,?,?,?,?,?,?),(?,?,?,?,?,?,?)")).
+		WithArgs(activity.TargetListItem, 1, 3, 5, 7, `{"id":1,"title":"Milk"}`, sqlmock.AnyArg(),
+			activity.TargetListItem, 2, 3, 1, 7, `{"id":2,"title":"Bread"}`, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	mock.ExpectCommit()
+
+	err := Save(gormDb.WithContext(activity.WithActor(context.Background(), 7)), activity.TargetListItem,
+		State{ListID: 3, TargetID: 1, Value: item{ID: 1, Title: "Milk"}},
+		State{ListID: 3, TargetID: 2, Value: item{ID: 2, Title: "Bread"}},
+	)
+
+	assert.NoError(t, err)
+	assert.NoError(t, Save(gormDb, activity.TargetListItem))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFind(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	createdAt := time.Now().UTC().Truncate(time.Second)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `versions` WHERE target_type = ? AND target_id = ?")).
+		WithArgs(activity.TargetList, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `versions` WHERE target_type = ? AND target_id = ? ORDER BY number DESC LIMIT 20")).
+		WithArgs(activity.TargetList, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "target_type", "target_id", "list_id", "number", "snapshot", "created_at"}).
+			AddRow(2, activity.TargetList, 3, 3, 2, `{"name":"Groceries"}`, createdAt).
+			AddRow(1, activity.TargetList, 3, 3, 1, `{"name":"Shopping"}`, createdAt))
+
+	result, err := Find(gormDb, activity.TargetList, 3, 1, 20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), result.Total)
+	assert.Equal(t, Snapshot(`{"name":"Groceries"}`), result.Versions[0].Snapshot)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGet_Not_Found(t *testing.T) {
+	gormDb, mock := getMockedDatabase(t)
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `versions` WHERE target_type = ? AND target_id = ? AND number = ? ORDER BY `versions`.`id` LIMIT 1")).
+		WithArgs(activity.TargetList, 3, 9).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	result, err := Get(gormDb, activity.TargetList, 3, 9)
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	assert.Nil(t, result)
+}
+
+func getMockedDatabase(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	gormDb, err := gorm.Open(mysql.New(mysql.Config{Conn: db, SkipInitializeWithVersion: true}), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	return gormDb, mock
+}
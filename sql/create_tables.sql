@@ -1,9 +1,18 @@
 CREATE TABLE IF NOT EXISTS product (
-                                       product_id INT NOT NULL,
+                                       product_id serial NOT NULL,
                                        name varchar(250) NOT NULL,
+                                       barcode varchar(14) NULL UNIQUE,
+                                       category varchar(100) NULL,
     PRIMARY KEY (product_id)
     );
 
+-- The columns added to the tables of the first schema are added again when missing, so the script can be run on an
+-- existing database to migrate it.
+ALTER TABLE product ADD COLUMN IF NOT EXISTS barcode varchar(14) NULL UNIQUE;
+ALTER TABLE product ADD COLUMN IF NOT EXISTS category varchar(100) NULL;
+
+CREATE INDEX IF NOT EXISTS product_lower_name_idx ON product (LOWER(name));
+
 
 CREATE TABLE IF NOT EXISTS users (
                               id serial PRIMARY KEY,
@@ -23,11 +32,23 @@ CREATE TABLE IF NOT EXISTS lists (
                               description varchar(150) NULL,
                               invite_code text NULL,
                               user_creator_id bigint NOT NULL,
+                              recurrence varchar(200) NULL,
+                              recurrence_start timestamp with time zone NULL,
+                              time_zone varchar(64) NULL,
+                              next_reset_at timestamp with time zone NULL,
                               created_at timestamp without time zone null DEFAULT CURRENT_TIMESTAMP,
                               updated_at timestamp without time zone null DEFAULT NULL,
                               deleted_at timestamp without time zone null DEFAULT NULL
 );
 
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS invite_code text NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS recurrence varchar(200) NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS recurrence_start timestamp with time zone NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS time_zone varchar(64) NULL;
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS next_reset_at timestamp with time zone NULL;
+
+CREATE INDEX IF NOT EXISTS lists_next_reset_at_idx ON lists (next_reset_at) WHERE next_reset_at IS NOT NULL AND deleted_at IS NULL;
+
 
 CREATE TABLE IF NOT EXISTS user_lists (
                                    id serial PRIMARY KEY,
@@ -41,17 +62,260 @@ CREATE TABLE IF NOT EXISTS user_lists (
 ALTER TABLE user_lists ADD CONSTRAINT user_lists_list_id_fk FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
 ALTER TABLE user_lists ADD CONSTRAINT user_lists_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
 
-CREATE TABLE list_items (
+CREATE TABLE IF NOT EXISTS list_items (
                               id serial PRIMARY KEY,
                               list_id bigint NOT NULL,
                               user_id bigint not null,
                               title varchar(150) NULL,
                               description TEXT NULL,
                               is_done boolean NULL DEFAULT false,
+                              position double precision NOT NULL DEFAULT 0,
+                              quantity numeric(12,3) NULL,
+                              unit varchar(10) NULL,
+                              unit_price numeric(12,2) NULL,
+                              currency char(3) NULL,
+                              product_id int NULL REFERENCES product(product_id) ON DELETE SET NULL,
+                              category varchar(100) NULL,
+                              tags text NOT NULL DEFAULT '[]',
+                              due_at timestamp with time zone NULL,
+                              all_day boolean NOT NULL DEFAULT false,
+                              time_zone varchar(64) NULL,
+                              priority varchar(10) NULL,
+                              completed_at timestamp with time zone NULL,
+                              recurrence varchar(200) NULL,
+                              series_id bigint NULL,
+                              parent_id bigint NULL REFERENCES list_items(id) ON DELETE CASCADE,
                               created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
                               updated_at timestamp without time zone NULL,
                               deleted_at timestamp without time zone NULL
 );
 
 ALTER TABLE list_item ADD CONSTRAINT item_creator_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
-ALTER TABLE list_item ADD CONSTRAINT list_id_fk FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
\ No newline at end of file
+ALTER TABLE list_item ADD CONSTRAINT list_id_fk FOREIGN KEY (list_id) REFERENCES lists(id) ON DELETE RESTRICT ON UPDATE RESTRICT;
+
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS position double precision NOT NULL DEFAULT 0;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS quantity numeric(12,3) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS unit varchar(10) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS unit_price numeric(12,2) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS currency char(3) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS product_id int NULL REFERENCES product(product_id) ON DELETE SET NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS category varchar(100) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS tags text NOT NULL DEFAULT '[]';
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS due_at timestamp with time zone NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS all_day boolean NOT NULL DEFAULT false;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS time_zone varchar(64) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS priority varchar(10) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS completed_at timestamp with time zone NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS recurrence varchar(200) NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS series_id bigint NULL;
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS parent_id bigint NULL REFERENCES list_items(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS list_items_list_id_position_idx ON list_items (list_id, position);
+CREATE INDEX IF NOT EXISTS list_items_pending_due_at_idx ON list_items (due_at) WHERE is_done = false AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS list_items_series_due_at_idx ON list_items (series_id, due_at) WHERE series_id IS NOT NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS list_items_parent_id_idx ON list_items (parent_id) WHERE parent_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS store_profiles (
+                              id serial PRIMARY KEY,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              name varchar(100) NOT NULL,
+                              category_order text NOT NULL DEFAULT '[]',
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              updated_at timestamp without time zone NULL,
+                              deleted_at timestamp without time zone NULL
+);
+
+CREATE INDEX IF NOT EXISTS store_profiles_user_id_idx ON store_profiles (user_id);
+
+
+CREATE TABLE IF NOT EXISTS list_item_assignees (
+                              id serial PRIMARY KEY,
+                              list_item_id bigint NOT NULL REFERENCES list_items(id) ON DELETE CASCADE,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              assigned_by bigint NULL REFERENCES users(id) ON DELETE SET NULL,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              UNIQUE (list_item_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS list_item_assignees_user_id_idx ON list_item_assignees (user_id);
+
+CREATE TABLE IF NOT EXISTS reminders (
+                              id serial PRIMARY KEY,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              list_id bigint NULL REFERENCES lists(id) ON DELETE CASCADE,
+                              list_item_id bigint NULL REFERENCES list_items(id) ON DELETE CASCADE,
+                              offset_minutes int NOT NULL DEFAULT 0,
+                              channel varchar(10) NOT NULL,
+                              target varchar(500) NULL,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              updated_at timestamp without time zone NULL,
+                              deleted_at timestamp without time zone NULL,
+                              CHECK ((list_id IS NULL) <> (list_item_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS reminders_user_id_idx ON reminders (user_id);
+CREATE INDEX IF NOT EXISTS reminders_list_id_idx ON reminders (list_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS reminders_list_item_id_idx ON reminders (list_item_id) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS reminder_deliveries (
+                              id bigserial PRIMARY KEY,
+                              reminder_id bigint NOT NULL REFERENCES reminders(id) ON DELETE CASCADE,
+                              list_item_id bigint NOT NULL REFERENCES list_items(id) ON DELETE CASCADE,
+                              due_at timestamp with time zone NOT NULL,
+                              fire_at timestamp with time zone NOT NULL,
+                              next_attempt_at timestamp with time zone NOT NULL,
+                              status varchar(10) NOT NULL DEFAULT 'pending',
+                              claimed_by varchar(100) NULL,
+                              claimed_until timestamp with time zone NULL,
+                              attempts int NOT NULL DEFAULT 0,
+                              last_error text NULL,
+                              sent_at timestamp with time zone NULL,
+                              created_at timestamp with time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              UNIQUE (reminder_id, list_item_id, due_at)
+);
+
+CREATE INDEX IF NOT EXISTS reminder_deliveries_pending_idx ON reminder_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS reminder_deliveries_claimed_idx ON reminder_deliveries (claimed_until) WHERE status = 'claimed';
+
+CREATE TABLE IF NOT EXISTS notifications (
+                              id bigserial PRIMARY KEY,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              delivery_id varchar(100) NOT NULL UNIQUE,
+                              kind varchar(20) NOT NULL DEFAULT 'reminder',
+                              reminder_id bigint NULL,
+                              comment_id bigint NULL,
+                              list_id bigint NULL,
+                              list_item_id bigint NULL,
+                              title varchar(150) NULL,
+                              due_at timestamp with time zone NULL,
+                              read_at timestamp with time zone NULL,
+                              created_at timestamp with time zone NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_idx ON notifications (user_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS comments (
+                              id bigserial PRIMARY KEY,
+                              list_id bigint NOT NULL REFERENCES lists(id) ON DELETE CASCADE,
+                              list_item_id bigint NULL REFERENCES list_items(id) ON DELETE CASCADE,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              body varchar(2000) NOT NULL,
+                              edited_at timestamp with time zone NULL,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              updated_at timestamp without time zone NULL,
+                              deleted_at timestamp without time zone NULL
+);
+
+CREATE INDEX IF NOT EXISTS comments_list_id_idx ON comments (list_id, created_at) WHERE list_item_id IS NULL AND deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS comments_list_item_id_idx ON comments (list_item_id, created_at) WHERE deleted_at IS NULL;
+
+-- list_item_id has no foreign key: rows must outlive hard deleted items until the purge job removes their files.
+CREATE TABLE IF NOT EXISTS attachments (
+                              id bigserial PRIMARY KEY,
+                              list_item_id bigint NOT NULL,
+                              list_id bigint NOT NULL,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              file_name varchar(255) NOT NULL,
+                              content_type varchar(100) NOT NULL,
+                              size bigint NOT NULL,
+                              checksum char(64) NOT NULL,
+                              storage_key varchar(255) NOT NULL UNIQUE,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              updated_at timestamp without time zone NULL,
+                              deleted_at timestamp without time zone NULL
+);
+
+CREATE INDEX IF NOT EXISTS attachments_list_item_id_idx ON attachments (list_item_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS attachments_deleted_at_idx ON attachments (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- list_id has no foreign key: the history of a list outlives the list, its items and its members.
+CREATE TABLE IF NOT EXISTS activities (
+                              id bigserial PRIMARY KEY,
+                              list_id bigint NOT NULL,
+                              actor_id bigint NULL,
+                              action varchar(30) NOT NULL,
+                              target_type varchar(30) NOT NULL,
+                              target_id bigint NOT NULL,
+                              changes text NOT NULL DEFAULT '{}',
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS activities_list_id_idx ON activities (list_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS activities_actor_id_idx ON activities (actor_id);
+
+-- Previous states of lists and items, target_id has no foreign key so versions of deleted rows can be restored.
+CREATE TABLE IF NOT EXISTS versions (
+                              id bigserial PRIMARY KEY,
+                              target_type varchar(30) NOT NULL,
+                              target_id bigint NOT NULL,
+                              list_id bigint NOT NULL,
+                              number integer NOT NULL,
+                              actor_id bigint NULL,
+                              snapshot text NOT NULL,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              UNIQUE (target_type, target_id, number)
+);
+
+CREATE TABLE IF NOT EXISTS undo_tokens (
+                              token varchar(36) PRIMARY KEY,
+                              user_id bigint NULL REFERENCES users(id) ON DELETE CASCADE,
+                              target_type varchar(30) NOT NULL,
+                              target_ids text NOT NULL,
+                              expires_at timestamp with time zone NOT NULL,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS undo_tokens_user_id_idx ON undo_tokens (user_id, expires_at);
+
+-- Full text search. The vectors hold the words of both the Spanish and the English configurations, weighting titles
+-- above descriptions. Postgres keeps generated columns and their indexes up to date, and the statements can be run
+-- again on an existing database to migrate it.
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('spanish', COALESCE("name", '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE("name", '')), 'A') ||
+    setweight(to_tsvector('spanish', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+) STORED;
+
+ALTER TABLE list_items ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('spanish', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('english', COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector('spanish', COALESCE(description, '')), 'B') ||
+    setweight(to_tsvector('english', COALESCE(description, '')), 'B')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS lists_search_vector_idx ON lists USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS list_items_search_vector_idx ON list_items USING GIN (search_vector);
+
+-- Imports of exports from other apps, the uploaded file is kept on the attachment storage until the job ends.
+CREATE TABLE IF NOT EXISTS import_jobs (
+                              id bigserial PRIMARY KEY,
+                              user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              source varchar(20) NOT NULL,
+                              file_name varchar(255) NOT NULL,
+                              storage_key varchar(255) NOT NULL UNIQUE,
+                              time_zone varchar(64) NULL,
+                              status varchar(20) NOT NULL DEFAULT 'pending',
+                              claimed_by varchar(100) NULL,
+                              claimed_until timestamp with time zone NULL,
+                              attempts integer NOT NULL DEFAULT 0,
+                              total_lists integer NOT NULL DEFAULT 0,
+                              processed_lists integer NOT NULL DEFAULT 0,
+                              item_count integer NOT NULL DEFAULT 0,
+                              lists text NULL,
+                              errors text NULL,
+                              last_error text NULL,
+                              started_at timestamp with time zone NULL,
+                              finished_at timestamp with time zone NULL,
+                              created_at timestamp without time zone NULL DEFAULT CURRENT_TIMESTAMP,
+                              updated_at timestamp without time zone NULL
+);
+
+CREATE INDEX IF NOT EXISTS import_jobs_pending_idx ON import_jobs (id) WHERE status IN ('pending', 'running');
+CREATE INDEX IF NOT EXISTS import_jobs_user_id_idx ON import_jobs (user_id, created_at);
+
+-- Per list counts returned with the lists of a user and the completion stats on /v1/me/stats.
+CREATE INDEX IF NOT EXISTS user_lists_user_id_idx ON user_lists (user_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS user_lists_list_id_idx ON user_lists (list_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS list_items_created_at_idx ON list_items (list_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS list_items_completed_at_idx ON list_items (list_id, completed_at) WHERE completed_at IS NOT NULL AND deleted_at IS NULL;